@@ -7,12 +7,16 @@ import (
 	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
 var (
 	errNoPipelinePriority    = errors.New("No pipelines are defined in the priority list")
 	errInvalidRetryIntervals = errors.New("Retry interval must be positive")
+	errNoHealthCheckExt      = errors.New("Health check extension must be specified")
+	errInvalidFailbackWindow = errors.New("Failback window must not be negative")
+	errInvalidQueueTimeout   = errors.New("Queue saturation timeout must not be negative")
 )
 
 type Config struct {
@@ -33,6 +37,31 @@ type Config struct {
 	// MaxRetry is the maximum retries per level, once this limit is hit for a level, even if the next pipeline level fails,
 	// it will not try to recover the level that exceeded the maximum retries
 	MaxRetries int `mapstructure:"max_retries"` // **Deprecated**
+
+	// HealthCheck enables failover driven by the component status reported for the pipelines of each priority
+	// level, in addition to the errors returned when consuming data
+	HealthCheck *HealthCheckConfig `mapstructure:"health_check"`
+
+	// QueueSaturationTimeout is the maximum time a priority level may block while consuming data before it is
+	// considered saturated and the connector fails over to the next level. Exporters whose sending queue blocks
+	// on overflow stop accepting data without returning an error once the queue is full. Zero disables the timeout
+	QueueSaturationTimeout time.Duration `mapstructure:"queue_saturation_timeout"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// HealthCheckConfig defines how component status events are used to determine the health of a priority level
+type HealthCheckConfig struct {
+	// Extension is the ID of the extension aggregating component status events, such as healthcheckv2
+	Extension component.ID `mapstructure:"extension"`
+
+	// IncludeRecoverable marks a priority level unhealthy when one of its components reports a recoverable
+	// error. Permanent and fatal errors always mark the level unhealthy
+	IncludeRecoverable bool `mapstructure:"include_recoverable"`
+
+	// FailbackWindow is how long a higher priority level must continuously report healthy before data is
+	// routed back to it
+	FailbackWindow time.Duration `mapstructure:"failback_window"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -45,5 +74,16 @@ func (c *Config) Validate() error {
 	if c.RetryInterval <= 0 {
 		return errInvalidRetryIntervals
 	}
+	if c.QueueSaturationTimeout < 0 {
+		return errInvalidQueueTimeout
+	}
+	if c.HealthCheck != nil {
+		if c.HealthCheck.Extension == (component.ID{}) {
+			return errNoHealthCheckExt
+		}
+		if c.HealthCheck.FailbackWindow < 0 {
+			return errInvalidFailbackWindow
+		}
+	}
 	return nil
 }
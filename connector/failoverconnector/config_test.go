@@ -55,6 +55,26 @@ func TestLoadConfig(t *testing.T) {
 				RetryInterval: 5 * time.Minute,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "health_check"),
+			expected: &Config{
+				PipelinePriority: [][]pipeline.ID{
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "first"),
+					},
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "second"),
+					},
+				},
+				RetryInterval:          5 * time.Minute,
+				QueueSaturationTimeout: 5 * time.Second,
+				HealthCheck: &HealthCheckConfig{
+					Extension:          component.MustNewID("healthcheckv2"),
+					IncludeRecoverable: true,
+					FailbackWindow:     2 * time.Minute,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -91,6 +111,11 @@ func TestValidateConfig(t *testing.T) {
 			id:   component.NewIDWithName(metadata.Type, "invalid"),
 			err:  errInvalidRetryIntervals,
 		},
+		{
+			name: "missing health check extension",
+			id:   component.NewIDWithName(metadata.Type, "invalid_health_check"),
+			err:  errNoHealthCheckExt,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -4,11 +4,17 @@
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sync"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/pipeline"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
 )
 
 var (
@@ -18,6 +24,11 @@ var (
 
 type consumerProvider[C any] func(...pipeline.ID) (C, error)
 
+// statusSubscriber is implemented by extensions that aggregate component status events, such as healthcheckv2
+type statusSubscriber interface {
+	Subscribe(scope status.Scope, verbosity status.Verbosity) (<-chan *status.AggregateStatus, status.UnsubscribeFunc)
+}
+
 // baseFailoverRouter provides the common infrastructure for failover routing
 type baseFailoverRouter[C any] struct {
 	cfg       *Config
@@ -27,6 +38,9 @@ type baseFailoverRouter[C any] struct {
 	errTryLock  *state.TryLock
 	notifyRetry chan struct{}
 	done        chan struct{}
+
+	health      *state.LevelHealth
+	subscribers sync.WaitGroup
 }
 
 // getCurrentConsumer returns the consumer for the current healthy level
@@ -49,8 +63,104 @@ func (f *baseFailoverRouter[C]) reportConsumerError(idx int) {
 	f.errTryLock.TryExecute(f.pS.HandleError, idx)
 }
 
+// isLevelHealthy returns false if the component status reported for the level at idx is unhealthy
+func (f *baseFailoverRouter[C]) isLevelHealthy(idx int) bool {
+	return f.health == nil || f.health.IsHealthy(idx)
+}
+
+// consumeContext bounds the time spent consuming at a single level when a queue saturation timeout is set
+func (f *baseFailoverRouter[C]) consumeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if f.cfg.QueueSaturationTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, f.cfg.QueueSaturationTimeout)
+}
+
+// Start subscribes to the component status of every pipeline when health checking is enabled
+func (f *baseFailoverRouter[C]) Start(host component.Host) error {
+	if f.cfg.HealthCheck == nil {
+		return nil
+	}
+
+	ext, ok := host.GetExtensions()[f.cfg.HealthCheck.Extension]
+	if !ok {
+		return fmt.Errorf("health check extension %q not found", f.cfg.HealthCheck.Extension)
+	}
+	subscriber, ok := ext.(statusSubscriber)
+	if !ok {
+		return fmt.Errorf("extension %q does not provide component status", f.cfg.HealthCheck.Extension)
+	}
+
+	for idx, pipelines := range f.cfg.PipelinePriority {
+		for _, id := range pipelines {
+			statusCh, unsubscribe := subscriber.Subscribe(status.Scope(id.String()), status.Verbose)
+			f.subscribers.Add(1)
+			go f.watchPipeline(idx, id, statusCh, unsubscribe)
+		}
+	}
+	return nil
+}
+
+// watchPipeline updates the health of a level from the status events of one of its pipelines
+func (f *baseFailoverRouter[C]) watchPipeline(idx int, id pipeline.ID, statusCh <-chan *status.AggregateStatus, unsubscribe status.UnsubscribeFunc) {
+	defer f.subscribers.Done()
+	defer unsubscribe()
+	for {
+		select {
+		case st, ok := <-statusCh:
+			if !ok {
+				return
+			}
+			if f.health.UpdatePipeline(idx, id.String(), f.isStatusHealthy(st)) {
+				f.reportConsumerError(idx)
+			}
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// isStatusHealthy returns false if any component of the pipeline reports an error considered unhealthy.
+// Pipelines that have not reported yet are considered healthy
+func (f *baseFailoverRouter[C]) isStatusHealthy(st *status.AggregateStatus) bool {
+	if st == nil {
+		return true
+	}
+	if len(st.ComponentStatusMap) == 0 {
+		return !f.isUnhealthyStatus(st.Status())
+	}
+	for _, cs := range st.ComponentStatusMap {
+		if f.isUnhealthyStatus(cs.Status()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *baseFailoverRouter[C]) isUnhealthyStatus(s componentstatus.Status) bool {
+	switch s {
+	case componentstatus.StatusPermanentError, componentstatus.StatusFatalError:
+		return true
+	case componentstatus.StatusRecoverableError:
+		return f.cfg.HealthCheck.IncludeRecoverable
+	default:
+		return false
+	}
+}
+
+// failback routes data back to a higher priority level that has been healthy for the failback window
+func (f *baseFailoverRouter[C]) failback(idx int) {
+	if idx < f.pS.CurrentPipeline() {
+		f.pS.ResetHealthyPipeline(idx)
+	}
+}
+
 func (f *baseFailoverRouter[C]) Shutdown() {
 	close(f.done)
+	if f.health != nil {
+		f.health.Stop()
+	}
+	f.subscribers.Wait()
 }
 
 func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config) (*baseFailoverRouter[C], error) {
@@ -72,14 +182,18 @@ func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config) (*b
 	}
 
 	selector := state.NewPipelineSelector(notifyRetry, done, pSConstants)
-	return &baseFailoverRouter[C]{
+	router := &baseFailoverRouter[C]{
 		consumers:   consumers,
 		cfg:         cfg,
 		pS:          selector,
 		errTryLock:  state.NewTryLock(),
 		done:        done,
 		notifyRetry: notifyRetry,
-	}, nil
+	}
+	if cfg.HealthCheck != nil {
+		router.health = state.NewLevelHealth(len(cfg.PipelinePriority), cfg.HealthCheck.FailbackWindow, router.failback)
+	}
+	return router, nil
 }
 
 // For Testing
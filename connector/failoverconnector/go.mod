@@ -3,8 +3,10 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/connector/failo
 go 1.23.0
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status v0.128.0
 	github.com/stretchr/testify v1.10.0
 	go.opentelemetry.io/collector/component v1.34.1-0.20250610090210-188191247685
+	go.opentelemetry.io/collector/component/componentstatus v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/component/componenttest v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/confmap v1.34.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/confmap/xconfmap v0.128.1-0.20250610090210-188191247685
@@ -61,3 +63,5 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	sigs.k8s.io/yaml v1.4.0 // indirect
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status => ../../pkg/status
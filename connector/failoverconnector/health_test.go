@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+var healthExtID = component.MustNewID("healthcheckv2")
+
+type statusExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	*status.Aggregator
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func newStatusHost(agg *status.Aggregator) component.Host {
+	return &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{healthExtID: &statusExtension{Aggregator: agg}},
+	}
+}
+
+func recordExporterStatus(agg *status.Aggregator, pipelineID pipeline.ID, st componentstatus.Status) {
+	exporterID := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter).WithPipelines(pipelineID)
+	agg.RecordStatus(exporterID, componentstatus.NewEvent(st))
+}
+
+func newHealthCheckTracesConnector(t *testing.T, cfg *Config, first, second consumer.Traces) *tracesFailover {
+	tracesFirst := cfg.PipelinePriority[0][0]
+	tracesSecond := cfg.PipelinePriority[1][0]
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  first,
+		tracesSecond: second,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	return conn.(*tracesFailover)
+}
+
+func TestTracesHealthCheckFailoverAndFailback(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    time.Hour,
+		HealthCheck: &HealthCheckConfig{
+			Extension:      healthExtID,
+			FailbackWindow: 50 * time.Millisecond,
+		},
+	}
+	failoverConnector := newHealthCheckTracesConnector(t, cfg, &sinkFirst, &sinkSecond)
+
+	agg := status.NewAggregator(status.PriorityPermanent)
+	require.NoError(t, failoverConnector.Start(context.Background(), newStatusHost(agg)))
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+	}()
+
+	tr := sampleTrace()
+	require.NoError(t, failoverConnector.ConsumeTraces(context.Background(), tr))
+	require.Len(t, sinkFirst.AllTraces(), 1)
+
+	recordExporterStatus(agg, tracesFirst, componentstatus.StatusPermanentError)
+
+	require.Eventually(t, func() bool {
+		return consumeTracesAndCheckStable(failoverConnector, 1, tr)
+	}, 3*time.Second, 5*time.Millisecond)
+	require.NotEmpty(t, sinkSecond.AllTraces())
+
+	recordExporterStatus(agg, tracesFirst, componentstatus.StatusOK)
+
+	require.Eventually(t, func() bool {
+		return consumeTracesAndCheckStable(failoverConnector, 0, tr)
+	}, 3*time.Second, 5*time.Millisecond)
+}
+
+func TestTracesHealthCheckIgnoresRecoverable(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    time.Hour,
+		HealthCheck: &HealthCheckConfig{
+			Extension: healthExtID,
+		},
+	}
+	failoverConnector := newHealthCheckTracesConnector(t, cfg, &sinkFirst, &sinkSecond)
+
+	agg := status.NewAggregator(status.PriorityPermanent)
+	require.NoError(t, failoverConnector.Start(context.Background(), newStatusHost(agg)))
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+	}()
+
+	recordExporterStatus(agg, tracesFirst, componentstatus.StatusRecoverableError)
+
+	tr := sampleTrace()
+	require.Never(t, func() bool {
+		return !consumeTracesAndCheckStable(failoverConnector, 0, tr)
+	}, 100*time.Millisecond, 5*time.Millisecond)
+	require.Empty(t, sinkSecond.AllTraces())
+}
+
+func TestTracesHealthCheckStartErrors(t *testing.T) {
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    time.Hour,
+		HealthCheck: &HealthCheckConfig{
+			Extension: healthExtID,
+		},
+	}
+
+	failoverConnector := newHealthCheckTracesConnector(t, cfg, consumertest.NewNop(), consumertest.NewNop())
+	err := failoverConnector.Start(context.Background(), componenttest.NewNopHost())
+	require.ErrorContains(t, err, `health check extension "healthcheckv2" not found`)
+	require.NoError(t, failoverConnector.Shutdown(context.Background()))
+
+	failoverConnector = newHealthCheckTracesConnector(t, cfg, consumertest.NewNop(), consumertest.NewNop())
+	host := &extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{healthExtID: &struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{}},
+	}
+	err = failoverConnector.Start(context.Background(), host)
+	require.ErrorContains(t, err, `extension "healthcheckv2" does not provide component status`)
+	require.NoError(t, failoverConnector.Shutdown(context.Background()))
+}
+
+type blockingTracesConsumer struct {
+	consumertest.TracesSink
+}
+
+func (b *blockingTracesConsumer) ConsumeTraces(ctx context.Context, _ ptrace.Traces) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestTracesQueueSaturationTimeout(t *testing.T) {
+	var sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := &Config{
+		PipelinePriority:       [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:          time.Hour,
+		QueueSaturationTimeout: 10 * time.Millisecond,
+	}
+	failoverConnector := newHealthCheckTracesConnector(t, cfg, &blockingTracesConsumer{}, &sinkSecond)
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+	}()
+
+	tr := sampleTrace()
+	require.NoError(t, failoverConnector.ConsumeTraces(context.Background(), tr))
+	require.Equal(t, 1, failoverConnector.failover.pS.CurrentPipeline())
+	require.Len(t, sinkSecond.AllTraces(), 1)
+}
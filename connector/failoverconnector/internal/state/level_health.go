@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
+
+import (
+	"sync"
+	"time"
+)
+
+// LevelHealth tracks the health of each priority level as reported by component status events. A level is
+// healthy when none of its pipelines report an unhealthy status
+type LevelHealth struct {
+	lock       sync.Mutex
+	levels     []levelState
+	window     time.Duration
+	onFailback func(int)
+	stopped    bool
+}
+
+type levelState struct {
+	unhealthy  map[string]struct{}
+	generation int
+	timer      *time.Timer
+}
+
+// NewLevelHealth creates a LevelHealth for the given number of levels, onFailback is invoked with the index of a
+// level once it has been healthy for the failback window after having been unhealthy
+func NewLevelHealth(levels int, window time.Duration, onFailback func(int)) *LevelHealth {
+	states := make([]levelState, levels)
+	for i := range states {
+		states[i].unhealthy = make(map[string]struct{})
+	}
+	return &LevelHealth{
+		levels:     states,
+		window:     window,
+		onFailback: onFailback,
+	}
+}
+
+// IsHealthy returns whether the level at idx currently reports a healthy status
+func (h *LevelHealth) IsHealthy(idx int) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return len(h.levels[idx].unhealthy) == 0
+}
+
+// UpdatePipeline records the health of a single pipeline of the level at idx and returns true if this update
+// caused the level to become unhealthy
+func (h *LevelHealth) UpdatePipeline(idx int, pipeline string, healthy bool) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	level := &h.levels[idx]
+	wasHealthy := len(level.unhealthy) == 0
+	if healthy {
+		delete(level.unhealthy, pipeline)
+	} else {
+		level.unhealthy[pipeline] = struct{}{}
+	}
+	isHealthy := len(level.unhealthy) == 0
+
+	switch {
+	case wasHealthy && !isHealthy:
+		level.generation++
+		if level.timer != nil {
+			level.timer.Stop()
+			level.timer = nil
+		}
+		return true
+	case !wasHealthy && isHealthy && !h.stopped:
+		level.generation++
+		level.timer = h.scheduleFailback(idx, level.generation)
+	}
+	return false
+}
+
+// scheduleFailback notifies the failback callback once the level has stayed healthy for the failback window
+func (h *LevelHealth) scheduleFailback(idx, generation int) *time.Timer {
+	return time.AfterFunc(h.window, func() {
+		h.lock.Lock()
+		level := &h.levels[idx]
+		fire := !h.stopped && level.generation == generation && len(level.unhealthy) == 0
+		if fire {
+			level.timer = nil
+		}
+		h.lock.Unlock()
+
+		if fire {
+			h.onFailback(idx)
+		}
+	})
+}
+
+// Stop cancels all pending failbacks
+func (h *LevelHealth) Stop() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.stopped = true
+	for i := range h.levels {
+		if h.levels[i].timer != nil {
+			h.levels[i].timer.Stop()
+			h.levels[i].timer = nil
+		}
+	}
+}
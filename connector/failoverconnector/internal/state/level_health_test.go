@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelHealthUpdatePipeline(t *testing.T) {
+	lh := NewLevelHealth(2, time.Minute, func(int) {})
+	defer lh.Stop()
+
+	require.True(t, lh.IsHealthy(0))
+
+	require.True(t, lh.UpdatePipeline(0, "traces/first", false))
+	require.False(t, lh.IsHealthy(0))
+	require.True(t, lh.IsHealthy(1))
+
+	// A second unhealthy pipeline does not change the level's health
+	require.False(t, lh.UpdatePipeline(0, "traces/also_first", false))
+
+	require.False(t, lh.UpdatePipeline(0, "traces/first", true))
+	require.False(t, lh.IsHealthy(0))
+
+	require.False(t, lh.UpdatePipeline(0, "traces/also_first", true))
+	require.True(t, lh.IsHealthy(0))
+}
+
+func TestLevelHealthFailback(t *testing.T) {
+	var failedBack atomic.Int64
+	failedBack.Store(-1)
+	lh := NewLevelHealth(2, 50*time.Millisecond, func(idx int) {
+		failedBack.Store(int64(idx))
+	})
+	defer lh.Stop()
+
+	lh.UpdatePipeline(0, "traces/first", false)
+	lh.UpdatePipeline(0, "traces/first", true)
+
+	require.Eventually(t, func() bool {
+		return failedBack.Load() == 0
+	}, 3*time.Second, 5*time.Millisecond)
+}
+
+func TestLevelHealthFailbackInterrupted(t *testing.T) {
+	var failedBack atomic.Bool
+	lh := NewLevelHealth(1, 100*time.Millisecond, func(int) {
+		failedBack.Store(true)
+	})
+	defer lh.Stop()
+
+	lh.UpdatePipeline(0, "traces/first", false)
+	lh.UpdatePipeline(0, "traces/first", true)
+	lh.UpdatePipeline(0, "traces/first", false)
+
+	time.Sleep(200 * time.Millisecond)
+	require.False(t, failedBack.Load())
+}
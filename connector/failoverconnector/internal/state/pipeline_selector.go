@@ -52,10 +52,7 @@ func (p *PipelineSelector) LaunchRetry() {
 
 	go func() {
 		ticker := time.NewTicker(p.constants.RetryInterval)
-		defer func() {
-			ticker.Stop()
-			p.returnRetryToken()
-		}()
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ticker.C:
@@ -88,8 +85,9 @@ func (p *PipelineSelector) CurrentPipeline() int {
 func (p *PipelineSelector) ResetHealthyPipeline(pipelineIndex int) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
-	if pipelineIndex == 0 {
-		p.retryCancel.Cancel()
+	// the token is returned synchronously so that a failure right after recovering can enable retries again
+	if pipelineIndex == 0 && p.retryCancel.Cancel() {
+		p.returnRetryToken()
 	}
 	p.currentPipeline = pipelineIndex
 }
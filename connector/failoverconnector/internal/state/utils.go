@@ -34,10 +34,14 @@ type CancelManager struct {
 	cancelFunc context.CancelFunc
 }
 
-func (c *CancelManager) Cancel() {
-	if c.cancelFunc != nil {
-		c.cancelFunc()
+// Cancel cancels the current function, if any, and reports whether there was one to cancel
+func (c *CancelManager) Cancel() bool {
+	if c.cancelFunc == nil {
+		return false
 	}
+	c.cancelFunc()
+	c.cancelFunc = nil
+	return true
 }
 
 func (c *CancelManager) UpdateFn(cancelFunc context.CancelFunc) {
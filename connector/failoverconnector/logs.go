@@ -46,7 +46,12 @@ func (f *logsRouter) consumeByHealthyPipeline(ctx context.Context, ld plog.Logs)
 			return errNoValidPipeline
 		}
 
-		if err := tc.ConsumeLogs(ctx, ld); err != nil {
+		if !f.isLevelHealthy(idx) {
+			f.reportConsumerError(idx)
+			continue
+		}
+
+		if err := f.consumeAtLevel(ctx, tc, ld); err != nil {
 			f.reportConsumerError(idx)
 			continue
 		}
@@ -59,8 +64,11 @@ func (f *logsRouter) consumeByHealthyPipeline(ctx context.Context, ld plog.Logs)
 func (f *logsRouter) sampleRetryConsumers(ctx context.Context, ld plog.Logs) bool {
 	stableIndex := f.pS.CurrentPipeline()
 	for i := 0; i < stableIndex; i++ {
+		if !f.isLevelHealthy(i) {
+			continue
+		}
 		consumer := f.getConsumerAtIndex(i)
-		err := consumer.ConsumeLogs(ctx, ld)
+		err := f.consumeAtLevel(ctx, consumer, ld)
 		if err == nil {
 			f.pS.ResetHealthyPipeline(i)
 			return true
@@ -69,8 +77,14 @@ func (f *logsRouter) sampleRetryConsumers(ctx context.Context, ld plog.Logs) boo
 	return false
 }
 
+// consumeAtLevel consumes the logs by a single level, bounded by the queue saturation timeout if set
+func (f *logsRouter) consumeAtLevel(ctx context.Context, tc consumer.Logs, ld plog.Logs) error {
+	ctx, cancel := f.consumeContext(ctx)
+	defer cancel()
+	return tc.ConsumeLogs(ctx, ld)
+}
+
 type logsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -87,6 +101,10 @@ func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return f.failover.Consume(ctx, ld)
 }
 
+func (f *logsFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *logsFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
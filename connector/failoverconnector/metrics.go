@@ -46,7 +46,12 @@ func (f *metricsRouter) consumeByHealthyPipeline(ctx context.Context, md pmetric
 			return errNoValidPipeline
 		}
 
-		if err := tc.ConsumeMetrics(ctx, md); err != nil {
+		if !f.isLevelHealthy(idx) {
+			f.reportConsumerError(idx)
+			continue
+		}
+
+		if err := f.consumeAtLevel(ctx, tc, md); err != nil {
 			f.reportConsumerError(idx)
 			continue
 		}
@@ -59,8 +64,11 @@ func (f *metricsRouter) consumeByHealthyPipeline(ctx context.Context, md pmetric
 func (f *metricsRouter) sampleRetryConsumers(ctx context.Context, md pmetric.Metrics) bool {
 	stableIndex := f.pS.CurrentPipeline()
 	for i := 0; i < stableIndex; i++ {
+		if !f.isLevelHealthy(i) {
+			continue
+		}
 		consumer := f.getConsumerAtIndex(i)
-		err := consumer.ConsumeMetrics(ctx, md)
+		err := f.consumeAtLevel(ctx, consumer, md)
 		if err == nil {
 			f.pS.ResetHealthyPipeline(i)
 			return true
@@ -69,8 +77,14 @@ func (f *metricsRouter) sampleRetryConsumers(ctx context.Context, md pmetric.Met
 	return false
 }
 
+// consumeAtLevel consumes the metrics by a single level, bounded by the queue saturation timeout if set
+func (f *metricsRouter) consumeAtLevel(ctx context.Context, tc consumer.Metrics, md pmetric.Metrics) error {
+	ctx, cancel := f.consumeContext(ctx)
+	defer cancel()
+	return tc.ConsumeMetrics(ctx, md)
+}
+
 type metricsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -87,6 +101,10 @@ func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics
 	return f.failover.Consume(ctx, md)
 }
 
+func (f *metricsFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *metricsFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
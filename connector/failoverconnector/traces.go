@@ -47,7 +47,12 @@ func (f *tracesRouter) consumeByHealthyPipeline(ctx context.Context, td ptrace.T
 			return errNoValidPipeline
 		}
 
-		if err := tc.ConsumeTraces(ctx, td); err != nil {
+		if !f.isLevelHealthy(idx) {
+			f.reportConsumerError(idx)
+			continue
+		}
+
+		if err := f.consumeAtLevel(ctx, tc, td); err != nil {
 			f.reportConsumerError(idx)
 			continue
 		}
@@ -60,8 +65,11 @@ func (f *tracesRouter) consumeByHealthyPipeline(ctx context.Context, td ptrace.T
 func (f *tracesRouter) sampleRetryConsumers(ctx context.Context, td ptrace.Traces) bool {
 	stableIndex := f.pS.CurrentPipeline()
 	for i := 0; i < stableIndex; i++ {
+		if !f.isLevelHealthy(i) {
+			continue
+		}
 		consumer := f.getConsumerAtIndex(i)
-		err := consumer.ConsumeTraces(ctx, td)
+		err := f.consumeAtLevel(ctx, consumer, td)
 		if err == nil {
 			f.pS.ResetHealthyPipeline(i)
 			return true
@@ -70,8 +78,14 @@ func (f *tracesRouter) sampleRetryConsumers(ctx context.Context, td ptrace.Trace
 	return false
 }
 
+// consumeAtLevel consumes the traces by a single level, bounded by the queue saturation timeout if set
+func (f *tracesRouter) consumeAtLevel(ctx context.Context, tc consumer.Traces, td ptrace.Traces) error {
+	ctx, cancel := f.consumeContext(ctx)
+	defer cancel()
+	return tc.ConsumeTraces(ctx, td)
+}
+
 type tracesFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -88,6 +102,10 @@ func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) er
 	return f.failover.Consume(ctx, td)
 }
 
+func (f *tracesFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *tracesFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector"
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var errNoWeightedPipeline = errors.New("at least one pipeline must have a positive weight")
+
+// balancer selects the pipeline that receives the next piece of data. Without a key, pipelines are picked
+// following a smooth weighted round-robin schedule. With a key, pipelines are picked using weighted rendezvous
+// hashing so that the same key always lands on the same pipeline while the set of available pipelines is stable.
+type balancer struct {
+	pipelineIDs []pipeline.ID
+	weights     []float64
+	seeds       []uint64
+	schedule    []int
+	counter     atomic.Uint64
+
+	errorBackoff time.Duration
+	failedUntil  []atomic.Int64
+}
+
+// newBalancer creates a balancer over the pipelines with a positive weight, in the order of pipelineIDs.
+func newBalancer(pipelineIDs []pipeline.ID, cfg *Config) (*balancer, error) {
+	for id := range cfg.Weights {
+		if !slices.Contains(pipelineIDs, id) {
+			return nil, fmt.Errorf("weight configured for pipeline %q which is not connected to the connector", id)
+		}
+	}
+
+	b := &balancer{errorBackoff: cfg.ErrorBackoff}
+	var intWeights []int
+	for _, id := range pipelineIDs {
+		weight, ok := cfg.Weights[id]
+		if !ok {
+			weight = 1
+		}
+		if weight == 0 {
+			continue
+		}
+		b.pipelineIDs = append(b.pipelineIDs, id)
+		b.weights = append(b.weights, float64(weight))
+		b.seeds = append(b.seeds, hashString(id.String()))
+		intWeights = append(intWeights, weight)
+	}
+	if len(b.pipelineIDs) == 0 {
+		return nil, errNoWeightedPipeline
+	}
+	b.schedule = smoothSchedule(intWeights)
+	b.failedUntil = make([]atomic.Int64, len(b.pipelineIDs))
+	return b, nil
+}
+
+// next returns the index of the next available pipeline in the weighted round-robin schedule.
+func (b *balancer) next() int {
+	now := time.Now().UnixNano()
+	n := uint64(len(b.schedule))
+	start := b.counter.Add(1)
+	for i := uint64(0); i < n; i++ {
+		idx := b.schedule[(start+i)%n]
+		if b.isAvailable(idx, now) {
+			return idx
+		}
+	}
+	// every pipeline recently failed, keep distributing the load rather than dropping data
+	return b.schedule[start%n]
+}
+
+// forKey returns the index of the available pipeline with the highest weighted rendezvous score for key.
+func (b *balancer) forKey(key []byte) int {
+	now := time.Now().UnixNano()
+	if idx := b.highestScore(key, func(idx int) bool { return b.isAvailable(idx, now) }); idx >= 0 {
+		return idx
+	}
+	// every pipeline recently failed, fall back to the placement ignoring failures
+	return b.highestScore(key, func(int) bool { return true })
+}
+
+func (b *balancer) highestScore(key []byte, include func(int) bool) int {
+	best, bestScore := -1, math.Inf(-1)
+	for idx, weight := range b.weights {
+		if !include(idx) {
+			continue
+		}
+		h := b.seeds[idx]
+		for _, c := range key {
+			h ^= uint64(c)
+			h *= fnvPrime64
+		}
+		// map the hash to (0, 1) and compute the weighted score -w/ln(u)
+		u := (float64(mix64(h)>>11) + 0.5) / (1 << 53)
+		score := -weight / math.Log(u)
+		if score > bestScore {
+			best, bestScore = idx, score
+		}
+	}
+	return best
+}
+
+// report records the outcome of sending data to the pipeline at idx.
+func (b *balancer) report(idx int, err error) {
+	if err == nil || b.errorBackoff <= 0 {
+		return
+	}
+	b.failedUntil[idx].Store(time.Now().Add(b.errorBackoff).UnixNano())
+}
+
+func (b *balancer) isAvailable(idx int, now int64) bool {
+	return b.errorBackoff <= 0 || b.failedUntil[idx].Load() <= now
+}
+
+// smoothSchedule returns the order in which pipelines are picked by the smooth weighted round-robin
+// algorithm, which interleaves pipelines instead of sending consecutive batches to the same one.
+func smoothSchedule(weights []int) []int {
+	divisor := 0
+	for _, w := range weights {
+		divisor = gcd(divisor, w)
+	}
+	total := 0
+	for i := range weights {
+		weights[i] /= divisor
+		total += weights[i]
+	}
+
+	current := make([]int, len(weights))
+	schedule := make([]int, 0, total)
+	for len(schedule) < total {
+		best := 0
+		for i, w := range weights {
+			current[i] += w
+			if current[i] > current[best] {
+				best = i
+			}
+		}
+		current[best] -= total
+		schedule = append(schedule, best)
+	}
+	return schedule
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+const fnvPrime64 = 1099511628211
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix64 is the splitmix64 finalizer, used to spread the bits of the FNV hash before mapping it to a float.
+func mix64(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roundrobinconnector
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func testPipelineIDs(n int) []pipeline.ID {
+	ids := make([]pipeline.ID, n)
+	for i := range ids {
+		ids[i] = pipeline.NewIDWithName(pipeline.SignalTraces, strconv.Itoa(i))
+	}
+	return ids
+}
+
+func TestSmoothSchedule(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2}, smoothSchedule([]int{1, 1, 1}))
+	assert.Equal(t, []int{0, 1}, smoothSchedule([]int{10, 10}))
+	assert.Equal(t, []int{0, 1, 0, 2, 0, 1, 0}, smoothSchedule([]int{4, 2, 1}))
+}
+
+func TestBalancerWeights(t *testing.T) {
+	ids := testPipelineIDs(3)
+	b, err := newBalancer(ids, &Config{Weights: map[pipeline.ID]int{ids[0]: 95, ids[1]: 5, ids[2]: 0}})
+	require.NoError(t, err)
+	require.Equal(t, ids[:2], b.pipelineIDs)
+
+	counts := make([]int, 2)
+	for i := 0; i < 1000; i++ {
+		counts[b.next()]++
+	}
+	assert.Equal(t, []int{950, 50}, counts)
+}
+
+func TestBalancerInvalidWeights(t *testing.T) {
+	ids := testPipelineIDs(2)
+
+	_, err := newBalancer(ids, &Config{Weights: map[pipeline.ID]int{ids[0]: 0, ids[1]: 0}})
+	require.ErrorIs(t, err, errNoWeightedPipeline)
+
+	unknown := pipeline.NewIDWithName(pipeline.SignalTraces, "unknown")
+	_, err = newBalancer(ids, &Config{Weights: map[pipeline.ID]int{unknown: 1}})
+	require.ErrorContains(t, err, `weight configured for pipeline "traces/unknown"`)
+}
+
+func TestBalancerForKey(t *testing.T) {
+	ids := testPipelineIDs(4)
+	b, err := newBalancer(ids, &Config{})
+	require.NoError(t, err)
+
+	counts := make([]int, len(ids))
+	for i := 0; i < 4000; i++ {
+		key := []byte(strconv.Itoa(i))
+		idx := b.forKey(key)
+		// the same key always lands on the same pipeline
+		require.Equal(t, idx, b.forKey(key))
+		counts[idx]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 1000, c, 150)
+	}
+}
+
+func TestBalancerForKeyWeighted(t *testing.T) {
+	ids := testPipelineIDs(2)
+	b, err := newBalancer(ids, &Config{Weights: map[pipeline.ID]int{ids[0]: 95, ids[1]: 5}})
+	require.NoError(t, err)
+
+	canary := 0
+	for i := 0; i < 10000; i++ {
+		if b.forKey([]byte(strconv.Itoa(i))) == 1 {
+			canary++
+		}
+	}
+	assert.InDelta(t, 500, canary, 100)
+}
+
+func TestBalancerErrorBackoff(t *testing.T) {
+	ids := testPipelineIDs(2)
+	b, err := newBalancer(ids, &Config{ErrorBackoff: time.Hour})
+	require.NoError(t, err)
+
+	key := []byte("key")
+	failed := b.forKey(key)
+	b.report(failed, errors.New("failed"))
+
+	assert.NotEqual(t, failed, b.forKey(key))
+	for i := 0; i < 10; i++ {
+		assert.NotEqual(t, failed, b.next())
+	}
+
+	// when every pipeline failed, data keeps being distributed
+	b.report(1-failed, errors.New("failed"))
+	assert.Equal(t, failed, b.forKey(key))
+}
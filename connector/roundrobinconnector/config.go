@@ -3,5 +3,57 @@
 
 package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector"
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const (
+	traceIDRoutingStr = "traceID"
+	attrRoutingStr    = "attributes"
+)
+
 // Config for the connector
-type Config struct{}
+type Config struct {
+	// Weights assigns a relative weight to pipelines. Pipelines that are not listed have a weight of 1,
+	// a weight of 0 stops sending data to the pipeline.
+	Weights map[pipeline.ID]int `mapstructure:"weights"`
+
+	// RoutingKey makes the distribution sticky: data sharing the same key is always sent to the same pipeline
+	// while the set of available pipelines does not change. Supported values are "traceID" (traces and logs)
+	// and "attributes". When empty, data is distributed in a weighted round-robin fashion.
+	RoutingKey string `mapstructure:"routing_key"`
+
+	// RoutingAttributes lists the resource attributes composing the routing key when RoutingKey is "attributes".
+	RoutingAttributes []string `mapstructure:"routing_attributes"`
+
+	// ErrorBackoff is how long a pipeline that returned an error is skipped. Zero disables skipping.
+	ErrorBackoff time.Duration `mapstructure:"error_backoff"`
+}
+
+// Validate checks if the connector configuration is valid.
+func (c *Config) Validate() error {
+	for id, weight := range c.Weights {
+		if weight < 0 {
+			return fmt.Errorf("weight of pipeline %q must not be negative", id)
+		}
+	}
+
+	switch c.RoutingKey {
+	case "", traceIDRoutingStr:
+	case attrRoutingStr:
+		if len(c.RoutingAttributes) == 0 {
+			return errors.New("routing_attributes must be set when routing_key is \"attributes\"")
+		}
+	default:
+		return fmt.Errorf("unsupported routing_key %q", c.RoutingKey)
+	}
+
+	if c.ErrorBackoff < 0 {
+		return errors.New("error_backoff must not be negative")
+	}
+	return nil
+}
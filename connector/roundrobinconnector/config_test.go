@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roundrobinconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/roundrobinconnector/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id          component.ID
+		expected    *Config
+		errContains string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: &Config{},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "weighted"),
+			expected: &Config{
+				Weights: map[pipeline.ID]int{
+					pipeline.NewIDWithName(pipeline.SignalTraces, "stable"): 95,
+					pipeline.NewIDWithName(pipeline.SignalTraces, "canary"): 5,
+				},
+				ErrorBackoff: 30 * time.Second,
+			},
+		},
+		{
+			id:       component.NewIDWithName(metadata.Type, "traceid"),
+			expected: &Config{RoutingKey: "traceID"},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "attributes"),
+			expected: &Config{
+				RoutingKey:        "attributes",
+				RoutingAttributes: []string{"service.name"},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_attributes"),
+			errContains: "routing_attributes must be set",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_routing_key"),
+			errContains: `unsupported routing_key "span"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "negative_weight"),
+			errContains: "must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.errContains != "" {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.errContains)
+				return
+			}
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
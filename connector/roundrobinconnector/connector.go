@@ -5,28 +5,43 @@ package roundrobinconnector // import "github.com/open-telemetry/opentelemetry-c
 
 import (
 	"context"
-	"sync/atomic"
+	"errors"
+	"slices"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 )
 
-func allConsumers[T any](r router[T]) ([]T, error) {
+var errTraceIDRoutingMetrics = errors.New("routing_key \"traceID\" is not supported for metrics")
+
+func allConsumers[T any](r router[T], cfg *Config) ([]T, *balancer, error) {
 	pipeIDs := r.PipelineIDs()
-	consumers := make([]T, len(pipeIDs))
-	for i, pipeID := range pipeIDs {
+	slices.SortFunc(pipeIDs, func(a, b pipeline.ID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	bal, err := newBalancer(pipeIDs, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	consumers := make([]T, len(bal.pipelineIDs))
+	for i, pipeID := range bal.pipelineIDs {
 		cons, err := r.Consumer(pipeID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		consumers[i] = cons
 	}
-	return consumers, nil
+	return consumers, bal, nil
 }
 
 type router[T any] interface {
@@ -34,40 +49,45 @@ type router[T any] interface {
 	Consumer(pipelineIDs ...pipeline.ID) (T, error)
 }
 
-func newLogs(nextConsumer consumer.Logs) (connector.Logs, error) {
-	nextConsumers, err := allConsumers[consumer.Logs](nextConsumer.(connector.LogsRouterAndConsumer))
+func newLogs(cfg *Config, nextConsumer consumer.Logs) (connector.Logs, error) {
+	nextConsumers, bal, err := allConsumers[consumer.Logs](nextConsumer.(connector.LogsRouterAndConsumer), cfg)
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextLogs: nextConsumers}, nil
+	return &roundRobin{cfg: cfg, balancer: bal, nextLogs: nextConsumers}, nil
 }
 
-func newMetrics(nextConsumer consumer.Metrics) (connector.Metrics, error) {
-	nextConsumers, err := allConsumers[consumer.Metrics](nextConsumer.(connector.MetricsRouterAndConsumer))
+func newMetrics(cfg *Config, nextConsumer consumer.Metrics) (connector.Metrics, error) {
+	if cfg.RoutingKey == traceIDRoutingStr {
+		return nil, errTraceIDRoutingMetrics
+	}
+	nextConsumers, bal, err := allConsumers[consumer.Metrics](nextConsumer.(connector.MetricsRouterAndConsumer), cfg)
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextMetrics: nextConsumers}, nil
+	return &roundRobin{cfg: cfg, balancer: bal, nextMetrics: nextConsumers}, nil
 }
 
-func newTraces(nextConsumer consumer.Traces) (connector.Traces, error) {
-	nextConsumers, err := allConsumers[consumer.Traces](nextConsumer.(connector.TracesRouterAndConsumer))
+func newTraces(cfg *Config, nextConsumer consumer.Traces) (connector.Traces, error) {
+	nextConsumers, bal, err := allConsumers[consumer.Traces](nextConsumer.(connector.TracesRouterAndConsumer), cfg)
 	if err != nil {
 		return nil, err
 	}
-	return &roundRobin{nextTraces: nextConsumers}, nil
+	return &roundRobin{cfg: cfg, balancer: bal, nextTraces: nextConsumers}, nil
 }
 
 // roundRobin is used to pass signals directly from one pipeline to one of the configured once in a round-robin mode.
 // This is useful when there is a need to scale (shard) data processing and downstream components do not
-// handle concurrent requests very well.
+// handle concurrent requests very well. Pipelines can be weighted, and data can be kept on the same pipeline
+// by routing it on its trace ID or on a set of resource attributes.
 type roundRobin struct {
 	component.StartFunc
 	component.ShutdownFunc
-	nextConsumer atomic.Uint64
-	nextMetrics  []consumer.Metrics
-	nextLogs     []consumer.Logs
-	nextTraces   []consumer.Traces
+	cfg         *Config
+	balancer    *balancer
+	nextMetrics []consumer.Metrics
+	nextLogs    []consumer.Logs
+	nextTraces  []consumer.Traces
 }
 
 func (rr *roundRobin) Capabilities() consumer.Capabilities {
@@ -75,13 +95,178 @@ func (rr *roundRobin) Capabilities() consumer.Capabilities {
 }
 
 func (rr *roundRobin) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	return rr.nextLogs[rr.nextConsumer.Add(1)%uint64(len(rr.nextLogs))].ConsumeLogs(ctx, ld)
+	switch rr.cfg.RoutingKey {
+	case traceIDRoutingStr:
+		groups := make(map[int]plog.Logs)
+		for _, batch := range batchpersignal.SplitLogs(ld) {
+			idx := rr.indexForTraceID(batch.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).TraceID())
+			batch.ResourceLogs().MoveAndAppendTo(logsGroup(groups, idx).ResourceLogs())
+		}
+		return logsError(consumeGroups(ctx, groups, rr.consumeLogs))
+	case attrRoutingStr:
+		groups := make(map[int]plog.Logs)
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			rl := ld.ResourceLogs().At(i)
+			rl.CopyTo(logsGroup(groups, rr.indexForResource(rl.Resource())).ResourceLogs().AppendEmpty())
+		}
+		return logsError(consumeGroups(ctx, groups, rr.consumeLogs))
+	default:
+		return rr.consumeLogs(ctx, rr.balancer.next(), ld)
+	}
 }
 
 func (rr *roundRobin) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	return rr.nextMetrics[rr.nextConsumer.Add(1)%uint64(len(rr.nextMetrics))].ConsumeMetrics(ctx, md)
+	if rr.cfg.RoutingKey == attrRoutingStr {
+		groups := make(map[int]pmetric.Metrics)
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			rm := md.ResourceMetrics().At(i)
+			rm.CopyTo(metricsGroup(groups, rr.indexForResource(rm.Resource())).ResourceMetrics().AppendEmpty())
+		}
+		return metricsError(consumeGroups(ctx, groups, rr.consumeMetrics))
+	}
+	return rr.consumeMetrics(ctx, rr.balancer.next(), md)
 }
 
 func (rr *roundRobin) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	return rr.nextTraces[rr.nextConsumer.Add(1)%uint64(len(rr.nextTraces))].ConsumeTraces(ctx, td)
+	switch rr.cfg.RoutingKey {
+	case traceIDRoutingStr:
+		groups := make(map[int]ptrace.Traces)
+		for _, batch := range batchpersignal.SplitTraces(td) {
+			idx := rr.indexForTraceID(batch.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+			batch.ResourceSpans().MoveAndAppendTo(tracesGroup(groups, idx).ResourceSpans())
+		}
+		return tracesError(consumeGroups(ctx, groups, rr.consumeTraces))
+	case attrRoutingStr:
+		groups := make(map[int]ptrace.Traces)
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rs := td.ResourceSpans().At(i)
+			rs.CopyTo(tracesGroup(groups, rr.indexForResource(rs.Resource())).ResourceSpans().AppendEmpty())
+		}
+		return tracesError(consumeGroups(ctx, groups, rr.consumeTraces))
+	default:
+		return rr.consumeTraces(ctx, rr.balancer.next(), td)
+	}
+}
+
+func (rr *roundRobin) consumeLogs(ctx context.Context, idx int, ld plog.Logs) error {
+	err := rr.nextLogs[idx].ConsumeLogs(ctx, ld)
+	rr.balancer.report(idx, err)
+	return err
+}
+
+func (rr *roundRobin) consumeMetrics(ctx context.Context, idx int, md pmetric.Metrics) error {
+	err := rr.nextMetrics[idx].ConsumeMetrics(ctx, md)
+	rr.balancer.report(idx, err)
+	return err
+}
+
+func (rr *roundRobin) consumeTraces(ctx context.Context, idx int, td ptrace.Traces) error {
+	err := rr.nextTraces[idx].ConsumeTraces(ctx, td)
+	rr.balancer.report(idx, err)
+	return err
+}
+
+// indexForTraceID returns the pipeline for a trace ID, data without a trace ID is distributed in round-robin.
+func (rr *roundRobin) indexForTraceID(traceID pcommon.TraceID) int {
+	if traceID.IsEmpty() {
+		return rr.balancer.next()
+	}
+	return rr.balancer.forKey(traceID[:])
+}
+
+// indexForResource returns the pipeline for the routing attributes of a resource, resources without any of
+// the routing attributes are distributed in round-robin.
+func (rr *roundRobin) indexForResource(res pcommon.Resource) int {
+	var key strings.Builder
+	found := false
+	for _, attr := range rr.cfg.RoutingAttributes {
+		if v, ok := res.Attributes().Get(attr); ok {
+			key.WriteString(v.AsString())
+			found = true
+		}
+		// separate values so that different attribute combinations produce different keys
+		key.WriteByte(0)
+	}
+	if !found {
+		return rr.balancer.next()
+	}
+	return rr.balancer.forKey([]byte(key.String()))
+}
+
+// consumeGroups sends every group to its pipeline and returns the groups that failed, so that only
+// their data is retried and the pipelines that accepted their group don't receive it twice.
+func consumeGroups[T any](ctx context.Context, groups map[int]T, consume func(context.Context, int, T) error) ([]T, error) {
+	var failed []T
+	var errs error
+	for idx, group := range groups {
+		if err := consume(ctx, idx, group); err != nil {
+			failed = append(failed, group)
+			errs = errors.Join(errs, err)
+		}
+	}
+	return failed, errs
+}
+
+// logsError returns an error carrying a copy of the failed groups, the groups themselves may still be
+// referenced by the pipelines they were sent to.
+func logsError(failed []plog.Logs, err error) error {
+	if err == nil {
+		return nil
+	}
+	ld := plog.NewLogs()
+	for _, group := range failed {
+		for i := 0; i < group.ResourceLogs().Len(); i++ {
+			group.ResourceLogs().At(i).CopyTo(ld.ResourceLogs().AppendEmpty())
+		}
+	}
+	return consumererror.NewLogs(err, ld)
+}
+
+// metricsError returns an error carrying a copy of the failed groups.
+func metricsError(failed []pmetric.Metrics, err error) error {
+	if err == nil {
+		return nil
+	}
+	md := pmetric.NewMetrics()
+	for _, group := range failed {
+		for i := 0; i < group.ResourceMetrics().Len(); i++ {
+			group.ResourceMetrics().At(i).CopyTo(md.ResourceMetrics().AppendEmpty())
+		}
+	}
+	return consumererror.NewMetrics(err, md)
+}
+
+// tracesError returns an error carrying a copy of the failed groups.
+func tracesError(failed []ptrace.Traces, err error) error {
+	if err == nil {
+		return nil
+	}
+	td := ptrace.NewTraces()
+	for _, group := range failed {
+		for i := 0; i < group.ResourceSpans().Len(); i++ {
+			group.ResourceSpans().At(i).CopyTo(td.ResourceSpans().AppendEmpty())
+		}
+	}
+	return consumererror.NewTraces(err, td)
+}
+
+func logsGroup(groups map[int]plog.Logs, idx int) plog.Logs {
+	if _, ok := groups[idx]; !ok {
+		groups[idx] = plog.NewLogs()
+	}
+	return groups[idx]
+}
+
+func metricsGroup(groups map[int]pmetric.Metrics, idx int) pmetric.Metrics {
+	if _, ok := groups[idx]; !ok {
+		groups[idx] = pmetric.NewMetrics()
+	}
+	return groups[idx]
+}
+
+func tracesGroup(groups map[int]ptrace.Traces, idx int) ptrace.Traces {
+	if _, ok := groups[idx]; !ok {
+		groups[idx] = ptrace.NewTraces()
+	}
+	return groups[idx]
 }
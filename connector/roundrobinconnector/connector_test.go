@@ -5,15 +5,20 @@ package roundrobinconnector
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -140,3 +145,178 @@ func TestTracesRoundRobin(t *testing.T) {
 
 	assert.NoError(t, traces.Shutdown(ctx))
 }
+
+func TestTracesWeighted(t *testing.T) {
+	f := NewFactory()
+	ctx := context.Background()
+	set := connectortest.NewNopSettings(metadata.Type)
+
+	sink1 := new(consumertest.TracesSink)
+	sink2 := new(consumertest.TracesSink)
+	pipelines := newPipelineMap[consumer.Traces](pipeline.SignalTraces, sink1, sink2)
+	cfg := &Config{Weights: map[pipeline.ID]int{
+		pipeline.NewIDWithName(pipeline.SignalTraces, "0"): 3,
+		pipeline.NewIDWithName(pipeline.SignalTraces, "1"): 1,
+	}}
+	traces, err := f.CreateTracesToTraces(ctx, set, cfg, connector.NewTracesRouter(pipelines))
+	require.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		assert.NoError(t, traces.ConsumeTraces(ctx, ptrace.NewTraces()))
+	}
+	assert.Len(t, sink1.AllTraces(), 6)
+	assert.Len(t, sink2.AllTraces(), 2)
+}
+
+func TestTracesRoutingKeyTraceID(t *testing.T) {
+	f := NewFactory()
+	ctx := context.Background()
+	set := connectortest.NewNopSettings(metadata.Type)
+
+	sinks := []*consumertest.TracesSink{new(consumertest.TracesSink), new(consumertest.TracesSink), new(consumertest.TracesSink)}
+	pipelines := newPipelineMap[consumer.Traces](pipeline.SignalTraces, sinks[0], sinks[1], sinks[2])
+	traces, err := f.CreateTracesToTraces(ctx, set, &Config{RoutingKey: traceIDRoutingStr}, connector.NewTracesRouter(pipelines))
+	require.NoError(t, err)
+
+	// every batch contains one span of each of the 16 traces
+	for batch := 0; batch < 3; batch++ {
+		td := ptrace.NewTraces()
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for i := byte(0); i < 16; i++ {
+			spans.AppendEmpty().SetTraceID(pcommon.TraceID{i + 1})
+		}
+		assert.NoError(t, traces.ConsumeTraces(ctx, td))
+	}
+
+	pipelineForTrace := map[pcommon.TraceID]int{}
+	total := 0
+	for idx, sink := range sinks {
+		for _, td := range sink.AllTraces() {
+			spans := td.ResourceSpans()
+			for i := 0; i < spans.Len(); i++ {
+				ss := spans.At(i).ScopeSpans().At(0).Spans()
+				for j := 0; j < ss.Len(); j++ {
+					traceID := ss.At(j).TraceID()
+					if prev, ok := pipelineForTrace[traceID]; ok {
+						assert.Equal(t, prev, idx, "spans of the same trace were sent to different pipelines")
+					}
+					pipelineForTrace[traceID] = idx
+					total++
+				}
+			}
+		}
+	}
+	assert.Equal(t, 48, total)
+	assert.Len(t, pipelineForTrace, 16)
+}
+
+func TestMetricsRoutingKeyAttributes(t *testing.T) {
+	f := NewFactory()
+	ctx := context.Background()
+	set := connectortest.NewNopSettings(metadata.Type)
+
+	sink1 := new(consumertest.MetricsSink)
+	sink2 := new(consumertest.MetricsSink)
+	pipelines := newPipelineMap[consumer.Metrics](pipeline.SignalMetrics, sink1, sink2)
+	cfg := &Config{RoutingKey: attrRoutingStr, RoutingAttributes: []string{"service.name"}}
+	metrics, err := f.CreateMetricsToMetrics(ctx, set, cfg, connector.NewMetricsRouter(pipelines))
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		md := pmetric.NewMetrics()
+		for _, svc := range []string{"a", "b", "c", "d"} {
+			md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", svc)
+		}
+		assert.NoError(t, metrics.ConsumeMetrics(ctx, md))
+	}
+
+	services := func(sink *consumertest.MetricsSink) map[string]int {
+		ret := map[string]int{}
+		for _, md := range sink.AllMetrics() {
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				v, _ := md.ResourceMetrics().At(i).Resource().Attributes().Get("service.name")
+				ret[v.Str()]++
+			}
+		}
+		return ret
+	}
+	svc1, svc2 := services(sink1), services(sink2)
+	for svc, count := range svc1 {
+		assert.Equal(t, 4, count)
+		assert.NotContains(t, svc2, svc)
+	}
+	for _, count := range svc2 {
+		assert.Equal(t, 4, count)
+	}
+	assert.Len(t, svc1, 4-len(svc2))
+}
+
+func TestMetricsRoutingKeyTraceIDUnsupported(t *testing.T) {
+	f := NewFactory()
+	set := connectortest.NewNopSettings(metadata.Type)
+	pipelines := newPipelineMap[consumer.Metrics](pipeline.SignalMetrics, consumertest.NewNop())
+	_, err := f.CreateMetricsToMetrics(context.Background(), set, &Config{RoutingKey: traceIDRoutingStr}, connector.NewMetricsRouter(pipelines))
+	require.ErrorIs(t, err, errTraceIDRoutingMetrics)
+}
+
+func TestLogsErrorBackoff(t *testing.T) {
+	f := NewFactory()
+	ctx := context.Background()
+	set := connectortest.NewNopSettings(metadata.Type)
+
+	sink := new(consumertest.LogsSink)
+	pipelines := newPipelineMap[consumer.Logs](pipeline.SignalLogs, consumertest.NewErr(errors.New("failed")), sink)
+	logs, err := f.CreateLogsToLogs(ctx, set, &Config{ErrorBackoff: time.Hour}, connector.NewLogsRouter(pipelines))
+	require.NoError(t, err)
+
+	var errs int
+	for i := 0; i < 10; i++ {
+		if logs.ConsumeLogs(ctx, plog.NewLogs()) != nil {
+			errs++
+		}
+	}
+	assert.Equal(t, 1, errs)
+	assert.Len(t, sink.AllLogs(), 9)
+}
+
+func TestTracesRoutingKeyTraceIDPartialFailure(t *testing.T) {
+	f := NewFactory()
+	ctx := context.Background()
+	set := connectortest.NewNopSettings(metadata.Type)
+
+	sink := new(consumertest.TracesSink)
+	pipelines := newPipelineMap[consumer.Traces](pipeline.SignalTraces, consumertest.NewErr(errors.New("failed")), sink)
+	traces, err := f.CreateTracesToTraces(ctx, set, &Config{RoutingKey: traceIDRoutingStr}, connector.NewTracesRouter(pipelines))
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := byte(0); i < 16; i++ {
+		spans.AppendEmpty().SetTraceID(pcommon.TraceID{i + 1})
+	}
+	err = traces.ConsumeTraces(ctx, td)
+	require.Error(t, err)
+
+	// only the spans of the failed pipeline are returned to be retried
+	var tracesErr consumererror.Traces
+	require.ErrorAs(t, err, &tracesErr)
+	traceIDs := func(td ptrace.Traces) map[pcommon.TraceID]bool {
+		ret := map[pcommon.TraceID]bool{}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			ss := td.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+			for j := 0; j < ss.Len(); j++ {
+				ret[ss.At(j).TraceID()] = true
+			}
+		}
+		return ret
+	}
+	failed := traceIDs(tracesErr.Data())
+	require.Len(t, sink.AllTraces(), 1)
+	accepted := traceIDs(sink.AllTraces()[0])
+	assert.NotEmpty(t, failed)
+	assert.NotEmpty(t, accepted)
+	assert.Len(t, failed, 16-len(accepted))
+	for traceID := range failed {
+		assert.NotContains(t, accepted, traceID)
+	}
+}
@@ -3,13 +3,16 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/connector/round
 go 1.23.0
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal v0.128.0
 	github.com/stretchr/testify v1.10.0
 	go.opentelemetry.io/collector/component v1.34.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/component/componenttest v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/confmap v1.34.1-0.20250610090210-188191247685
+	go.opentelemetry.io/collector/confmap/xconfmap v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/connector v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/connector/connectortest v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/consumer v1.34.1-0.20250610090210-188191247685
+	go.opentelemetry.io/collector/consumer/consumererror v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/consumer/consumertest v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/pdata v1.34.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/pipeline v0.128.1-0.20250610090210-188191247685
@@ -60,3 +63,5 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	sigs.k8s.io/yaml v1.4.0 // indirect
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal => ../../pkg/batchpersignal
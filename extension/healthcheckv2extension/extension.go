@@ -167,6 +167,12 @@ func (hc *healthCheckExtension) NotReady() error {
 	return nil
 }
 
+// Subscribe allows other components, such as the failover connector, to subscribe to the aggregated
+// component status for the given scope. See status.Aggregator.Subscribe for details.
+func (hc *healthCheckExtension) Subscribe(scope status.Scope, verbosity status.Verbosity) (<-chan *status.AggregateStatus, status.UnsubscribeFunc) {
+	return hc.aggregator.Subscribe(scope, verbosity)
+}
+
 func (hc *healthCheckExtension) eventLoop(ctx context.Context) {
 	// Record events with component.StatusStarting, but queue other events until
 	// PipelineWatcher.Ready is called. This prevents aggregate statuses from
@@ -93,6 +93,33 @@ func TestComponentStatus(t *testing.T) {
 	assert.Equal(t, componentstatus.StatusStopping, st.Status())
 }
 
+func TestSubscribe(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPConfig.Endpoint = testutil.GetAvailableLocalAddress(t)
+	ext := newExtension(context.Background(), *cfg, extensiontest.NewNopSettings(extensiontest.NopType))
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+	require.NoError(t, ext.Ready())
+
+	traces := testhelpers.NewPipelineMetadata(pipeline.SignalTraces)
+	statusCh, unsubscribe := ext.Subscribe(status.Scope(traces.PipelineID.String()), status.Concise)
+	defer unsubscribe()
+
+	// The pipeline has not reported yet
+	assert.Nil(t, <-statusCh)
+
+	ext.ComponentStatusChanged(traces.ExporterID, componentstatus.NewEvent(componentstatus.StatusPermanentError))
+
+	select {
+	case st := <-statusCh:
+		require.NotNil(t, st)
+		assert.Equal(t, componentstatus.StatusPermanentError, st.Status())
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for status event")
+	}
+}
+
 func TestNotifyConfig(t *testing.T) {
 	confMap, err := confmaptest.LoadConf(
 		filepath.Join("internal", "http", "testdata", "config.yaml"),
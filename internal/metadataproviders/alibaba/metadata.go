@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibaba // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/alibaba"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// Alibaba Cloud ECS instance metadata endpoint, see
+	// https://www.alibabacloud.com/help/en/ecs/user-guide/view-instance-metadata
+	metadataEndpoint = "http://100.100.100.200"
+
+	tokenPath    = "/latest/api/token"
+	documentPath = "/latest/dynamic/instance-identity/document"
+	hostnamePath = "/latest/meta-data/hostname"
+
+	tokenHeader    = "X-aliyun-ecs-metadata-token"
+	tokenTTLHeader = "X-aliyun-ecs-metadata-token-ttl-seconds"
+	tokenTTL       = "60"
+)
+
+// Provider gets metadata from the Alibaba Cloud ECS instance metadata service.
+type Provider interface {
+	Metadata(context.Context) (*InstanceMetadata, error)
+}
+
+type alibabaProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider
+func NewProvider() Provider {
+	return &alibabaProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   &http.Client{},
+	}
+}
+
+// InstanceMetadata is the ECS instance identity document, completed with
+// the hostname of the instance.
+type InstanceMetadata struct {
+	AccountID    string `json:"owner-account-id"`
+	InstanceID   string `json:"instance-id"`
+	InstanceType string `json:"instance-type"`
+	ImageID      string `json:"image-id"`
+	RegionID     string `json:"region-id"`
+	ZoneID       string `json:"zone-id"`
+	Hostname     string `json:"-"`
+}
+
+// Metadata queries the instance metadata service in security hardening mode,
+// fetching a session token first.
+func (p *alibabaProviderImpl) Metadata(ctx context.Context) (*InstanceMetadata, error) {
+	token, err := p.request(ctx, http.MethodPut, tokenPath, map[string]string{tokenTTLHeader: tokenTTL})
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{tokenHeader: string(token)}
+
+	document, err := p.request(ctx, http.MethodGet, documentPath, headers)
+	if err != nil {
+		return nil, err
+	}
+	var metadata InstanceMetadata
+	if err = json.Unmarshal(document, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode Alibaba Cloud instance metadata service reply: %w", err)
+	}
+
+	hostname, err := p.request(ctx, http.MethodGet, hostnamePath, headers)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Hostname = strings.TrimSpace(string(hostname))
+
+	return &metadata, nil
+}
+
+func (p *alibabaProviderImpl) request(ctx context.Context, method, path string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Alibaba Cloud instance metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from Alibaba Cloud instance metadata service for %s: %s", path, resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Alibaba Cloud instance metadata service reply: %w", err)
+	}
+	return respBody, nil
+}
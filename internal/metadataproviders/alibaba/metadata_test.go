@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibaba
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider()
+	assert.NotNil(t, provider)
+}
+
+const instanceDocument = `{
+  "zone-id": "cn-hangzhou-i",
+  "serial-number": "4acd2b47-b328-4762-852f-998c5b2d5a3f",
+  "instance-id": "i-bp1ecr1fdlsl11n4aw0l",
+  "region-id": "cn-hangzhou",
+  "private-ipv4": "192.168.0.10",
+  "owner-account-id": "1609235876243251",
+  "mac": "00:16:3e:10:2b:5c",
+  "image-id": "aliyun_3_x64_20G_alibase_20230727.vhd",
+  "instance-type": "ecs.g7.large"
+}`
+
+func newMetadataServer(t *testing.T, document string) *httptest.Server {
+	const token = "AAAAAJ0dOWBS6dQk"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == tokenPath {
+			if r.Method != http.MethodPut || r.Header.Get(tokenTTLHeader) == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, err := fmt.Fprint(w, token)
+			assert.NoError(t, err)
+			return
+		}
+		if r.Header.Get(tokenHeader) != token {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var err error
+		switch r.URL.Path {
+		case documentPath:
+			_, err = fmt.Fprint(w, document)
+		case hostnamePath:
+			_, err = fmt.Fprint(w, "iZbp1ecr1fdlsl11n4aw0lZ")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		assert.NoError(t, err)
+	}))
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &alibabaProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := newMetadataServer(t, instanceDocument)
+	defer ts.Close()
+
+	provider := &alibabaProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceMetadata{
+		AccountID:    "1609235876243251",
+		InstanceID:   "i-bp1ecr1fdlsl11n4aw0l",
+		InstanceType: "ecs.g7.large",
+		ImageID:      "aliyun_3_x64_20G_alibase_20230727.vhd",
+		RegionID:     "cn-hangzhou",
+		ZoneID:       "cn-hangzhou-i",
+		Hostname:     "iZbp1ecr1fdlsl11n4aw0lZ",
+	}, metadata)
+}
+
+func TestQueryEndpointNull(t *testing.T) {
+	ts := newMetadataServer(t, "null")
+	defer ts.Close()
+
+	provider := &alibabaProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceMetadata{Hostname: "iZbp1ecr1fdlsl11n4aw0lZ"}, metadata)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/digitalocean"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// DigitalOcean droplet metadata endpoint, see
+	// https://docs.digitalocean.com/reference/api/metadata-api/
+	metadataEndpoint = "http://169.254.169.254/metadata/v1.json"
+)
+
+// Provider gets metadata from the DigitalOcean droplet metadata service.
+type Provider interface {
+	Metadata(context.Context) (*DropletMetadata, error)
+}
+
+type digitalOceanProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider
+func NewProvider() Provider {
+	return &digitalOceanProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   &http.Client{},
+	}
+}
+
+// DropletMetadata is the DigitalOcean droplet metadata response format
+type DropletMetadata struct {
+	DropletID int64    `json:"droplet_id"`
+	Hostname  string   `json:"hostname"`
+	Region    string   `json:"region"`
+	Tags      []string `json:"tags"`
+}
+
+// Metadata queries the metadata endpoint and parses the reply
+func (p *digitalOceanProviderImpl) Metadata(ctx context.Context) (*DropletMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query DigitalOcean metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from DigitalOcean metadata service: %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DigitalOcean metadata service reply: %w", err)
+	}
+
+	var metadata DropletMetadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode DigitalOcean metadata service reply: %w", err)
+	}
+
+	return &metadata, nil
+}
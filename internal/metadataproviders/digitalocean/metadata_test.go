@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider()
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &digitalOceanProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &digitalOceanProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointNull(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, "null")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &digitalOceanProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &DropletMetadata{}, metadata)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{
+  "droplet_id": 2756294,
+  "hostname": "sample-droplet",
+  "vendor_data": "#cloud-config",
+  "public_keys": ["ssh-rsa AAAA"],
+  "region": "nyc3",
+  "tags": ["web", "production"],
+  "features": {"dhcp_enabled": false}
+}`)
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &digitalOceanProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &DropletMetadata{
+		DropletID: 2756294,
+		Hostname:  "sample-droplet",
+		Region:    "nyc3",
+		Tags:      []string{"web", "production"},
+	}, metadata)
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/hetzner"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// Hetzner Cloud server metadata endpoint, see
+	// https://docs.hetzner.cloud/#server-metadata
+	metadataEndpoint = "http://169.254.169.254/hetzner/v1/metadata"
+)
+
+// Provider gets metadata from the Hetzner Cloud metadata service.
+type Provider interface {
+	Metadata(context.Context) (*ServerMetadata, error)
+}
+
+type hetznerProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider
+func NewProvider() Provider {
+	return &hetznerProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   &http.Client{},
+	}
+}
+
+// ServerMetadata holds the Hetzner Cloud server metadata
+type ServerMetadata struct {
+	InstanceID       string
+	Hostname         string
+	Region           string
+	AvailabilityZone string
+}
+
+// Metadata queries the metadata keys of the server
+func (p *hetznerProviderImpl) Metadata(ctx context.Context) (*ServerMetadata, error) {
+	var metadata ServerMetadata
+	for key, dst := range map[string]*string{
+		"instance-id":       &metadata.InstanceID,
+		"hostname":          &metadata.Hostname,
+		"region":            &metadata.Region,
+		"availability-zone": &metadata.AvailabilityZone,
+	} {
+		value, err := p.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		*dst = value
+	}
+	return &metadata, nil
+}
+
+func (p *hetznerProviderImpl) get(ctx context.Context, key string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/"+key, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query Hetzner metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code from Hetzner metadata service for %q: %s", key, resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Hetzner metadata service reply: %w", err)
+	}
+	return strings.TrimSpace(string(respBody)), nil
+}
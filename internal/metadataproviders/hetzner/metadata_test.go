@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider()
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &hetznerProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	values := map[string]string{
+		"/hetzner/v1/metadata/instance-id":       "42\n",
+		"/hetzner/v1/metadata/hostname":          "my-server",
+		"/hetzner/v1/metadata/region":            "eu-central",
+		"/hetzner/v1/metadata/availability-zone": "fsn1-dc14",
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := values[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := fmt.Fprint(w, value)
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &hetznerProviderImpl{
+		endpoint: ts.URL + "/hetzner/v1/metadata",
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &ServerMetadata{
+		InstanceID:       "42",
+		Hostname:         "my-server",
+		Region:           "eu-central",
+		AvailabilityZone: "fsn1-dc14",
+	}, metadata)
+}
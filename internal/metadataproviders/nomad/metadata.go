@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nomad // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/nomad"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultAddress is the default address of the local Nomad agent
+	DefaultAddress = "http://127.0.0.1:4646"
+
+	// agent information endpoint, see https://developer.hashicorp.com/nomad/api-docs/agent#query-self
+	agentSelfPath = "/v1/agent/self"
+	tokenHeader   = "X-Nomad-Token"
+)
+
+// Provider gets the node metadata from the local Nomad agent.
+type Provider interface {
+	Metadata(context.Context) (*NodeMetadata, error)
+}
+
+type nomadProviderImpl struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+// NewProvider creates a new metadata provider querying the agent at address,
+// authenticating with token if not empty.
+func NewProvider(address, token string) Provider {
+	if address == "" {
+		address = DefaultAddress
+	}
+	return &nomadProviderImpl{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		client:  &http.Client{},
+	}
+}
+
+// NodeMetadata holds the metadata of the node the agent runs on
+type NodeMetadata struct {
+	NodeID     string
+	NodeName   string
+	Datacenter string
+	Region     string
+}
+
+type agentSelf struct {
+	Config struct {
+		Name       string `json:"Name"`
+		Datacenter string `json:"Datacenter"`
+		Region     string `json:"Region"`
+	} `json:"config"`
+	Stats struct {
+		Client struct {
+			NodeID string `json:"node_id"`
+		} `json:"client"`
+	} `json:"stats"`
+}
+
+// Metadata queries the agent and parses its configuration
+func (p *nomadProviderImpl) Metadata(ctx context.Context) (*NodeMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+agentSelfPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if p.token != "" {
+		req.Header.Set(tokenHeader, p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Nomad agent: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from Nomad agent: %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Nomad agent reply: %w", err)
+	}
+
+	var self agentSelf
+	if err = json.Unmarshal(respBody, &self); err != nil {
+		return nil, fmt.Errorf("failed to decode Nomad agent reply: %w", err)
+	}
+
+	return &NodeMetadata{
+		NodeID:     self.Stats.Client.NodeID,
+		NodeName:   self.Config.Name,
+		Datacenter: self.Config.Datacenter,
+		Region:     self.Config.Region,
+	}, nil
+}
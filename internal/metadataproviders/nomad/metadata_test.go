@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("", "")
+	require.NotNil(t, provider)
+	assert.Equal(t, DefaultAddress, provider.(*nomadProviderImpl).address)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL, "").Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL, "").Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != agentSelfPath || r.Header.Get(tokenHeader) != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, err := fmt.Fprint(w, `{
+  "config": {"Name": "node-1", "Datacenter": "dc1", "Region": "global", "Version": {"Version": "1.9.0"}},
+  "member": {"Name": "node-1.global"},
+  "stats": {"client": {"node_id": "8a5e2a24-3c3b-4f56-b2a0-3e2c1c1d5e60", "known_servers": "10.0.0.1:4647"}}
+}`)
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	metadata, err := NewProvider(ts.URL+"/", "secret").Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &NodeMetadata{
+		NodeID:     "8a5e2a24-3c3b-4f56-b2a0-3e2c1c1d5e60",
+		NodeName:   "node-1",
+		Datacenter: "dc1",
+		Region:     "global",
+	}, metadata)
+}
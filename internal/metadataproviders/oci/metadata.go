@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oci // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/oci"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// OCI instance metadata service v2 endpoint, see
+	// https://docs.oracle.com/en-us/iaas/Content/Compute/Tasks/gettingmetadata.htm
+	metadataEndpoint = "http://169.254.169.254/opc/v2/instance/"
+)
+
+// Provider gets metadata from the OCI instance metadata service.
+type Provider interface {
+	Metadata(context.Context) (*ComputeMetadata, error)
+}
+
+type ociProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider
+func NewProvider() Provider {
+	return &ociProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   &http.Client{},
+	}
+}
+
+// ComputeMetadata is the OCI instance metadata response format
+type ComputeMetadata struct {
+	ID                  string `json:"id"`
+	DisplayName         string `json:"displayName"`
+	Hostname            string `json:"hostname"`
+	CompartmentID       string `json:"compartmentId"`
+	TenantID            string `json:"tenantId"`
+	Region              string `json:"region"`
+	CanonicalRegionName string `json:"canonicalRegionName"`
+	AvailabilityDomain  string `json:"availabilityDomain"`
+	FaultDomain         string `json:"faultDomain"`
+	Shape               string `json:"shape"`
+	Image               string `json:"image"`
+}
+
+// Metadata queries the instance metadata endpoint and parses the reply
+func (p *ociProviderImpl) Metadata(ctx context.Context) (*ComputeMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	// the v2 endpoint rejects requests without this header
+	req.Header.Add("Authorization", "Bearer Oracle")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OCI instance metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from OCI instance metadata service: %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCI instance metadata service reply: %w", err)
+	}
+
+	var metadata ComputeMetadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode OCI instance metadata service reply: %w", err)
+	}
+
+	return &metadata, nil
+}
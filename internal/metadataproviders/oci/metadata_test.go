@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider()
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &ociProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &ociProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointNull(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, "null")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &ociProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &ComputeMetadata{}, metadata)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer Oracle" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := fmt.Fprint(w, `{
+  "availabilityDomain": "EMIr:PHX-AD-1",
+  "faultDomain": "FAULT-DOMAIN-3",
+  "compartmentId": "ocid1.compartment.oc1..example",
+  "tenantId": "ocid1.tenancy.oc1..example",
+  "displayName": "my-instance",
+  "hostname": "my-hostname",
+  "id": "ocid1.instance.oc1.phx.example",
+  "image": "ocid1.image.oc1.phx.example",
+  "region": "phx",
+  "canonicalRegionName": "us-phoenix-1",
+  "shape": "VM.Standard.E4.Flex",
+  "state": "Running"
+}`)
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &ociProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &ComputeMetadata{
+		ID:                  "ocid1.instance.oc1.phx.example",
+		DisplayName:         "my-instance",
+		Hostname:            "my-hostname",
+		CompartmentID:       "ocid1.compartment.oc1..example",
+		TenantID:            "ocid1.tenancy.oc1..example",
+		Region:              "phx",
+		CanonicalRegionName: "us-phoenix-1",
+		AvailabilityDomain:  "EMIr:PHX-AD-1",
+		FaultDomain:         "FAULT-DOMAIN-3",
+		Shape:               "VM.Standard.E4.Flex",
+		Image:               "ocid1.image.oc1.phx.example",
+	}, metadata)
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// OpenStack Nova metadata service endpoint, see
+	// https://docs.openstack.org/nova/latest/user/metadata.html
+	metadataEndpoint = "http://169.254.169.254/openstack/latest/meta_data.json"
+)
+
+// Provider gets metadata from the OpenStack metadata service.
+type Provider interface {
+	Metadata(context.Context) (*InstanceMetadata, error)
+}
+
+type openstackProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider
+func NewProvider() Provider {
+	return &openstackProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   &http.Client{},
+	}
+}
+
+// InstanceMetadata is the OpenStack meta_data.json response format
+type InstanceMetadata struct {
+	UUID             string            `json:"uuid"`
+	Name             string            `json:"name"`
+	Hostname         string            `json:"hostname"`
+	AvailabilityZone string            `json:"availability_zone"`
+	ProjectID        string            `json:"project_id"`
+	Meta             map[string]string `json:"meta"`
+}
+
+// Metadata queries the metadata endpoint and parses the reply
+func (p *openstackProviderImpl) Metadata(ctx context.Context) (*InstanceMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OpenStack metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from OpenStack metadata service: %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenStack metadata service reply: %w", err)
+	}
+
+	var metadata InstanceMetadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenStack metadata service reply: %w", err)
+	}
+
+	return &metadata, nil
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider()
+	assert.NotNil(t, provider)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	provider := &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	_, err := provider.Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointNull(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, "null")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceMetadata{}, metadata)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{
+  "uuid": "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+  "name": "test",
+  "hostname": "test.novalocal",
+  "availability_zone": "nova",
+  "project_id": "f7ac731cc11f40efbc03a9f9e1d1d21f",
+  "launch_index": 0,
+  "meta": {"role": "webserver"},
+  "devices": []
+}`)
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	provider := &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+
+	metadata, err := provider.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceMetadata{
+		UUID:             "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		Name:             "test",
+		Hostname:         "test.novalocal",
+		AvailabilityZone: "nova",
+		ProjectID:        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		Meta:             map[string]string{"role": "webserver"},
+	}, metadata)
+}
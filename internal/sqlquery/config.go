@@ -6,8 +6,12 @@ package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-co
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 )
 
@@ -44,6 +48,12 @@ type Query struct {
 	Logs               []LogsCfg   `mapstructure:"logs"`
 	TrackingColumn     string      `mapstructure:"tracking_column"`
 	TrackingStartValue string      `mapstructure:"tracking_start_value"`
+	// CollectionInterval overrides the collection interval of the receiver for this query
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// Timeout cancels the executions of the query that take longer. 0 means no timeout
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Params are the values bound to the parameters of the query, in order
+	Params []ParamCfg `mapstructure:"params"`
 }
 
 func (q Query) Validate() error {
@@ -54,6 +64,20 @@ func (q Query) Validate() error {
 	if len(q.Logs) == 0 && len(q.Metrics) == 0 {
 		errs = append(errs, errors.New("at least one of 'query.logs' and 'query.metrics' must not be empty"))
 	}
+	if q.CollectionInterval < 0 {
+		errs = append(errs, errors.New("'query.collection_interval' must not be negative"))
+	}
+	if q.Timeout < 0 {
+		errs = append(errs, errors.New("'query.timeout' must not be negative"))
+	}
+	for _, param := range q.Params {
+		if err := param.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+		if param.Source == ParamSourceTrackingValue && q.TrackingColumn == "" {
+			errs = append(errs, errors.New("'tracking_column' must not be empty for a param of source 'tracking_value'"))
+		}
+	}
 	for _, logs := range q.Logs {
 		if err := logs.Validate(); err != nil {
 			errs = append(errs, err)
@@ -68,8 +92,16 @@ func (q Query) Validate() error {
 }
 
 type LogsCfg struct {
-	BodyColumn       string   `mapstructure:"body_column"`
-	AttributeColumns []string `mapstructure:"attribute_columns"`
+	BodyColumn       string         `mapstructure:"body_column"`
+	BodyFormat       LogsBodyFormat `mapstructure:"body_format"`
+	AttributeColumns []string       `mapstructure:"attribute_columns"`
+	TsColumn         string         `mapstructure:"ts_column"`
+	ObservedTsColumn string         `mapstructure:"observed_ts_column"`
+	SeverityColumn   string         `mapstructure:"severity_column"`
+	// SeverityMapping maps the severities to the values of the severity column
+	SeverityMapping map[string][]string `mapstructure:"severity_mapping"`
+	TraceIDColumn   string              `mapstructure:"trace_id_column"`
+	SpanIDColumn    string              `mapstructure:"span_id_column"`
 }
 
 func (config LogsCfg) Validate() error {
@@ -77,9 +109,66 @@ func (config LogsCfg) Validate() error {
 	if config.BodyColumn == "" {
 		errs = append(errs, errors.New("'body_column' must not be empty"))
 	}
+	if err := config.BodyFormat.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(config.SeverityMapping) > 0 && config.SeverityColumn == "" {
+		errs = append(errs, errors.New("'severity_column' must not be empty with a 'severity_mapping'"))
+	}
+	for severity := range config.SeverityMapping {
+		if _, ok := severityNumbers[strings.ToLower(severity)]; !ok {
+			errs = append(errs, fmt.Errorf("'severity_mapping' has unsupported severity: '%s'", severity))
+		}
+	}
 	return errors.Join(errs...)
 }
 
+type LogsBodyFormat string
+
+const (
+	LogsBodyFormatUnspecified LogsBodyFormat = ""
+	LogsBodyFormatString      LogsBodyFormat = "string"
+	// LogsBodyFormatJSON expands a JSON body column into a structured body
+	LogsBodyFormatJSON LogsBodyFormat = "json"
+)
+
+func (f LogsBodyFormat) Validate() error {
+	switch f {
+	case LogsBodyFormatUnspecified, LogsBodyFormatString, LogsBodyFormatJSON:
+		return nil
+	}
+	return fmt.Errorf("logs config has unsupported body_format: '%s'", f)
+}
+
+// ParseSeverity returns the severity of a value of the severity column. Without a mapping
+// for the value, it parses the names of the severities, e.g. "INFO" or "error2", and their
+// numbers, e.g. "9" for info.
+func (config LogsCfg) ParseSeverity(value string) plog.SeverityNumber {
+	for severity, values := range config.SeverityMapping {
+		for _, v := range values {
+			if v == value {
+				return severityNumbers[strings.ToLower(severity)]
+			}
+		}
+	}
+	if severity, ok := severityNumbers[strings.ToLower(value)]; ok {
+		return severity
+	}
+	if number, err := strconv.Atoi(value); err == nil && number >= int(plog.SeverityNumberTrace) && number <= int(plog.SeverityNumberFatal4) {
+		return plog.SeverityNumber(number)
+	}
+	return plog.SeverityNumberUnspecified
+}
+
+// severityNumbers are the severities by their lowercase names, e.g. "info" or "error2"
+var severityNumbers = func() map[string]plog.SeverityNumber {
+	severities := map[string]plog.SeverityNumber{}
+	for severity := plog.SeverityNumberTrace; severity <= plog.SeverityNumberFatal4; severity++ {
+		severities[strings.ToLower(severity.String())] = severity
+	}
+	return severities
+}()
+
 type MetricCfg struct {
 	MetricName       string            `mapstructure:"metric_name"`
 	ValueColumn      string            `mapstructure:"value_column"`
@@ -170,9 +259,15 @@ func (a MetricAggregation) Validate() error {
 }
 
 type TelemetryConfig struct {
-	Logs TelemetryLogsConfig `mapstructure:"logs"`
+	Logs   TelemetryLogsConfig   `mapstructure:"logs"`
+	Traces TelemetryTracesConfig `mapstructure:"traces"`
 }
 
 type TelemetryLogsConfig struct {
 	Query bool `mapstructure:"query"`
 }
+
+type TelemetryTracesConfig struct {
+	// Query emits a span for each execution of a query
+	Query bool `mapstructure:"query"`
+}
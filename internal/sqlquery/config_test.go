@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLogsCfg_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    LogsCfg
+		errMsg string
+	}{
+		{
+			name: "valid",
+			cfg: LogsCfg{
+				BodyColumn:      "body",
+				BodyFormat:      LogsBodyFormatJSON,
+				SeverityColumn:  "level",
+				SeverityMapping: map[string][]string{"Error": {"E", "ERR"}, "warn2": {"W"}},
+			},
+		},
+		{
+			name:   "unsupported body format",
+			cfg:    LogsCfg{BodyColumn: "body", BodyFormat: "xml"},
+			errMsg: "logs config has unsupported body_format: 'xml'",
+		},
+		{
+			name:   "severity mapping without severity column",
+			cfg:    LogsCfg{BodyColumn: "body", SeverityMapping: map[string][]string{"error": {"E"}}},
+			errMsg: "'severity_column' must not be empty with a 'severity_mapping'",
+		},
+		{
+			name:   "unsupported severity",
+			cfg:    LogsCfg{BodyColumn: "body", SeverityColumn: "level", SeverityMapping: map[string][]string{"critical": {"C"}}},
+			errMsg: "'severity_mapping' has unsupported severity: 'critical'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestLogsCfg_ParseSeverity(t *testing.T) {
+	cfg := LogsCfg{
+		SeverityMapping: map[string][]string{
+			"error": {"E", "ERR"},
+			"Warn2": {"W"},
+			"info":  {"error"},
+		},
+	}
+	tests := map[string]plog.SeverityNumber{
+		"E":       plog.SeverityNumberError,
+		"ERR":     plog.SeverityNumberError,
+		"W":       plog.SeverityNumberWarn2,
+		"error":   plog.SeverityNumberInfo,
+		"DEBUG":   plog.SeverityNumberDebug,
+		"fatal4":  plog.SeverityNumberFatal4,
+		"9":       plog.SeverityNumberInfo,
+		"24":      plog.SeverityNumberFatal4,
+		"0":       plog.SeverityNumberUnspecified,
+		"25":      plog.SeverityNumberUnspecified,
+		"e":       plog.SeverityNumberUnspecified,
+		"warning": plog.SeverityNumberUnspecified,
+	}
+	for value, expected := range tests {
+		assert.Equal(t, expected, cfg.ParseSeverity(value), value)
+	}
+}
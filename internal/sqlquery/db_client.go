@@ -74,9 +74,12 @@ type FakeDBClient struct {
 	RequestCounter int
 	StringMaps     [][]StringMap
 	Err            error
+	// Args are the arguments of each request
+	Args [][]any
 }
 
-func (c *FakeDBClient) QueryRows(context.Context, ...any) ([]StringMap, error) {
+func (c *FakeDBClient) QueryRows(_ context.Context, args ...any) ([]StringMap, error) {
+	c.Args = append(c.Args, args)
 	if c.Err != nil {
 		return nil, c.Err
 	}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type ParamCfg struct {
+	// Name binds the value to a named parameter, e.g. @name, rather than to a positional one.
+	// The named parameters must be supported by the driver.
+	Name string `mapstructure:"name"`
+	// Value is a constant value
+	Value string `mapstructure:"value"`
+	// Source binds a value computed for each execution of the query
+	Source ParamSource `mapstructure:"source"`
+}
+
+func (p ParamCfg) Validate() error {
+	if err := p.Source.Validate(); err != nil {
+		return err
+	}
+	if p.Source != ParamSourceUnspecified && p.Value != "" {
+		return fmt.Errorf("param has both a value and source '%s'", p.Source)
+	}
+	return nil
+}
+
+type ParamSource string
+
+const (
+	ParamSourceUnspecified ParamSource = ""
+	// ParamSourceTrackingValue is the value of the tracking column in the last row of the previous execution
+	ParamSourceTrackingValue ParamSource = "tracking_value"
+	// ParamSourceWindowStart is the time of the previous execution, or one collection interval ago for the first one
+	ParamSourceWindowStart ParamSource = "window_start"
+	// ParamSourceWindowEnd is the time of the execution
+	ParamSourceWindowEnd ParamSource = "window_end"
+)
+
+func (s ParamSource) Validate() error {
+	switch s {
+	case ParamSourceUnspecified, ParamSourceTrackingValue, ParamSourceWindowStart, ParamSourceWindowEnd:
+		return nil
+	}
+	return fmt.Errorf("param has unsupported source: '%s'", s)
+}
+
+// ParamValues are the values computed for an execution of a query
+type ParamValues struct {
+	TrackingValue string
+	WindowStart   time.Time
+	WindowEnd     time.Time
+}
+
+// Args returns the arguments of an execution of the query
+func (q Query) Args(values ParamValues) []any {
+	var args []any
+	for _, param := range q.Params {
+		var value any
+		switch param.Source {
+		case ParamSourceTrackingValue:
+			value = values.TrackingValue
+		case ParamSourceWindowStart:
+			value = values.WindowStart
+		case ParamSourceWindowEnd:
+			value = values.WindowEnd
+		default:
+			value = param.Value
+		}
+		if param.Name != "" {
+			value = sql.Named(param.Name, value)
+		}
+		args = append(args, value)
+	}
+	return args
+}
+
+// Window tracks the time window between the successive executions of a query
+type Window struct {
+	end time.Time
+}
+
+// Next returns the window of an execution of the query at the given time. The window only
+// moves forward once the execution succeeded, see Advance.
+func (w *Window) Next(now time.Time, interval time.Duration) (start, end time.Time) {
+	if w.end.IsZero() {
+		return now.Add(-interval), now
+	}
+	return w.end, now
+}
+
+// Advance moves the window after a successful execution ending at the given time
+func (w *Window) Advance(end time.Time) {
+	w.end = end
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const querySpanName = "sqlquery.query"
+
+// Attributes of the spans of the executions of the queries
+const (
+	attributeQueryText    = "db.query.text"
+	attributeReturnedRows = "db.response.returned_rows"
+)
+
+var errQueryTimeout = errors.New("query timed out")
+
+// QueryRows runs an execution of the query with its timeout, and records a span of the
+// execution when the tracer isn't nil.
+func QueryRows(ctx context.Context, client DbClient, query Query, tracer trace.Tracer, args ...any) ([]StringMap, error) {
+	if query.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, query.Timeout)
+		defer cancel()
+	}
+
+	if tracer != nil {
+		var span trace.Span
+		ctx, span = tracer.Start(ctx, querySpanName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String(attributeQueryText, query.SQL)),
+		)
+		defer span.End()
+		rows, err := queryRows(ctx, client, query, args)
+		span.SetAttributes(attribute.Int(attributeReturnedRows, len(rows)))
+		if err != nil && !errors.Is(err, ErrNullValueWarning) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return rows, err
+	}
+	return queryRows(ctx, client, query, args)
+}
+
+func queryRows(ctx context.Context, client DbClient, query Query, args []any) ([]StringMap, error) {
+	rows, err := client.QueryRows(ctx, args...)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return rows, fmt.Errorf("%w after %s: %w", errQueryTimeout, query.Timeout, err)
+	}
+	return rows, err
+}
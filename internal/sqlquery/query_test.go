@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type blockingDBClient struct{}
+
+func (blockingDBClient) QueryRows(ctx context.Context, _ ...any) ([]StringMap, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestQueryRows_Span(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	client := &FakeDBClient{
+		StringMaps: [][]StringMap{{{"count": "42"}, {"count": "63"}}},
+	}
+	query := Query{SQL: "select count from foo where id > ?"}
+
+	rows, err := QueryRows(context.Background(), client, query, tracer, "7")
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, [][]any{{"7"}}, client.Args)
+
+	client.Err = errors.New("oops")
+	_, err = QueryRows(context.Background(), client, query, tracer)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Equal(t, querySpanName, span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Contains(t, span.Attributes(), attribute.String(attributeQueryText, query.SQL))
+	}
+	assert.Contains(t, spans[0].Attributes(), attribute.Int(attributeReturnedRows, 2))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Contains(t, spans[1].Attributes(), attribute.Int(attributeReturnedRows, 0))
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestQueryRows_Timeout(t *testing.T) {
+	_, err := QueryRows(context.Background(), blockingDBClient{}, Query{Timeout: 10 * time.Millisecond}, nil)
+	require.ErrorIs(t, err, errQueryTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQuery_Args(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	end := start.Add(time.Minute)
+	query := Query{
+		Params: []ParamCfg{
+			{Source: ParamSourceTrackingValue},
+			{Name: "start", Source: ParamSourceWindowStart},
+			{Name: "end", Source: ParamSourceWindowEnd},
+			{Value: "acme"},
+		},
+	}
+	args := query.Args(ParamValues{TrackingValue: "42", WindowStart: start, WindowEnd: end})
+	assert.Equal(t, []any{"42", sql.Named("start", start), sql.Named("end", end), "acme"}, args)
+
+	assert.Nil(t, Query{}.Args(ParamValues{TrackingValue: "42"}))
+}
+
+func TestWindow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var w Window
+
+	start, end := w.Next(now, time.Minute)
+	assert.Equal(t, now.Add(-time.Minute), start)
+	assert.Equal(t, now, end)
+
+	// The window doesn't move forward until the execution succeeded
+	start, end = w.Next(now.Add(time.Minute), time.Minute)
+	assert.Equal(t, now, start)
+	assert.Equal(t, now.Add(time.Minute), end)
+	w.Advance(end)
+
+	start, end = w.Next(now.Add(3*time.Minute), time.Minute)
+	assert.Equal(t, now.Add(time.Minute), start)
+	assert.Equal(t, now.Add(3*time.Minute), end)
+}
+
+func TestQuery_Validate(t *testing.T) {
+	metrics := []MetricCfg{{MetricName: "my.metric", ValueColumn: "count"}}
+	tests := []struct {
+		name   string
+		query  Query
+		errMsg string
+	}{
+		{
+			name: "valid",
+			query: Query{
+				SQL:                "select count from foo where id > ? and ts < @end",
+				Metrics:            metrics,
+				TrackingColumn:     "id",
+				CollectionInterval: time.Minute,
+				Timeout:            time.Second,
+				Params: []ParamCfg{
+					{Source: ParamSourceTrackingValue},
+					{Name: "end", Source: ParamSourceWindowEnd},
+				},
+			},
+		},
+		{
+			name:   "negative collection interval",
+			query:  Query{SQL: "select 1", Metrics: metrics, CollectionInterval: -time.Second},
+			errMsg: "'query.collection_interval' must not be negative",
+		},
+		{
+			name:   "negative timeout",
+			query:  Query{SQL: "select 1", Metrics: metrics, Timeout: -time.Second},
+			errMsg: "'query.timeout' must not be negative",
+		},
+		{
+			name:   "unsupported param source",
+			query:  Query{SQL: "select 1", Metrics: metrics, Params: []ParamCfg{{Source: "now"}}},
+			errMsg: "param has unsupported source: 'now'",
+		},
+		{
+			name:   "param with value and source",
+			query:  Query{SQL: "select 1", Metrics: metrics, Params: []ParamCfg{{Value: "1", Source: ParamSourceWindowEnd}}},
+			errMsg: "param has both a value and source 'window_end'",
+		},
+		{
+			name:   "tracking value param without tracking column",
+			query:  Query{SQL: "select 1", Metrics: metrics, Params: []ParamCfg{{Source: ParamSourceTrackingValue}}},
+			errMsg: "'tracking_column' must not be empty for a param of source 'tracking_value'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/scraper"
 	"go.opentelemetry.io/collector/scraper/scrapererror"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -37,11 +38,15 @@ type Scraper struct {
 	Client               DbClient
 	Db                   *sql.DB
 	InstrumentationScope pcommon.InstrumentationScope
+	Tracer               trace.Tracer
+
+	trackingValue string
+	window        Window
 }
 
 var _ scraper.Metrics = (*Scraper)(nil)
 
-func NewScraper(id component.ID, query Query, scrapeCfg scraperhelper.ControllerConfig, logger *zap.Logger, telemetry TelemetryConfig, dbProviderFunc DbProviderFunc, clientProviderFunc ClientProviderFunc, instrumentationScope pcommon.InstrumentationScope) *Scraper {
+func NewScraper(id component.ID, query Query, scrapeCfg scraperhelper.ControllerConfig, logger *zap.Logger, telemetry TelemetryConfig, dbProviderFunc DbProviderFunc, clientProviderFunc ClientProviderFunc, instrumentationScope pcommon.InstrumentationScope, tracer trace.Tracer) *Scraper {
 	return &Scraper{
 		id:                   id,
 		Query:                query,
@@ -51,6 +56,8 @@ func NewScraper(id component.ID, query Query, scrapeCfg scraperhelper.Controller
 		DbProviderFunc:       dbProviderFunc,
 		ClientProviderFunc:   clientProviderFunc,
 		InstrumentationScope: instrumentationScope,
+		Tracer:               tracer,
+		trackingValue:        query.TrackingStartValue,
 	}
 }
 
@@ -72,13 +79,23 @@ func (s *Scraper) Start(context.Context, component.Host) error {
 
 func (s *Scraper) ScrapeMetrics(ctx context.Context) (pmetric.Metrics, error) {
 	out := pmetric.NewMetrics()
-	rows, err := s.Client.QueryRows(ctx)
+	windowStart, windowEnd := s.window.Next(time.Now(), s.ScrapeCfg.CollectionInterval)
+	args := s.Query.Args(ParamValues{
+		TrackingValue: s.trackingValue,
+		WindowStart:   windowStart,
+		WindowEnd:     windowEnd,
+	})
+	rows, err := QueryRows(ctx, s.Client, s.Query, s.Tracer, args...)
 	if err != nil {
 		if !errors.Is(err, ErrNullValueWarning) {
 			return out, fmt.Errorf("Scraper: %w", err)
 		}
 		s.Logger.Warn("problems encountered getting metric rows", zap.Error(err))
 	}
+	s.window.Advance(windowEnd)
+	if s.Query.TrackingColumn != "" && len(rows) > 0 {
+		s.trackingValue = rows[len(rows)-1][s.Query.TrackingColumn]
+	}
 	ts := pcommon.NewTimestampFromTime(time.Now())
 	rms := out.ResourceMetrics()
 	rm := rms.AppendEmpty()
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
 	"go.uber.org/zap"
 )
 
@@ -528,3 +530,52 @@ func TestScraper_StartAndTS_ErrorOnParse(t *testing.T) {
 	_, err := scrpr.ScrapeMetrics(context.Background())
 	assert.Error(t, err)
 }
+
+func TestScraper_Params(t *testing.T) {
+	client := &FakeDBClient{
+		StringMaps: [][]StringMap{
+			{{"id": "3", "count": "1"}, {"id": "5", "count": "2"}},
+			nil,
+			{{"id": "8", "count": "3"}},
+		},
+	}
+	scrpr := Scraper{
+		InstrumentationScope: pcommon.NewInstrumentationScope(),
+		Client:               client,
+		ScrapeCfg:            scraperhelper.ControllerConfig{CollectionInterval: time.Minute},
+		Query: Query{
+			TrackingColumn: "id",
+			Params: []ParamCfg{
+				{Source: ParamSourceTrackingValue},
+				{Source: ParamSourceWindowStart},
+				{Source: ParamSourceWindowEnd},
+			},
+			Metrics: []MetricCfg{{
+				MetricName:  "my.metric",
+				ValueColumn: "count",
+				ValueType:   MetricValueTypeInt,
+				DataType:    MetricTypeGauge,
+			}},
+		},
+		trackingValue: "0",
+	}
+	for range client.StringMaps {
+		_, err := scrpr.ScrapeMetrics(context.Background())
+		require.NoError(t, err)
+	}
+
+	require.Len(t, client.Args, 3)
+	trackingValues := []any{client.Args[0][0], client.Args[1][0], client.Args[2][0]}
+	// The tracking value is kept when a scrape returns no rows
+	assert.Equal(t, []any{"0", "5", "5"}, trackingValues)
+	for i, args := range client.Args {
+		start, end := args[1].(time.Time), args[2].(time.Time)
+		assert.False(t, end.Before(start))
+		if i == 0 {
+			assert.Equal(t, time.Minute, end.Sub(start))
+		} else {
+			// The windows of successive scrapes are contiguous
+			assert.Equal(t, client.Args[i-1][2], start)
+		}
+	}
+}
@@ -4,10 +4,15 @@
 package syslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/syslog"
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"time"
 
+	"github.com/jpillora/backoff"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
@@ -39,9 +44,18 @@ type Config struct {
 	syslog.BaseConfig  `mapstructure:",squash"`
 	TCP                *tcp.BaseConfig `mapstructure:"tcp"`
 	UDP                *udp.BaseConfig `mapstructure:"udp"`
+	RELP               *RELPConfig     `mapstructure:"relp"`
 	OnError            string          `mapstructure:"on_error"`
 }
 
+// RELPConfig is the configuration of a listener of the Reliable Event Logging Protocol.
+type RELPConfig struct {
+	MaxLogSize    helper.ByteSize         `mapstructure:"max_log_size,omitempty"`
+	ListenAddress string                  `mapstructure:"listen_address,omitempty"`
+	TLS           *configtls.ServerConfig `mapstructure:"tls,omitempty"`
+	AddAttributes bool                    `mapstructure:"add_attributes,omitempty"`
+}
+
 func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
 	inputBase, err := c.InputConfig.Build(set)
 	if err != nil {
@@ -66,7 +80,10 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 		tcpInputCfg.AttributerConfig = c.AttributerConfig
 		tcpInputCfg.IdentifierConfig = c.IdentifierConfig
 		tcpInputCfg.BaseConfig = *c.TCP
-		if syslogParserCfg.EnableOctetCounting {
+		switch {
+		case syslogParserCfg.EnableOctetCounting && syslogParserCfg.NonTransparentFramingTrailer != nil:
+			tcpInputCfg.SplitFuncBuilder = MixedSplitFuncBuilder(*syslogParserCfg.NonTransparentFramingTrailer)
+		case syslogParserCfg.EnableOctetCounting:
 			tcpInputCfg.SplitFuncBuilder = OctetSplitFuncBuilder
 		}
 
@@ -115,5 +132,59 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 		}, nil
 	}
 
-	return nil, errors.New("need tcp config or udp config")
+	if c.RELP != nil {
+		return c.buildRELP(inputBase, syslogParser.(*syslog.Parser))
+	}
+
+	return nil, errors.New("need tcp, udp or relp config")
+}
+
+func (c Config) buildRELP(inputBase helper.InputOperator, syslogParser *syslog.Parser) (operator.Operator, error) {
+	// RELP frames the messages itself
+	if c.EnableOctetCounting || c.NonTransparentFramingTrailer != nil {
+		return nil, errors.New("octet_counting and non_transparent_framing is not compatible with RELP")
+	}
+
+	maxLogSize := c.RELP.MaxLogSize
+	if maxLogSize == 0 {
+		maxLogSize = tcp.DefaultMaxLogSize
+	}
+
+	if c.RELP.ListenAddress == "" {
+		return nil, errors.New("missing required parameter 'relp.listen_address'")
+	}
+
+	// validate the input address
+	if _, err := net.ResolveTCPAddr("tcp", c.RELP.ListenAddress); err != nil {
+		return nil, fmt.Errorf("failed to resolve relp.listen_address: %w", err)
+	}
+
+	input := &Input{
+		InputOperator: inputBase,
+		parser:        syslogParser,
+	}
+	input.relp = &relpInput{
+		InputOperator: &input.InputOperator,
+		parser:        syslogParser,
+		address:       c.RELP.ListenAddress,
+		maxLogSize:    int(maxLogSize),
+		addAttributes: c.RELP.AddAttributes,
+		backoff: backoff.Backoff{
+			Max: 3 * time.Second,
+		},
+	}
+
+	if c.RELP.AddAttributes {
+		input.relp.resolver = helper.NewIPResolver()
+	}
+
+	if c.RELP.TLS != nil {
+		var err error
+		input.relp.tls, err = c.RELP.TLS.LoadTLSConfig(context.Background())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return input, nil
 }
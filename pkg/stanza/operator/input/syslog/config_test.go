@@ -67,6 +67,26 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "relp",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Protocol = "rfc5424"
+					cfg.RELP = &RELPConfig{
+						MaxLogSize:    1000000,
+						ListenAddress: "10.0.0.1:2514",
+						AddAttributes: true,
+						TLS: &configtls.ServerConfig{
+							Config: configtls.Config{
+								CertFile: "foo",
+								KeyFile:  "foo2",
+							},
+						},
+					}
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -5,6 +5,7 @@ package syslog // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"bufio"
+	"bytes"
 	"regexp"
 	"strconv"
 
@@ -17,19 +18,23 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/syslog"
 )
 
-// Input is an operator that listens for log entries over tcp.
+// Input is an operator that listens for log entries over tcp, udp or relp.
 type Input struct {
 	helper.InputOperator
 	tcp    *tcp.Input
 	udp    *udp.Input
+	relp   *relpInput
 	parser *syslog.Parser
 }
 
-// Start will start listening for log entries over tcp or udp.
+// Start will start listening for log entries over tcp, udp or relp.
 func (i *Input) Start(p operator.Persister) error {
 	if i.tcp != nil {
 		return i.tcp.Start(p)
 	}
+	if i.relp != nil {
+		return i.relp.Start()
+	}
 	return i.udp.Start(p)
 }
 
@@ -38,12 +43,18 @@ func (i *Input) Stop() error {
 	if i.tcp != nil {
 		return i.tcp.Stop()
 	}
+	if i.relp != nil {
+		return i.relp.Stop()
+	}
 	return i.udp.Stop()
 }
 
 // SetOutputs will set the outputs of the internal syslog parser.
 func (i *Input) SetOutputs(operators []operator.Operator) error {
 	i.parser.SetOutputIDs(i.GetOutputIDs())
+	if i.relp != nil {
+		operators = i.relp.wrapOutputs(operators)
+	}
 	return i.parser.SetOutputs(operators)
 }
 
@@ -82,3 +93,38 @@ func newOctetFrameSplitFunc(flushAtEOF bool) bufio.SplitFunc {
 		return advance, data[:advance], nil
 	}
 }
+
+// MixedSplitFuncBuilder returns a split func builder for a stream which mixes octet counted frames
+// and non-transparent frames ending with the given trailer.
+func MixedSplitFuncBuilder(trailer string) tcp.SplitFuncBuilder {
+	return func(_ encoding.Encoding) (bufio.SplitFunc, error) {
+		trailerByte := byte('\n')
+		if trailer == syslog.NULTrailer {
+			trailerByte = 0
+		}
+		return newMixedFrameSplitFunc(trailerByte, true), nil
+	}
+}
+
+func newMixedFrameSplitFunc(trailer byte, flushAtEOF bool) bufio.SplitFunc {
+	frameRegex := regexp.MustCompile(`^[1-9]\d*\s`)
+	octetFrameSplitFunc := newOctetFrameSplitFunc(flushAtEOF)
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		// An octet counted frame starts with its length, while a non-transparent frame starts
+		// with the PRI header
+		if frameRegex.Match(data) {
+			return octetFrameSplitFunc(data, atEOF)
+		}
+
+		// The trailer is kept, as the parser expects it like it expects the length of an octet counted frame
+		if i := bytes.IndexByte(data, trailer); i >= 0 {
+			return i + 1, data[:i+1], nil
+		}
+
+		// Flush if no more data is expected
+		if len(data) != 0 && atEOF && flushAtEOF {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
@@ -328,3 +328,63 @@ func TestOctetFramingSplitFunc(t *testing.T) {
 		t.Run(tc.name, splittest.New(splitFunc, tc.input, tc.steps...))
 	}
 }
+
+func TestMixedFramingSplitFunc(t *testing.T) {
+	testCases := []struct {
+		name    string
+		trailer string
+		input   []byte
+		steps   []splittest.Step
+	}{
+		{
+			name:    "OctetCounting",
+			trailer: syslog.LFTrailer,
+			input:   []byte("17 my log LOGEND 123"),
+			steps: []splittest.Step{
+				splittest.ExpectToken("17 my log LOGEND 123"),
+			},
+		},
+		{
+			name:    "NonTransparentFraming",
+			trailer: syslog.LFTrailer,
+			input:   []byte("<13>1 my log\n<14>1 my other log\n"),
+			steps: []splittest.Step{
+				splittest.ExpectToken("<13>1 my log\n"),
+				splittest.ExpectToken("<14>1 my other log\n"),
+			},
+		},
+		{
+			name:    "Mixed",
+			trailer: syslog.LFTrailer,
+			input:   []byte("<13>1 my log\n18 <14>1 my\nother log<15>1 my last log\n"),
+			steps: []splittest.Step{
+				splittest.ExpectToken("<13>1 my log\n"),
+				splittest.ExpectToken("18 <14>1 my\nother log"),
+				splittest.ExpectToken("<15>1 my last log\n"),
+			},
+		},
+		{
+			name:    "MixedNULTrailer",
+			trailer: syslog.NULTrailer,
+			input:   []byte("18 <14>1 my\x00other log<13>1 my\nlog\x00"),
+			steps: []splittest.Step{
+				splittest.ExpectToken("18 <14>1 my\x00other log"),
+				splittest.ExpectToken("<13>1 my\nlog\x00"),
+			},
+		},
+		{
+			name:    "NoTrailer",
+			trailer: syslog.LFTrailer,
+			input:   []byte("<13>1 my log"),
+			steps: []splittest.Step{
+				splittest.ExpectToken("<13>1 my log"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		splitFunc, err := MixedSplitFuncBuilder(tc.trailer)(nil)
+		require.NoError(t, err)
+		t.Run(tc.name, splittest.New(splitFunc, tc.input, tc.steps...))
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/syslog"
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/syslog"
+)
+
+// RELP commands, see https://www.rsyslog.com/doc/relp.html
+const (
+	relpCommandOpen   = "open"
+	relpCommandClose  = "close"
+	relpCommandSyslog = "syslog"
+	relpCommandRsp    = "rsp"
+
+	relpVersion = "0"
+
+	// relpMaxTxnr is the maximum transaction number, which wraps around to 1 after it
+	relpMaxTxnr = 999999999
+	// relpMaxCommandLen is the maximum length of a command
+	relpMaxCommandLen = 32
+)
+
+// relpInput listens for syslog messages over RELP, the Reliable Event Logging Protocol.
+// Each message is acknowledged to the sender once its entry was written to the outputs of
+// the syslog parser, or rejected if it was dropped or failed to be written so that the sender
+// retransmits it.
+type relpInput struct {
+	*helper.InputOperator
+	parser        *syslog.Parser
+	address       string
+	maxLogSize    int
+	addAttributes bool
+
+	listener net.Listener
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	tls      *tls.Config
+	backoff  backoff.Backoff
+	resolver *helper.IPResolver
+}
+
+// relpFrame is a RELP frame, i.e. "TXNR SP COMMAND SP DATALEN [SP DATA] LF"
+type relpFrame struct {
+	txnr    int
+	command string
+	data    []byte
+}
+
+// relpWriteKey is the context key of the relpWrite of a message
+type relpWriteKey struct{}
+
+// relpWrite records whether the entry of a message was written to the outputs of the parser
+type relpWrite struct {
+	written bool
+	err     error
+}
+
+// relpOutput is an output of the syslog parser, which records the writes of the entries of
+// the messages in their relpWrite.
+type relpOutput struct {
+	operator.Operator
+}
+
+func (o relpOutput) Process(ctx context.Context, e *entry.Entry) error {
+	err := o.Operator.Process(ctx, e)
+	if write, ok := ctx.Value(relpWriteKey{}).(*relpWrite); ok {
+		write.written = true
+		if err != nil {
+			write.err = err
+		}
+	}
+	return err
+}
+
+// wrapOutputs returns the outputs of the syslog parser, wrapped to record the writes of the entries.
+func (r *relpInput) wrapOutputs(operators []operator.Operator) []operator.Operator {
+	outputs := make([]operator.Operator, 0, len(operators))
+	for _, op := range operators {
+		outputs = append(outputs, relpOutput{Operator: op})
+	}
+	return outputs
+}
+
+// Start will start listening for RELP sessions.
+func (r *relpInput) Start() error {
+	if err := r.configureListener(); err != nil {
+		return fmt.Errorf("failed to listen on interface: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.goListen(ctx)
+	return nil
+}
+
+func (r *relpInput) configureListener() error {
+	if r.tls == nil {
+		listener, err := net.Listen("tcp", r.address)
+		if err != nil {
+			return fmt.Errorf("failed to configure relp listener: %w", err)
+		}
+		r.listener = listener
+		return nil
+	}
+
+	r.tls.Time = time.Now
+	r.tls.Rand = rand.Reader
+
+	listener, err := tls.Listen("tcp", r.address, r.tls)
+	if err != nil {
+		return fmt.Errorf("failed to configure tls listener: %w", err)
+	}
+
+	r.listener = listener
+	return nil
+}
+
+// goListen will listen for RELP sessions.
+func (r *relpInput) goListen(ctx context.Context) {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		for {
+			conn, err := r.listener.Accept()
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					r.Logger().Debug("Listener accept error", zap.Error(err))
+					time.Sleep(r.backoff.Duration())
+					continue
+				}
+			}
+			r.backoff.Reset()
+
+			r.Logger().Debug("Received RELP connection", zap.String("address", conn.RemoteAddr().String()))
+			subctx, cancel := context.WithCancel(ctx)
+			r.goHandleClose(subctx, conn)
+			r.goHandleSession(subctx, conn, cancel)
+		}
+	}()
+}
+
+// goHandleClose will wait for the context to finish before closing a connection.
+func (r *relpInput) goHandleClose(ctx context.Context, conn net.Conn) {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+		<-ctx.Done()
+		r.Logger().Debug("Closing RELP connection", zap.String("address", conn.RemoteAddr().String()))
+		if err := conn.Close(); err != nil {
+			r.Logger().Error("Failed to close RELP connection", zap.Error(err))
+		}
+	}()
+}
+
+// goHandleSession will handle the commands of a RELP session.
+func (r *relpInput) goHandleSession(ctx context.Context, conn net.Conn, cancel context.CancelFunc) {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+		defer cancel()
+
+		reader := bufio.NewReader(conn)
+		opened := false
+		for {
+			frame, err := readRELPFrame(reader, r.maxLogSize)
+			if err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					r.Logger().Error("Failed to read RELP frame", zap.Error(err))
+				}
+				return
+			}
+
+			rsp := relpFrame{txnr: frame.txnr, command: relpCommandRsp}
+			switch {
+			case frame.command == relpCommandOpen:
+				opened = true
+				rsp.data = []byte("200 OK\nrelp_version=" + relpVersion + "\nrelp_software=opentelemetry-collector\ncommands=" + relpCommandSyslog)
+			case !opened:
+				rsp.data = []byte("500 session not opened")
+			case frame.command == relpCommandClose:
+				// The response of a close command is empty
+			case frame.command == relpCommandSyslog:
+				if err := r.handleMessage(ctx, conn, frame.data); err != nil {
+					rsp.data = []byte("500 " + err.Error())
+				} else {
+					rsp.data = []byte("200 OK")
+				}
+			default:
+				rsp.data = []byte("500 unsupported command " + frame.command)
+			}
+
+			if err := writeRELPFrame(conn, rsp); err != nil {
+				r.Logger().Error("Failed to write RELP response", zap.Error(err))
+				return
+			}
+			if frame.command == relpCommandClose || !opened {
+				return
+			}
+		}
+	}()
+}
+
+// handleMessage processes a syslog message synchronously, so that it can be acknowledged.
+// It returns an error if the entry wasn't written to the outputs of the parser, i.e. it was
+// dropped according to on_error, or if writing it failed.
+func (r *relpInput) handleMessage(ctx context.Context, conn net.Conn, message []byte) error {
+	entry, err := r.NewEntry(string(message))
+	if err != nil {
+		r.Logger().Error("Failed to create entry", zap.Error(err))
+		return err
+	}
+
+	if r.addAttributes {
+		entry.AddAttribute("net.transport", "IP.TCP")
+		if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+			ip := addr.IP.String()
+			entry.AddAttribute("net.peer.ip", ip)
+			entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+			entry.AddAttribute("net.peer.name", r.resolver.GetHostFromIP(ip))
+		}
+
+		if addr, ok := conn.LocalAddr().(*net.TCPAddr); ok {
+			ip := addr.IP.String()
+			entry.AddAttribute("net.host.ip", ip)
+			entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+			entry.AddAttribute("net.host.name", r.resolver.GetHostFromIP(ip))
+		}
+	}
+
+	write := &relpWrite{}
+	err = r.parser.Process(context.WithValue(ctx, relpWriteKey{}, write), entry)
+	switch {
+	case write.err != nil:
+		return write.err
+	case !write.written && err == nil:
+		return errors.New("the entry was dropped")
+	case !write.written:
+		return err
+	}
+	if err != nil {
+		// The entry is sent despite the error, with on_error set to send
+		r.Logger().Debug("Sent entry after error", zap.Error(err))
+	}
+	return nil
+}
+
+// Stop will stop listening for RELP sessions.
+func (r *relpInput) Stop() error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+
+	if r.listener != nil {
+		if err := r.listener.Close(); err != nil {
+			r.Logger().Error("failed to close RELP listener", zap.Error(err))
+		}
+	}
+
+	r.wg.Wait()
+	if r.resolver != nil {
+		r.resolver.Stop()
+	}
+	return nil
+}
+
+// readRELPFrame reads a frame, whose data can't be longer than maxDataLen.
+func readRELPFrame(reader *bufio.Reader, maxDataLen int) (relpFrame, error) {
+	var frame relpFrame
+
+	txnr, delim, err := readRELPHeaderField(reader, len(strconv.Itoa(relpMaxTxnr)))
+	if err != nil {
+		return frame, err
+	}
+	frame.txnr, err = strconv.Atoi(txnr)
+	if err != nil || frame.txnr < 1 || frame.txnr > relpMaxTxnr || delim != ' ' {
+		return frame, fmt.Errorf("invalid RELP transaction number: %q", txnr)
+	}
+
+	frame.command, delim, err = readRELPHeaderField(reader, relpMaxCommandLen)
+	if err != nil {
+		return frame, err
+	}
+	if frame.command == "" || delim != ' ' {
+		return frame, fmt.Errorf("invalid RELP command: %q", frame.command)
+	}
+
+	dataLenField, delim, err := readRELPHeaderField(reader, len(strconv.Itoa(maxDataLen)))
+	if err != nil {
+		return frame, err
+	}
+	dataLen, err := strconv.Atoi(dataLenField)
+	if err != nil || dataLen < 0 {
+		return frame, fmt.Errorf("invalid RELP data length: %q", dataLenField)
+	}
+	if dataLen > maxDataLen {
+		return frame, fmt.Errorf("RELP data length %d exceeds the maximum of %d", dataLen, maxDataLen)
+	}
+	if dataLen == 0 {
+		if delim != '\n' {
+			return frame, errors.New("missing RELP frame trailer")
+		}
+		return frame, nil
+	}
+	if delim != ' ' {
+		return frame, fmt.Errorf("missing RELP data of length %d", dataLen)
+	}
+
+	frame.data = make([]byte, dataLen)
+	if _, err = io.ReadFull(reader, frame.data); err != nil {
+		return frame, err
+	}
+	trailer, err := reader.ReadByte()
+	if err != nil {
+		return frame, err
+	}
+	if trailer != '\n' {
+		return frame, errors.New("missing RELP frame trailer")
+	}
+	return frame, nil
+}
+
+// readRELPHeaderField reads a field of the header up to its delimiter, either a space or
+// the trailer of a frame without data.
+func readRELPHeaderField(reader *bufio.Reader, maxLen int) (string, byte, error) {
+	field := make([]byte, 0, maxLen)
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return "", 0, err
+		}
+		if b == ' ' || b == '\n' {
+			return string(field), b, nil
+		}
+		if len(field) == maxLen {
+			return "", 0, fmt.Errorf("RELP header field is longer than %d bytes", maxLen)
+		}
+		field = append(field, b)
+	}
+}
+
+func writeRELPFrame(w io.Writer, frame relpFrame) error {
+	header := strconv.Itoa(frame.txnr) + " " + frame.command + " " + strconv.Itoa(len(frame.data))
+	if len(frame.data) > 0 {
+		header += " "
+	}
+	_, err := w.Write(append(append([]byte(header), frame.data...), '\n'))
+	return err
+}
@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/syslog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func NewConfigWithRELP(syslogCfg *syslog.BaseConfig) *Config {
+	cfg := NewConfigWithID("test_syslog")
+	cfg.BaseConfig = *syslogCfg
+	cfg.RELP = &RELPConfig{ListenAddress: ":14202"}
+	cfg.OutputIDs = []string{"fake"}
+	return cfg
+}
+
+func TestRELPInput(t *testing.T) {
+	cfg := NewConfigWithRELP(&syslog.BaseConfig{Protocol: syslog.RFC5424})
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	p, err := pipeline.NewDirectedPipeline([]operator.Operator{op, fake})
+	require.NoError(t, err)
+	require.NoError(t, p.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, p.Stop())
+	}()
+
+	conn, err := net.Dial("tcp", cfg.RELP.ListenAddress)
+	require.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	exchange := func(request string) string {
+		_, err = conn.Write([]byte(request))
+		require.NoError(t, err)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		frame, err := readRELPFrame(reader, 1024)
+		require.NoError(t, err)
+		return strings.TrimSpace(fmt.Sprintf("%d %s %s", frame.txnr, frame.command, frame.data))
+	}
+
+	// Messages are rejected before the session is opened
+	require.Equal(t, "1 rsp 500 session not opened", exchange("1 syslog 5 hello\n"))
+	conn.Close()
+
+	conn, err = net.Dial("tcp", cfg.RELP.ListenAddress)
+	require.NoError(t, err)
+	reader = bufio.NewReader(conn)
+
+	offer := "relp_version=0\nrelp_software=librelp\ncommands=syslog"
+	require.Equal(t,
+		"1 rsp 200 OK\nrelp_version=0\nrelp_software=opentelemetry-collector\ncommands=syslog",
+		exchange("1 open "+strconv.Itoa(len(offer))+" "+offer+"\n"))
+
+	message := `<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 PEN="27389"] Found the user`
+	require.Equal(t, "2 rsp 200 OK", exchange("2 syslog "+strconv.Itoa(len(message))+" "+message+"\n"))
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, message, e.Body)
+		require.Equal(t, time.Date(2015, 8, 5, 21, 58, 59, 693000000, time.UTC), e.Timestamp)
+		require.Equal(t, entry.Info, e.Severity)
+		require.Equal(t, map[string]any{"SecureAuth@27389": map[string]any{"PEN": "27389"}}, e.Attributes["structured_data"])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry to be processed")
+	}
+
+	// A message which fails to be parsed is acknowledged once it is sent, with on_error set to send
+	require.Equal(t, "3 rsp 200 OK", exchange("3 syslog 5 <86>a\n"))
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "<86>a", e.Body)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry to be processed")
+	}
+
+	require.Equal(t, "4 rsp 500 unsupported command starttls", exchange("4 starttls 0\n"))
+	require.Equal(t, "5 rsp", exchange("5 close 0\n"))
+
+	// The server closes the connection after the close command
+	_, err = reader.ReadByte()
+	require.Error(t, err)
+}
+
+func TestRELPInputOnError(t *testing.T) {
+	cases := []struct {
+		name       string
+		onError    string
+		writeError bool
+		message    string
+		expected   string
+		sent       bool
+	}{
+		{
+			name:     "Drop",
+			onError:  "drop",
+			message:  "<86>a",
+			expected: "500 expecting a version value in the range 1-999 [col 4]",
+		},
+		{
+			name:     "DropQuiet",
+			onError:  "drop_quiet",
+			message:  "<86>a",
+			expected: "500 the entry was dropped",
+		},
+		{
+			name:     "SendQuiet",
+			onError:  "send_quiet",
+			message:  "<86>a",
+			expected: "200 OK",
+			sent:     true,
+		},
+		{
+			name:       "WriteError",
+			onError:    "send",
+			writeError: true,
+			message:    "<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 - Found the user",
+			expected:   "500 Operator can not process logs.",
+			sent:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithRELP(&syslog.BaseConfig{Protocol: syslog.RFC5424})
+			cfg.OnError = tc.onError
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			if tc.writeError {
+				fake = testutil.NewFakeOutputWithProcessError(t)
+			}
+			p, err := pipeline.NewDirectedPipeline([]operator.Operator{op, fake})
+			require.NoError(t, err)
+			require.NoError(t, p.Start(testutil.NewUnscopedMockPersister()))
+			defer func() {
+				require.NoError(t, p.Stop())
+			}()
+
+			conn, err := net.Dial("tcp", cfg.RELP.ListenAddress)
+			require.NoError(t, err)
+			defer conn.Close()
+			reader := bufio.NewReader(conn)
+
+			_, err = conn.Write([]byte("1 open 0\n2 syslog " + strconv.Itoa(len(tc.message)) + " " + tc.message + "\n"))
+			require.NoError(t, err)
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+			_, err = readRELPFrame(reader, 1024)
+			require.NoError(t, err)
+			frame, err := readRELPFrame(reader, 1024)
+			require.NoError(t, err)
+			require.Equal(t, 2, frame.txnr)
+			require.Equal(t, tc.expected, string(frame.data))
+
+			if tc.sent {
+				fake.ExpectBody(t, tc.message)
+			} else {
+				fake.ExpectNoEntry(t, 100*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestReadRELPFrame(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		expect relpFrame
+		errMsg string
+	}{
+		{
+			name:   "WithData",
+			input:  "12 syslog 11 my\nlog\x00data\n",
+			expect: relpFrame{txnr: 12, command: "syslog", data: []byte("my\nlog\x00data")},
+		},
+		{
+			name:   "WithoutData",
+			input:  "999999999 close 0\n",
+			expect: relpFrame{txnr: 999999999, command: "close"},
+		},
+		{
+			name:   "InvalidTransactionNumber",
+			input:  "0 close 0\n",
+			errMsg: `invalid RELP transaction number: "0"`,
+		},
+		{
+			name:   "TransactionNumberTooLong",
+			input:  "1000000000 close 0\n",
+			errMsg: "RELP header field is longer than 9 bytes",
+		},
+		{
+			name:   "MissingCommand",
+			input:  "1 0\n",
+			errMsg: `invalid RELP command: "0"`,
+		},
+		{
+			name:   "InvalidDataLength",
+			input:  "1 syslog 4a data\n",
+			errMsg: `invalid RELP data length: "4a"`,
+		},
+		{
+			name:   "DataTooLong",
+			input:  "1 syslog 65 data\n",
+			errMsg: "RELP data length 65 exceeds the maximum of 64",
+		},
+		{
+			name:   "MissingData",
+			input:  "1 syslog 4\n",
+			errMsg: "missing RELP data of length 4",
+		},
+		{
+			name:   "MissingTrailer",
+			input:  "1 syslog 4 data.",
+			errMsg: "missing RELP frame trailer",
+		},
+		{
+			name:   "Truncated",
+			input:  "1 syslog 4 da",
+			errMsg: "unexpected EOF",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			frame, err := readRELPFrame(bufio.NewReader(strings.NewReader(tc.input)), 64)
+			if tc.errMsg != "" {
+				require.EqualError(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, frame)
+		})
+	}
+}
+
+func TestRELPConfigErrors(t *testing.T) {
+	framingTrailer := syslog.LFTrailer
+	testCases := []struct {
+		name   string
+		cfg    func() *Config
+		errMsg string
+	}{
+		{
+			name: "OctetCounting",
+			cfg: func() *Config {
+				return NewConfigWithRELP(&syslog.BaseConfig{Protocol: syslog.RFC5424, EnableOctetCounting: true})
+			},
+			errMsg: "octet_counting and non_transparent_framing is not compatible with RELP",
+		},
+		{
+			name: "NonTransparentFraming",
+			cfg: func() *Config {
+				return NewConfigWithRELP(&syslog.BaseConfig{Protocol: syslog.RFC5424, NonTransparentFramingTrailer: &framingTrailer})
+			},
+			errMsg: "octet_counting and non_transparent_framing is not compatible with RELP",
+		},
+		{
+			name: "MissingListenAddress",
+			cfg: func() *Config {
+				cfg := NewConfigWithRELP(&syslog.BaseConfig{Protocol: syslog.RFC5424})
+				cfg.RELP.ListenAddress = ""
+				return cfg
+			},
+			errMsg: "missing required parameter 'relp.listen_address'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.cfg().Build(componenttest.NewNopTelemetrySettings())
+			require.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
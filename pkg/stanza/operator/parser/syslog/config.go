@@ -80,8 +80,6 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 		return nil, errors.New("missing field 'protocol'")
 	case proto != RFC5424 && (c.NonTransparentFramingTrailer != nil || c.EnableOctetCounting):
 		return nil, errors.New("octet_counting and non_transparent_framing are only compatible with protocol rfc5424")
-	case proto == RFC5424 && (c.NonTransparentFramingTrailer != nil && c.EnableOctetCounting):
-		return nil, errors.New("only one of octet_counting or non_transparent_framing can be enabled")
 	case proto == RFC5424 && c.NonTransparentFramingTrailer != nil:
 		if *c.NonTransparentFramingTrailer != NULTrailer && *c.NonTransparentFramingTrailer != LFTrailer {
 			return nil, fmt.Errorf("invalid non_transparent_framing_trailer '%s'. Must be either 'LF' or 'NUL'", *c.NonTransparentFramingTrailer)
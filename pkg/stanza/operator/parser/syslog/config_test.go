@@ -162,7 +162,7 @@ func TestRFC6587ConfigOptions(t *testing.T) {
 			errContents: "octet_counting and non_transparent_framing are only compatible with protocol rfc5424",
 		},
 		{
-			desc: "Non-Transparent-Framing and Octet counting both enabled with RFC5424",
+			desc: "Valid Non-Transparent-Framing and Octet counting both enabled with RFC5424",
 			cfg: &Config{
 				ParserConfig: helper.NewParserConfig(operatorType, operatorType),
 				BaseConfig: BaseConfig{
@@ -171,7 +171,7 @@ func TestRFC6587ConfigOptions(t *testing.T) {
 					EnableOctetCounting:          true,
 				},
 			},
-			errContents: "only one of octet_counting or non_transparent_framing can be enabled",
+			errContents: "",
 		},
 		{
 			desc: "Valid Octet Counting",
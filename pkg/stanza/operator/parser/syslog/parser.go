@@ -21,7 +21,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
 
-var priRegex = regexp.MustCompile(`\<\d{1,3}\>`)
+var (
+	priRegex = regexp.MustCompile(`\<\d{1,3}\>`)
+	// octetFrameRegex matches the message length which prefixes an octet counted frame
+	octetFrameRegex = regexp.MustCompile(`^[1-9]\d*\s`)
+)
 
 // parseFunc a parseFunc determines how the raw input is to be parsed into a syslog message
 type parseFunc func(input []byte) (sl.Message, error)
@@ -98,6 +102,16 @@ func (p *Parser) buildParseFunc() (parseFunc, error) {
 		}, nil
 	case RFC5424:
 		switch {
+		// Mixed Octet Counting and Non-Transparent-Framing Parsing RFC6587
+		case p.enableOctetCounting && p.nonTransparentFramingTrailer != nil:
+			octetCountingParseFunc := newOctetCountingParseFunc(p.maxOctets)
+			nonTransparentFramingParseFunc := newNonTransparentFramingParseFunc(trailerType(*p.nonTransparentFramingTrailer))
+			return func(input []byte) (sl.Message, error) {
+				if octetFrameRegex.Match(input) {
+					return octetCountingParseFunc(input)
+				}
+				return nonTransparentFramingParseFunc(input)
+			}, nil
 		// Octet Counting Parsing RFC6587
 		case p.enableOctetCounting:
 			return newOctetCountingParseFunc(p.maxOctets), nil
@@ -318,6 +332,13 @@ func newOctetCountingParseFunc(maxOctets int) parseFunc {
 	}
 }
 
+func trailerType(trailer string) nontransparent.TrailerType {
+	if trailer == NULTrailer {
+		return nontransparent.NUL
+	}
+	return nontransparent.LF
+}
+
 func newNonTransparentFramingParseFunc(trailerType nontransparent.TrailerType) parseFunc {
 	return func(input []byte) (message sl.Message, err error) {
 		listener := func(res *sl.Result) {
@@ -410,6 +410,126 @@ func CreateCases(basicConfig func() *syslog.Config) ([]Case, error) {
 			true,
 			false,
 		},
+		{
+			"RFC6587 Mixed Framing Octet Counting",
+			func() *syslog.Config {
+				cfg := basicConfig()
+				cfg.Protocol = syslog.RFC5424
+				cfg.EnableOctetCounting = true
+				cfg.NonTransparentFramingTrailer = &nulFramingTrailer
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: `215 <86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 UserHostAddress="192.168.2.132" Realm="SecureAuth0" UserID="Tester2" PEN="27389"] Found the user for retrieving user's profile`,
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(2015, 8, 5, 21, 58, 59, 693000000, time.UTC),
+				Severity:     entry.Info,
+				SeverityText: "info",
+				Attributes: map[string]any{
+					"appname":  "SecureAuth0",
+					"facility": 10,
+					"hostname": "192.168.2.132",
+					"message":  "Found the user for retrieving user's profile",
+					"msg_id":   "ID52020",
+					"priority": 86,
+					"proc_id":  "23108",
+					"structured_data": map[string]any{
+						"SecureAuth@27389": map[string]any{
+							"PEN":             "27389",
+							"Realm":           "SecureAuth0",
+							"UserHostAddress": "192.168.2.132",
+							"UserID":          "Tester2",
+						},
+					},
+					"version": 1,
+				},
+				Body: `215 <86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 UserHostAddress="192.168.2.132" Realm="SecureAuth0" UserID="Tester2" PEN="27389"] Found the user for retrieving user's profile`,
+			},
+			true,
+			false,
+		},
+		{
+			"RFC6587 Mixed Framing Non-Transparent-framing",
+			func() *syslog.Config {
+				cfg := basicConfig()
+				cfg.Protocol = syslog.RFC5424
+				cfg.EnableOctetCounting = true
+				cfg.NonTransparentFramingTrailer = &nulFramingTrailer
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: nonTransparentBody,
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(2015, 8, 5, 21, 58, 59, 693000000, time.UTC),
+				Severity:     entry.Info,
+				SeverityText: "info",
+				Attributes: map[string]any{
+					"appname":  "SecureAuth0",
+					"facility": 10,
+					"hostname": "192.168.2.132",
+					"message":  "Found the user for retrieving user's profile",
+					"msg_id":   "ID52020",
+					"priority": 86,
+					"proc_id":  "23108",
+					"structured_data": map[string]any{
+						"SecureAuth@27389": map[string]any{
+							"PEN":             "27389",
+							"Realm":           "SecureAuth0",
+							"UserHostAddress": "192.168.2.132",
+							"UserID":          "Tester2",
+						},
+					},
+					"version": 1,
+				},
+				Body: nonTransparentBody,
+			},
+			true,
+			false,
+		},
+		{
+			"RFC5424 Multiple Structured Data Elements",
+			func() *syslog.Config {
+				cfg := basicConfig()
+				cfg.Protocol = syslog.RFC5424
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"][examplePriority@32473 class="high"][origin ip="192.0.2.1" software="a \"quoted\" \]name\\"] An application event log entry`,
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+				Severity:     entry.Info2,
+				SeverityText: "notice",
+				Attributes: map[string]any{
+					"appname":  "evntslog",
+					"facility": 20,
+					"hostname": "mymachine.example.com",
+					"message":  "An application event log entry",
+					"msg_id":   "ID47",
+					"priority": 165,
+					"structured_data": map[string]any{
+						"exampleSDID@32473": map[string]any{
+							"eventID":     "1011",
+							"eventSource": "Application",
+							"iut":         "3",
+						},
+						"examplePriority@32473": map[string]any{
+							"class": "high",
+						},
+						"origin": map[string]any{
+							"ip":       "192.0.2.1",
+							"software": `a "quoted" ]name\`,
+						},
+					},
+					"version": 1,
+				},
+				Body: `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"][examplePriority@32473 class="high"][origin ip="192.0.2.1" software="a \"quoted\" \]name\\"] An application event log entry`,
+			},
+			true,
+			true,
+		},
 	}
 
 	return cases, nil
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	ErrInvalidDefaultLimitValue = errors.New("invalid default_limit value")
+	ErrInvalidExpirationValue   = errors.New("invalid expiration value")
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// DefaultLimit is the maximum number of active series of each metric, unless
+	// overridden in Limits. 0 means no limit.
+	DefaultLimit int `mapstructure:"default_limit"`
+	// Limits are the maximum numbers of active series of the metrics, by metric name.
+	// 0 means no limit.
+	Limits map[string]int `mapstructure:"limits"`
+	// ResourceAttributes is the list of resource attributes, such as service.name, whose
+	// combinations of values get a budget of their own for each metric.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+	// Action is what happens to the data points of the new series once a budget is
+	// exceeded: <drop|overflow>.
+	Action Action `mapstructure:"action"`
+	// Mode is how the active series are counted: <exact|approximate>.
+	Mode Mode `mapstructure:"mode"`
+	// Expiration is the time after which a series which received no data point stops
+	// counting as active.
+	Expiration time.Duration `mapstructure:"expiration"`
+}
+
+// Action is the enum of the actions applied to the series over budget.
+type Action string
+
+const (
+	// Drop drops the data points of the series over budget.
+	Drop Action = "drop"
+	// Overflow folds the data points of the series over budget into a single
+	// series with the otel.metric.overflow="true" attribute for delta sums and
+	// histograms, and drops them for the other metrics.
+	Overflow Action = "overflow"
+)
+
+var actions = []Action{Drop, Overflow}
+
+// Mode is the enum of the ways the active series are counted.
+type Mode string
+
+const (
+	// Exact tracks the set of the active series, which protects the series admitted
+	// before the budget is exceeded.
+	Exact Mode = "exact"
+	// Approximate estimates the number of the active series with a HyperLogLog sketch,
+	// and admits a stable subset of the series, selected by their hash, of about the
+	// size of the budget.
+	Approximate Mode = "approximate"
+)
+
+var modes = []Mode{Exact, Approximate}
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (config *Config) Validate() error {
+	if config.DefaultLimit < 0 {
+		return ErrInvalidDefaultLimitValue
+	}
+
+	for name, limit := range config.Limits {
+		if limit < 0 {
+			return fmt.Errorf("limit of metric %q must not be negative", name)
+		}
+	}
+
+	switch config.Action {
+	case Drop, Overflow:
+	default:
+		return fmt.Errorf("%q must be in %q", "action", actions)
+	}
+
+	switch config.Mode {
+	case Exact, Approximate:
+	default:
+		return fmt.Errorf("%q must be in %q", "mode", modes)
+	}
+
+	if config.Expiration <= 0 {
+		return ErrInvalidExpirationValue
+	}
+
+	return nil
+}
+
+// limit returns the budget of the metric.
+func (config *Config) limit(metricName string) int {
+	if limit, ok := config.Limits[metricName]; ok {
+		return limit
+	}
+	return config.DefaultLimit
+}
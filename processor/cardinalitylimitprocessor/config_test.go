@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				DefaultLimit: 5000,
+				Limits: map[string]int{
+					"http.server.request.duration": 1000,
+					"k8s.pod.cpu.usage":            0,
+				},
+				ResourceAttributes: []string{"service.name"},
+				Action:             Drop,
+				Mode:               Approximate,
+				Expiration:         10 * time.Minute,
+			},
+		},
+		{
+			id:       component.NewIDWithName(metadata.Type, "defaults"),
+			expected: createDefaultConfig(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_default_limit"),
+			errorMessage: ErrInvalidDefaultLimitValue.Error(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_limit"),
+			errorMessage: `limit of metric "http.server.request.duration" must not be negative`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_action"),
+			errorMessage: `"action" must be in ["drop" "overflow"]`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_mode"),
+			errorMessage: `"mode" must be in ["exact" "approximate"]`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_expiration"),
+			errorMessage: ErrInvalidExpirationValue.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
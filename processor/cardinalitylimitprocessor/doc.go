@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package cardinalitylimitprocessor implements a processor which limits the
+// number of active series of each metric, optionally per combination of
+// resource attribute values, and drops the data points of the series over
+// budget or folds them into an overflow series.
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hll implements a HyperLogLog sketch, which estimates the number of
+// distinct 64-bit hashes added to it in constant memory.
+package hll // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/hll"
+
+import (
+	"math"
+	"math/bits"
+)
+
+// precision is the number of bits of the hashes which select a register. The
+// 4096 registers of a sketch estimate its cardinality with a standard error of
+// about 1.6%.
+const precision = 12
+
+const registers = 1 << precision
+
+// Sketch is a HyperLogLog sketch. The zero value is an empty sketch.
+type Sketch struct {
+	registers [registers]uint8
+}
+
+// Add adds the hash to the sketch.
+func (s *Sketch) Add(hash uint64) {
+	idx := hash >> (64 - precision)
+	// The rank is the position of the leftmost 1 bit of the remaining bits, the
+	// sentinel bit bounds it when they are all 0.
+	rank := uint8(bits.LeadingZeros64(hash<<precision|1<<(precision-1))) + 1
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// Estimate returns the estimated number of distinct hashes added to the sketch.
+func (s *Sketch) Estimate() uint64 {
+	var sum float64
+	var zeros int
+	for _, r := range s.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	m := float64(registers)
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small cardinalities.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Reset empties the sketch.
+func (s *Sketch) Reset() {
+	clear(s.registers[:])
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hll
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, 1, 10, 100, 1000, 10000, 100000, 1000000} {
+		var s Sketch
+		rng := rand.New(rand.NewPCG(uint64(n), 0))
+		for range n {
+			hash := rng.Uint64()
+			// Adding the same hash again doesn't change the estimate
+			s.Add(hash)
+			s.Add(hash)
+		}
+		assert.InEpsilon(t, float64(n)+1, float64(s.Estimate())+1, 0.05, "n=%d", n)
+	}
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	var s Sketch
+	for i := range uint64(100) {
+		s.Add(i * 0x9e3779b97f4a7c15)
+	}
+	assert.NotZero(t, s.Estimate())
+
+	s.Reset()
+	assert.Zero(t, s.Estimate())
+}
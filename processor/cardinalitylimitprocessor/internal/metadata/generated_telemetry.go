@@ -0,0 +1,109 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                                         metric.Meter
+	mu                                            sync.Mutex
+	registrations                                 []metric.Registration
+	ProcessorCardinalitylimitDatapointsDropped    metric.Int64Counter
+	ProcessorCardinalitylimitDatapointsOverflowed metric.Int64Counter
+	ProcessorCardinalitylimitSeriesActive         metric.Int64ObservableUpDownCounter
+}
+
+// TelemetryBuilderOption applies changes to default builder.
+type TelemetryBuilderOption interface {
+	apply(*TelemetryBuilder)
+}
+
+type telemetryBuilderOptionFunc func(mb *TelemetryBuilder)
+
+func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
+	tbof(mb)
+}
+
+// RegisterProcessorCardinalitylimitSeriesActiveCallback sets callback for observable ProcessorCardinalitylimitSeriesActive metric.
+func (builder *TelemetryBuilder) RegisterProcessorCardinalitylimitSeriesActiveCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ProcessorCardinalitylimitSeriesActive, obs: o})
+		return nil
+	}, builder.ProcessorCardinalitylimitSeriesActive)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
+type observerInt64 struct {
+	embedded.Int64Observer
+	inst metric.Int64Observable
+	obs  metric.Observer
+}
+
+func (oi *observerInt64) Observe(value int64, opts ...metric.ObserveOption) {
+	oi.obs.ObserveInt64(oi.inst, value, opts...)
+}
+
+// Shutdown unregister all registered callbacks for async instruments.
+func (builder *TelemetryBuilder) Shutdown() {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	for _, reg := range builder.registrations {
+		reg.Unregister()
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...TelemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{}
+	for _, op := range options {
+		op.apply(&builder)
+	}
+	builder.meter = Meter(settings)
+	var err, errs error
+	builder.ProcessorCardinalitylimitDatapointsDropped, err = builder.meter.Int64Counter(
+		"otelcol_processor_cardinalitylimit_datapoints.dropped",
+		metric.WithDescription("Number of data points of the series over budget dropped by the processor"),
+		metric.WithUnit("{datapoint}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorCardinalitylimitDatapointsOverflowed, err = builder.meter.Int64Counter(
+		"otelcol_processor_cardinalitylimit_datapoints.overflowed",
+		metric.WithDescription("Number of data points of the series over budget folded into the overflow series by the processor"),
+		metric.WithUnit("{datapoint}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorCardinalitylimitSeriesActive, err = builder.meter.Int64ObservableUpDownCounter(
+		"otelcol_processor_cardinalitylimit_series.active",
+		metric.WithDescription("Number of active series counted against the budgets, estimated in approximate mode"),
+		metric.WithUnit("{series}"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
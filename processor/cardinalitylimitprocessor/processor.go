@@ -0,0 +1,357 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/aggregateutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/hll"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/metadata"
+)
+
+// overflowKey is the attribute of the series the series over budget are folded into.
+const overflowKey = "otel.metric.overflow"
+
+type cardinalityLimitProcessor struct {
+	logger           *zap.Logger
+	config           *Config
+	telemetryBuilder *metadata.TelemetryBuilder
+
+	stateLock sync.Mutex
+	budgets   map[budgetKey]*budget
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// budgetKey identifies the budget of a metric for a combination of values of
+// the configured resource attributes.
+type budgetKey struct {
+	metric   string
+	resource [16]byte
+}
+
+// budget is the state of the active series of a budget.
+type budget struct {
+	limit int
+	// series is the time each active series was last seen at, in exact mode.
+	series map[uint64]time.Time
+	// current and previous are the sketches of the series seen during the current
+	// and the previous expiration periods, in approximate mode.
+	current, previous *hll.Sketch
+	// exceeded is true if the budget was exceeded since the last sweep.
+	exceeded bool
+}
+
+func newProcessor(config *Config, set processor.Settings) (*cardinalityLimitProcessor, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &cardinalityLimitProcessor{
+		logger:           set.Logger,
+		config:           config,
+		telemetryBuilder: telemetryBuilder,
+		budgets:          map[budgetKey]*budget{},
+		lastSweep:        time.Now(),
+		now:              time.Now,
+	}
+
+	err = telemetryBuilder.RegisterProcessorCardinalitylimitSeriesActiveCallback(func(_ context.Context, observer metric.Int64Observer) error {
+		p.stateLock.Lock()
+		defer p.stateLock.Unlock()
+
+		observer.Observe(int64(p.activeSeries()))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *cardinalityLimitProcessor) shutdown(context.Context) error {
+	p.telemetryBuilder.Shutdown()
+	return nil
+}
+
+func (p *cardinalityLimitProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+
+	now := p.now()
+	if now.Sub(p.lastSweep) >= p.config.Expiration {
+		p.sweep(now)
+		p.lastSweep = now
+	}
+
+	var dropped, overflowed int64
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		resID := identity.OfResource(rm.Resource())
+		resourceKey := p.resourceKey(rm.Resource())
+
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			scopeID := identity.OfScope(resID, sm.Scope())
+
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				limit := p.config.limit(m.Name())
+				if limit == 0 {
+					return false
+				}
+
+				key := budgetKey{metric: m.Name(), resource: resourceKey}
+				b, ok := p.budgets[key]
+				if !ok {
+					b = p.newBudget(limit)
+					p.budgets[key] = b
+				}
+
+				metricID := identity.OfMetric(scopeID, m)
+				admit := func(attrs pcommon.Map) bool {
+					if b.admit(streamHash(metricID, attrs), now) {
+						return true
+					}
+					if !b.exceeded {
+						b.exceeded = true
+						p.logger.Warn("Cardinality limit exceeded",
+							zap.String("metric", m.Name()),
+							zap.Any("resource", p.resourceAttributes(rm.Resource()).AsRaw()),
+							zap.Int("limit", limit),
+							zap.String("action", string(p.config.Action)))
+					}
+					return false
+				}
+
+				d, o := p.limitMetric(m, admit)
+				dropped += d
+				overflowed += o
+				return !hasDataPoints(m)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+
+	if dropped > 0 {
+		p.telemetryBuilder.ProcessorCardinalitylimitDatapointsDropped.Add(ctx, dropped)
+	}
+	if overflowed > 0 {
+		p.telemetryBuilder.ProcessorCardinalitylimitDatapointsOverflowed.Add(ctx, overflowed)
+	}
+
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// limitMetric applies the action to the data points of the metric whose series
+// are not admitted, it returns the number of dropped and overflowed data points.
+func (p *cardinalityLimitProcessor) limitMetric(m pmetric.Metric, admit func(pcommon.Map) bool) (dropped, overflowed int64) {
+	overflow := pmetric.NewMetric()
+	aggregateutil.CopyMetricDetails(m, overflow)
+
+	action := p.config.Action
+	if !canOverflow(m) {
+		action = Drop
+	}
+
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dropped, overflowed = limitDataPoints(m.Gauge().DataPoints(), overflow.Gauge().DataPoints(), action, admit)
+	case pmetric.MetricTypeSum:
+		dropped, overflowed = limitDataPoints(m.Sum().DataPoints(), overflow.Sum().DataPoints(), action, admit)
+	case pmetric.MetricTypeHistogram:
+		dropped, overflowed = limitDataPoints(m.Histogram().DataPoints(), overflow.Histogram().DataPoints(), action, admit)
+	case pmetric.MetricTypeExponentialHistogram:
+		dropped, overflowed = limitDataPoints(m.ExponentialHistogram().DataPoints(), overflow.ExponentialHistogram().DataPoints(), action, admit)
+	case pmetric.MetricTypeSummary:
+		dropped, _ = limitDataPoints(m.Summary().DataPoints(), overflow.Summary().DataPoints(), action, admit)
+	case pmetric.MetricTypeEmpty:
+	}
+
+	if overflowed > 0 {
+		// Fold the data points of the series over budget with the same timestamp.
+		var ag aggregateutil.AggGroups
+		aggregateutil.GroupDataPoints(overflow, &ag)
+		aggregateutil.MergeDataPoints(m, aggregateutil.Sum, ag)
+	}
+	return dropped, overflowed
+}
+
+// canOverflow returns whether the data points of the metric can be folded into an overflow
+// series: only the ones of delta sums and histograms, which add up. The value of a cumulative
+// overflow series would depend on the series over budget in each batch, the values of gauges
+// don't add up, and summaries can't be merged.
+func canOverflow(m pmetric.Metric) bool {
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		return m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+	case pmetric.MetricTypeGauge, pmetric.MetricTypeSummary, pmetric.MetricTypeEmpty:
+	}
+	return false
+}
+
+type dataPointSlice[DP dataPoint[DP]] interface {
+	RemoveIf(func(DP) bool)
+	AppendEmpty() DP
+}
+
+type dataPoint[Self any] interface {
+	Attributes() pcommon.Map
+	MoveTo(Self)
+}
+
+// limitDataPoints removes the data points of the series which are not admitted,
+// and moves them to overflow with the overflow attribute if action is Overflow.
+func limitDataPoints[DPS dataPointSlice[DP], DP dataPoint[DP]](dataPoints, overflow DPS, action Action, admit func(pcommon.Map) bool) (dropped, overflowed int64) {
+	dataPoints.RemoveIf(func(dp DP) bool {
+		if admit(dp.Attributes()) {
+			return false
+		}
+		if action == Drop {
+			dropped++
+			return true
+		}
+		dp.Attributes().Clear()
+		dp.Attributes().PutBool(overflowKey, true)
+		dp.MoveTo(overflow.AppendEmpty())
+		overflowed++
+		return true
+	})
+	return dropped, overflowed
+}
+
+func (p *cardinalityLimitProcessor) newBudget(limit int) *budget {
+	b := &budget{limit: limit}
+	if p.config.Mode == Approximate {
+		b.current, b.previous = &hll.Sketch{}, &hll.Sketch{}
+	} else {
+		b.series = map[uint64]time.Time{}
+	}
+	return b
+}
+
+// admit records the series and returns whether it is within the budget.
+func (b *budget) admit(hash uint64, now time.Time) bool {
+	if b.series != nil {
+		if _, ok := b.series[hash]; ok || len(b.series) < b.limit {
+			b.series[hash] = now
+			return true
+		}
+		return false
+	}
+
+	b.current.Add(hash)
+	estimate := b.estimate()
+	if estimate <= uint64(b.limit) {
+		return true
+	}
+	// Admit the series whose hash is in the fraction of the hash space of the
+	// size of the budget relative to the estimated number of series.
+	return float64(hash) < float64(b.limit)/float64(estimate)*math.MaxUint64
+}
+
+// estimate returns the number of active series of the budget.
+func (b *budget) estimate() uint64 {
+	if b.series != nil {
+		return uint64(len(b.series))
+	}
+	// The series seen during the previous period are still considered active,
+	// which avoids admitting every series right after a sweep.
+	return max(b.current.Estimate(), b.previous.Estimate())
+}
+
+// sweep removes the expired series, and the budgets left without active series.
+func (p *cardinalityLimitProcessor) sweep(now time.Time) {
+	for key, b := range p.budgets {
+		b.exceeded = false
+		if b.series != nil {
+			for hash, lastSeen := range b.series {
+				if now.Sub(lastSeen) >= p.config.Expiration {
+					delete(b.series, hash)
+				}
+			}
+		} else {
+			b.current, b.previous = b.previous, b.current
+			b.current.Reset()
+		}
+		if b.estimate() == 0 {
+			delete(p.budgets, key)
+		}
+	}
+}
+
+func (p *cardinalityLimitProcessor) activeSeries() uint64 {
+	var active uint64
+	for _, b := range p.budgets {
+		active += b.estimate()
+	}
+	return active
+}
+
+// resourceAttributes returns the configured resource attributes of the resource.
+func (p *cardinalityLimitProcessor) resourceAttributes(res pcommon.Resource) pcommon.Map {
+	attrs := pcommon.NewMap()
+	for _, name := range p.config.ResourceAttributes {
+		if v, ok := res.Attributes().Get(name); ok {
+			v.CopyTo(attrs.PutEmpty(name))
+		}
+	}
+	return attrs
+}
+
+func (p *cardinalityLimitProcessor) resourceKey(res pcommon.Resource) [16]byte {
+	if len(p.config.ResourceAttributes) == 0 {
+		return [16]byte{}
+	}
+	return pdatautil.MapHash(p.resourceAttributes(res))
+}
+
+// streamHash returns the hash of the series of the metric with the attributes,
+// which is the same as the one of identity.Stream.
+func streamHash(metricID identity.Metric, attrs pcommon.Map) uint64 {
+	sum := metricID.Hash()
+	attrsHash := pdatautil.MapHash(attrs)
+	sum.Write(attrsHash[:])
+	return sum.Sum64()
+}
+
+func hasDataPoints(m pmetric.Metric) bool {
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len() > 0
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len() > 0
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len() > 0
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len() > 0
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len() > 0
+	case pmetric.MetricTypeEmpty:
+	}
+	return true
+}
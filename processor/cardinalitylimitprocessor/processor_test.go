@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/metadatatest"
+)
+
+func TestLimit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		config *Config
+	}{
+		{
+			name: "series_over_budget_are_dropped",
+			config: &Config{
+				DefaultLimit: 2,
+				Limits:       map[string]int{"http.server.errors": 3},
+				Action:       Drop,
+				Mode:         Exact,
+				Expiration:   time.Minute,
+			},
+		},
+		{
+			name: "series_over_budget_overflow",
+			config: &Config{
+				DefaultLimit: 2,
+				Action:       Overflow,
+				Mode:         Exact,
+				Expiration:   time.Minute,
+			},
+		},
+		{
+			name: "budgets_per_resource",
+			config: &Config{
+				DefaultLimit:       2,
+				ResourceAttributes: []string{"service.name"},
+				Action:             Overflow,
+				Mode:               Exact,
+				Expiration:         time.Minute,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			next := &consumertest.MetricsSink{}
+			mp, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(metadata.Type), tc.config, next)
+			require.NoError(t, err)
+
+			dir := filepath.Join("testdata", tc.name)
+			md, err := golden.ReadMetrics(filepath.Join(dir, "input.yaml"))
+			require.NoError(t, err)
+			require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+
+			allMetrics := next.AllMetrics()
+			require.Len(t, allMetrics, 1)
+
+			expected, err := golden.ReadMetrics(filepath.Join(dir, "output.yaml"))
+			require.NoError(t, err)
+			require.NoError(t, pmetrictest.CompareMetrics(expected, allMetrics[0]))
+			require.NoError(t, mp.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestAdmittedSeriesAreKept(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProcessor(t, &Config{DefaultLimit: 2, Action: Drop, Mode: Exact, Expiration: time.Minute}, processortest.NewNopSettings(metadata.Type))
+
+	// The series admitted first keep being admitted while the budget is exceeded
+	assert.Equal(t, []string{"a", "b"}, process(t, p, "a", "b", "c"))
+	assert.Equal(t, []string{"b", "a"}, process(t, p, "d", "b", "a"))
+}
+
+func TestExpiration(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProcessor(t, &Config{DefaultLimit: 2, Action: Drop, Mode: Exact, Expiration: time.Minute}, processortest.NewNopSettings(metadata.Type))
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	p.lastSweep = now
+
+	assert.Equal(t, []string{"a", "b"}, process(t, p, "a", "b", "c"))
+
+	// a is still active, b expires at the next sweep
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, []string{"a"}, process(t, p, "a", "c"))
+	now = now.Add(40 * time.Second)
+	assert.Equal(t, []string{"a", "c"}, process(t, p, "a", "c", "b"))
+
+	// The budgets left without active series are removed
+	p.sweep(now.Add(2 * time.Minute))
+	assert.Empty(t, p.budgets)
+}
+
+func TestApproximate(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProcessor(t, &Config{DefaultLimit: 100, Action: Drop, Mode: Approximate, Expiration: time.Minute}, processortest.NewNopSettings(metadata.Type))
+
+	series := make([]string, 1000)
+	for i := range series {
+		series[i] = fmt.Sprintf("series-%d", i)
+	}
+
+	// All the series are admitted until the budget is exceeded
+	assert.Len(t, process(t, p, series[:90]...), 90)
+
+	// Then about as many series as the budget are admitted, always the same ones
+	process(t, p, series...)
+	admitted := process(t, p, series...)
+	assert.InDelta(t, 100, len(admitted), 30)
+	assert.Equal(t, admitted, process(t, p, series...))
+}
+
+func TestTelemetry(t *testing.T) {
+	t.Parallel()
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	p := newTestProcessor(t, &Config{DefaultLimit: 2, Action: Drop, Mode: Exact, Expiration: time.Minute}, metadatatest.NewSettings(tel))
+	process(t, p, "a", "b", "c", "d")
+
+	p.config.Action = Overflow
+	process(t, p, "a", "e")
+
+	metadatatest.AssertEqualProcessorCardinalitylimitDatapointsDropped(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorCardinalitylimitDatapointsOverflowed(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorCardinalitylimitSeriesActive(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestOverflowOnlyDeltas(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProcessor(t, &Config{DefaultLimit: 1, Action: Overflow, Mode: Exact, Expiration: time.Minute}, processortest.NewNopSettings(metadata.Type))
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := ms.AppendEmpty()
+	gauge.SetName("test.gauge")
+	gauge.SetEmptyGauge()
+	cumulative := ms.AppendEmpty()
+	cumulative.SetName("test.cumulative")
+	cumulative.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	histogram := ms.AppendEmpty()
+	histogram.SetName("test.histogram")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	delta := ms.AppendEmpty()
+	delta.SetName("test.delta")
+	delta.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for _, s := range []string{"a", "b", "c"} {
+		gauge.Gauge().DataPoints().AppendEmpty().Attributes().PutStr("series", s)
+		cumulative.Sum().DataPoints().AppendEmpty().Attributes().PutStr("series", s)
+		histogram.Histogram().DataPoints().AppendEmpty().Attributes().PutStr("series", s)
+		dp := delta.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("series", s)
+		dp.SetIntValue(1)
+	}
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	// The series over budget of gauges and cumulative metrics are dropped
+	assert.Equal(t, 1, gauge.Gauge().DataPoints().Len())
+	assert.Equal(t, 1, cumulative.Sum().DataPoints().Len())
+	assert.Equal(t, 1, histogram.Histogram().DataPoints().Len())
+
+	// The ones of delta metrics are folded into the overflow series
+	require.Equal(t, 2, delta.Sum().DataPoints().Len())
+	overflow := delta.Sum().DataPoints().At(1)
+	assert.Equal(t, map[string]any{overflowKey: true}, overflow.Attributes().AsRaw())
+	assert.Equal(t, int64(2), overflow.IntValue())
+	assert.Equal(t, 4, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+}
+
+func newTestProcessor(t *testing.T, config *Config, set processor.Settings) *cardinalityLimitProcessor {
+	p, err := newProcessor(config, set)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, p.shutdown(context.Background())) })
+	return p
+}
+
+// process sends a delta sum data point for each series to the processor, and
+// returns the series whose data points were kept.
+func process(t *testing.T, p *cardinalityLimitProcessor, series ...string) []string {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("test.sum")
+	m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dps := m.Sum().DataPoints()
+	for _, s := range series {
+		dps.AppendEmpty().Attributes().PutStr("series", s)
+	}
+
+	md, err := p.processMetrics(context.Background(), md)
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	require.NoError(t, err)
+
+	var kept []string
+	dps = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		if v, ok := dps.At(i).Attributes().Get("series"); ok {
+			kept = append(kept, v.Str())
+		}
+	}
+	return kept
+}
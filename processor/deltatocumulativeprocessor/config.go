@@ -20,6 +20,13 @@ var _ xconfmap.Validator = (*Config)(nil)
 type Config struct {
 	MaxStale   time.Duration `mapstructure:"max_stale"`
 	MaxStreams int           `mapstructure:"max_streams"`
+
+	// Storage is the ID of a storage extension used to persist the state of
+	// all streams, so it survives restarts.
+	Storage *component.ID `mapstructure:"storage"`
+	// SnapshotInterval is how often the state is persisted to Storage. State
+	// is also persisted on shutdown.
+	SnapshotInterval time.Duration `mapstructure:"snapshot_interval"`
 }
 
 func (c *Config) Validate() error {
@@ -29,6 +36,9 @@ func (c *Config) Validate() error {
 	if c.MaxStreams < 0 {
 		return fmt.Errorf("max_streams must be a positive number (got %d)", c.MaxStreams)
 	}
+	if c.Storage != nil && c.SnapshotInterval <= 0 {
+		return fmt.Errorf("snapshot_interval must be a positive duration (got %s)", c.SnapshotInterval)
+	}
 	return nil
 }
 
@@ -39,6 +49,8 @@ func createDefaultConfig() component.Config {
 		// TODO: find good default
 		// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/31603
 		MaxStreams: math.MaxInt,
+
+		SnapshotInterval: time.Minute,
 	}
 }
 
@@ -21,6 +21,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.MustNewID("file_storage")
+
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
@@ -33,6 +35,8 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   1 * time.Minute,
 				MaxStreams: 10,
+
+				SnapshotInterval: time.Minute,
 			},
 		},
 		{
@@ -40,6 +44,8 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   2 * time.Minute,
 				MaxStreams: math.MaxInt,
+
+				SnapshotInterval: time.Minute,
 			},
 		},
 		{
@@ -47,6 +53,18 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   5 * time.Minute,
 				MaxStreams: 20,
+
+				SnapshotInterval: time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "storage"),
+			expected: &Config{
+				MaxStale:   5 * time.Minute,
+				MaxStreams: math.MaxInt,
+
+				Storage:          &storageID,
+				SnapshotInterval: 30 * time.Second,
 			},
 		},
 	}
@@ -65,3 +83,11 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSnapshotInterval(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &storageID
+	cfg.SnapshotInterval = 0
+	require.ErrorContains(t, cfg.Validate(), "snapshot_interval must be a positive duration")
+}
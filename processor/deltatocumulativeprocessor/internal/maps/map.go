@@ -106,6 +106,12 @@ func (m *Parallel[K, V]) LoadAndDelete(k K) (_ V, loaded bool) {
 	return v, loaded
 }
 
+// Range calls f sequentially for each key and value present in the map.
+// If f returns false, range stops the iteration.
+func (m *Parallel[K, V]) Range(f func(k K, v V) bool) {
+	m.elems.Range(f)
+}
+
 func (ctx Context) Size() int64 {
 	return ctx.total.Load()
 }
@@ -5,14 +5,17 @@ package deltatocumulativeprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/puzpuzpuz/xsync/v3"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
@@ -33,12 +36,21 @@ type Processor struct {
 
 	ctx    context.Context
 	cancel context.CancelFunc
+	// snapshots tracks the goroutine persisting the state periodically
+	snapshots sync.WaitGroup
 
 	stale *xsync.MapOf[identity.Stream, time.Time]
 	tel   telemetry.Metrics
+
+	id  component.ID
+	log *zap.Logger
+
+	// storage and meta are only used if persistence is configured
+	storage storage.Client
+	meta    *xsync.MapOf[identity.Metric, metricMeta]
 }
 
-func newProcessor(cfg *Config, tel telemetry.Metrics, next consumer.Metrics) *Processor {
+func newProcessor(cfg *Config, set processor.Settings, tel telemetry.Metrics, next consumer.Metrics) *Processor {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	limit := maps.Limit(int64(cfg.MaxStreams))
@@ -57,6 +69,10 @@ func newProcessor(cfg *Config, tel telemetry.Metrics, next consumer.Metrics) *Pr
 
 		stale: xsync.NewMapOf[identity.Stream, time.Time](),
 		tel:   tel,
+
+		id:   set.ID,
+		log:  set.Logger,
+		meta: xsync.NewMapOf[identity.Metric, metricMeta](),
 	}
 
 	tel.WithTracked(proc.last.Size)
@@ -90,6 +106,14 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 			return keep
 		}
 
+		if p.cfg.Storage != nil {
+			// remember what the streams of this metric belong to, so they can
+			// be persisted
+			p.meta.LoadOrCompute(m.Ident(), func() metricMeta {
+				return newMetricMeta(m.Resource(), m.Scope(), m.Metric)
+			})
+		}
+
 		// aggregate the datapoints.
 		// using filter here, as the pmetric.*DataPoint are reference types so
 		// we can modify them using their "value".
@@ -184,7 +208,32 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 	return p.next.ConsumeMetrics(ctx, md)
 }
 
-func (p *Processor) Start(_ context.Context, _ component.Host) error {
+func (p *Processor) Start(ctx context.Context, host component.Host) error {
+	if p.cfg.Storage != nil {
+		if err := p.startStorage(ctx, host); err != nil {
+			return err
+		}
+
+		// persist the state periodically, so not all progress is lost on
+		// crashes
+		p.snapshots.Add(1)
+		go func() {
+			defer p.snapshots.Done()
+			tick := time.NewTicker(p.cfg.SnapshotInterval)
+			defer tick.Stop()
+			for {
+				select {
+				case <-p.ctx.Done():
+					return
+				case <-tick.C:
+					if err := p.persist(p.ctx); err != nil {
+						p.log.Warn("failed to persist state", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
 	if p.cfg.MaxStale != 0 {
 		// delete stale streams once per minute
 		go func() {
@@ -213,9 +262,15 @@ func (p *Processor) Start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
-func (p *Processor) Shutdown(_ context.Context) error {
+func (p *Processor) Shutdown(ctx context.Context) error {
 	p.cancel()
-	return nil
+	if p.storage == nil {
+		return nil
+	}
+	// wait for an ongoing snapshot, so the state is persisted once more before
+	// the storage is closed
+	p.snapshots.Wait()
+	return errors.Join(p.persist(ctx), p.storage.Close(ctx))
 }
 
 func (p *Processor) Capabilities() consumer.Capabilities {
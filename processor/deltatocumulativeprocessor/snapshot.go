@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/maps"
+)
+
+// snapshotKey is the storage key under which the state of all streams is kept.
+const snapshotKey = "streams"
+
+// snapshot is the persisted form of the processor state.
+//
+// Metrics holds one datapoint per stream, carrying the cumulative value. LastSeen
+// holds the time (unix nanoseconds) each stream was last seen, in the order the
+// datapoints appear in Metrics.
+type snapshot struct {
+	Metrics  []byte  `json:"metrics"`
+	LastSeen []int64 `json:"last_seen"`
+}
+
+// metricMeta holds the resource, scope and metric (without datapoints) a
+// stream belongs to. Stream identities are hashes, so this is required to
+// reconstruct the datapoints when persisting them.
+type metricMeta struct {
+	res    pcommon.Resource
+	scope  pcommon.InstrumentationScope
+	metric pmetric.Metric
+}
+
+func newMetricMeta(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric) metricMeta {
+	meta := metricMeta{
+		res:    pcommon.NewResource(),
+		scope:  pcommon.NewInstrumentationScope(),
+		metric: pmetric.NewMetric(),
+	}
+	res.CopyTo(meta.res)
+	scope.CopyTo(meta.scope)
+
+	meta.metric.SetName(m.Name())
+	meta.metric.SetDescription(m.Description())
+	meta.metric.SetUnit(m.Unit())
+	m.Metadata().CopyTo(meta.metric.Metadata())
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		sum := meta.metric.SetEmptySum()
+		sum.SetAggregationTemporality(m.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(m.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		meta.metric.SetEmptyHistogram().SetAggregationTemporality(m.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		meta.metric.SetEmptyExponentialHistogram().SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+	}
+	return meta
+}
+
+// startStorage obtains the storage client and restores the persisted state.
+func (p *Processor) startStorage(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[*p.cfg.Storage]
+	if !ok {
+		return fmt.Errorf("storage extension %q not found", p.cfg.Storage)
+	}
+	se, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", p.cfg.Storage)
+	}
+	client, err := se.GetClient(ctx, component.KindProcessor, p.id, "")
+	if err != nil {
+		return err
+	}
+	p.storage = client
+
+	if err := p.restore(ctx); err != nil {
+		// a corrupted or incompatible snapshot must not prevent startup. streams
+		// will restart from zero instead.
+		p.log.Warn("failed to restore state from storage", zap.Error(err))
+	}
+	return nil
+}
+
+// restore loads the persisted state, skipping streams that went stale while
+// the processor was not running.
+func (p *Processor) restore(ctx context.Context) error {
+	buf, err := p.storage.Get(ctx, snapshotKey)
+	if err != nil || buf == nil {
+		return err
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(buf, &snap); err != nil {
+		return err
+	}
+	md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(snap.Metrics)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	n := 0
+	restored := 0
+	streams(md, func(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, id identity.Stream, dp any) {
+		if n >= len(snap.LastSeen) {
+			return
+		}
+		last := time.Unix(0, snap.LastSeen[n])
+		n++
+		if p.cfg.MaxStale != 0 && now.Sub(last) > p.cfg.MaxStale {
+			return
+		}
+
+		var stored bool
+		switch dp := dp.(type) {
+		case pmetric.NumberDataPoint:
+			stored = store(p.last.nums, id, dp)
+		case pmetric.HistogramDataPoint:
+			stored = store(p.last.hist, id, dp)
+		case pmetric.ExponentialHistogramDataPoint:
+			stored = store(p.last.expo, id, dp)
+		}
+		if !stored {
+			return
+		}
+
+		p.stale.Store(id, last)
+		p.meta.LoadOrCompute(id.Metric(), func() metricMeta {
+			return newMetricMeta(res, scope, m)
+		})
+		restored++
+	})
+
+	p.log.Debug("restored state from storage", zap.Int("streams", restored))
+	return nil
+}
+
+// persist writes the state of all streams to storage.
+func (p *Processor) persist(ctx context.Context) error {
+	md := pmetric.NewMetrics()
+	out := make(map[identity.Metric]pmetric.MetricSlice)
+
+	// metric returns the datapoint container of the metric of id, creating
+	// it from the recorded metadata on first use
+	metric := func(id identity.Stream) (pmetric.Metric, bool) {
+		mid := id.Metric()
+		if ms, ok := out[mid]; ok {
+			return ms.At(0), true
+		}
+		meta, ok := p.meta.Load(mid)
+		if !ok {
+			return pmetric.Metric{}, false
+		}
+		rm := md.ResourceMetrics().AppendEmpty()
+		meta.res.CopyTo(rm.Resource())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		meta.scope.CopyTo(sm.Scope())
+		meta.metric.CopyTo(sm.Metrics().AppendEmpty())
+		out[mid] = sm.Metrics()
+		return sm.Metrics().At(0), true
+	}
+
+	p.last.nums.Range(func(id identity.Stream, v *mutex[pmetric.NumberDataPoint]) bool {
+		if m, ok := metric(id); ok {
+			v.use(func(dp pmetric.NumberDataPoint) {
+				dp.CopyTo(m.Sum().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+	p.last.hist.Range(func(id identity.Stream, v *mutex[pmetric.HistogramDataPoint]) bool {
+		if m, ok := metric(id); ok {
+			v.use(func(dp pmetric.HistogramDataPoint) {
+				dp.CopyTo(m.Histogram().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+	p.last.expo.Range(func(id identity.Stream, v *mutex[pmetric.ExponentialHistogramDataPoint]) bool {
+		if m, ok := metric(id); ok {
+			v.use(func(dp pmetric.ExponentialHistogramDataPoint) {
+				dp.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+
+	// drop metadata of metrics that no longer have any stream
+	p.meta.Range(func(mid identity.Metric, _ metricMeta) bool {
+		if _, ok := out[mid]; !ok {
+			p.meta.Delete(mid)
+		}
+		return true
+	})
+
+	snap := snapshot{LastSeen: make([]int64, 0, md.DataPointCount())}
+	streams(md, func(_ pcommon.Resource, _ pcommon.InstrumentationScope, _ pmetric.Metric, id identity.Stream, _ any) {
+		last, _ := p.stale.Load(id)
+		snap.LastSeen = append(snap.LastSeen, last.UnixNano())
+	})
+
+	var err error
+	if snap.Metrics, err = (&pmetric.ProtoMarshaler{}).MarshalMetrics(md); err != nil {
+		return err
+	}
+	buf, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	return p.storage.Set(ctx, snapshotKey, buf)
+}
+
+// store inserts dp as the state of a stream that is not yet tracked, reporting
+// whether it was stored.
+func store[D any](m *maps.Parallel[identity.Stream, *mutex[D]], id identity.Stream, dp D) bool {
+	v, loaded := m.LoadOrStore(id, guard(dp))
+	return !loaded && !maps.Exceeded(v, loaded)
+}
+
+// streams calls fn for every datapoint of the cumulative-tracked metric types,
+// in the order they appear in md.
+func streams(md pmetric.Metrics, fn func(pcommon.Resource, pcommon.InstrumentationScope, pmetric.Metric, identity.Stream, any)) {
+	for _, rm := range md.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				mid := identity.OfResourceMetric(rm.Resource(), sm.Scope(), m)
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					for _, dp := range m.Sum().DataPoints().All() {
+						fn(rm.Resource(), sm.Scope(), m, identity.OfStream(mid, dp), dp)
+					}
+				case pmetric.MetricTypeHistogram:
+					for _, dp := range m.Histogram().DataPoints().All() {
+						fn(rm.Resource(), sm.Scope(), m, identity.OfStream(mid, dp), dp)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for _, dp := range m.ExponentialHistogram().DataPoints().All() {
+						fn(rm.Resource(), sm.Scope(), m, identity.OfStream(mid, dp), dp)
+					}
+				}
+			}
+		}
+	}
+}
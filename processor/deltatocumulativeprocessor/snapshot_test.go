@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+func deltaSum(ts time.Time, v int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("path", "/")
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(ts.Add(-time.Second)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	dp.SetIntValue(v)
+	return md
+}
+
+func lastSumValue(t *testing.T, sink *consumertest.MetricsSink) int64 {
+	all := sink.AllMetrics()
+	require.NotEmpty(t, all)
+	md := all[len(all)-1]
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	return m.Sum().DataPoints().At(0).IntValue()
+}
+
+func TestStateSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("state", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &ext.ID
+
+	now := time.Now()
+
+	sink := new(consumertest.MetricsSink)
+	proc, _ := setup(t, cfg, sink)
+	require.NoError(t, proc.Start(ctx, host))
+	require.NoError(t, proc.ConsumeMetrics(ctx, deltaSum(now, 3)))
+	require.NoError(t, proc.ConsumeMetrics(ctx, deltaSum(now.Add(time.Second), 4)))
+	require.Equal(t, int64(7), lastSumValue(t, sink))
+	require.NoError(t, proc.Shutdown(ctx))
+
+	sink = new(consumertest.MetricsSink)
+	proc, _ = setup(t, cfg, sink)
+	require.NoError(t, proc.Start(ctx, host))
+	require.NoError(t, proc.ConsumeMetrics(ctx, deltaSum(now.Add(2*time.Second), 5)))
+	require.Equal(t, int64(12), lastSumValue(t, sink))
+	require.NoError(t, proc.Shutdown(ctx))
+}
+
+func TestRestoreSkipsStaleStreams(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("state", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &ext.ID
+
+	now := time.Now()
+
+	proc, _ := setup(t, cfg, new(consumertest.MetricsSink))
+	require.NoError(t, proc.Start(ctx, host))
+	require.NoError(t, proc.ConsumeMetrics(ctx, deltaSum(now, 3)))
+	// pretend the stream was last seen long ago
+	p := proc.(*Processor)
+	p.stale.Range(func(id identity.Stream, _ time.Time) bool {
+		p.stale.Store(id, now.Add(-2*cfg.MaxStale))
+		return true
+	})
+	require.NoError(t, proc.Shutdown(ctx))
+
+	sink := new(consumertest.MetricsSink)
+	proc, _ = setup(t, cfg, sink)
+	require.NoError(t, proc.Start(ctx, host))
+	require.Equal(t, 0, proc.(*Processor).last.Size())
+	require.NoError(t, proc.ConsumeMetrics(ctx, deltaSum(now.Add(time.Second), 5)))
+	require.Equal(t, int64(5), lastSumValue(t, sink))
+	require.NoError(t, proc.Shutdown(ctx))
+}
+
+func TestShutdownWaitsForSnapshot(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("state", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &ext.ID
+	cfg.SnapshotInterval = time.Microsecond
+
+	core, logs := observer.New(zap.WarnLevel)
+	tt := setupTestTelemetry()
+	set := tt.newSettings()
+	set.Logger = zap.New(core)
+
+	now := time.Now()
+	for i := range 10 {
+		proc, err := NewFactory().CreateMetrics(ctx, set, cfg, new(consumertest.MetricsSink))
+		require.NoError(t, err)
+		require.NoError(t, proc.Start(ctx, host))
+		require.NoError(t, proc.ConsumeMetrics(ctx, deltaSum(now.Add(time.Duration(i)*time.Second), 1)))
+		time.Sleep(time.Millisecond)
+		require.NoError(t, proc.Shutdown(ctx))
+	}
+	// no snapshot was taken while or after the storage was closed
+	require.Zero(t, logs.FilterMessage("failed to persist state").Len())
+}
+
+func TestStartMissingStorage(t *testing.T) {
+	ext := storagetest.NewFileBackedStorageExtension("state", t.TempDir())
+	cfg := createDefaultConfig().(*Config)
+	cfg.Storage = &ext.ID
+
+	proc, _ := setup(t, cfg, new(consumertest.MetricsSink))
+	err := proc.Start(context.Background(), storagetest.NewStorageHost())
+	require.ErrorContains(t, err, "storage extension")
+	require.NoError(t, proc.Shutdown(context.Background()))
+}
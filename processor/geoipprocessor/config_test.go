@@ -7,6 +7,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +19,9 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	cidr "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/cidrprovider"
+	dbip "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/dbipprovider"
+	ipinfo "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipinfoprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -76,6 +80,19 @@ func TestLoadConfig(t *testing.T) {
 				Attributes: []attribute.Key{"client.address", "source.address", "custom.address"},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_providers"),
+			expected: &Config{
+				Context: resource,
+				Providers: map[string]provider.Config{
+					"maxmind": &maxmind.Config{DatabasePath: "/tmp/db", ReloadInterval: time.Hour},
+					"ipinfo":  &ipinfo.Config{DatabasePath: "/tmp/ipinfo.mmdb"},
+					"dbip":    &dbip.Config{DatabasePath: "/tmp/dbip.mmdb", ReloadInterval: 10 * time.Minute},
+					"cidr":    &cidr.Config{DatabasePath: "/tmp/sites.csv", ReloadInterval: 30 * time.Second},
+				},
+				Attributes: defaultAttributes,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -14,9 +14,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
@@ -268,3 +270,27 @@ func TestProcessorShutdownError(t *testing.T) {
 
 	assert.EqualError(t, processor.shutdown(context.Background()), "test error 1; test error 2")
 }
+
+func TestProcessAttributesTypes(t *testing.T) {
+	processor := newGeoIPProcessor(createDefaultConfig().(*Config), []provider.GeoIPProvider{
+		&providerMock{
+			LocationF: func(context.Context, net.IP) (attribute.Set, error) {
+				return attribute.NewSet(
+					attribute.String(conventions.AttributeGeoCityName, "Boxford"),
+					attribute.Float64(conventions.AttributeGeoLocationLat, 51.75),
+					attribute.Int64(conventions.AttributeNetworkASNumber, 15169),
+				), nil
+			},
+		},
+	}, processortest.NewNopSettings(metadata.Type))
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr(string(semconv.ClientAddressKey), "1.2.3.4")
+	require.NoError(t, processor.processAttributes(context.Background(), attrs))
+	assert.Equal(t, map[string]any{
+		string(semconv.ClientAddressKey):     "1.2.3.4",
+		conventions.AttributeGeoCityName:     "Boxford",
+		conventions.AttributeGeoLocationLat:  51.75,
+		conventions.AttributeNetworkASNumber: int64(15169),
+	}, attrs.AsRaw())
+}
@@ -37,4 +37,13 @@ const (
 
 	// AttributeGeoLocationLon represents the attribute name for the longitude.
 	AttributeGeoLocationLon = "geo.location.lon"
+
+	// AttributeNetworkASNumber represents the attribute name for the autonomous system number.
+	AttributeNetworkASNumber = "network.as.number"
+
+	// AttributeNetworkASOrganizationName represents the attribute name for the organization owning the autonomous system.
+	AttributeNetworkASOrganizationName = "network.as.organization.name"
+
+	// AttributeNetworkASDomain represents the attribute name for the domain of the organization owning the autonomous system.
+	AttributeNetworkASDomain = "network.as.domain"
 )
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cidr // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/cidrprovider"
+
+import (
+	"errors"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// Config defines configuration for CIDR provider.
+type Config struct {
+	// DatabasePath section allows specifying a local CSV file mapping network ranges (CIDR)
+	// to the attributes to add. See the README for the file format.
+	DatabasePath string `mapstructure:"database_path"`
+
+	// ReloadInterval is how often the database file is checked for changes. The database
+	// is reloaded when the file changed. Zero disables reloading.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+var _ provider.Config = (*Config)(nil)
+
+// Validate implements provider.Config.
+func (c *Config) Validate() error {
+	if c.DatabasePath == "" {
+		return errors.New("a local CSV file path must be provided")
+	}
+	if c.ReloadInterval < 0 {
+		return errors.New("reload_interval must not be negative")
+	}
+	return nil
+}
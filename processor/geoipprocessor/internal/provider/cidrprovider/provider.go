@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cidr // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/cidrprovider"
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/reloader"
+)
+
+// networkColumn is the name of the column holding the network range of each row.
+const networkColumn = "network"
+
+// allowedPrefixes are the attribute namespaces the columns of the CSV file may use.
+var allowedPrefixes = []string{"geo.", "network."}
+
+var errMissingNetworkColumn = fmt.Errorf("the CSV header must contain a %q column", networkColumn)
+
+// table maps network ranges to attributes. Lookups return the attributes of the most specific
+// network containing the address.
+type table struct {
+	// networks holds the attributes of the networks, indexed by their prefix length.
+	networks map[int]map[netip.Prefix]attribute.Set
+	// bits holds the prefix lengths present in networks, longest first.
+	bits []int
+}
+
+// Close implements io.Closer, the table does not hold any resources.
+func (*table) Close() error {
+	return nil
+}
+
+func (t *table) lookup(addr netip.Addr) (attribute.Set, bool) {
+	for _, bits := range t.bits {
+		if bits > addr.BitLen() {
+			continue
+		}
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			continue
+		}
+		if attrs, ok := t.networks[bits][prefix]; ok {
+			return attrs, true
+		}
+	}
+	return attribute.Set{}, false
+}
+
+type cidrProvider struct {
+	table *reloader.Database[*table]
+}
+
+var _ provider.GeoIPProvider = (*cidrProvider)(nil)
+
+func newCIDRProvider(cfg *Config, logger *zap.Logger) (*cidrProvider, error) {
+	t, err := reloader.New(cfg.DatabasePath, cfg.ReloadInterval, loadTable, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &cidrProvider{table: t}, nil
+}
+
+// loadTable reads the CSV file at path. The first row is a header naming the columns: the
+// `network` column holds the network range in CIDR notation, every other column holds the value
+// of the attribute named after the column.
+func loadTable(path string) (*table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open CIDR database: %w", err)
+	}
+	defer f.Close()
+
+	t, err := parseTable(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CIDR database %q: %w", path, err)
+	}
+	return t, nil
+}
+
+func parseTable(r io.Reader) (*table, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errMissingNetworkColumn
+	} else if err != nil {
+		return nil, err
+	}
+
+	networkIdx := -1
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		header[i] = column
+		if column == networkColumn {
+			networkIdx = i
+			continue
+		}
+		if !hasAllowedPrefix(column) {
+			return nil, fmt.Errorf("invalid column %q, attribute columns must start with one of %v", column, allowedPrefixes)
+		}
+	}
+	if networkIdx < 0 {
+		return nil, errMissingNetworkColumn
+	}
+
+	t := &table{networks: map[int]map[netip.Prefix]attribute.Set{}}
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(row[networkIdx]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		prefix = prefix.Masked()
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+
+		attrs := make([]attribute.KeyValue, 0, len(row)-1)
+		for i, value := range row {
+			value = strings.TrimSpace(value)
+			if i == networkIdx || value == "" {
+				continue
+			}
+			kv, err := toAttribute(header[i], value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			attrs = append(attrs, kv)
+		}
+
+		byPrefix, ok := t.networks[prefix.Bits()]
+		if !ok {
+			byPrefix = map[netip.Prefix]attribute.Set{}
+			t.networks[prefix.Bits()] = byPrefix
+			t.bits = append(t.bits, prefix.Bits())
+		}
+		if _, ok := byPrefix[prefix]; ok {
+			return nil, fmt.Errorf("line %d: duplicate network %s", line, prefix)
+		}
+		byPrefix[prefix] = attribute.NewSet(attrs...)
+	}
+	slices.Sort(t.bits)
+	slices.Reverse(t.bits)
+	return t, nil
+}
+
+func hasAllowedPrefix(column string) bool {
+	for _, prefix := range allowedPrefixes {
+		if strings.HasPrefix(column, prefix) && len(column) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// toAttribute converts a CSV value to an attribute. Coordinates are numeric and the AS number is
+// an integer, all other values are strings.
+func toAttribute(key, value string) (attribute.KeyValue, error) {
+	switch key {
+	case conventions.AttributeGeoLocationLat, conventions.AttributeGeoLocationLon:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return attribute.KeyValue{}, fmt.Errorf("invalid %s value %q: %w", key, value, err)
+		}
+		return attribute.Float64(key, f), nil
+	case conventions.AttributeNetworkASNumber:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return attribute.KeyValue{}, fmt.Errorf("invalid %s value %q: %w", key, value, err)
+		}
+		return attribute.Int64(key, n), nil
+	}
+	return attribute.String(key, value), nil
+}
+
+// Location implements provider.GeoIPProvider for CIDR tables.
+func (p *cidrProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	addr, ok := netip.AddrFromSlice(ipAddress)
+	if !ok {
+		return attribute.Set{}, fmt.Errorf("invalid IP address: %s", ipAddress)
+	}
+	addr = addr.Unmap()
+
+	var attrs attribute.Set
+	var found bool
+	_ = p.table.Use(func(t *table) error {
+		attrs, found = t.lookup(addr)
+		return nil
+	})
+	if !found || attrs.Len() == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attrs, nil
+}
+
+// Close stops watching the CSV file.
+func (p *cidrProvider) Close(context.Context) error {
+	if p.table != nil {
+		return p.table.Close()
+	}
+	return nil
+}
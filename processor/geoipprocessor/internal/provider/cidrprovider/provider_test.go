@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cidr
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+const sites = `# private networks
+network,geo.city_name,geo.location.lat,geo.location.lon,network.as.number,network.site
+10.0.0.0/8,Barcelona,41.38,2.17,,campus
+10.1.0.0/16,Madrid,40.41,-3.70,64512,datacenter
+fd00::/8,Lisbon,,,,
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newCIDRProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open CIDR database")
+}
+
+func TestInvalidTable(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		expectedErrMsg string
+	}{
+		{
+			name:           "empty file",
+			expectedErrMsg: `the CSV header must contain a "network" column`,
+		},
+		{
+			name:           "missing network column",
+			content:        "geo.city_name\nBarcelona\n",
+			expectedErrMsg: `the CSV header must contain a "network" column`,
+		},
+		{
+			name:           "column outside of the allowed namespaces",
+			content:        "network,service.name\n10.0.0.0/8,foo\n",
+			expectedErrMsg: `invalid column "service.name"`,
+		},
+		{
+			name:           "invalid network",
+			content:        "network,geo.city_name\n10.0.0.0/33,Barcelona\n",
+			expectedErrMsg: "line 2: netip.ParsePrefix",
+		},
+		{
+			name:           "invalid coordinates",
+			content:        "network,geo.location.lat\n10.0.0.0/8,north\n",
+			expectedErrMsg: `line 2: invalid geo.location.lat value "north"`,
+		},
+		{
+			name:           "duplicate network",
+			content:        "network,geo.city_name\n10.0.0.0/8,Barcelona\n10.0.0.1/8,Madrid\n",
+			expectedErrMsg: "line 3: duplicate network 10.0.0.0/8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "sites.csv")
+			writeFile(t, path, tt.content)
+			_, err := newCIDRProvider(&Config{DatabasePath: path}, zap.NewNop())
+			require.ErrorContains(t, err, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestProviderLocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.csv")
+	writeFile(t, path, sites)
+
+	provider, err := newCIDRProvider(&Config{DatabasePath: path}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(context.Background()))
+	}()
+
+	tests := []struct {
+		name               string
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:     "network match",
+			sourceIP: net.IPv4(10, 2, 3, 4),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCityName, "Barcelona"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 41.38),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 2.17),
+				attribute.String("network.site", "campus"),
+			),
+		},
+		{
+			name:     "most specific network match",
+			sourceIP: net.IPv4(10, 1, 3, 4),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCityName, "Madrid"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 40.41),
+				attribute.Float64(conventions.AttributeGeoLocationLon, -3.70),
+				attribute.Int64(conventions.AttributeNetworkASNumber, 64512),
+				attribute.String("network.site", "datacenter"),
+			),
+		},
+		{
+			name:     "IPv6 network match",
+			sourceIP: net.ParseIP("fd12::1"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCityName, "Lisbon"),
+			),
+		},
+		{
+			name:           "IP not in table",
+			sourceIP:       net.IPv4(192, 168, 1, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), actualAttributes.ToSlice())
+		})
+	}
+}
+
+func TestProviderReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.csv")
+	writeFile(t, path, sites)
+
+	provider, err := newCIDRProvider(&Config{DatabasePath: path, ReloadInterval: 10 * time.Millisecond}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(context.Background()))
+	}()
+
+	cityOf := func(ip net.IP) string {
+		attrs, err := provider.Location(context.Background(), ip)
+		if err != nil {
+			return ""
+		}
+		city, _ := attrs.Value(conventions.AttributeGeoCityName)
+		return city.AsString()
+	}
+	require.Equal(t, "Barcelona", cityOf(net.IPv4(10, 2, 3, 4)))
+
+	// an invalid file keeps the previous table
+	writeFile(t, path, "not a table\n")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, "Barcelona", cityOf(net.IPv4(10, 2, 3, 4)))
+
+	writeFile(t, path, "network,geo.city_name\n10.0.0.0/8,Valencia\n")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	assert.Eventually(t, func() bool {
+		return cityOf(net.IPv4(10, 2, 3, 4)) == "Valencia"
+	}, 5*time.Second, 10*time.Millisecond)
+}
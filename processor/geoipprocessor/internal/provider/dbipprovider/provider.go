@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbip // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/dbipprovider"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/reloader"
+)
+
+var (
+	// defaultLanguageCode specifies English as the default Geolocation language code
+	defaultLanguageCode = "en"
+
+	errUnsupportedDB = errors.New("unsupported geo IP database type")
+)
+
+// DB-IP database types, see https://db-ip.com/db/format/
+const (
+	cityLiteDBType     = "DBIP-City-Lite"
+	countryLiteDBType  = "DBIP-Country-Lite"
+	countryDBType      = "DBIP-Country"
+	locationDBType     = "DBIP-Location (compat=City)"
+	ispDBType          = "DBIP-ISP (compat=Enterprise)"
+	locationISPDBType  = "DBIP-Location-ISP (compat=Enterprise)"
+	asnLiteDBType      = "DBIP-ASN-Lite (compat=GeoLite2-ASN)"
+	expectedAttributes = 14
+)
+
+type dbipProvider struct {
+	geoReader *reloader.Database[*geoip2.Reader]
+	// language code to be used in name retrieval, e.g. "en" or "pt-BR"
+	langCode string
+}
+
+var _ provider.GeoIPProvider = (*dbipProvider)(nil)
+
+func newDBIPProvider(cfg *Config, logger *zap.Logger) (*dbipProvider, error) {
+	geoReader, err := reloader.New(cfg.DatabasePath, cfg.ReloadInterval, maxmind.OpenDatabase, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &dbipProvider{geoReader: geoReader, langCode: defaultLanguageCode}, nil
+}
+
+// Location implements provider.GeoIPProvider for DB-IP. The MMDB databases of DB-IP are compatible with the
+// MaxMind ones: location databases provide geographical metadata, ISP and ASN databases provide the
+// autonomous system.
+func (p *dbipProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	attrs := make([]attribute.KeyValue, 0, expectedAttributes)
+	err := p.geoReader.Use(func(geoReader *geoip2.Reader) error {
+		switch dbType := geoReader.Metadata().DatabaseType; dbType {
+		case cityLiteDBType, countryLiteDBType, countryDBType, locationDBType:
+			city, err := geoReader.City(ipAddress)
+			if err != nil {
+				return err
+			}
+			attrs = append(attrs, maxmind.CityAttributes(city, p.langCode)...)
+		case ispDBType, locationISPDBType:
+			enterprise, err := geoReader.Enterprise(ipAddress)
+			if err != nil {
+				return err
+			}
+			if dbType == locationISPDBType {
+				city, err := geoReader.City(ipAddress)
+				if err != nil {
+					return err
+				}
+				attrs = append(attrs, maxmind.CityAttributes(city, p.langCode)...)
+			}
+			attrs = appendASAttributes(attrs, enterprise.Traits.AutonomousSystemNumber, enterprise.Traits.AutonomousSystemOrganization)
+		case asnLiteDBType:
+			asn, err := geoReader.ASN(ipAddress)
+			if err != nil {
+				return err
+			}
+			attrs = appendASAttributes(attrs, asn.AutonomousSystemNumber, asn.AutonomousSystemOrganization)
+		default:
+			return fmt.Errorf("%w type: %s", errUnsupportedDB, dbType)
+		}
+		return nil
+	})
+	if err != nil {
+		return attribute.Set{}, err
+	} else if len(attrs) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attribute.NewSet(attrs...), nil
+}
+
+// Close unmaps the geo database file from virtual memory and returns the
+// resources to the system.
+func (p *dbipProvider) Close(context.Context) error {
+	if p.geoReader != nil {
+		return p.geoReader.Close()
+	}
+	return nil
+}
+
+func appendASAttributes(attrs []attribute.KeyValue, number uint, organization string) []attribute.KeyValue {
+	if number != 0 {
+		attrs = append(attrs, attribute.Int64(conventions.AttributeNetworkASNumber, int64(number)))
+	}
+	if organization != "" {
+		attrs = append(attrs, attribute.String(conventions.AttributeNetworkASOrganizationName, organization))
+	}
+	return attrs
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbip
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+// writeDB writes a DB-IP like MMDB database of the given type holding the given networks.
+func writeDB(t *testing.T, path, dbType string, networks map[string]mmdbtype.Map) {
+	t.Helper()
+	tree, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: dbType, IncludeReservedNetworks: true})
+	require.NoError(t, err)
+	for cidr, data := range networks {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		require.NoError(t, tree.Insert(network, data))
+	}
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = tree.WriteTo(f)
+	require.NoError(t, err)
+}
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newDBIPProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open geoip database")
+}
+
+func TestProviderLocation(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cityDB := filepath.Join(tmpDir, "dbip-city-lite.mmdb")
+	writeDB(t, cityDB, cityLiteDBType, map[string]mmdbtype.Map{
+		"1.2.3.0/24": {
+			"city":      mmdbtype.Map{"names": mmdbtype.Map{"en": mmdbtype.String("Boxford")}},
+			"country":   mmdbtype.Map{"iso_code": mmdbtype.String("GB"), "names": mmdbtype.Map{"en": mmdbtype.String("United Kingdom")}},
+			"continent": mmdbtype.Map{"code": mmdbtype.String("EU"), "names": mmdbtype.Map{"en": mmdbtype.String("Europe")}},
+			"location":  mmdbtype.Map{"latitude": mmdbtype.Float64(51.75), "longitude": mmdbtype.Float64(-1.25)},
+		},
+	})
+
+	asnDB := filepath.Join(tmpDir, "dbip-asn-lite.mmdb")
+	writeDB(t, asnDB, asnLiteDBType, map[string]mmdbtype.Map{
+		"8.8.8.0/24": {
+			"autonomous_system_number":       mmdbtype.Uint32(15169),
+			"autonomous_system_organization": mmdbtype.String("Google LLC"),
+		},
+	})
+
+	unsupportedDB := filepath.Join(tmpDir, "unsupported.mmdb")
+	writeDB(t, unsupportedDB, "GeoIP2-Domain", map[string]mmdbtype.Map{})
+
+	tests := []struct {
+		name               string
+		dbPath             string
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:     "city database",
+			dbPath:   cityDB,
+			sourceIP: net.IPv4(1, 2, 3, 4),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCityName, "Boxford"),
+				attribute.String(conventions.AttributeGeoCountryName, "United Kingdom"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "GB"),
+				attribute.String(conventions.AttributeGeoContinentName, "Europe"),
+				attribute.String(conventions.AttributeGeoContinentCode, "EU"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 51.75),
+				attribute.Float64(conventions.AttributeGeoLocationLon, -1.25),
+			),
+		},
+		{
+			name:           "city database, IP not found",
+			dbPath:         cityDB,
+			sourceIP:       net.IPv4(10, 0, 0, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:     "ASN database",
+			dbPath:   asnDB,
+			sourceIP: net.IPv4(8, 8, 8, 8),
+			expectedAttributes: attribute.NewSet(
+				attribute.Int64(conventions.AttributeNetworkASNumber, 15169),
+				attribute.String(conventions.AttributeNetworkASOrganizationName, "Google LLC"),
+			),
+		},
+		{
+			name:           "unsupported database",
+			dbPath:         unsupportedDB,
+			sourceIP:       net.IPv4(8, 8, 8, 8),
+			expectedErrMsg: "unsupported geo IP database type type: GeoIP2-Domain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := newDBIPProvider(&Config{DatabasePath: tt.dbPath}, zap.NewNop())
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, provider.Close(context.Background()))
+			}()
+
+			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), actualAttributes.ToSlice())
+		})
+	}
+}
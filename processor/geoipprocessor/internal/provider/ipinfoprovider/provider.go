@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipinfo // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipinfoprovider"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/reloader"
+)
+
+// record holds the fields of the IPinfo MMDB databases. The available fields depend on the
+// database: e.g. the location databases contain city and coordinates, the ASN databases contain
+// the autonomous system. Depending on the database, `country` and `continent` hold either a code
+// or a name.
+type record struct {
+	City          string `maxminddb:"city"`
+	Region        string `maxminddb:"region"`
+	RegionCode    string `maxminddb:"region_code"`
+	Country       string `maxminddb:"country"`
+	CountryCode   string `maxminddb:"country_code"`
+	CountryName   string `maxminddb:"country_name"`
+	Continent     string `maxminddb:"continent"`
+	ContinentCode string `maxminddb:"continent_code"`
+	ContinentName string `maxminddb:"continent_name"`
+	PostalCode    string `maxminddb:"postal_code"`
+	Timezone      string `maxminddb:"timezone"`
+	Lat           any    `maxminddb:"lat"`
+	Lng           any    `maxminddb:"lng"`
+	ASN           any    `maxminddb:"asn"`
+	ASName        string `maxminddb:"as_name"`
+	ASDomain      string `maxminddb:"as_domain"`
+}
+
+type ipinfoProvider struct {
+	reader *reloader.Database[*maxminddb.Reader]
+}
+
+var _ provider.GeoIPProvider = (*ipinfoProvider)(nil)
+
+func newIPinfoProvider(cfg *Config, logger *zap.Logger) (*ipinfoProvider, error) {
+	reader, err := reloader.New(cfg.DatabasePath, cfg.ReloadInterval, openDatabase, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &ipinfoProvider{reader: reader}, nil
+}
+
+func openDatabase(path string) (*maxminddb.Reader, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open IPinfo database: %w", err)
+	}
+	return reader, nil
+}
+
+// Location implements provider.GeoIPProvider for IPinfo.
+func (p *ipinfoProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	var rec record
+	var found bool
+	err := p.reader.Use(func(reader *maxminddb.Reader) error {
+		var err error
+		_, found, err = reader.LookupNetwork(ipAddress, &rec)
+		return err
+	})
+	if err != nil {
+		return attribute.Set{}, err
+	}
+
+	attrs := rec.attributes()
+	if !found || len(attrs) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attribute.NewSet(attrs...), nil
+}
+
+// Close releases the database.
+func (p *ipinfoProvider) Close(context.Context) error {
+	if p.reader != nil {
+		return p.reader.Close()
+	}
+	return nil
+}
+
+// attributes returns the geographical and network metadata of the record following the internal geo IP conventions.
+func (r *record) attributes() []attribute.KeyValue {
+	attributes := make([]attribute.KeyValue, 0, 14)
+	appendIfNotEmpty := func(keyName, value string) {
+		if value != "" {
+			attributes = append(attributes, attribute.String(keyName, value))
+		}
+	}
+
+	countryCode, countryName := codeOrName(r.CountryCode, r.CountryName, r.Country)
+	continentCode, continentName := codeOrName(r.ContinentCode, r.ContinentName, r.Continent)
+
+	appendIfNotEmpty(conventions.AttributeGeoCityName, r.City)
+	appendIfNotEmpty(conventions.AttributeGeoCountryName, countryName)
+	appendIfNotEmpty(conventions.AttributeGeoCountryIsoCode, countryCode)
+	appendIfNotEmpty(conventions.AttributeGeoContinentName, continentName)
+	appendIfNotEmpty(conventions.AttributeGeoContinentCode, continentCode)
+	appendIfNotEmpty(conventions.AttributeGeoPostalCode, r.PostalCode)
+	appendIfNotEmpty(conventions.AttributeGeoRegionName, r.Region)
+	appendIfNotEmpty(conventions.AttributeGeoRegionIsoCode, r.RegionCode)
+	appendIfNotEmpty(conventions.AttributeGeoTimezone, r.Timezone)
+
+	lat, latOK := toFloat(r.Lat)
+	lon, lonOK := toFloat(r.Lng)
+	if latOK && lonOK {
+		attributes = append(attributes, attribute.Float64(conventions.AttributeGeoLocationLat, lat), attribute.Float64(conventions.AttributeGeoLocationLon, lon))
+	}
+
+	if asn, ok := toASNumber(r.ASN); ok {
+		attributes = append(attributes, attribute.Int64(conventions.AttributeNetworkASNumber, asn))
+	}
+	appendIfNotEmpty(conventions.AttributeNetworkASOrganizationName, r.ASName)
+	appendIfNotEmpty(conventions.AttributeNetworkASDomain, r.ASDomain)
+
+	return attributes
+}
+
+// codeOrName resolves the code and the name of a country or a continent. IPinfo databases either
+// use dedicated `*_code` and `*_name` fields, or a single field holding a two letter code when the
+// other one is absent.
+func codeOrName(code, name, either string) (string, string) {
+	if either == "" {
+		return code, name
+	}
+	if code == "" && len(either) == 2 && strings.ToUpper(either) == either {
+		return either, name
+	}
+	if name == "" {
+		return code, either
+	}
+	return code, name
+}
+
+// toFloat converts coordinates, which are stored as strings or doubles depending on the database.
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toASNumber converts an AS number, which is stored either as a number or as a string like "AS15169".
+func toASNumber(v any) (int64, bool) {
+	switch v := v.(type) {
+	case uint64:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(v), "AS"), 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
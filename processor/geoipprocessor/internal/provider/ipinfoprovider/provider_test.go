@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipinfo
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+// writeDB writes an IPinfo like MMDB database holding the given networks.
+func writeDB(t *testing.T, path string, networks map[string]mmdbtype.Map) {
+	t.Helper()
+	tree, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: "ipinfo standard_location.mmdb", IncludeReservedNetworks: true})
+	require.NoError(t, err)
+	for cidr, data := range networks {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		require.NoError(t, tree.Insert(network, data))
+	}
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = tree.WriteTo(f)
+	require.NoError(t, err)
+}
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newIPinfoProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open IPinfo database")
+}
+
+func TestProviderLocation(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "ipinfo.mmdb")
+	writeDB(t, dbPath, map[string]mmdbtype.Map{
+		// location database layout
+		"1.2.3.0/24": {
+			"city":        mmdbtype.String("Boxford"),
+			"region":      mmdbtype.String("England"),
+			"country":     mmdbtype.String("GB"),
+			"postal_code": mmdbtype.String("OX1"),
+			"timezone":    mmdbtype.String("Europe/London"),
+			"lat":         mmdbtype.String("51.75"),
+			"lng":         mmdbtype.String("-1.25"),
+		},
+		// lite database layout
+		"8.8.8.0/24": {
+			"asn":            mmdbtype.String("AS15169"),
+			"as_name":        mmdbtype.String("Google LLC"),
+			"as_domain":      mmdbtype.String("google.com"),
+			"country":        mmdbtype.String("United States"),
+			"country_code":   mmdbtype.String("US"),
+			"continent":      mmdbtype.String("North America"),
+			"continent_code": mmdbtype.String("NA"),
+		},
+		"9.9.9.0/24": {},
+	})
+
+	provider, err := newIPinfoProvider(&Config{DatabasePath: dbPath}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, provider.Close(context.Background()))
+	}()
+
+	tests := []struct {
+		name               string
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:     "location database record",
+			sourceIP: net.IPv4(1, 2, 3, 4),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCityName, "Boxford"),
+				attribute.String(conventions.AttributeGeoRegionName, "England"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "GB"),
+				attribute.String(conventions.AttributeGeoPostalCode, "OX1"),
+				attribute.String(conventions.AttributeGeoTimezone, "Europe/London"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 51.75),
+				attribute.Float64(conventions.AttributeGeoLocationLon, -1.25),
+			),
+		},
+		{
+			name:     "lite database record",
+			sourceIP: net.IPv4(8, 8, 8, 8),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryName, "United States"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "US"),
+				attribute.String(conventions.AttributeGeoContinentName, "North America"),
+				attribute.String(conventions.AttributeGeoContinentCode, "NA"),
+				attribute.Int64(conventions.AttributeNetworkASNumber, 15169),
+				attribute.String(conventions.AttributeNetworkASOrganizationName, "Google LLC"),
+				attribute.String(conventions.AttributeNetworkASDomain, "google.com"),
+			),
+		},
+		{
+			name:           "empty record",
+			sourceIP:       net.IPv4(9, 9, 9, 9),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:           "IP not in database",
+			sourceIP:       net.IPv4(10, 0, 0, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), actualAttributes.ToSlice())
+		})
+	}
+}
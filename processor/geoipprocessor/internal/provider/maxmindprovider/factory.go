@@ -27,7 +27,7 @@ func (f *Factory) CreateDefaultConfig() provider.Config {
 }
 
 // CreateGeoIPProvider creates a provider based on this config.
-func (f *Factory) CreateGeoIPProvider(_ context.Context, _ processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+func (f *Factory) CreateGeoIPProvider(_ context.Context, settings processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
 	maxMindConfig := cfg.(*Config)
-	return newMaxMindProvider(maxMindConfig)
+	return newMaxMindProvider(maxMindConfig, settings.Logger)
 }
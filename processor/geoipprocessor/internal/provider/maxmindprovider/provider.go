@@ -11,9 +11,11 @@ import (
 
 	"github.com/oschwald/geoip2-golang"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 
 	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/reloader"
 )
 
 var (
@@ -26,36 +28,53 @@ var (
 )
 
 type maxMindProvider struct {
-	geoReader *geoip2.Reader
+	geoReader *reloader.Database[*geoip2.Reader]
 	// language code to be used in name retrieval, e.g. "en" or "pt-BR"
 	langCode string
 }
 
 var _ provider.GeoIPProvider = (*maxMindProvider)(nil)
 
-func newMaxMindProvider(cfg *Config) (*maxMindProvider, error) {
-	geoReader, err := geoip2.Open(cfg.DatabasePath)
+func newMaxMindProvider(cfg *Config, logger *zap.Logger) (*maxMindProvider, error) {
+	geoReader, err := reloader.New(cfg.DatabasePath, cfg.ReloadInterval, OpenDatabase, logger)
 	if err != nil {
-		return nil, fmt.Errorf("could not open geoip database: %w", err)
+		return nil, err
 	}
 
 	return &maxMindProvider{geoReader: geoReader, langCode: defaultLanguageCode}, nil
 }
 
+// OpenDatabase opens the MaxMind formatted database located at path.
+func OpenDatabase(path string) (*geoip2.Reader, error) {
+	geoReader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open geoip database: %w", err)
+	}
+	return geoReader, nil
+}
+
 // Location implements provider.GeoIPProvider for MaxMind. If a non City database type is used or no metadata is found in the database, an error will be returned.
 func (g *maxMindProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
-	switch g.geoReader.Metadata().DatabaseType {
-	case geoIP2CityDBType, geoLite2CityDBType:
-		attrs, err := g.cityAttributes(ipAddress)
-		if err != nil {
-			return attribute.Set{}, err
-		} else if len(*attrs) == 0 {
-			return attribute.Set{}, provider.ErrNoMetadataFound
+	var attrs []attribute.KeyValue
+	err := g.geoReader.Use(func(geoReader *geoip2.Reader) error {
+		switch geoReader.Metadata().DatabaseType {
+		case geoIP2CityDBType, geoLite2CityDBType:
+			city, err := geoReader.City(ipAddress)
+			if err != nil {
+				return err
+			}
+			attrs = CityAttributes(city, g.langCode)
+			return nil
+		default:
+			return fmt.Errorf("%w type: %s", errUnsupportedDB, geoReader.Metadata().DatabaseType)
 		}
-		return attribute.NewSet(*attrs...), nil
-	default:
-		return attribute.Set{}, fmt.Errorf("%w type: %s", errUnsupportedDB, g.geoReader.Metadata().DatabaseType)
+	})
+	if err != nil {
+		return attribute.Set{}, err
+	} else if len(attrs) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
 	}
+	return attribute.NewSet(attrs...), nil
 }
 
 // Close unmaps the geo database file from virtual memory and returns the
@@ -67,15 +86,10 @@ func (g *maxMindProvider) Close(context.Context) error {
 	return nil
 }
 
-// cityAttributes returns a list of key-values containing geographical metadata associated to the provided IP. The key names are populated using the internal geo IP conventions package. If an invalid or nil IP is provided, an error is returned.
-func (g *maxMindProvider) cityAttributes(ipAddress net.IP) (*[]attribute.KeyValue, error) {
+// CityAttributes returns a list of key-values containing the geographical metadata of a City database record. The key names are populated using the internal geo IP conventions package.
+func CityAttributes(city *geoip2.City, langCode string) []attribute.KeyValue {
 	attributes := make([]attribute.KeyValue, 0, 11)
 
-	city, err := g.geoReader.City(ipAddress)
-	if err != nil {
-		return nil, err
-	}
-
 	// The exact set of top-level keys varies based on the particular GeoIP2 web service you are using. If a key maps to an undefined or empty value, it is not included in the JSON object. The following anonymous function appends the given key-value only if the value is not empty.
 	appendIfNotEmpty := func(keyName, value string) {
 		if value != "" {
@@ -84,12 +98,12 @@ func (g *maxMindProvider) cityAttributes(ipAddress net.IP) (*[]attribute.KeyValu
 	}
 
 	// city
-	appendIfNotEmpty(conventions.AttributeGeoCityName, city.City.Names[g.langCode])
+	appendIfNotEmpty(conventions.AttributeGeoCityName, city.City.Names[langCode])
 	// country
-	appendIfNotEmpty(conventions.AttributeGeoCountryName, city.Country.Names[g.langCode])
+	appendIfNotEmpty(conventions.AttributeGeoCountryName, city.Country.Names[langCode])
 	appendIfNotEmpty(conventions.AttributeGeoCountryIsoCode, city.Country.IsoCode)
 	// continent
-	appendIfNotEmpty(conventions.AttributeGeoContinentName, city.Continent.Names[g.langCode])
+	appendIfNotEmpty(conventions.AttributeGeoContinentName, city.Continent.Names[langCode])
 	appendIfNotEmpty(conventions.AttributeGeoContinentCode, city.Continent.Code)
 	// postal code
 	appendIfNotEmpty(conventions.AttributeGeoPostalCode, city.Postal.Code)
@@ -97,7 +111,7 @@ func (g *maxMindProvider) cityAttributes(ipAddress net.IP) (*[]attribute.KeyValu
 	if len(city.Subdivisions) > 0 {
 		// The most specific subdivision is located at the last array position, see https://github.com/maxmind/GeoIP2-java/blob/2fe4c65424fed2c3c2449e5530381b6452b0560f/src/main/java/com/maxmind/geoip2/model/AbstractCityResponse.java#L112
 		mostSpecificSubdivision := city.Subdivisions[len(city.Subdivisions)-1]
-		appendIfNotEmpty(conventions.AttributeGeoRegionName, mostSpecificSubdivision.Names[g.langCode])
+		appendIfNotEmpty(conventions.AttributeGeoRegionName, mostSpecificSubdivision.Names[langCode])
 		appendIfNotEmpty(conventions.AttributeGeoRegionIsoCode, mostSpecificSubdivision.IsoCode)
 	}
 
@@ -107,5 +121,5 @@ func (g *maxMindProvider) cityAttributes(ipAddress net.IP) (*[]attribute.KeyValu
 		attributes = append(attributes, attribute.Float64(conventions.AttributeGeoLocationLat, city.Location.Latitude), attribute.Float64(conventions.AttributeGeoLocationLon, city.Location.Longitude))
 	}
 
-	return &attributes, err
+	return attributes
 }
@@ -13,20 +13,21 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 
 	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
 )
 
 func TestInvalidNewProvider(t *testing.T) {
-	_, err := newMaxMindProvider(&Config{})
+	_, err := newMaxMindProvider(&Config{}, zap.NewNop())
 	expectedErrMsgSuffix := "no such file or directory"
 	if runtime.GOOS == "windows" {
 		expectedErrMsgSuffix = "The system cannot find the file specified."
 	}
 	require.ErrorContains(t, err, "could not open geoip database: open : "+expectedErrMsgSuffix)
 
-	_, err = newMaxMindProvider(&Config{DatabasePath: "no valid path"})
+	_, err = newMaxMindProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
 	require.ErrorContains(t, err, "could not open geoip database: open no valid path: "+expectedErrMsgSuffix)
 }
 
@@ -98,7 +99,7 @@ func TestProviderLocation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// prepare provider
-			provider, err := newMaxMindProvider(&Config{DatabasePath: tmpDBfiles + "/" + tt.testDatabase})
+			provider, err := newMaxMindProvider(&Config{DatabasePath: tmpDBfiles + "/" + tt.testDatabase}, zap.NewNop())
 			assert.NoError(t, err)
 
 			// assert metrics
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reloader provides hot reloading of file based geo IP databases.
+package reloader // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/reloader"
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LoadFunc opens the database located at path.
+type LoadFunc[T io.Closer] func(path string) (T, error)
+
+// Database holds a database loaded from a file. If an interval is configured, the file is
+// checked for changes at every interval and the database is reloaded when its modification
+// time or size changed. A failed reload keeps the previously loaded database.
+type Database[T io.Closer] struct {
+	path   string
+	load   LoadFunc[T]
+	logger *zap.Logger
+
+	// mu guards db: readers hold a read lock while using the database so that it is
+	// not closed while in use.
+	mu   sync.RWMutex
+	db   T
+	stat os.FileInfo
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New loads the database at path and, if interval is positive, starts watching it for changes.
+func New[T io.Closer](path string, interval time.Duration, load LoadFunc[T], logger *zap.Logger) (*Database[T], error) {
+	db, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+
+	d := &Database[T]{
+		path:   path,
+		load:   load,
+		logger: logger,
+		db:     db,
+		stat:   stat,
+		done:   make(chan struct{}),
+	}
+
+	if interval > 0 {
+		d.wg.Add(1)
+		go d.watch(interval)
+	}
+	return d, nil
+}
+
+// Use calls fn with the current database. The database is not closed while fn runs.
+func (d *Database[T]) Use(fn func(db T) error) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return fn(d.db)
+}
+
+// Close stops watching the file and closes the database.
+func (d *Database[T]) Close() error {
+	close(d.done)
+	d.wg.Wait()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.db.Close()
+}
+
+func (d *Database[T]) watch(interval time.Duration) {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+			if err := d.reloadIfChanged(); err != nil {
+				d.logger.Warn("failed to reload geoip database, keeping the previous one", zap.String("path", d.path), zap.Error(err))
+			}
+		}
+	}
+}
+
+// reloadIfChanged reloads the database if the file changed since it was last loaded.
+func (d *Database[T]) reloadIfChanged() error {
+	stat, err := os.Stat(d.path)
+	if err != nil {
+		return err
+	}
+	if stat.ModTime().Equal(d.stat.ModTime()) && stat.Size() == d.stat.Size() {
+		return nil
+	}
+
+	db, err := d.load(d.path)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	old := d.db
+	d.db = db
+	d.stat = stat
+	d.mu.Unlock()
+
+	d.logger.Info("reloaded geoip database", zap.String("path", d.path))
+	return old.Close()
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reloader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fakeDB struct {
+	content string
+	closed  atomic.Bool
+}
+
+func (db *fakeDB) Close() error {
+	db.closed.Store(true)
+	return nil
+}
+
+// loadFake loads the content of the file, files containing "invalid" fail to load.
+func loadFake(path string) (*fakeDB, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if string(content) == "invalid" {
+		return nil, errors.New("invalid database")
+	}
+	return &fakeDB{content: string(content)}, nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func current(t *testing.T, d *Database[*fakeDB]) *fakeDB {
+	var db *fakeDB
+	require.NoError(t, d.Use(func(cur *fakeDB) error {
+		db = cur
+		return nil
+	}))
+	return db
+}
+
+func TestNewFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	_, err := New(path, 0, loadFake, zaptest.NewLogger(t))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	writeFile(t, path, "invalid")
+	_, err = New(path, 0, loadFake, zaptest.NewLogger(t))
+	require.EqualError(t, err, "invalid database")
+}
+
+func TestReloadOnFileReplacement(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "db")
+	writeFile(t, path, "v1")
+
+	d, err := New(path, time.Millisecond, loadFake, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	first := current(t, d)
+	assert.Equal(t, "v1", first.content)
+
+	// replace the file the way database updaters do, by renaming a new file over it
+	tmp := filepath.Join(dir, "db.tmp")
+	writeFile(t, tmp, "v2 with a different size")
+	require.NoError(t, os.Rename(tmp, path))
+
+	assert.Eventually(t, func() bool {
+		return current(t, d).content == "v2 with a different size"
+	}, 5*time.Second, time.Millisecond)
+	assert.True(t, first.closed.Load(), "the replaced database must be closed")
+
+	second := current(t, d)
+	require.NoError(t, d.Close())
+	assert.True(t, second.closed.Load())
+}
+
+func TestFailedReloadKeepsDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	writeFile(t, path, "v1")
+
+	d, err := New(path, 0, loadFake, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+	first := current(t, d)
+
+	writeFile(t, path, "invalid")
+	require.EqualError(t, d.reloadIfChanged(), "invalid database")
+	assert.Same(t, first, current(t, d))
+	assert.False(t, first.closed.Load())
+
+	require.NoError(t, os.Remove(path))
+	require.ErrorIs(t, d.reloadIfChanged(), os.ErrNotExist)
+	assert.Same(t, first, current(t, d))
+
+	// the database is reloaded once the file is fixed
+	writeFile(t, path, "v2")
+	require.NoError(t, d.reloadIfChanged())
+	assert.Equal(t, "v2", current(t, d).content)
+	assert.True(t, first.closed.Load())
+}
+
+func TestCloseDuringReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	writeFile(t, path, "v1")
+
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	var reloaded *fakeDB
+	load := func(path string) (*fakeDB, error) {
+		db, err := loadFake(path)
+		if err != nil || db.content == "v1" {
+			return db, err
+		}
+		reloaded = db
+		close(loading)
+		<-release
+		return db, nil
+	}
+
+	d, err := New(path, time.Millisecond, load, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	first := current(t, d)
+
+	writeFile(t, path, "v2")
+	select {
+	case <-loading:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the database was not reloaded")
+	}
+
+	closed := make(chan error)
+	go func() { closed <- d.Close() }()
+	select {
+	case <-closed:
+		require.FailNow(t, "Close returned while a reload was in progress")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-closed)
+	assert.True(t, first.closed.Load())
+	assert.True(t, reloaded.closed.Load(), "the database loaded during Close must be closed")
+}
@@ -5,6 +5,7 @@ package intervalprocessor // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -21,6 +22,12 @@ type Config struct {
 	// PassThrough is a configuration that determines whether gauge and summary metrics should be passed through
 	// as they are or aggregated.
 	PassThrough PassThrough `mapstructure:"pass_through"`
+	// GaugeAggregation is the function used to downsample the gauges which are not
+	// passed through over the interval: <last|min|max|avg>.
+	GaugeAggregation GaugeAggregation `mapstructure:"gauge_aggregation"`
+	// AccumulateDeltas is a flag that determines whether delta sums, histograms and exponential histograms should be
+	// accumulated into a single data point per interval or passed through as they are.
+	AccumulateDeltas bool `mapstructure:"accumulate_deltas"`
 }
 
 type PassThrough struct {
@@ -32,6 +39,22 @@ type PassThrough struct {
 	Summary bool `mapstructure:"summary"`
 }
 
+// GaugeAggregation is the enum of the functions used to downsample gauges.
+type GaugeAggregation string
+
+const (
+	// GaugeLast keeps the latest value of the interval.
+	GaugeLast GaugeAggregation = "last"
+	// GaugeMin keeps the minimum value of the interval.
+	GaugeMin GaugeAggregation = "min"
+	// GaugeMax keeps the maximum value of the interval.
+	GaugeMax GaugeAggregation = "max"
+	// GaugeAvg computes the average value of the interval.
+	GaugeAvg GaugeAggregation = "avg"
+)
+
+var gaugeAggregations = []GaugeAggregation{GaugeLast, GaugeMin, GaugeMax, GaugeAvg}
+
 // Validate checks whether the input configuration has all of the required fields for the processor.
 // An error is returned if there are any invalid inputs.
 func (config *Config) Validate() error {
@@ -39,5 +62,11 @@ func (config *Config) Validate() error {
 		return ErrInvalidIntervalValue
 	}
 
+	switch config.GaugeAggregation {
+	case "", GaugeLast, GaugeMin, GaugeMax, GaugeAvg:
+	default:
+		return fmt.Errorf("%q must be in %q", "gauge_aggregation", gaugeAggregations)
+	}
+
 	return nil
 }
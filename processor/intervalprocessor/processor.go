@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -37,6 +38,8 @@ type intervalProcessor struct {
 	histogramLookup    map[identity.Stream]pmetric.HistogramDataPoint
 	expHistogramLookup map[identity.Stream]pmetric.ExponentialHistogramDataPoint
 	summaryLookup      map[identity.Stream]pmetric.SummaryDataPoint
+	// gaugeCountLookup is the number of data points averaged into each gauge stream
+	gaugeCountLookup map[identity.Stream]int
 
 	config *Config
 
@@ -61,6 +64,7 @@ func newProcessor(config *Config, log *zap.Logger, nextConsumer consumer.Metrics
 		histogramLookup:    map[identity.Stream]pmetric.HistogramDataPoint{},
 		expHistogramLookup: map[identity.Stream]pmetric.ExponentialHistogramDataPoint{},
 		summaryLookup:      map[identity.Stream]pmetric.SummaryDataPoint{},
+		gaugeCountLookup:   map[identity.Stream]int{},
 
 		config: config,
 
@@ -118,12 +122,22 @@ func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 					}
 
 					mClone, metricID := p.getOrCloneMetric(rm, sm, m)
-					aggregateDataPoints(m.Gauge().DataPoints(), mClone.Gauge().DataPoints(), metricID, p.numberLookup)
+					p.aggregateGaugeDataPoints(m.Gauge().DataPoints(), mClone.Gauge().DataPoints(), metricID)
 					return true
 				case pmetric.MetricTypeSum:
 					// Check if we care about this value
 					sum := m.Sum()
 
+					if sum.AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+						if !p.config.AccumulateDeltas {
+							return false
+						}
+
+						mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+						p.accumulateNumberDataPoints(sum.DataPoints(), mClone.Sum().DataPoints(), metricID)
+						return true
+					}
+
 					if !sum.IsMonotonic() {
 						return false
 					}
@@ -140,6 +154,16 @@ func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 				case pmetric.MetricTypeHistogram:
 					histogram := m.Histogram()
 
+					if histogram.AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+						if !p.config.AccumulateDeltas {
+							return false
+						}
+
+						mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+						p.accumulateHistogramDataPoints(histogram.DataPoints(), mClone.Histogram().DataPoints(), metricID)
+						return true
+					}
+
 					if histogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
 						return false
 					}
@@ -152,6 +176,16 @@ func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 				case pmetric.MetricTypeExponentialHistogram:
 					expHistogram := m.ExponentialHistogram()
 
+					if expHistogram.AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+						if !p.config.AccumulateDeltas {
+							return false
+						}
+
+						mClone, metricID := p.getOrCloneMetric(rm, sm, m)
+						p.accumulateExpHistogramDataPoints(expHistogram.DataPoints(), mClone.ExponentialHistogram().DataPoints(), metricID)
+						return true
+					}
+
 					if expHistogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
 						return false
 					}
@@ -201,6 +235,249 @@ func aggregateDataPoints[DPS metrics.DataPointSlice[DP], DP metrics.DataPoint[DP
 	}
 }
 
+func (p *intervalProcessor) aggregateGaugeDataPoints(dataPoints, mCloneDataPoints pmetric.NumberDataPointSlice, metricID identity.Metric) {
+	if p.config.GaugeAggregation == "" || p.config.GaugeAggregation == GaugeLast {
+		aggregateDataPoints(dataPoints, mCloneDataPoints, metricID, p.numberLookup)
+		return
+	}
+
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		existingDP, ok := p.numberLookup[streamID]
+		if !ok {
+			dpClone := mCloneDataPoints.AppendEmpty()
+			dp.CopyTo(dpClone)
+			if p.config.GaugeAggregation == GaugeAvg {
+				// The average of integers is not an integer
+				dpClone.SetDoubleValue(numberValue(dp))
+			}
+			p.numberLookup[streamID] = dpClone
+			p.gaugeCountLookup[streamID] = 1
+			continue
+		}
+
+		// The downsampled datapoint is as recent as the newest one of the interval
+		timestamp := max(existingDP.Timestamp(), dp.Timestamp())
+
+		switch p.config.GaugeAggregation {
+		case GaugeMin:
+			if numberValue(dp) < numberValue(existingDP) {
+				dp.CopyTo(existingDP)
+			}
+		case GaugeMax:
+			if numberValue(dp) > numberValue(existingDP) {
+				dp.CopyTo(existingDP)
+			}
+		case GaugeAvg:
+			count := p.gaugeCountLookup[streamID] + 1
+			p.gaugeCountLookup[streamID] = count
+
+			avg := existingDP.DoubleValue()
+			existingDP.SetDoubleValue(avg + (numberValue(dp)-avg)/float64(count))
+		}
+
+		existingDP.SetTimestamp(timestamp)
+	}
+}
+
+func (p *intervalProcessor) accumulateNumberDataPoints(dataPoints, mCloneDataPoints pmetric.NumberDataPointSlice, metricID identity.Metric) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		existingDP, ok := p.numberLookup[streamID]
+		if !ok {
+			dpClone := mCloneDataPoints.AppendEmpty()
+			dp.CopyTo(dpClone)
+			p.numberLookup[streamID] = dpClone
+			continue
+		}
+
+		if existingDP.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			existingDP.SetIntValue(existingDP.IntValue() + dp.IntValue())
+		} else {
+			existingDP.SetDoubleValue(numberValue(existingDP) + numberValue(dp))
+		}
+		dp.Exemplars().MoveAndAppendTo(existingDP.Exemplars())
+		existingDP.SetStartTimestamp(min(existingDP.StartTimestamp(), dp.StartTimestamp()))
+		existingDP.SetTimestamp(max(existingDP.Timestamp(), dp.Timestamp()))
+	}
+}
+
+func (p *intervalProcessor) accumulateHistogramDataPoints(dataPoints, mCloneDataPoints pmetric.HistogramDataPointSlice, metricID identity.Metric) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		existingDP, ok := p.histogramLookup[streamID]
+		if !ok {
+			dpClone := mCloneDataPoints.AppendEmpty()
+			dp.CopyTo(dpClone)
+			p.histogramLookup[streamID] = dpClone
+			continue
+		}
+
+		// Histograms with different buckets can't be merged, keep the newest one
+		if !slices.Equal(existingDP.ExplicitBounds().AsRaw(), dp.ExplicitBounds().AsRaw()) ||
+			existingDP.BucketCounts().Len() != dp.BucketCounts().Len() {
+			if dp.Timestamp() > existingDP.Timestamp() {
+				dp.CopyTo(existingDP)
+			}
+			continue
+		}
+
+		for j := 0; j < dp.BucketCounts().Len(); j++ {
+			existingDP.BucketCounts().SetAt(j, existingDP.BucketCounts().At(j)+dp.BucketCounts().At(j))
+		}
+		existingDP.SetCount(existingDP.Count() + dp.Count())
+		if dp.HasSum() {
+			existingDP.SetSum(existingDP.Sum() + dp.Sum())
+		}
+		if dp.HasMin() && (!existingDP.HasMin() || dp.Min() < existingDP.Min()) {
+			existingDP.SetMin(dp.Min())
+		}
+		if dp.HasMax() && (!existingDP.HasMax() || dp.Max() > existingDP.Max()) {
+			existingDP.SetMax(dp.Max())
+		}
+		dp.Exemplars().MoveAndAppendTo(existingDP.Exemplars())
+		existingDP.SetStartTimestamp(min(existingDP.StartTimestamp(), dp.StartTimestamp()))
+		existingDP.SetTimestamp(max(existingDP.Timestamp(), dp.Timestamp()))
+	}
+}
+
+func (p *intervalProcessor) accumulateExpHistogramDataPoints(dataPoints, mCloneDataPoints pmetric.ExponentialHistogramDataPointSlice, metricID identity.Metric) {
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+
+		streamID := identity.OfStream(metricID, dp)
+		existingDP, ok := p.expHistogramLookup[streamID]
+		if !ok {
+			dpClone := mCloneDataPoints.AppendEmpty()
+			dp.CopyTo(dpClone)
+			p.expHistogramLookup[streamID] = dpClone
+			continue
+		}
+
+		// Histograms with different zero buckets can't be merged, keep the newest one
+		if existingDP.ZeroThreshold() != dp.ZeroThreshold() {
+			if dp.Timestamp() > existingDP.Timestamp() {
+				dp.CopyTo(existingDP)
+			}
+			continue
+		}
+
+		// The buckets are merged at the finest scale at which both histograms fit in
+		// maxExpHistogramBuckets buckets
+		scale := min(existingDP.Scale(), dp.Scale())
+		for scale > minExpHistogramScale &&
+			(mergedBucketsLen(existingDP.Positive(), existingDP.Scale()-scale, dp.Positive(), dp.Scale()-scale) > maxExpHistogramBuckets ||
+				mergedBucketsLen(existingDP.Negative(), existingDP.Scale()-scale, dp.Negative(), dp.Scale()-scale) > maxExpHistogramBuckets) {
+			scale--
+		}
+		downscaleBuckets(existingDP.Positive(), existingDP.Scale()-scale)
+		downscaleBuckets(existingDP.Negative(), existingDP.Scale()-scale)
+		downscaleBuckets(dp.Positive(), dp.Scale()-scale)
+		downscaleBuckets(dp.Negative(), dp.Scale()-scale)
+		existingDP.SetScale(scale)
+		addBuckets(existingDP.Positive(), dp.Positive())
+		addBuckets(existingDP.Negative(), dp.Negative())
+
+		existingDP.SetZeroCount(existingDP.ZeroCount() + dp.ZeroCount())
+		existingDP.SetCount(existingDP.Count() + dp.Count())
+		if dp.HasSum() {
+			existingDP.SetSum(existingDP.Sum() + dp.Sum())
+		}
+		if dp.HasMin() && (!existingDP.HasMin() || dp.Min() < existingDP.Min()) {
+			existingDP.SetMin(dp.Min())
+		}
+		if dp.HasMax() && (!existingDP.HasMax() || dp.Max() > existingDP.Max()) {
+			existingDP.SetMax(dp.Max())
+		}
+		dp.Exemplars().MoveAndAppendTo(existingDP.Exemplars())
+		existingDP.SetStartTimestamp(min(existingDP.StartTimestamp(), dp.StartTimestamp()))
+		existingDP.SetTimestamp(max(existingDP.Timestamp(), dp.Timestamp()))
+	}
+}
+
+// Limits of the accumulated exponential histograms: the scale is lowered until the buckets
+// fit in the default maximum size of the SDKs, down to the minimum scale of the data model.
+const (
+	maxExpHistogramBuckets = 160
+	minExpHistogramScale   = -10
+)
+
+// mergedBucketsLen returns the number of buckets of a and b merged, once they are downscaled by
+// shiftA and shiftB. The index of a bucket downscaled by shift is its index >> shift.
+func mergedBucketsLen(a pmetric.ExponentialHistogramDataPointBuckets, shiftA int32, b pmetric.ExponentialHistogramDataPointBuckets, shiftB int32) int {
+	switch {
+	case a.BucketCounts().Len() == 0:
+		return bucketsLen(b, shiftB)
+	case b.BucketCounts().Len() == 0:
+		return bucketsLen(a, shiftA)
+	}
+	low := min(a.Offset()>>shiftA, b.Offset()>>shiftB)
+	high := max((a.Offset()+int32(a.BucketCounts().Len())-1)>>shiftA, (b.Offset()+int32(b.BucketCounts().Len())-1)>>shiftB)
+	return int(high-low) + 1
+}
+
+func bucketsLen(buckets pmetric.ExponentialHistogramDataPointBuckets, shift int32) int {
+	if buckets.BucketCounts().Len() == 0 {
+		return 0
+	}
+	return int((buckets.Offset()+int32(buckets.BucketCounts().Len())-1)>>shift-buckets.Offset()>>shift) + 1
+}
+
+// downscaleBuckets lowers the scale of the buckets by shift, adding up the buckets which merge
+func downscaleBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, shift int32) {
+	counts := buckets.BucketCounts()
+	if shift == 0 || counts.Len() == 0 {
+		return
+	}
+
+	offset := buckets.Offset() >> shift
+	merged := make([]uint64, 0, bucketsLen(buckets, shift))
+	for i := 0; i < counts.Len(); i++ {
+		j := int((buckets.Offset()+int32(i))>>shift - offset)
+		if j == len(merged) {
+			merged = append(merged, 0)
+		}
+		merged[j] += counts.At(i)
+	}
+	buckets.SetOffset(offset)
+	counts.FromRaw(merged)
+}
+
+// addBuckets adds the counts of the buckets of from to the buckets of the same scale of to
+func addBuckets(to, from pmetric.ExponentialHistogramDataPointBuckets) {
+	switch {
+	case from.BucketCounts().Len() == 0:
+		return
+	case to.BucketCounts().Len() == 0:
+		from.CopyTo(to)
+		return
+	}
+
+	low := min(to.Offset(), from.Offset())
+	high := max(to.Offset()+int32(to.BucketCounts().Len()), from.Offset()+int32(from.BucketCounts().Len()))
+	merged := make([]uint64, high-low)
+	for _, buckets := range []pmetric.ExponentialHistogramDataPointBuckets{to, from} {
+		for i := 0; i < buckets.BucketCounts().Len(); i++ {
+			merged[int(buckets.Offset()-low)+i] += buckets.BucketCounts().At(i)
+		}
+	}
+	to.SetOffset(low)
+	to.BucketCounts().FromRaw(merged)
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
 func (p *intervalProcessor) exportMetrics() {
 	md := func() pmetric.Metrics {
 		p.stateLock.Lock()
@@ -219,6 +496,7 @@ func (p *intervalProcessor) exportMetrics() {
 		clear(p.histogramLookup)
 		clear(p.expHistogramLookup)
 		clear(p.summaryLookup)
+		clear(p.gaugeCountLookup)
 
 		return out
 	}()
@@ -23,8 +23,10 @@ func TestAggregation(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
-		name        string
-		passThrough bool
+		name             string
+		passThrough      bool
+		gaugeAggregation GaugeAggregation
+		accumulateDeltas bool
 	}{
 		{name: "basic_aggregation"},
 		{name: "histograms_are_aggregated"},
@@ -35,6 +37,10 @@ func TestAggregation(t *testing.T) {
 		{name: "non_monotonic_sums_are_passed_through"}, // Non-monotonic sums are passed through even when aggregation is enabled
 		{name: "gauges_are_passed_through", passThrough: true},
 		{name: "summaries_are_passed_through", passThrough: true},
+		{name: "gauges_are_downsampled_min", gaugeAggregation: GaugeMin},
+		{name: "gauges_are_downsampled_max", gaugeAggregation: GaugeMax},
+		{name: "gauges_are_downsampled_avg", gaugeAggregation: GaugeAvg},
+		{name: "deltas_are_accumulated", accumulateDeltas: true},
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -42,7 +48,12 @@ func TestAggregation(t *testing.T) {
 
 	var config *Config
 	for _, tc := range testCases {
-		config = &Config{Interval: time.Second, PassThrough: PassThrough{Gauge: tc.passThrough, Summary: tc.passThrough}}
+		config = &Config{
+			Interval:         time.Second,
+			PassThrough:      PassThrough{Gauge: tc.passThrough, Summary: tc.passThrough},
+			GaugeAggregation: tc.gaugeAggregation,
+			AccumulateDeltas: tc.accumulateDeltas,
+		}
 
 		t.Run(tc.name, func(t *testing.T) {
 			// next stores the results of the filter metric processor
@@ -80,6 +91,7 @@ func TestAggregation(t *testing.T) {
 			require.Empty(t, processor.histogramLookup)
 			require.Empty(t, processor.expHistogramLookup)
 			require.Empty(t, processor.summaryLookup)
+			require.Empty(t, processor.gaugeCountLookup)
 
 			// Exporting again should return nothing
 			processor.exportMetrics()
@@ -107,3 +119,39 @@ func TestAggregation(t *testing.T) {
 		})
 	}
 }
+
+func TestAccumulateExpHistogramsBucketLimit(t *testing.T) {
+	next := &consumertest.MetricsSink{}
+	mgp, err := NewFactory().CreateMetrics(
+		context.Background(),
+		processortest.NewNopSettings(metadata.Type),
+		&Config{Interval: time.Second, AccumulateDeltas: true},
+		next,
+	)
+	require.NoError(t, err)
+	processor := mgp.(*intervalProcessor)
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("delta.exphistogram.test")
+	m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for _, offset := range []int32{0, 1000} {
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw([]uint64{1})
+		dp.SetCount(1)
+	}
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), md))
+	processor.exportMetrics()
+
+	// The buckets 0 and 1000 are 126 buckets apart once downscaled to fit in 160 buckets
+	dps := next.AllMetrics()[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	require.Equal(t, int32(-3), dps.At(0).Scale())
+	require.Equal(t, uint64(2), dps.At(0).Count())
+	require.Equal(t, int32(0), dps.At(0).Positive().Offset())
+	counts := dps.At(0).Positive().BucketCounts().AsRaw()
+	require.Len(t, counts, 126)
+	require.Equal(t, uint64(1), counts[0])
+	require.Equal(t, uint64(1), counts[125])
+}
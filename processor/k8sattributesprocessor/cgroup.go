@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sattributesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor"
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// containerIDCacheSize bounds the number of processes whose container ID is cached.
+	containerIDCacheSize = 4096
+	// containerIDCacheTTL bounds the time a container ID is cached, as the
+	// pid can be reused by another process once the process exits.
+	containerIDCacheTTL = time.Minute
+)
+
+// cgroupContainerIDRegex matches the container ID at the end of a cgroup path,
+// e.g. /kubepods/burstable/pod<uid>/<id> with cgroupfs or
+// /kubepods.slice/.../cri-containerd-<id>.scope with the systemd driver.
+var cgroupContainerIDRegex = regexp.MustCompile(`[/\-]([0-9a-f]{64})(?:\.scope)?$`)
+
+// procDir returns the directory of the proc filesystem of the host, which can
+// be overridden with the HOST_PROC environment variable when the collector
+// runs in a container.
+func procDir() string {
+	if dir := os.Getenv("HOST_PROC"); dir != "" {
+		return dir
+	}
+	return "/proc"
+}
+
+// containerIDCache caches the container ID of the processes, so that the
+// cgroup of a process isn't read for every resource it sends.
+type containerIDCache struct {
+	entries *expirable.LRU[int, string]
+}
+
+func newContainerIDCache() *containerIDCache {
+	return &containerIDCache{
+		entries: expirable.NewLRU[int, string](containerIDCacheSize, nil, containerIDCacheTTL),
+	}
+}
+
+// containerIDFromPID returns the ID of the container running the process
+// identified by the pid attribute, read from the cgroup of the process.
+func (c *containerIDCache) containerIDFromPID(pid pcommon.Value) string {
+	n, err := intFromAttribute(pid)
+	if err != nil || n <= 0 {
+		return ""
+	}
+	if containerID, ok := c.entries.Get(n); ok {
+		return containerID
+	}
+	containerID, err := readContainerID(n)
+	if err != nil {
+		// the process may not be visible yet, don't cache the failure
+		return ""
+	}
+	c.entries.Add(n, containerID)
+	return containerID
+}
+
+// readContainerID reads the ID of the container running the process from the
+// cgroup file of the process, or an empty ID when it doesn't run in a container.
+func readContainerID(pid int) (string, error) {
+	f, err := os.Open(filepath.Join(procDir(), strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return containerIDFromCgroup(bufio.NewScanner(f)), nil
+}
+
+// containerIDFromCgroup returns the container ID found in the cgroup paths of
+// the /proc/<pid>/cgroup file lines.
+func containerIDFromCgroup(lines *bufio.Scanner) string {
+	for lines.Scan() {
+		if match := cgroupContainerIDRegex.FindStringSubmatch(lines.Text()); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
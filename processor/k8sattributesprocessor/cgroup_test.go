@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sattributesprocessor
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestContainerIDFromCgroup(t *testing.T) {
+	const id = "0f3a2b0c4e5d6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a"
+	tests := []struct {
+		name   string
+		cgroup string
+		want   string
+	}{
+		{
+			name:   "cgroup v1 cgroupfs",
+			cgroup: "12:memory:/kubepods/burstable/pod1d7e1a39-0f4b-4b3e-9c33-9c0d5b8d0e6e/" + id + "\n",
+			want:   id,
+		},
+		{
+			name:   "cgroup v2 systemd containerd",
+			cgroup: "0::/kubepods.slice/kubepods-pod1d7e1a39.slice/cri-containerd-" + id + ".scope\n",
+			want:   id,
+		},
+		{
+			name:   "cri-o",
+			cgroup: "0::/kubepods.slice/kubepods-besteffort.slice/crio-" + id + ".scope\n",
+			want:   id,
+		},
+		{
+			name:   "docker",
+			cgroup: "1:name=systemd:/system.slice/docker-" + id + ".scope\n",
+			want:   id,
+		},
+		{
+			name:   "not in a container",
+			cgroup: "0::/user.slice/user-1000.slice/session-2.scope\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, containerIDFromCgroup(bufio.NewScanner(strings.NewReader(tt.cgroup))))
+		})
+	}
+}
+
+func TestContainerIDFromPID(t *testing.T) {
+	t.Setenv("HOST_PROC", "testdata/proc")
+	c := newContainerIDCache()
+
+	assert.Equal(t, "0f3a2b0c4e5d6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a", c.containerIDFromPID(pcommon.NewValueInt(1234)))
+	assert.Equal(t, "0f3a2b0c4e5d6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a", c.containerIDFromPID(pcommon.NewValueStr("1234")))
+	assert.Empty(t, c.containerIDFromPID(pcommon.NewValueInt(5678)))
+	assert.Empty(t, c.containerIDFromPID(pcommon.NewValueInt(9999)))
+	assert.Empty(t, c.containerIDFromPID(pcommon.NewValueStr("self")))
+}
+
+func TestContainerIDFromPIDCached(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "1234"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1234", "cgroup"), []byte("0::/kubepods.slice/cri-containerd-"+strings.Repeat("a", 64)+".scope\n"), 0o600))
+	t.Setenv("HOST_PROC", dir)
+	c := newContainerIDCache()
+
+	assert.Equal(t, strings.Repeat("a", 64), c.containerIDFromPID(pcommon.NewValueInt(1234)))
+	// the cgroup isn't read again while the container ID is cached
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "1234")))
+	assert.Equal(t, strings.Repeat("a", 64), c.containerIDFromPID(pcommon.NewValueInt(1234)))
+	// missing processes aren't cached
+	assert.Empty(t, c.containerIDFromPID(pcommon.NewValueInt(5678)))
+	assert.Equal(t, 1, c.entries.Len())
+}
@@ -29,6 +29,7 @@ type fakeClient struct {
 	Namespaces         map[string]*kube.Namespace
 	Nodes              map[string]*kube.Node
 	Deployments        map[string]*kube.Deployment
+	Workloads          map[string]*kube.Workload
 	StopCh             chan struct{}
 }
 
@@ -77,6 +78,11 @@ func (f *fakeClient) GetDeployment(deploymentUID string) (*kube.Deployment, bool
 	return d, ok
 }
 
+func (f *fakeClient) GetWorkload(workloadUID string) (*kube.Workload, bool) {
+	w, ok := f.Workloads[workloadUID]
+	return w, ok
+}
+
 // Start is a noop for FakeClient.
 func (f *fakeClient) Start() error {
 	if f.Informer != nil {
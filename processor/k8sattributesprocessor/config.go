@@ -75,9 +75,10 @@ func (cfg *Config) Validate() error {
 		}
 
 		switch f.From {
-		case "", kube.MetadataFromPod, kube.MetadataFromNamespace, kube.MetadataFromNode, kube.MetadataFromDeployment:
+		case "", kube.MetadataFromPod, kube.MetadataFromNamespace, kube.MetadataFromNode, kube.MetadataFromDeployment,
+			kube.MetadataFromStatefulSet, kube.MetadataFromDaemonSet, kube.MetadataFromJob, kube.MetadataFromCronJob:
 		default:
-			return fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, deployment, node", f.From)
+			return fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, deployment, statefulset, daemonset, job, cronjob, node", f.From)
 		}
 
 		if f.KeyRegex != "" {
@@ -103,7 +104,7 @@ func (cfg *Config) Validate() error {
 			string(conventions.ContainerImageNameKey), string(conventions.ContainerImageTagKey),
 			string(conventions.ServiceNamespaceKey), string(conventions.ServiceNameKey),
 			string(conventions.ServiceVersionKey), string(conventions.ServiceInstanceIDKey),
-			containerImageRepoDigests, clusterUID:
+			containerImageRepoDigests, clusterUID, workloadName, workloadKind:
 		default:
 			return fmt.Errorf("\"%s\" is not a supported metadata field", field)
 		}
@@ -143,7 +144,7 @@ type ExtractConfig struct {
 	//   k8s.statefulset.name, k8s.statefulset.uid,
 	//   k8s.container.name, container.id, container.image.name,
 	//   container.image.tag, container.image.repo_digests
-	//   k8s.cluster.uid
+	//   k8s.cluster.uid, k8s.workload.name, k8s.workload.kind
 	//
 	// Specifying anything other than these values will result in an error.
 	// By default, the following fields are extracted and added to spans, metrics and logs as resource attributes:
@@ -208,7 +209,8 @@ type FieldExtractConfig struct {
 	KeyRegex string `mapstructure:"key_regex"`
 
 	// From represents the source of the labels/annotations.
-	// Allowed values are "pod", "namespace", and "node". The default is pod.
+	// Allowed values are "pod", "namespace", "node", "deployment", "statefulset",
+	// "daemonset", "job" and "cronjob". The default is pod.
 	From string `mapstructure:"from"`
 }
 
@@ -317,11 +319,14 @@ type ExcludePodConfig struct {
 
 type PodAssociationSourceConfig struct {
 	// From represents the source of the association.
-	// Allowed values are "connection" and "resource_attribute".
+	// Allowed values are "connection", "resource_attribute", "container_id"
+	// and "process_cgroup".
 	From string `mapstructure:"from"`
 
 	// Name represents extracted key name.
 	// e.g. ip, pod_uid, k8s.pod.ip
+	// For the "container_id" source it defaults to container.id and for the
+	// "process_cgroup" source, which reads the cgroup of the process, to process.pid.
 	Name string `mapstructure:"name"`
 
 	// prevent unkeyed literal initialization
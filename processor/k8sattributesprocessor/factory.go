@@ -176,6 +176,7 @@ func createKubernetesProcessor(
 		cfg:               cfg,
 		options:           options,
 		telemetrySettings: params.TelemetrySettings,
+		containerIDs:      newContainerIDCache(),
 	}
 
 	return kp
@@ -58,6 +58,7 @@ type WatchClient struct {
 	nodeInformer           cache.SharedInformer
 	deploymentInformer     cache.SharedInformer
 	replicasetInformer     cache.SharedInformer
+	workloadInformers      map[string]cache.SharedInformer
 	replicasetRegex        *regexp.Regexp
 	cronJobRegex           *regexp.Regexp
 	deleteQueue            []deleteRequest
@@ -89,6 +90,11 @@ type WatchClient struct {
 	// Key is replicaset uid
 	ReplicaSets map[string]*ReplicaSet
 
+	// A map containing StatefulSet, DaemonSet, Job and CronJob related data,
+	// used to associate them with resources.
+	// Key is workload uid
+	Workloads map[string]*Workload
+
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
@@ -145,6 +151,7 @@ func New(
 	c.Nodes = map[string]*Node{}
 	c.ReplicaSets = map[string]*ReplicaSet{}
 	c.Deployments = map[string]*Deployment{}
+	c.Workloads = map[string]*Workload{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -191,7 +198,7 @@ func New(
 				return object, nil
 			}
 
-			return removeUnnecessaryPodData(originalPod, c.Rules), nil
+			return removeUnnecessaryPodData(originalPod, c.Rules, c.useContainerIDs()), nil
 		},
 	)
 	if err != nil {
@@ -200,7 +207,7 @@ func New(
 
 	c.namespaceInformer = informersFactory.newNamespaceInformer(c.kc)
 
-	if rules.DeploymentName || rules.DeploymentUID {
+	if rules.DeploymentName || rules.DeploymentUID || c.extractWorkload() {
 		if informersFactory.newReplicaSetInformer == nil {
 			informersFactory.newReplicaSetInformer = newReplicaSetSharedInformer
 		}
@@ -228,6 +235,11 @@ func New(
 		c.deploymentInformer = newDeploymentSharedInformer(c.kc, c.Filters.Namespace)
 	}
 
+	c.workloadInformers, err = c.newWorkloadInformers()
+	if err != nil {
+		return nil, err
+	}
+
 	return c, err
 }
 
@@ -236,7 +248,7 @@ func (c *WatchClient) Start() error {
 	synced := make([]cache.InformerSynced, 0)
 	// start the replicaSet informer first, as the replica sets need to be
 	// present at the time the pods are handled, to correctly establish the connection between pods and deployments
-	if c.Rules.DeploymentName || c.Rules.DeploymentUID {
+	if c.replicasetInformer != nil {
 		reg, err := c.replicasetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handleReplicaSetAdd,
 			UpdateFunc: c.handleReplicaSetUpdate,
@@ -286,6 +298,19 @@ func (c *WatchClient) Start() error {
 		go c.deploymentInformer.Run(c.stopCh)
 	}
 
+	for _, informer := range c.workloadInformers {
+		reg, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleWorkloadAdd,
+			UpdateFunc: c.handleWorkloadUpdate,
+			DeleteFunc: c.handleWorkloadDelete,
+		})
+		if err != nil {
+			return err
+		}
+		synced = append(synced, reg.HasSynced)
+		go informer.Run(c.stopCh)
+	}
+
 	reg, err = c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
@@ -330,11 +355,14 @@ func (c *WatchClient) handlePodAdd(obj any) {
 	c.telemetryBuilder.OtelsvcK8sPodTableSize.Record(context.Background(), int64(podTableSize))
 }
 
-func (c *WatchClient) handlePodUpdate(_, newPod any) {
+func (c *WatchClient) handlePodUpdate(oldPod, newPod any) {
 	c.telemetryBuilder.OtelsvcK8sPodUpdated.Add(context.Background(), 1)
 	if pod, ok := newPod.(*api_v1.Pod); ok {
 		// TODO: update or remove based on whether container is ready/unready?.
 		c.addOrUpdatePod(pod)
+		if old, ok := oldPod.(*api_v1.Pod); ok && c.useContainerIDs() {
+			c.forgetContainers(old, pod)
+		}
 	} else {
 		c.logger.Error("object received was not of type api_v1.Pod", zap.Any("received", newPod))
 	}
@@ -659,6 +687,17 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		}
 	}
 
+	if c.extractWorkload() {
+		if kind, name := c.podWorkload(pod); name != "" {
+			if c.Rules.WorkloadName {
+				tags[tagWorkloadName] = name
+			}
+			if c.Rules.WorkloadKind {
+				tags[tagWorkloadKind] = kind
+			}
+		}
+	}
+
 	if c.Rules.Node {
 		tags[tagNodeName] = pod.Spec.NodeName
 	}
@@ -698,7 +737,7 @@ func copyLabel(pod *api_v1.Pod, tags map[string]string, labelKey string, key att
 }
 
 // This function removes all data from the Pod except what is required by extraction rules and pod association
-func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Pod {
+func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules, keepContainerIDs bool) *api_v1.Pod {
 	// name, namespace, uid, start time and ip are needed for identifying Pods
 	// there's room to optimize this further, it's kept this way for simplicity
 	transformedPod := api_v1.Pod{
@@ -732,7 +771,7 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 		transformedPod.Spec.Hostname = pod.Spec.Hostname
 	}
 
-	if needContainerAttributes(rules) {
+	if needContainerAttributes(rules) || keepContainerIDs {
 		removeUnnecessaryContainerStatus := func(c api_v1.ContainerStatus) api_v1.ContainerStatus {
 			transformedContainerStatus := api_v1.ContainerStatus{
 				Name:         c.Name,
@@ -742,6 +781,11 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 			if rules.ContainerImageRepoDigests {
 				transformedContainerStatus.ImageID = c.ImageID
 			}
+			if keepContainerIDs && c.LastTerminationState.Terminated != nil {
+				transformedContainerStatus.LastTerminationState.Terminated = &api_v1.ContainerStateTerminated{
+					ContainerID: c.LastTerminationState.Terminated.ContainerID,
+				}
+			}
 			return transformedContainerStatus
 		}
 
@@ -755,7 +799,16 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 				transformedPod.Status.InitContainerStatuses, removeUnnecessaryContainerStatus(containerStatus),
 			)
 		}
+		for _, containerStatus := range pod.Status.EphemeralContainerStatuses {
+			if keepContainerIDs {
+				transformedPod.Status.EphemeralContainerStatuses = append(
+					transformedPod.Status.EphemeralContainerStatuses, removeUnnecessaryContainerStatus(containerStatus),
+				)
+			}
+		}
+	}
 
+	if needContainerAttributes(rules) {
 		removeUnnecessaryContainerData := func(c api_v1.Container) api_v1.Container {
 			transformedContainer := api_v1.Container{}
 			transformedContainer.Name = c.Name // we always need the name, it's used for identification
@@ -962,11 +1015,58 @@ func (c *WatchClient) podFromAPI(pod *api_v1.Pod) *Pod {
 		if needContainerAttributes(c.Rules) {
 			newPod.Containers = c.extractPodContainersAttributes(pod)
 		}
+		if len(c.workloadInformers) > 0 {
+			newPod.WorkloadUIDs = c.podWorkloadUIDs(pod)
+		}
+	}
+
+	if c.useContainerIDs() {
+		newPod.ContainerIDs = podContainerIDs(pod)
 	}
 
 	return newPod
 }
 
+// useContainerIDs returns true if any association identifies pods by the IDs
+// of their containers.
+func (c *WatchClient) useContainerIDs() bool {
+	for _, assoc := range c.Associations {
+		if assoc.usesContainerID() {
+			return true
+		}
+	}
+	return false
+}
+
+// podContainerIDs returns the IDs of the running and last terminated
+// containers of the pod, without the container runtime prefix. The last
+// terminated containers are kept to associate the telemetry they emitted
+// before restarting.
+func podContainerIDs(pod *api_v1.Pod) []string {
+	var ids []string
+	add := func(containerID string) {
+		if _, id, ok := strings.Cut(containerID, "://"); ok {
+			containerID = id
+		}
+		if containerID != "" {
+			ids = append(ids, containerID)
+		}
+	}
+	for _, statuses := range [][]api_v1.ContainerStatus{
+		pod.Status.ContainerStatuses,
+		pod.Status.InitContainerStatuses,
+		pod.Status.EphemeralContainerStatuses,
+	} {
+		for _, status := range statuses {
+			add(status.ContainerID)
+			if status.LastTerminationState.Terminated != nil {
+				add(status.LastTerminationState.Terminated.ContainerID)
+			}
+		}
+	}
+	return ids
+}
+
 func getPodReplicaSetUID(pod *api_v1.Pod) string {
 	for _, ref := range pod.OwnerReferences {
 		if ref.Kind == "ReplicaSet" {
@@ -980,55 +1080,17 @@ func getPodReplicaSetUID(pod *api_v1.Pod) string {
 func (c *WatchClient) getIdentifiersFromAssoc(pod *Pod) []PodIdentifier {
 	var ids []PodIdentifier
 	for _, assoc := range c.Associations {
-		ret := PodIdentifier{}
-		skip := false
-		for i, source := range assoc.Sources {
-			// If association configured to take IP address from connection
-			switch source.From {
-			case ConnectionSource:
-				if pod.Address == "" {
-					skip = true
-					break
-				}
-				// Host network mode is not supported right now with IP based
-				// tagging as all pods in host network get same IP addresses.
-				// Such pods are very rare and usually are used to monitor or control
-				// host traffic (e.g, linkerd, flannel) instead of service business needs.
-				if pod.HostNetwork {
-					skip = true
-					break
-				}
-				ret[i] = PodIdentifierAttributeFromSource(source, pod.Address)
-			case ResourceSource:
-				attr := ""
-				switch source.Name {
-				case string(conventions.K8SNamespaceNameKey):
-					attr = pod.Namespace
-				case string(conventions.K8SPodNameKey):
-					attr = pod.Name
-				case string(conventions.K8SPodUIDKey):
-					attr = pod.PodUID
-				case string(conventions.HostNameKey):
-					attr = pod.Address
-				// k8s.pod.ip is set by passthrough mode
-				case K8sIPLabelName:
-					attr = pod.Address
-				default:
-					if v, ok := pod.Attributes[source.Name]; ok {
-						attr = v
-					}
-				}
-
-				if attr == "" {
-					skip = true
-					break
-				}
-				ret[i] = PodIdentifierAttributeFromSource(source, attr)
+		if !assoc.usesContainerID() {
+			if id, ok := getIdentifierFromAssoc(pod, assoc, ""); ok {
+				ids = append(ids, id)
 			}
+			continue
 		}
-
-		if !skip {
-			ids = append(ids, ret)
+		// associations using container IDs identify the pod by each of its containers
+		for _, containerID := range pod.ContainerIDs {
+			if id, ok := getIdentifierFromAssoc(pod, assoc, containerID); ok {
+				ids = append(ids, id)
+			}
 		}
 	}
 
@@ -1052,6 +1114,59 @@ func (c *WatchClient) getIdentifiersFromAssoc(pod *Pod) []PodIdentifier {
 	return ids
 }
 
+// getIdentifierFromAssoc returns the PodIdentifier of the pod for the given
+// association, resolving the container sources to containerID. It returns
+// false if any of the sources has no value for the pod.
+func getIdentifierFromAssoc(pod *Pod, assoc Association, containerID string) (PodIdentifier, bool) {
+	ret := PodIdentifier{}
+	for i, source := range assoc.Sources {
+		switch source.From {
+		case ConnectionSource:
+			if pod.Address == "" {
+				return ret, false
+			}
+			// Host network mode is not supported right now with IP based
+			// tagging as all pods in host network get same IP addresses.
+			// Such pods are very rare and usually are used to monitor or control
+			// host traffic (e.g, linkerd, flannel) instead of service business needs.
+			if pod.HostNetwork {
+				return ret, false
+			}
+			ret[i] = PodIdentifierAttributeFromSource(source, pod.Address)
+		case ContainerIDSource, CgroupSource:
+			if containerID == "" {
+				return ret, false
+			}
+			ret[i] = PodIdentifierAttributeFromSource(source, containerID)
+		case ResourceSource:
+			attr := ""
+			switch source.Name {
+			case string(conventions.K8SNamespaceNameKey):
+				attr = pod.Namespace
+			case string(conventions.K8SPodNameKey):
+				attr = pod.Name
+			case string(conventions.K8SPodUIDKey):
+				attr = pod.PodUID
+			case string(conventions.HostNameKey):
+				attr = pod.Address
+			// k8s.pod.ip is set by passthrough mode
+			case K8sIPLabelName:
+				attr = pod.Address
+			default:
+				if v, ok := pod.Attributes[source.Name]; ok {
+					attr = v
+				}
+			}
+
+			if attr == "" {
+				return ret, false
+			}
+			ret[i] = PodIdentifierAttributeFromSource(source, attr)
+		}
+	}
+	return ret, true
+}
+
 func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
 	newPod := c.podFromAPI(pod)
 
@@ -1083,6 +1198,40 @@ func (c *WatchClient) forgetPod(pod *api_v1.Pod) {
 	}
 }
 
+// forgetContainers schedules the deletion of the identifiers of the containers
+// of oldPod that are no longer part of newPod, e.g. after several restarts.
+func (c *WatchClient) forgetContainers(oldPod, newPod *api_v1.Pod) {
+	current := map[string]struct{}{}
+	for _, id := range podContainerIDs(newPod) {
+		current[id] = struct{}{}
+	}
+	var removed []string
+	for _, id := range podContainerIDs(oldPod) {
+		if _, ok := current[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+
+	pod := c.podFromAPI(oldPod)
+	for _, assoc := range c.Associations {
+		if !assoc.usesContainerID() {
+			continue
+		}
+		for _, containerID := range removed {
+			id, ok := getIdentifierFromAssoc(pod, assoc, containerID)
+			if !ok {
+				continue
+			}
+			if p, ok := c.GetPod(id); ok && p.Name == oldPod.Name {
+				c.appendDeleteQueue(id, oldPod.Name)
+			}
+		}
+	}
+}
+
 func (c *WatchClient) appendDeleteQueue(podID PodIdentifier, podName string) {
 	c.deleteMut.Lock()
 	c.deleteQueue = append(c.deleteQueue, deleteRequest{
@@ -404,6 +404,60 @@ func TestPodAddOutOfSync(t *testing.T) {
 	assert.Equal(t, "podB", got.Name)
 }
 
+func TestPodContainerIDAssociation(t *testing.T) {
+	c, _ := newTestClient(t)
+	c.Associations = []Association{
+		{
+			Sources: []AssociationSource{
+				{
+					From: ContainerIDSource,
+					Name: "container.id",
+				},
+			},
+		},
+	}
+
+	newPod := func(containerIDs ...string) *api_v1.Pod {
+		pod := &api_v1.Pod{}
+		pod.Name = "podA"
+		pod.Spec.HostNetwork = true
+		pod.Status.PodIP = "10.0.0.1"
+		pod.Status.ContainerStatuses = []api_v1.ContainerStatus{
+			{
+				Name:        "app",
+				ContainerID: "containerd://" + containerIDs[0],
+			},
+		}
+		if len(containerIDs) > 1 {
+			pod.Status.ContainerStatuses[0].LastTerminationState.Terminated = &api_v1.ContainerStateTerminated{
+				ContainerID: "containerd://" + containerIDs[1],
+			}
+		}
+		return pod
+	}
+
+	pod := newPod("aaa", "bbb")
+	transformed := removeUnnecessaryPodData(pod, c.Rules, true)
+	assert.Equal(t, "containerd://bbb", transformed.Status.ContainerStatuses[0].LastTerminationState.Terminated.ContainerID)
+
+	c.handlePodAdd(transformed)
+	assert.Len(t, c.Pods, 2)
+	got, ok := c.GetPod(newPodIdentifier(ContainerIDSource, "container.id", "aaa"))
+	require.True(t, ok)
+	assert.Equal(t, "podA", got.Name)
+	assert.Equal(t, []string{"aaa", "bbb"}, got.ContainerIDs)
+	_, ok = c.GetPod(newPodIdentifier(ContainerIDSource, "container.id", "bbb"))
+	assert.True(t, ok)
+
+	// the container restarted again, the oldest container is forgotten
+	c.handlePodUpdate(pod, newPod("ccc", "aaa"))
+	_, ok = c.GetPod(newPodIdentifier(ContainerIDSource, "container.id", "ccc"))
+	assert.True(t, ok)
+	require.Len(t, c.deleteQueue, 1)
+	assert.Equal(t, newPodIdentifier(ContainerIDSource, "container.id", "bbb"), c.deleteQueue[0].id)
+	assert.Equal(t, "podA", c.deleteQueue[0].podName)
+}
+
 func TestPodUpdate(t *testing.T) {
 	c, _ := newTestClient(t)
 	podAddAndUpdateTest(t, c, func(obj any) {
@@ -1043,7 +1097,7 @@ func TestExtractionRules(t *testing.T) {
 			for k, v := range tc.additionalLabels {
 				podCopy.Labels[k] = v
 			}
-			transformedPod := removeUnnecessaryPodData(podCopy, c.Rules)
+			transformedPod := removeUnnecessaryPodData(podCopy, c.Rules, false)
 			transformedReplicaset := removeUnnecessaryReplicaSetData(replicaset)
 			c.handleReplicaSetAdd(transformedReplicaset)
 			c.handlePodAdd(transformedPod)
@@ -1199,7 +1253,7 @@ func TestReplicaSetExtractionRules(t *testing.T) {
 
 			// manually call the data removal functions here
 			// normally the informer does this, but fully emulating the informer in this test is annoying
-			transformedPod := removeUnnecessaryPodData(pod, c.Rules)
+			transformedPod := removeUnnecessaryPodData(pod, c.Rules, false)
 			transformedReplicaset := removeUnnecessaryReplicaSetData(replicaset)
 			c.handleReplicaSetAdd(transformedReplicaset)
 			c.handlePodAdd(transformedPod)
@@ -1989,7 +2043,7 @@ func Test_extractPodContainersAttributes(t *testing.T) {
 			c := WatchClient{Rules: tt.rules}
 			// manually call the data removal function here
 			// normally the informer does this, but fully emulating the informer in this test is annoying
-			transformedPod := removeUnnecessaryPodData(tt.pod, c.Rules)
+			transformedPod := removeUnnecessaryPodData(tt.pod, c.Rules, false)
 			assert.Equal(t, tt.want, c.extractPodContainersAttributes(transformedPod))
 		})
 	}
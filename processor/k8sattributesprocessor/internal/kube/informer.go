@@ -7,6 +7,7 @@ import (
 	"context"
 
 	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -181,3 +182,59 @@ func deploymentWatchFuncWithSelectors(client kubernetes.Interface, namespace str
 		return client.AppsV1().Deployments(namespace).Watch(context.Background(), opts)
 	}
 }
+
+// newWorkloadSharedInformer returns an informer watching the workloads of the
+// given kind: StatefulSet, DaemonSet, Job or CronJob.
+func newWorkloadSharedInformer(
+	client kubernetes.Interface,
+	namespace string,
+	kind string,
+) cache.SharedInformer {
+	var (
+		lw  *cache.ListWatch
+		obj runtime.Object
+	)
+	switch kind {
+	case kindStatefulSet:
+		obj = &apps_v1.StatefulSet{}
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.AppsV1().StatefulSets(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.AppsV1().StatefulSets(namespace).Watch(context.Background(), opts)
+			},
+		}
+	case kindDaemonSet:
+		obj = &apps_v1.DaemonSet{}
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.AppsV1().DaemonSets(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.AppsV1().DaemonSets(namespace).Watch(context.Background(), opts)
+			},
+		}
+	case kindJob:
+		obj = &batch_v1.Job{}
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.BatchV1().Jobs(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.BatchV1().Jobs(namespace).Watch(context.Background(), opts)
+			},
+		}
+	default:
+		obj = &batch_v1.CronJob{}
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.BatchV1().CronJobs(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.BatchV1().CronJobs(namespace).Watch(context.Background(), opts)
+			},
+		}
+	}
+	return cache.NewSharedInformer(lw, obj, watchSyncPeriod)
+}
@@ -6,6 +6,7 @@ package kube // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -23,6 +24,8 @@ const (
 	tagStartTime            = "k8s.pod.start_time"
 	tagHostName             = "k8s.pod.hostname"
 	tagClusterUID           = "k8s.cluster.uid"
+	tagWorkloadName         = "k8s.workload.name"
+	tagWorkloadKind         = "k8s.workload.kind"
 	// MetadataFromPod is used to specify to extract metadata/labels/annotations from pod
 	MetadataFromPod = "pod"
 	// MetadataFromNamespace is used to specify to extract metadata/labels/annotations from namespace
@@ -31,11 +34,25 @@ const (
 	MetadataFromNode = "node"
 	// MetadataFromDeployment is used to specify to extract metadata/labels/annotations from deployment
 	MetadataFromDeployment = "deployment"
+	// MetadataFromStatefulSet is used to specify to extract metadata/labels/annotations from statefulset
+	MetadataFromStatefulSet = "statefulset"
+	// MetadataFromDaemonSet is used to specify to extract metadata/labels/annotations from daemonset
+	MetadataFromDaemonSet = "daemonset"
+	// MetadataFromJob is used to specify to extract metadata/labels/annotations from job
+	MetadataFromJob = "job"
+	// MetadataFromCronJob is used to specify to extract metadata/labels/annotations from cronjob
+	MetadataFromCronJob    = "cronjob"
 	PodIdentifierMaxLength = 4
 
 	ResourceSource   = "resource_attribute"
 	ConnectionSource = "connection"
-	K8sIPLabelName   = "k8s.pod.ip"
+	// ContainerIDSource associates the resource with the pod running the
+	// container whose ID is set in the resource attribute.
+	ContainerIDSource = "container_id"
+	// CgroupSource associates the resource with the pod running the container
+	// found in the cgroup of the process whose ID is set in the resource attribute.
+	CgroupSource   = "process_cgroup"
+	K8sIPLabelName = "k8s.pod.ip"
 )
 
 // PodIdentifierAttribute represents AssociationSource with matching value for pod
@@ -94,6 +111,7 @@ type Client interface {
 	GetNamespace(string) (*Namespace, bool)
 	GetNode(string) (*Node, bool)
 	GetDeployment(string) (*Deployment, bool)
+	GetWorkload(string) (*Workload, bool)
 	Start() error
 	Stop()
 }
@@ -118,9 +136,17 @@ type Pod struct {
 	DeploymentUID string
 	HostNetwork   bool
 
+	// WorkloadUIDs holds the UIDs of the statefulset, daemonset, job and
+	// cronjob owning the pod whose labels and annotations are extracted.
+	WorkloadUIDs []string
+
 	// Containers specifies all containers in this pod.
 	Containers PodContainers
 
+	// ContainerIDs holds the IDs of the running and last terminated containers
+	// of the pod. It is only set if an association uses the container IDs.
+	ContainerIDs []string
+
 	DeletedAt time.Time
 }
 
@@ -248,6 +274,8 @@ type ExtractionRules struct {
 	ServiceName               bool
 	ServiceVersion            bool
 	ServiceInstanceID         bool
+	WorkloadName              bool
+	WorkloadKind              bool
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
@@ -267,12 +295,21 @@ func (rules *ExtractionRules) IncludesOwnerMetadata() bool {
 		rules.ReplicaSetName,
 		rules.StatefulSetUID,
 		rules.StatefulSetName,
+		rules.WorkloadName,
+		rules.WorkloadKind,
 	}
 	for _, ruleEnabled := range rulesNeedingOwnerMetadata {
 		if ruleEnabled {
 			return true
 		}
 	}
+	// The workloads whose labels and annotations are extracted are found from the owners of the pods
+	for _, rule := range slices.Concat(rules.Labels, rules.Annotations) {
+		switch rule.From {
+		case MetadataFromStatefulSet, MetadataFromDaemonSet, MetadataFromJob, MetadataFromCronJob:
+			return true
+		}
+	}
 	return rules.ServiceName
 }
 
@@ -290,11 +327,15 @@ type FieldExtractionRule struct {
 	// Full value is extracted when no regexp is provided.
 	Regex *regexp.Regexp
 	// From determines the kubernetes object the field should be retrieved from.
-	// Currently only three values are supported,
+	// The supported values are,
 	//  - pod
 	//  - namespace
 	//  - node
 	//  - deployment
+	//  - statefulset
+	//  - daemonset
+	//  - job
+	//  - cronjob
 	From string
 }
 
@@ -323,6 +364,12 @@ func (r *FieldExtractionRule) extractFromDeploymentMetadata(metadata map[string]
 	}
 }
 
+func (r *FieldExtractionRule) extractFromWorkloadMetadata(from string, metadata map[string]string, tags map[string]string, formatter string) {
+	if r.From == from {
+		r.extractFromMetadata(metadata, tags, formatter)
+	}
+}
+
 func (r *FieldExtractionRule) extractFromMetadata(metadata map[string]string, tags map[string]string, formatter string) {
 	if r.KeyRegex != nil {
 		for k, v := range metadata {
@@ -382,6 +429,22 @@ type AssociationSource struct {
 	Name string
 }
 
+// usesContainerID returns true if the source is resolved to a container ID.
+func (s AssociationSource) usesContainerID() bool {
+	return s.From == ContainerIDSource || s.From == CgroupSource
+}
+
+// usesContainerID returns true if any source of the association is resolved
+// to a container ID.
+func (a Association) usesContainerID() bool {
+	for _, source := range a.Sources {
+		if source.usesContainerID() {
+			return true
+		}
+	}
+	return false
+}
+
 // Deployment represents a kubernetes deployment.
 type Deployment struct {
 	Name       string
@@ -389,6 +452,24 @@ type Deployment struct {
 	Attributes map[string]string
 }
 
+// Workload represents a kubernetes statefulset, daemonset, job or cronjob.
+type Workload struct {
+	Kind       string
+	Name       string
+	UID        string
+	Attributes map[string]string
+
+	// Owner is the workload controlling this one, e.g. the cronjob of a job.
+	Owner *WorkloadOwner
+}
+
+// WorkloadOwner references the workload controlling another one.
+type WorkloadOwner struct {
+	Kind string
+	Name string
+	UID  string
+}
+
 // ReplicaSet represents a kubernetes replicaset.
 type ReplicaSet struct {
 	Name       string
@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"go.uber.org/zap"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// Semconv attributes https://github.com/open-telemetry/semantic-conventions/blob/main/docs/resource/k8s.md
+	K8sStatefulSetLabel      = "k8s.statefulset.label.%s"
+	K8sStatefulSetAnnotation = "k8s.statefulset.annotation.%s"
+	K8sDaemonSetLabel        = "k8s.daemonset.label.%s"
+	K8sDaemonSetAnnotation   = "k8s.daemonset.annotation.%s"
+	K8sJobLabel              = "k8s.job.label.%s"
+	K8sJobAnnotation         = "k8s.job.annotation.%s"
+	K8sCronJobLabel          = "k8s.cronjob.label.%s"
+	K8sCronJobAnnotation     = "k8s.cronjob.annotation.%s"
+
+	kindReplicaSet  = "ReplicaSet"
+	kindDeployment  = "Deployment"
+	kindStatefulSet = "StatefulSet"
+	kindDaemonSet   = "DaemonSet"
+	kindJob         = "Job"
+	kindCronJob     = "CronJob"
+)
+
+// workloadMetadata holds, by workload kind, the value of FieldExtractionRule.From
+// selecting the workload and the formats of the label and annotation attributes.
+var workloadMetadata = map[string]struct {
+	from             string
+	labelFormat      string
+	annotationFormat string
+}{
+	kindStatefulSet: {MetadataFromStatefulSet, K8sStatefulSetLabel, K8sStatefulSetAnnotation},
+	kindDaemonSet:   {MetadataFromDaemonSet, K8sDaemonSetLabel, K8sDaemonSetAnnotation},
+	kindJob:         {MetadataFromJob, K8sJobLabel, K8sJobAnnotation},
+	kindCronJob:     {MetadataFromCronJob, K8sCronJobLabel, K8sCronJobAnnotation},
+}
+
+// workloadKinds returns the kinds of the workloads to watch: the ones labels
+// or annotations are extracted from and the jobs, needed to find the cronjob
+// owning a pod.
+func (c *WatchClient) workloadKinds() []string {
+	var kinds []string
+	for _, kind := range []string{kindStatefulSet, kindDaemonSet, kindJob, kindCronJob} {
+		if c.extractWorkloadLabelsAnnotations(workloadMetadata[kind].from) ||
+			(kind == kindJob && (c.extractWorkloadLabelsAnnotations(MetadataFromCronJob) || c.extractWorkload())) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+func (c *WatchClient) extractWorkloadLabelsAnnotations(from string) bool {
+	for _, r := range c.Rules.Labels {
+		if r.From == from {
+			return true
+		}
+	}
+
+	for _, r := range c.Rules.Annotations {
+		if r.From == from {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractWorkload returns true if the top-level workload of the pods is extracted.
+func (c *WatchClient) extractWorkload() bool {
+	return c.Rules.WorkloadName || c.Rules.WorkloadKind
+}
+
+// workloadObject returns the kind and the metadata of a workload object.
+func workloadObject(obj any) (string, meta_v1.Object, bool) {
+	switch o := obj.(type) {
+	case *apps_v1.StatefulSet:
+		return kindStatefulSet, o, true
+	case *apps_v1.DaemonSet:
+		return kindDaemonSet, o, true
+	case *batch_v1.Job:
+		return kindJob, o, true
+	case *batch_v1.CronJob:
+		return kindCronJob, o, true
+	}
+	return "", nil, false
+}
+
+func (c *WatchClient) handleWorkloadAdd(obj any) {
+	if kind, workload, ok := workloadObject(obj); ok {
+		c.addOrUpdateWorkload(kind, workload)
+	} else {
+		c.logger.Error("object received was not a workload", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleWorkloadUpdate(_, newWorkload any) {
+	c.handleWorkloadAdd(newWorkload)
+}
+
+func (c *WatchClient) handleWorkloadDelete(obj any) {
+	if _, workload, ok := workloadObject(ignoreDeletedFinalStateUnknown(obj)); ok {
+		c.m.Lock()
+		delete(c.Workloads, string(workload.GetUID()))
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not a workload", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) addOrUpdateWorkload(kind string, workload meta_v1.Object) {
+	newWorkload := &Workload{
+		Kind:       kind,
+		Name:       workload.GetName(),
+		UID:        string(workload.GetUID()),
+		Attributes: c.extractWorkloadAttributes(kind, workload),
+	}
+	if ref := controllerReference(workload.GetOwnerReferences()); ref != nil {
+		newWorkload.Owner = &WorkloadOwner{
+			Kind: ref.Kind,
+			Name: ref.Name,
+			UID:  string(ref.UID),
+		}
+	}
+
+	c.m.Lock()
+	previous := c.Workloads[newWorkload.UID]
+	if newWorkload.UID != "" {
+		c.Workloads[newWorkload.UID] = newWorkload
+	}
+	c.m.Unlock()
+
+	// The pods of a job may have been added before it, when its cronjob was still unknown
+	if kind == kindJob && newWorkload.Owner != nil && (previous == nil || previous.Owner == nil || *previous.Owner != *newWorkload.Owner) {
+		c.updateJobPods(newWorkload.UID)
+	}
+}
+
+// updateJobPods updates the pods owned by the job, to link them to the cronjob owning it.
+func (c *WatchClient) updateJobPods(uid string) {
+	if c.informer == nil {
+		return
+	}
+	for _, obj := range c.informer.GetStore().List() {
+		pod, ok := obj.(*api_v1.Pod)
+		if !ok {
+			continue
+		}
+		for _, ref := range pod.OwnerReferences {
+			if ref.Kind == kindJob && string(ref.UID) == uid {
+				c.addOrUpdatePod(pod)
+				break
+			}
+		}
+	}
+}
+
+func (c *WatchClient) extractWorkloadAttributes(kind string, workload meta_v1.Object) map[string]string {
+	tags := map[string]string{}
+	md := workloadMetadata[kind]
+
+	for _, r := range c.Rules.Labels {
+		r.extractFromWorkloadMetadata(md.from, workload.GetLabels(), tags, md.labelFormat)
+	}
+
+	for _, r := range c.Rules.Annotations {
+		r.extractFromWorkloadMetadata(md.from, workload.GetAnnotations(), tags, md.annotationFormat)
+	}
+
+	return tags
+}
+
+// GetWorkload takes a workload UID and returns the statefulset, daemonset, job
+// or cronjob it identifies.
+func (c *WatchClient) GetWorkload(uid string) (*Workload, bool) {
+	c.m.RLock()
+	workload, ok := c.Workloads[uid]
+	c.m.RUnlock()
+	if ok {
+		return workload, ok
+	}
+	return nil, false
+}
+
+// podWorkloadUIDs returns the UIDs of the workloads owning the pod whose
+// labels or annotations are extracted, including the cronjob of its job.
+func (c *WatchClient) podWorkloadUIDs(pod *api_v1.Pod) []string {
+	var uids []string
+	for _, ref := range pod.OwnerReferences {
+		switch ref.Kind {
+		case kindStatefulSet, kindDaemonSet:
+			uids = append(uids, string(ref.UID))
+		case kindJob:
+			uids = append(uids, string(ref.UID))
+			if job, ok := c.GetWorkload(string(ref.UID)); ok && job.Owner != nil && job.Owner.Kind == kindCronJob {
+				uids = append(uids, job.Owner.UID)
+			}
+		}
+	}
+	return uids
+}
+
+// podWorkload returns the kind and the name of the top-level workload
+// controlling the pod, following the owner chain: the deployment of the
+// replicaset or the cronjob of the job owning the pod.
+func (c *WatchClient) podWorkload(pod *api_v1.Pod) (string, string) {
+	ref := controllerReference(pod.OwnerReferences)
+	if ref == nil {
+		return "", ""
+	}
+	switch ref.Kind {
+	case kindReplicaSet:
+		if replicaset, ok := c.getReplicaSet(string(ref.UID)); ok && replicaset.Deployment.Name != "" {
+			return kindDeployment, replicaset.Deployment.Name
+		}
+	case kindJob:
+		if job, ok := c.GetWorkload(string(ref.UID)); ok && job.Owner != nil {
+			return job.Owner.Kind, job.Owner.Name
+		}
+	}
+	return ref.Kind, ref.Name
+}
+
+// controllerReference returns the owner reference of the controller of an
+// object, or nil if it has none.
+func controllerReference(refs []meta_v1.OwnerReference) *meta_v1.OwnerReference {
+	for i := range refs {
+		if refs[i].Controller != nil && *refs[i].Controller {
+			return &refs[i]
+		}
+	}
+	return nil
+}
+
+// removeUnnecessaryWorkloadData removes all data from the workload except its
+// metadata, used by the extraction rules and to follow the owner chain.
+func removeUnnecessaryWorkloadData(object any) (any, error) {
+	kind, workload, ok := workloadObject(object)
+	if !ok { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+		return object, nil
+	}
+	objectMeta := meta_v1.ObjectMeta{
+		Name:            workload.GetName(),
+		Namespace:       workload.GetNamespace(),
+		UID:             workload.GetUID(),
+		ResourceVersion: workload.GetResourceVersion(),
+		Labels:          workload.GetLabels(),
+		Annotations:     workload.GetAnnotations(),
+		OwnerReferences: workload.GetOwnerReferences(),
+	}
+	switch kind {
+	case kindStatefulSet:
+		return &apps_v1.StatefulSet{ObjectMeta: objectMeta}, nil
+	case kindDaemonSet:
+		return &apps_v1.DaemonSet{ObjectMeta: objectMeta}, nil
+	case kindJob:
+		return &batch_v1.Job{ObjectMeta: objectMeta}, nil
+	default:
+		return &batch_v1.CronJob{ObjectMeta: objectMeta}, nil
+	}
+}
+
+// newWorkloadInformers returns the informers of the workloads to watch by kind.
+func (c *WatchClient) newWorkloadInformers() (map[string]cache.SharedInformer, error) {
+	informers := map[string]cache.SharedInformer{}
+	for _, kind := range c.workloadKinds() {
+		informer := newWorkloadSharedInformer(c.kc, c.Filters.Namespace, kind)
+		if err := informer.SetTransform(removeUnnecessaryWorkloadData); err != nil {
+			return nil, err
+		}
+		informers[kind] = informer
+	}
+	return informers, nil
+}
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func TestWorkloadKinds(t *testing.T) {
+	c, _ := newTestClient(t)
+	testCases := []struct {
+		name  string
+		rules ExtractionRules
+		kinds []string
+	}{
+		{
+			name:  "empty-rules",
+			rules: ExtractionRules{},
+		},
+		{
+			name: "pod-and-deployment-rules",
+			rules: ExtractionRules{
+				Labels: []FieldExtractionRule{
+					{Name: "l1", Key: "label1", From: MetadataFromPod},
+					{Name: "l2", Key: "label2", From: MetadataFromDeployment},
+				},
+			},
+		},
+		{
+			name: "statefulset-and-daemonset-rules",
+			rules: ExtractionRules{
+				Labels:      []FieldExtractionRule{{Name: "l1", Key: "label1", From: MetadataFromStatefulSet}},
+				Annotations: []FieldExtractionRule{{Name: "a1", Key: "annotation1", From: MetadataFromDaemonSet}},
+			},
+			kinds: []string{kindStatefulSet, kindDaemonSet},
+		},
+		{
+			name: "cronjob-rules",
+			rules: ExtractionRules{
+				Annotations: []FieldExtractionRule{{Name: "a1", Key: "annotation1", From: MetadataFromCronJob}},
+			},
+			kinds: []string{kindJob, kindCronJob},
+		},
+		{
+			name:  "workload-name",
+			rules: ExtractionRules{WorkloadName: true},
+			kinds: []string{kindJob},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Rules = tc.rules
+			assert.Equal(t, tc.kinds, c.workloadKinds())
+		})
+	}
+}
+
+func TestWorkloadOwnerChain(t *testing.T) {
+	c, _ := newTestClient(t)
+	c.Rules = ExtractionRules{
+		WorkloadName: true,
+		WorkloadKind: true,
+		Labels: []FieldExtractionRule{
+			{Name: "team", Key: "team", From: MetadataFromCronJob},
+			{Name: "tier", Key: "tier", From: MetadataFromStatefulSet},
+		},
+		Annotations: []FieldExtractionRule{
+			{KeyRegex: regexp.MustCompile(`^(?:owner)$`), From: MetadataFromJob},
+		},
+	}
+	controller := true
+
+	c.handleWorkloadAdd(&batch_v1.CronJob{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   "backup",
+			UID:    "cronjob-uid",
+			Labels: map[string]string{"team": "storage"},
+		},
+	})
+	c.handleWorkloadAdd(&batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "backup-28000000",
+			UID:         "job-uid",
+			Annotations: map[string]string{"owner": "jane"},
+			OwnerReferences: []meta_v1.OwnerReference{
+				{Kind: "CronJob", Name: "backup", UID: "cronjob-uid", Controller: &controller},
+			},
+		},
+	})
+	c.handleWorkloadAdd(&apps_v1.StatefulSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   "db",
+			UID:    "statefulset-uid",
+			Labels: map[string]string{"tier": "backend"},
+		},
+	})
+	c.handleReplicaSetAdd(&apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "web-7d9c5b",
+			UID:  "replicaset-uid",
+			OwnerReferences: []meta_v1.OwnerReference{
+				{Kind: "Deployment", Name: "web", UID: "deployment-uid", Controller: &controller},
+			},
+		},
+	})
+	require.Len(t, c.Workloads, 3)
+
+	job, ok := c.GetWorkload("job-uid")
+	require.True(t, ok)
+	assert.Equal(t, &Workload{
+		Kind:       "Job",
+		Name:       "backup-28000000",
+		UID:        "job-uid",
+		Attributes: map[string]string{"k8s.job.annotation.owner": "jane"},
+		Owner:      &WorkloadOwner{Kind: "CronJob", Name: "backup", UID: "cronjob-uid"},
+	}, job)
+
+	newPod := func(kind, name, uid string) *api_v1.Pod {
+		pod := &api_v1.Pod{}
+		pod.Name = name + "-pod"
+		pod.OwnerReferences = []meta_v1.OwnerReference{
+			{Kind: kind, Name: name, UID: types.UID(uid), Controller: &controller},
+		}
+		return pod
+	}
+
+	testCases := []struct {
+		name         string
+		pod          *api_v1.Pod
+		workloadName string
+		workloadKind string
+		workloadUIDs []string
+	}{
+		{
+			name:         "cronjob",
+			pod:          newPod("Job", "backup-28000000", "job-uid"),
+			workloadName: "backup",
+			workloadKind: "CronJob",
+			workloadUIDs: []string{"job-uid", "cronjob-uid"},
+		},
+		{
+			name:         "statefulset",
+			pod:          newPod("StatefulSet", "db", "statefulset-uid"),
+			workloadName: "db",
+			workloadKind: "StatefulSet",
+			workloadUIDs: []string{"statefulset-uid"},
+		},
+		{
+			name:         "deployment",
+			pod:          newPod("ReplicaSet", "web-7d9c5b", "replicaset-uid"),
+			workloadName: "web",
+			workloadKind: "Deployment",
+		},
+		{
+			name:         "unknown replicaset",
+			pod:          newPod("ReplicaSet", "api-5f6d7c", "other-uid"),
+			workloadName: "api-5f6d7c",
+			workloadKind: "ReplicaSet",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.workloadInformers = map[string]cache.SharedInformer{kindJob: nil}
+			pod := c.podFromAPI(tc.pod)
+			assert.Equal(t, tc.workloadName, pod.Attributes["k8s.workload.name"])
+			assert.Equal(t, tc.workloadKind, pod.Attributes["k8s.workload.kind"])
+			assert.Equal(t, tc.workloadUIDs, pod.WorkloadUIDs)
+		})
+	}
+
+	cronJob, ok := c.GetWorkload("cronjob-uid")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"team": "storage"}, cronJob.Attributes)
+
+	c.handleWorkloadDelete(cache.DeletedFinalStateUnknown{Obj: &batch_v1.CronJob{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "backup", UID: "cronjob-uid"},
+	}})
+	_, ok = c.GetWorkload("cronjob-uid")
+	assert.False(t, ok)
+}
+
+func TestRemoveUnnecessaryWorkloadData(t *testing.T) {
+	controller := true
+	job := &batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "backup-28000000",
+			Namespace:   "default",
+			UID:         "job-uid",
+			Labels:      map[string]string{"team": "storage"},
+			Annotations: map[string]string{"owner": "jane"},
+			OwnerReferences: []meta_v1.OwnerReference{
+				{Kind: "CronJob", Name: "backup", UID: "cronjob-uid", Controller: &controller},
+			},
+		},
+		Spec: batch_v1.JobSpec{
+			Template: api_v1.PodTemplateSpec{
+				Spec: api_v1.PodSpec{Containers: []api_v1.Container{{Name: "backup"}}},
+			},
+		},
+	}
+
+	transformed, err := removeUnnecessaryWorkloadData(job)
+	require.NoError(t, err)
+	assert.Equal(t, &batch_v1.Job{ObjectMeta: job.ObjectMeta}, transformed)
+
+	deleted := cache.DeletedFinalStateUnknown{Obj: job}
+	transformed, err = removeUnnecessaryWorkloadData(deleted)
+	require.NoError(t, err)
+	assert.Equal(t, deleted, transformed)
+}
+
+func TestWorkloadPodInformer(t *testing.T) {
+	controller := true
+	pod := &api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "backup-28000000-x7k2p",
+			Namespace: "default",
+			UID:       "pod-uid",
+			OwnerReferences: []meta_v1.OwnerReference{
+				{Kind: "Job", Name: "backup-28000000", UID: "job-uid", Controller: &controller},
+			},
+		},
+	}
+	cronJob := &batch_v1.CronJob{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "default",
+			UID:       "cronjob-uid",
+			Labels:    map[string]string{"team": "storage"},
+		},
+	}
+	clientset := fake.NewSimpleClientset(pod, cronJob)
+
+	// Only the pod name and the labels of the cronjobs are extracted, which still need the owners of the pods
+	rules := ExtractionRules{
+		PodName: true,
+		Labels:  []FieldExtractionRule{{Name: "team", Key: "team", From: MetadataFromCronJob}},
+	}
+	associations := []Association{{Sources: []AssociationSource{{From: "resource_attribute", Name: "k8s.pod.uid"}}}}
+	client, err := New(
+		componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, rules, Filters{}, associations, Excludes{},
+		func(k8sconfig.APIConfig) (kubernetes.Interface, error) { return clientset, nil },
+		InformersFactoryList{newInformer: newSharedInformer}, false, 10*time.Second,
+	)
+	require.NoError(t, err)
+	c := client.(*WatchClient)
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	id := newPodIdentifier("resource_attribute", "k8s.pod.uid", "pod-uid")
+	workloadUIDs := func() []string {
+		if p, ok := c.GetPod(id); ok {
+			return p.WorkloadUIDs
+		}
+		return nil
+	}
+	require.EventuallyWithT(t, func(ct *assert.CollectT) {
+		assert.Equal(ct, []string{"job-uid"}, workloadUIDs())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The pod is linked to the cronjob once its job is added
+	_, err = clientset.BatchV1().Jobs("default").Create(context.Background(), &batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "backup-28000000",
+			Namespace: "default",
+			UID:       "job-uid",
+			OwnerReferences: []meta_v1.OwnerReference{
+				{Kind: "CronJob", Name: "backup", UID: "cronjob-uid", Controller: &controller},
+			},
+		},
+	}, meta_v1.CreateOptions{})
+	require.NoError(t, err)
+	require.EventuallyWithT(t, func(ct *assert.CollectT) {
+		assert.Equal(ct, []string{"job-uid", "cronjob-uid"}, workloadUIDs())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	workload, ok := c.GetWorkload("cronjob-uid")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"team": "storage"}, workload.Attributes)
+}
@@ -52,6 +52,8 @@ type ResourceAttributesConfig struct {
 	K8sReplicasetUID          ResourceAttributeConfig `mapstructure:"k8s.replicaset.uid"`
 	K8sStatefulsetName        ResourceAttributeConfig `mapstructure:"k8s.statefulset.name"`
 	K8sStatefulsetUID         ResourceAttributeConfig `mapstructure:"k8s.statefulset.uid"`
+	K8sWorkloadKind           ResourceAttributeConfig `mapstructure:"k8s.workload.kind"`
+	K8sWorkloadName           ResourceAttributeConfig `mapstructure:"k8s.workload.name"`
 	ServiceInstanceID         ResourceAttributeConfig `mapstructure:"service.instance.id"`
 	ServiceName               ResourceAttributeConfig `mapstructure:"service.name"`
 	ServiceNamespace          ResourceAttributeConfig `mapstructure:"service.namespace"`
@@ -135,6 +137,12 @@ func DefaultResourceAttributesConfig() ResourceAttributesConfig {
 		K8sStatefulsetUID: ResourceAttributeConfig{
 			Enabled: false,
 		},
+		K8sWorkloadKind: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		K8sWorkloadName: ResourceAttributeConfig{
+			Enabled: false,
+		},
 		ServiceInstanceID: ResourceAttributeConfig{
 			Enabled: false,
 		},
@@ -50,6 +50,8 @@ func TestResourceAttributesConfig(t *testing.T) {
 				K8sReplicasetUID:          ResourceAttributeConfig{Enabled: true},
 				K8sStatefulsetName:        ResourceAttributeConfig{Enabled: true},
 				K8sStatefulsetUID:         ResourceAttributeConfig{Enabled: true},
+				K8sWorkloadKind:           ResourceAttributeConfig{Enabled: true},
+				K8sWorkloadName:           ResourceAttributeConfig{Enabled: true},
 				ServiceInstanceID:         ResourceAttributeConfig{Enabled: true},
 				ServiceName:               ResourceAttributeConfig{Enabled: true},
 				ServiceNamespace:          ResourceAttributeConfig{Enabled: true},
@@ -84,6 +86,8 @@ func TestResourceAttributesConfig(t *testing.T) {
 				K8sReplicasetUID:          ResourceAttributeConfig{Enabled: false},
 				K8sStatefulsetName:        ResourceAttributeConfig{Enabled: false},
 				K8sStatefulsetUID:         ResourceAttributeConfig{Enabled: false},
+				K8sWorkloadKind:           ResourceAttributeConfig{Enabled: false},
+				K8sWorkloadName:           ResourceAttributeConfig{Enabled: false},
 				ServiceInstanceID:         ResourceAttributeConfig{Enabled: false},
 				ServiceName:               ResourceAttributeConfig{Enabled: false},
 				ServiceNamespace:          ResourceAttributeConfig{Enabled: false},
@@ -196,6 +196,20 @@ func (rb *ResourceBuilder) SetK8sStatefulsetUID(val string) {
 	}
 }
 
+// SetK8sWorkloadKind sets provided value as "k8s.workload.kind" attribute.
+func (rb *ResourceBuilder) SetK8sWorkloadKind(val string) {
+	if rb.config.K8sWorkloadKind.Enabled {
+		rb.res.Attributes().PutStr("k8s.workload.kind", val)
+	}
+}
+
+// SetK8sWorkloadName sets provided value as "k8s.workload.name" attribute.
+func (rb *ResourceBuilder) SetK8sWorkloadName(val string) {
+	if rb.config.K8sWorkloadName.Enabled {
+		rb.res.Attributes().PutStr("k8s.workload.name", val)
+	}
+}
+
 // SetServiceInstanceID sets provided value as "service.instance.id" attribute.
 func (rb *ResourceBuilder) SetServiceInstanceID(val string) {
 	if rb.config.ServiceInstanceID.Enabled {
@@ -38,6 +38,8 @@ func TestResourceBuilder(t *testing.T) {
 			rb.SetK8sReplicasetUID("k8s.replicaset.uid-val")
 			rb.SetK8sStatefulsetName("k8s.statefulset.name-val")
 			rb.SetK8sStatefulsetUID("k8s.statefulset.uid-val")
+			rb.SetK8sWorkloadKind("k8s.workload.kind-val")
+			rb.SetK8sWorkloadName("k8s.workload.name-val")
 			rb.SetServiceInstanceID("service.instance.id-val")
 			rb.SetServiceName("service.name-val")
 			rb.SetServiceNamespace("service.namespace-val")
@@ -50,7 +52,7 @@ func TestResourceBuilder(t *testing.T) {
 			case "default":
 				assert.Equal(t, 8, res.Attributes().Len())
 			case "all_set":
-				assert.Equal(t, 29, res.Attributes().Len())
+				assert.Equal(t, 31, res.Attributes().Len())
 			case "none_set":
 				assert.Equal(t, 0, res.Attributes().Len())
 				return
@@ -183,6 +185,16 @@ func TestResourceBuilder(t *testing.T) {
 			if ok {
 				assert.Equal(t, "k8s.statefulset.uid-val", val.Str())
 			}
+			val, ok = res.Attributes().Get("k8s.workload.kind")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "k8s.workload.kind-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("k8s.workload.name")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "k8s.workload.name-val", val.Str())
+			}
 			val, ok = res.Attributes().Get("service.instance.id")
 			assert.Equal(t, tt == "all_set", ok)
 			if ok {
@@ -31,6 +31,8 @@ const (
 	//   replace containerRepoDigests with string(conventions.ContainerImageRepoDigestsKey)
 	clusterUID                = "k8s.cluster.uid"
 	containerImageRepoDigests = "container.image.repo_digests"
+	workloadName              = "k8s.workload.name"
+	workloadKind              = "k8s.workload.kind"
 )
 
 // option represents a configuration option that can be passes.
@@ -145,6 +147,12 @@ func enabledAttributes() (attributes []string) {
 	if defaultConfig.ServiceInstanceID.Enabled {
 		attributes = append(attributes, string(conventions.ServiceInstanceIDKey))
 	}
+	if defaultConfig.K8sWorkloadName.Enabled {
+		attributes = append(attributes, workloadName)
+	}
+	if defaultConfig.K8sWorkloadKind.Enabled {
+		attributes = append(attributes, workloadKind)
+	}
 	return
 }
 
@@ -212,6 +220,10 @@ func withExtractMetadata(fields ...string) option {
 				p.rules.ServiceVersion = true
 			case string(conventions.ServiceInstanceIDKey):
 				p.rules.ServiceInstanceID = true
+			case workloadName:
+				p.rules.WorkloadName = true
+			case workloadKind:
+				p.rules.WorkloadKind = true
 			}
 		}
 		return nil
@@ -369,9 +381,16 @@ func withExtractPodAssociations(podAssociations ...PodAssociationConfig) option
 			var name string
 
 			for _, associationSource := range association.Sources {
-				if associationSource.From == kube.ConnectionSource {
+				switch {
+				case associationSource.From == kube.ConnectionSource:
 					name = ""
-				} else {
+				case associationSource.Name != "":
+					name = associationSource.Name
+				case associationSource.From == kube.ContainerIDSource:
+					name = string(conventions.ContainerIDKey)
+				case associationSource.From == kube.CgroupSource:
+					name = string(conventions.ProcessPIDKey)
+				default:
 					name = associationSource.Name
 				}
 				assoc.Sources = append(assoc.Sources, kube.AssociationSource{
@@ -16,7 +16,7 @@ import (
 )
 
 // extractPodIds returns pod identifier for first association matching all sources
-func extractPodID(ctx context.Context, attrs pcommon.Map, associations []kube.Association) kube.PodIdentifier {
+func extractPodID(ctx context.Context, attrs pcommon.Map, associations []kube.Association, containerIDs *containerIDCache) kube.PodIdentifier {
 	// If pod association is not set
 	if len(associations) == 0 {
 		return extractPodIDNoAssociations(ctx, attrs)
@@ -53,6 +53,25 @@ func extractPodID(ctx context.Context, attrs pcommon.Map, associations []kube.As
 				}
 
 				ret[i] = kube.PodIdentifierAttributeFromSource(source, attributeValue)
+			case kube.ContainerIDSource:
+				containerID := stringAttributeFromMap(attrs, source.Name)
+				if containerID == "" {
+					skip = true
+					break
+				}
+				ret[i] = kube.PodIdentifierAttributeFromSource(source, containerID)
+			case kube.CgroupSource:
+				pid, ok := attrs.Get(source.Name)
+				if !ok {
+					skip = true
+					break
+				}
+				containerID := containerIDs.containerIDFromPID(pid)
+				if containerID == "" {
+					skip = true
+					break
+				}
+				ret[i] = kube.PodIdentifierAttributeFromSource(source, containerID)
 			}
 		}
 
@@ -41,6 +41,7 @@ type kubernetesprocessor struct {
 	podIgnore              kube.Excludes
 	waitForMetadata        bool
 	waitForMetadataTimeout time.Duration
+	containerIDs           *containerIDCache
 }
 
 func (kp *kubernetesprocessor) initKubeClient(set component.TelemetrySettings, kubeClient kube.ClientProvider) error {
@@ -139,7 +140,7 @@ func (kp *kubernetesprocessor) processProfiles(ctx context.Context, pd pprofile.
 
 // processResource adds Pod metadata tags to resource based on pod association configuration
 func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pcommon.Resource) {
-	podIdentifierValue := extractPodID(ctx, resource.Attributes(), kp.podAssociations)
+	podIdentifierValue := extractPodID(ctx, resource.Attributes(), kp.podAssociations, kp.containerIDs)
 	kp.logger.Debug("evaluating pod identifier", zap.Any("value", podIdentifierValue))
 
 	for i := range podIdentifierValue {
@@ -161,6 +162,14 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 			for key, val := range pod.Attributes {
 				setResourceAttribute(resource.Attributes(), key, val)
 			}
+			// the container resolved from the process cgroup identifies the
+			// container attributes to add
+			for i := range podIdentifierValue {
+				if podIdentifierValue[i].Source.From == kube.CgroupSource && podIdentifierValue[i].Value != "" {
+					setResourceAttribute(resource.Attributes(), string(conventions.ContainerIDKey), podIdentifierValue[i].Value)
+					break
+				}
+			}
 			kp.addContainerAttributes(resource.Attributes(), pod)
 		}
 	}
@@ -196,6 +205,15 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 			setResourceAttribute(resource.Attributes(), key, val)
 		}
 	}
+
+	if pod != nil {
+		for _, workloadUID := range pod.WorkloadUIDs {
+			attrsToAdd := kp.getAttributesForPodsWorkload(workloadUID)
+			for key, val := range attrsToAdd {
+				setResourceAttribute(resource.Attributes(), key, val)
+			}
+		}
+	}
 }
 
 func setResourceAttribute(attributes pcommon.Map, key string, val string) {
@@ -326,6 +344,14 @@ func (kp *kubernetesprocessor) getAttributesForPodsDeployment(deploymentUID stri
 	return d.Attributes
 }
 
+func (kp *kubernetesprocessor) getAttributesForPodsWorkload(workloadUID string) map[string]string {
+	w, ok := kp.kc.GetWorkload(workloadUID)
+	if !ok {
+		return nil
+	}
+	return w.Attributes
+}
+
 func (kp *kubernetesprocessor) getUIDForPodsNode(nodeName string) string {
 	node, ok := kp.kc.GetNode(nodeName)
 	if !ok {
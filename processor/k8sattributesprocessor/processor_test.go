@@ -728,6 +728,67 @@ func TestPodUID(t *testing.T) {
 	})
 }
 
+func TestContainerAssociation(t *testing.T) {
+	t.Setenv("HOST_PROC", "testdata/proc")
+	const containerID = "0f3a2b0c4e5d6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a"
+
+	tests := []struct {
+		name     string
+		source   kube.AssociationSource
+		resource generateResourceFunc
+	}{
+		{
+			name:   "container id",
+			source: kube.AssociationSource{From: kube.ContainerIDSource, Name: "container.id"},
+			resource: func(res pcommon.Resource) {
+				res.Attributes().PutStr("container.id", containerID)
+			},
+		},
+		{
+			name:   "process cgroup",
+			source: kube.AssociationSource{From: kube.CgroupSource, Name: "process.pid"},
+			resource: func(res pcommon.Resource) {
+				res.Attributes().PutInt("process.pid", 1234)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMultiTest(t, NewFactory().CreateDefaultConfig(), nil)
+			m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+				kp.podAssociations = []kube.Association{{Sources: []kube.AssociationSource{tt.source}}}
+				kp.rules.ContainerID = true
+				kp.kc.(*fakeClient).Pods[kube.PodIdentifier{kube.PodIdentifierAttributeFromSource(tt.source, containerID)}] = &kube.Pod{
+					Name:       "PodA",
+					Attributes: map[string]string{"k8s.pod.name": "PodA"},
+					Containers: kube.PodContainers{
+						ByID: map[string]*kube.Container{
+							containerID: {Name: "app", Statuses: map[int]kube.ContainerStatus{0: {ContainerID: containerID}}},
+						},
+						ByName: map[string]*kube.Container{
+							"app": {Name: "app", Statuses: map[int]kube.ContainerStatus{0: {ContainerID: containerID}}},
+						},
+					},
+				}
+			})
+
+			m.testConsume(context.Background(),
+				generateTraces(tt.resource),
+				generateMetrics(tt.resource),
+				generateLogs(tt.resource),
+				generateProfiles(tt.resource),
+				nil)
+
+			m.assertBatchesLen(1)
+			m.assertResource(0, func(r pcommon.Resource) {
+				assertResourceHasStringAttribute(t, r, "k8s.pod.name", "PodA")
+				assertResourceHasStringAttribute(t, r, "container.id", containerID)
+				assertResourceHasStringAttribute(t, r, "k8s.container.name", "app")
+			})
+		})
+	}
+}
+
 func TestAddPodLabels(t *testing.T) {
 	m := newMultiTest(
 		t,
@@ -944,6 +1005,55 @@ func TestAddNodeLabels(t *testing.T) {
 	})
 }
 
+func TestAddWorkloadLabels(t *testing.T) {
+	m := newMultiTest(t, NewFactory().CreateDefaultConfig(), nil)
+
+	podIP := "1.1.1.1"
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "connection",
+					},
+				},
+			},
+		}
+		pi := kube.PodIdentifier{
+			kube.PodIdentifierAttributeFromConnection(podIP),
+		}
+		kp.kc.(*fakeClient).Pods[pi] = &kube.Pod{Name: "backup-28000000-x7k2p", WorkloadUIDs: []string{"job-uid", "cronjob-uid", "unknown-uid"}}
+		kp.kc.(*fakeClient).Workloads = map[string]*kube.Workload{
+			"job-uid":     {Kind: "Job", Attributes: map[string]string{"k8s.job.label.team": "storage"}},
+			"cronjob-uid": {Kind: "CronJob", Attributes: map[string]string{"k8s.cronjob.annotation.owner": "jane"}},
+		}
+	})
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Addr: &net.IPAddr{
+			IP: net.ParseIP(podIP),
+		},
+	})
+	m.testConsume(
+		ctx,
+		generateTraces(),
+		generateMetrics(),
+		generateLogs(),
+		generateProfiles(),
+		func(err error) {
+			assert.NoError(t, err)
+		})
+
+	m.assertBatchesLen(1)
+	m.assertResourceObjectLen(0)
+	m.assertResource(0, func(res pcommon.Resource) {
+		assert.Equal(t, 3, res.Attributes().Len())
+		assertResourceHasStringAttribute(t, res, "k8s.pod.ip", podIP)
+		assertResourceHasStringAttribute(t, res, "k8s.job.label.team", "storage")
+		assertResourceHasStringAttribute(t, res, "k8s.cronjob.annotation.owner", "jane")
+	})
+}
+
 func TestAddNodeUID(t *testing.T) {
 	nodeUID := "asdfasdf-asdfasdf-asdf"
 	m := newMultiTest(
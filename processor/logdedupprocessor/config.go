@@ -24,6 +24,12 @@ const (
 	// defaultTimezone is the default timezone
 	defaultTimezone = "UTC"
 
+	// defaultCheckpointInterval is the default interval at which in-flight aggregates are persisted
+	defaultCheckpointInterval = 10 * time.Second
+
+	// defaultCountMetricKeyLimit is the default maximum number of dedup keys recorded by the count metric
+	defaultCountMetricKeyLimit = 1000
+
 	// bodyField is the name of the body field
 	bodyField = "body"
 
@@ -37,6 +43,10 @@ var (
 	errInvalidInterval          = errors.New("interval must be greater than 0")
 	errCannotExcludeBody        = errors.New("cannot exclude the entire body")
 	errCannotIncludeBody        = errors.New("cannot include the entire body")
+	errInvalidFieldSamples      = errors.New("excluded_field_samples must not be negative")
+	errFieldSamplesNoExclude    = errors.New("excluded_field_samples requires exclude_fields")
+	errInvalidCheckpoint        = errors.New("checkpoint_interval must be greater than 0")
+	errInvalidCountMetricLimit  = errors.New("count_metric_key_limit must be greater than 0")
 )
 
 // Config is the config of the processor.
@@ -47,17 +57,36 @@ type Config struct {
 	ExcludeFields     []string      `mapstructure:"exclude_fields"`
 	IncludeFields     []string      `mapstructure:"include_fields"`
 	Conditions        []string      `mapstructure:"conditions"`
+
+	// ExcludedFieldSamples is the maximum number of distinct values of each excluded field
+	// kept on aggregated logs. Zero disables sampling.
+	ExcludedFieldSamples int `mapstructure:"excluded_field_samples"`
+
+	// EmitCountMetric records the number of logs per dedup key as internal telemetry on export.
+	EmitCountMetric bool `mapstructure:"emit_count_metric"`
+
+	// CountMetricKeyLimit is the maximum number of distinct dedup keys recorded by the count metric.
+	// Logs of further dedup keys are recorded in a single overflow series.
+	CountMetricKeyLimit int `mapstructure:"count_metric_key_limit"`
+
+	// StorageID is the storage extension used to persist in-flight aggregates.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// CheckpointInterval is how often in-flight aggregates are persisted when they changed.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
 }
 
 // createDefaultConfig returns the default config for the processor.
 func createDefaultConfig() component.Config {
 	return &Config{
-		LogCountAttribute: defaultLogCountAttribute,
-		Interval:          defaultInterval,
-		Timezone:          defaultTimezone,
-		ExcludeFields:     []string{},
-		IncludeFields:     []string{},
-		Conditions:        []string{},
+		LogCountAttribute:   defaultLogCountAttribute,
+		Interval:            defaultInterval,
+		Timezone:            defaultTimezone,
+		ExcludeFields:       []string{},
+		IncludeFields:       []string{},
+		Conditions:          []string{},
+		CountMetricKeyLimit: defaultCountMetricKeyLimit,
+		CheckpointInterval:  defaultCheckpointInterval,
 	}
 }
 
@@ -88,6 +117,22 @@ func (c Config) Validate() error {
 		return err
 	}
 
+	if c.ExcludedFieldSamples < 0 {
+		return errInvalidFieldSamples
+	}
+
+	if c.ExcludedFieldSamples > 0 && len(c.ExcludeFields) == 0 {
+		return errFieldSamplesNoExclude
+	}
+
+	if c.EmitCountMetric && c.CountMetricKeyLimit <= 0 {
+		return errInvalidCountMetricLimit
+	}
+
+	if c.StorageID != nil && c.CheckpointInterval <= 0 {
+		return errInvalidCheckpoint
+	}
+
 	return nil
 }
 
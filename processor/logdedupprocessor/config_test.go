@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 )
 
 func TestCreateDefaultProcessorConfig(t *testing.T) {
@@ -16,6 +17,8 @@ func TestCreateDefaultProcessorConfig(t *testing.T) {
 	require.Equal(t, defaultLogCountAttribute, cfg.LogCountAttribute)
 	require.Equal(t, defaultTimezone, cfg.Timezone)
 	require.Equal(t, []string{}, cfg.ExcludeFields)
+	require.Equal(t, defaultCountMetricKeyLimit, cfg.CountMetricKeyLimit)
+	require.Equal(t, defaultCheckpointInterval, cfg.CheckpointInterval)
 }
 
 func TestValidateConfig(t *testing.T) {
@@ -148,6 +151,58 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: errors.New("cannot define both exclude_fields and include_fields"),
 		},
+		{
+			desc: "invalid negative excluded_field_samples",
+			cfg: &Config{
+				LogCountAttribute:    defaultLogCountAttribute,
+				Interval:             defaultInterval,
+				Timezone:             defaultTimezone,
+				ExcludeFields:        []string{"body.thing"},
+				ExcludedFieldSamples: -1,
+			},
+			expectedErr: errInvalidFieldSamples,
+		},
+		{
+			desc: "invalid excluded_field_samples without exclude_fields",
+			cfg: &Config{
+				LogCountAttribute:    defaultLogCountAttribute,
+				Interval:             defaultInterval,
+				Timezone:             defaultTimezone,
+				ExcludedFieldSamples: 3,
+			},
+			expectedErr: errFieldSamplesNoExclude,
+		},
+		{
+			desc: "valid config excluded_field_samples",
+			cfg: &Config{
+				LogCountAttribute:    defaultLogCountAttribute,
+				Interval:             defaultInterval,
+				Timezone:             defaultTimezone,
+				ExcludeFields:        []string{"body.thing"},
+				ExcludedFieldSamples: 3,
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "invalid count_metric_key_limit",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				EmitCountMetric:   true,
+			},
+			expectedErr: errInvalidCountMetricLimit,
+		},
+		{
+			desc: "invalid checkpoint_interval",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				StorageID:         &component.ID{},
+			},
+			expectedErr: errInvalidCheckpoint,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -5,10 +5,14 @@ package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"slices"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/metadata"
@@ -20,6 +24,15 @@ const (
 	lastObservedTSAttr  = "last_observed_timestamp"
 )
 
+// excludedFieldSamplesAttr is the attribute name for samples of excluded field values
+const excludedFieldSamplesAttr = "excluded_field_samples"
+
+// dedupKeyAttr is the metric attribute identifying the dedup key of aggregated logs
+const dedupKeyAttr = "dedup_key"
+
+// overflowAttr is the metric attribute of the series recording the logs of the dedup keys over the limit
+const overflowAttr = "otel.metric.overflow"
+
 // timeNow can be reassigned for testing
 var timeNow = time.Now
 
@@ -30,16 +43,27 @@ type logAggregator struct {
 	timezone          *time.Location
 	telemetryBuilder  *metadata.TelemetryBuilder
 	dedupFields       []string
+
+	// sampleLimit is the maximum number of distinct values kept per excluded field
+	sampleLimit int
+	// emitCountMetric records the log count per dedup key on export
+	emitCountMetric bool
+	// countMetricKeyLimit is the maximum number of dedup keys recorded by the count metric
+	countMetricKeyLimit int
+	// countMetricKeys holds the dedup keys recorded by the count metric, up to countMetricKeyLimit
+	countMetricKeys map[uint64]struct{}
 }
 
 // newLogAggregator creates a new LogCounter.
 func newLogAggregator(logCountAttribute string, timezone *time.Location, telemetryBuilder *metadata.TelemetryBuilder, dedupFields []string) *logAggregator {
 	return &logAggregator{
-		resources:         make(map[uint64]*resourceAggregator),
-		logCountAttribute: logCountAttribute,
-		timezone:          timezone,
-		telemetryBuilder:  telemetryBuilder,
-		dedupFields:       dedupFields,
+		resources:           make(map[uint64]*resourceAggregator),
+		logCountAttribute:   logCountAttribute,
+		timezone:            timezone,
+		telemetryBuilder:    telemetryBuilder,
+		dedupFields:         dedupFields,
+		countMetricKeyLimit: defaultCountMetricKeyLimit,
+		countMetricKeys:     make(map[uint64]struct{}),
 	}
 }
 
@@ -58,6 +82,10 @@ func (l *logAggregator) Export(ctx context.Context) plog.Logs {
 			for _, logAggregator := range scopeAggregator.logCounters {
 				// Record aggregated logs records
 				l.telemetryBuilder.DedupProcessorAggregatedLogs.Record(ctx, logAggregator.count)
+				if l.emitCountMetric {
+					l.telemetryBuilder.DedupProcessorLogCount.Add(ctx, logAggregator.count,
+						metric.WithAttributeSet(l.countMetricAttributes(logAggregator)))
+				}
 
 				lr := sl.LogRecords().AppendEmpty()
 				logAggregator.logRecord.CopyTo(lr)
@@ -73,6 +101,17 @@ func (l *logAggregator) Export(ctx context.Context) plog.Logs {
 				lr.Attributes().PutStr(firstObservedTSAttr, firstTimestampStr)
 				lastTimestampStr := logAggregator.lastObservedTimestamp.In(l.timezone).Format(time.RFC3339)
 				lr.Attributes().PutStr(lastObservedTSAttr, lastTimestampStr)
+
+				// Add samples of the values of the excluded fields
+				if len(logAggregator.samples) > 0 {
+					samples := lr.Attributes().PutEmptyMap(excludedFieldSamplesAttr)
+					for field, values := range logAggregator.samples {
+						slice := samples.PutEmptySlice(field)
+						for _, v := range values {
+							slice.AppendEmpty().SetStr(v)
+						}
+					}
+				}
 			}
 		}
 	}
@@ -80,15 +119,46 @@ func (l *logAggregator) Export(ctx context.Context) plog.Logs {
 	return logs
 }
 
-// Add adds the logRecord to the resource aggregator that is identified by the resource attributes
-func (l *logAggregator) Add(resource pcommon.Resource, scope pcommon.InstrumentationScope, logRecord plog.LogRecord) {
+// countMetricAttributes returns the attributes identifying the dedup key of a log counter.
+// The values of the include fields are added so that the key is recognizable. Once the limit
+// of dedup keys is reached, the logs of new keys share a single overflow series to bound
+// the cardinality of the metric.
+func (l *logAggregator) countMetricAttributes(lc *logCounter) attribute.Set {
+	if _, ok := l.countMetricKeys[lc.key]; !ok {
+		if len(l.countMetricKeys) >= l.countMetricKeyLimit {
+			return attribute.NewSet(attribute.Bool(overflowAttr, true))
+		}
+		l.countMetricKeys[lc.key] = struct{}{}
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(l.dedupFields)+1)
+	attrs = append(attrs, attribute.String(dedupKeyAttr, strconv.FormatUint(lc.key, 16)))
+	for _, key := range l.dedupFields {
+		f := field{key: key, keyParts: splitField(key)}
+		if value, ok := f.getValue(lc.logRecord); ok {
+			attrs = append(attrs, attribute.String(key, value.AsString()))
+		}
+	}
+	return attribute.NewSet(attrs...)
+}
+
+// Add adds the logRecord to the resource aggregator that is identified by the resource attributes.
+// samples holds the values of the excluded fields of the logRecord, if any.
+func (l *logAggregator) Add(resource pcommon.Resource, scope pcommon.InstrumentationScope, logRecord plog.LogRecord, samples map[string]string) {
+	resourceAggregator := l.resourceAggregator(resource)
+	lc := resourceAggregator.Add(scope, logRecord)
+	lc.addSamples(samples, l.sampleLimit)
+}
+
+// resourceAggregator returns the resource aggregator for the resource, creating it if needed
+func (l *logAggregator) resourceAggregator(resource pcommon.Resource) *resourceAggregator {
 	key := getResourceKey(resource)
 	resourceAggregator, ok := l.resources[key]
 	if !ok {
 		resourceAggregator = newResourceAggregator(resource, l.dedupFields)
 		l.resources[key] = resourceAggregator
 	}
-	resourceAggregator.Add(scope, logRecord)
+	return resourceAggregator
 }
 
 // Reset resets the counter.
@@ -113,14 +183,19 @@ func newResourceAggregator(resource pcommon.Resource, dedupFields []string) *res
 }
 
 // Add increments the counter that the logRecord matches.
-func (r *resourceAggregator) Add(scope pcommon.InstrumentationScope, logRecord plog.LogRecord) {
+func (r *resourceAggregator) Add(scope pcommon.InstrumentationScope, logRecord plog.LogRecord) *logCounter {
+	return r.scopeAggregator(scope).Add(logRecord)
+}
+
+// scopeAggregator returns the scope aggregator for the scope, creating it if needed
+func (r *resourceAggregator) scopeAggregator(scope pcommon.InstrumentationScope) *scopeAggregator {
 	key := getScopeKey(scope)
 	scopeAggregator, ok := r.scopeCounters[key]
 	if !ok {
 		scopeAggregator = newScopeAggregator(scope, r.dedupFields)
 		r.scopeCounters[key] = scopeAggregator
 	}
-	scopeAggregator.Add(logRecord)
+	return scopeAggregator
 }
 
 // scopeAggregator dimensions the counter by scope.
@@ -140,22 +215,26 @@ func newScopeAggregator(scope pcommon.InstrumentationScope, dedupFields []string
 }
 
 // Add increments the counter that the logRecord matches.
-func (s *scopeAggregator) Add(logRecord plog.LogRecord) {
+func (s *scopeAggregator) Add(logRecord plog.LogRecord) *logCounter {
 	key := getLogKey(logRecord, s.dedupFields)
 	lc, ok := s.logCounters[key]
 	if !ok {
 		lc = newLogCounter(logRecord)
+		lc.key = key
 		s.logCounters[key] = lc
 	}
 	lc.Increment()
+	return lc
 }
 
 // logCounter is a counter for a log record.
 type logCounter struct {
 	logRecord              plog.LogRecord
+	key                    uint64
 	firstObservedTimestamp time.Time
 	lastObservedTimestamp  time.Time
 	count                  int64
+	samples                map[string][]string
 }
 
 // newLogCounter creates a new AttributeCounter.
@@ -174,6 +253,23 @@ func (a *logCounter) Increment() {
 	a.count++
 }
 
+// addSamples records the distinct values of excluded fields, up to limit values per field.
+func (a *logCounter) addSamples(samples map[string]string, limit int) {
+	if limit <= 0 {
+		return
+	}
+	for field, value := range samples {
+		values := a.samples[field]
+		if len(values) >= limit || slices.Contains(values, value) {
+			continue
+		}
+		if a.samples == nil {
+			a.samples = make(map[string][]string)
+		}
+		a.samples[field] = append(values, value)
+	}
+}
+
 // getResourceKey creates a unique hash for the resource to use as a map key
 func getResourceKey(resource pcommon.Resource) uint64 {
 	return pdatautil.Hash64(
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/metadata"
@@ -57,7 +58,7 @@ func Test_logAggregatorAdd(t *testing.T) {
 	expectedLogKey := getLogKey(logRecord, nil)
 
 	// Add logRecord
-	aggregator.Add(resource, scope, logRecord)
+	aggregator.Add(resource, scope, logRecord, nil)
 
 	// Check resourceCounter was set
 	resourceCounter, ok := aggregator.resources[expectedResourceKey]
@@ -85,7 +86,7 @@ func Test_logAggregatorAdd(t *testing.T) {
 		return secondExpectedTimestamp
 	}
 
-	aggregator.Add(resource, scope, logRecord)
+	aggregator.Add(resource, scope, logRecord, nil)
 	require.Equal(t, int64(2), lc.count)
 	require.Equal(t, secondExpectedTimestamp, lc.lastObservedTimestamp)
 }
@@ -139,7 +140,7 @@ func Test_logAggregatorExport(t *testing.T) {
 	logRecord := generateTestLogRecord(t, "body string")
 
 	// Add logRecord
-	aggregator.Add(resource, scope, logRecord)
+	aggregator.Add(resource, scope, logRecord, nil)
 
 	exportedLogs := aggregator.Export(context.Background())
 	require.Equal(t, 1, exportedLogs.LogRecordCount())
@@ -319,3 +320,91 @@ func generateTestLogRecordWithMap(t *testing.T) plog.LogRecord {
 	logRecord.Body().SetEmptyMap()
 	return logRecord
 }
+
+func Test_logCounterAddSamples(t *testing.T) {
+	lc := newLogCounter(plog.NewLogRecord())
+
+	lc.addSamples(map[string]string{"body.id": "1"}, 0)
+	require.Empty(t, lc.samples)
+
+	lc.addSamples(map[string]string{"body.id": "1", "attributes.host": "a"}, 2)
+	lc.addSamples(map[string]string{"body.id": "1"}, 2)
+	lc.addSamples(map[string]string{"body.id": "2"}, 2)
+	lc.addSamples(map[string]string{"body.id": "3"}, 2)
+	require.Equal(t, map[string][]string{
+		"body.id":         {"1", "2"},
+		"attributes.host": {"a"},
+	}, lc.samples)
+}
+
+func Test_logAggregatorExportSamplesAndCountMetric(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	aggregator := newLogAggregator(defaultLogCountAttribute, time.UTC, telemetryBuilder, []string{"attributes.service"})
+	aggregator.sampleLimit = 5
+	aggregator.emitCountMetric = true
+
+	resource := pcommon.NewResource()
+	scope := pcommon.NewInstrumentationScope()
+	logRecord := generateTestLogRecord(t, "body string")
+	logRecord.Attributes().PutStr("service", "checkout")
+
+	aggregator.Add(resource, scope, logRecord, map[string]string{"body.id": "1"})
+	aggregator.Add(resource, scope, logRecord, map[string]string{"body.id": "2"})
+
+	exportedLogs := aggregator.Export(context.Background())
+	require.Equal(t, 1, exportedLogs.LogRecordCount())
+	attrs := exportedLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	require.Equal(t, map[string]any{"body.id": []any{"1", "2"}}, attrs[excludedFieldSamplesAttr])
+
+	m, err := tel.GetMetric("otelcol_dedup_processor_log_count")
+	require.NoError(t, err)
+	sum := m.Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	require.Equal(t, int64(2), sum.DataPoints[0].Value)
+	service, ok := sum.DataPoints[0].Attributes.Value("attributes.service")
+	require.True(t, ok)
+	require.Equal(t, "checkout", service.AsString())
+	_, ok = sum.DataPoints[0].Attributes.Value(dedupKeyAttr)
+	require.True(t, ok)
+}
+
+func Test_logAggregatorCountMetricKeyLimit(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	aggregator := newLogAggregator(defaultLogCountAttribute, time.UTC, telemetryBuilder, nil)
+	aggregator.emitCountMetric = true
+	aggregator.countMetricKeyLimit = 2
+
+	resource := pcommon.NewResource()
+	scope := pcommon.NewInstrumentationScope()
+	for _, body := range []string{"one", "two", "three", "four"} {
+		aggregator.Add(resource, scope, generateTestLogRecord(t, body), nil)
+	}
+	aggregator.Export(context.Background())
+	aggregator.Reset()
+
+	// A known dedup key keeps its own series after the limit is reached
+	aggregator.Add(resource, scope, generateTestLogRecord(t, "one"), nil)
+	aggregator.Export(context.Background())
+
+	m, err := tel.GetMetric("otelcol_dedup_processor_log_count")
+	require.NoError(t, err)
+	sum := m.Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 3)
+	var overflow, total int64
+	for _, dp := range sum.DataPoints {
+		total += dp.Value
+		if _, ok := dp.Attributes.Value(overflowAttr); ok {
+			overflow = dp.Value
+		}
+	}
+	require.Equal(t, int64(2), overflow)
+	require.Equal(t, int64(5), total)
+}
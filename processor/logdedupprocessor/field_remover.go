@@ -26,6 +26,7 @@ type fieldRemover struct {
 
 // field represents a field and it's compound key to match on
 type field struct {
+	key      string
 	keyParts []string
 }
 
@@ -37,6 +38,7 @@ func newFieldRemover(fieldKeys []string) *fieldRemover {
 
 	for _, f := range fieldKeys {
 		fe.fields = append(fe.fields, &field{
+			key:      f,
 			keyParts: splitField(f),
 		})
 	}
@@ -51,6 +53,46 @@ func (fe *fieldRemover) RemoveFields(logRecord plog.LogRecord) {
 	}
 }
 
+// FieldValues returns the string values of the fields present in the log record, keyed by field
+func (fe *fieldRemover) FieldValues(logRecord plog.LogRecord) map[string]string {
+	var values map[string]string
+	for _, field := range fe.fields {
+		value, ok := field.getValue(logRecord)
+		if !ok {
+			continue
+		}
+		if values == nil {
+			values = make(map[string]string, len(fe.fields))
+		}
+		values[field.key] = value.AsString()
+	}
+	return values
+}
+
+// getValue returns the value of the field in the log record if it exists
+func (f *field) getValue(logRecord plog.LogRecord) (pcommon.Value, bool) {
+	firstPart, remainingParts := f.keyParts[0], f.keyParts[1:]
+
+	switch firstPart {
+	case bodyField:
+		if logRecord.Body().Type() == pcommon.ValueTypeMap && len(remainingParts) > 0 {
+			return getKeyValue(logRecord.Body().Map(), remainingParts)
+		}
+	case attributeField:
+		// The whole attribute map is excluded
+		if len(remainingParts) == 0 {
+			if logRecord.Attributes().Len() == 0 {
+				return pcommon.NewValueEmpty(), false
+			}
+			value := pcommon.NewValueMap()
+			logRecord.Attributes().CopyTo(value.Map())
+			return value, true
+		}
+		return getKeyValue(logRecord.Attributes(), remainingParts)
+	}
+	return pcommon.NewValueEmpty(), false
+}
+
 // removeField removes the field from the log record if it exists
 func (f *field) removeField(logRecord plog.LogRecord) {
 	firstPart, remainingParts := f.keyParts[0], f.keyParts[1:]
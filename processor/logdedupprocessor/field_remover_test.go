@@ -25,15 +25,19 @@ func Test_newFieldRemover(t *testing.T) {
 	expected := &fieldRemover{
 		fields: []*field{
 			{
+				key:      "single_field",
 				keyParts: []string{"single_field"},
 			},
 			{
+				key:      "compound.field.one",
 				keyParts: []string{"compound", "field", "one"},
 			},
 			{
+				key:      "escaped\\.field",
 				keyParts: []string{"escaped.field"},
 			},
 			{
+				key:      "escaped\\.compound.field",
 				keyParts: []string{"escaped.compound", "field"},
 			},
 		},
@@ -98,3 +102,17 @@ func TestRemoveFields(t *testing.T) {
 	require.Equal(t, expectedAttrHash, actualAttrHash)
 	require.Equal(t, expectedBodyHash, actualBodyHash)
 }
+
+func TestFieldValues(t *testing.T) {
+	remover := newFieldRemover([]string{"body.id", "attributes.str", "attributes.missing"})
+
+	logRecord := generateTestLogRecordWithMap(t)
+	logRecord.Body().Map().PutInt("id", 42)
+
+	require.Equal(t, map[string]string{
+		"body.id":        "42",
+		"attributes.str": "attr str",
+	}, remover.FieldValues(logRecord))
+
+	require.Nil(t, newFieldRemover([]string{"body.id"}).FieldValues(generateTestLogRecord(t, "not a map")))
+}
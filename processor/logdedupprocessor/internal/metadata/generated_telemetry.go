@@ -25,6 +25,7 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 type TelemetryBuilder struct {
 	meter                        metric.Meter
 	DedupProcessorAggregatedLogs metric.Int64Histogram
+	DedupProcessorLogCount       metric.Int64Counter
 	level                        configtelemetry.Level
 }
 
@@ -57,5 +58,11 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{records}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.DedupProcessorLogCount, err = builder.meter.Int64Counter(
+		"otelcol_dedup_processor_log_count",
+		metric.WithDescription("Number of log records per deduplication key. Only recorded when `emit_count_metric` is enabled."),
+		metric.WithUnit("{records}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
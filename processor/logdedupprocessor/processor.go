@@ -5,12 +5,14 @@ package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor"
@@ -32,6 +34,13 @@ type logDedupProcessor struct {
 	cancel       context.CancelFunc
 	wg           sync.WaitGroup
 	mux          sync.Mutex
+
+	id                 component.ID
+	storageID          *component.ID
+	storage            storage.Client
+	checkpointInterval time.Duration
+	dirty              bool
+	sampleFields       bool
 }
 
 func newProcessor(cfg *Config, nextConsumer consumer.Logs, settings processor.Settings) (*logDedupProcessor, error) {
@@ -46,17 +55,39 @@ func newProcessor(cfg *Config, nextConsumer consumer.Logs, settings processor.Se
 		return nil, fmt.Errorf("invalid timezone: %w", err)
 	}
 
+	aggregator := newLogAggregator(cfg.LogCountAttribute, timezone, telemetryBuilder, cfg.IncludeFields)
+	aggregator.sampleLimit = cfg.ExcludedFieldSamples
+	aggregator.emitCountMetric = cfg.EmitCountMetric
+	aggregator.countMetricKeyLimit = cfg.CountMetricKeyLimit
+
 	return &logDedupProcessor{
-		emitInterval: cfg.Interval,
-		aggregator:   newLogAggregator(cfg.LogCountAttribute, timezone, telemetryBuilder, cfg.IncludeFields),
-		remover:      newFieldRemover(cfg.ExcludeFields),
-		nextConsumer: nextConsumer,
-		logger:       settings.Logger,
+		emitInterval:       cfg.Interval,
+		aggregator:         aggregator,
+		remover:            newFieldRemover(cfg.ExcludeFields),
+		nextConsumer:       nextConsumer,
+		logger:             settings.Logger,
+		id:                 settings.ID,
+		storageID:          cfg.StorageID,
+		checkpointInterval: cfg.CheckpointInterval,
+		sampleFields:       cfg.ExcludedFieldSamples > 0,
 	}, nil
 }
 
 // Start starts the processor.
-func (p *logDedupProcessor) Start(ctx context.Context, _ component.Host) error {
+func (p *logDedupProcessor) Start(ctx context.Context, host component.Host) error {
+	if p.storageID != nil {
+		client, err := getStorageClient(ctx, host, *p.storageID, p.id)
+		if err != nil {
+			return fmt.Errorf("failed to get storage client: %w", err)
+		}
+		p.storage = client
+
+		if err := p.restoreAggregates(ctx); err != nil {
+			// Don't fail startup, the aggregates of the previous run are lost
+			p.logger.Error("failed to restore persisted aggregates", zap.Error(err))
+		}
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	p.cancel = cancel
 
@@ -72,12 +103,17 @@ func (p *logDedupProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Shutdown stops the processor.
-func (p *logDedupProcessor) Shutdown(_ context.Context) error {
+func (p *logDedupProcessor) Shutdown(ctx context.Context) error {
 	if p.cancel != nil {
 		// Call cancel to stop the export interval goroutine and wait for it to finish.
 		p.cancel()
 		p.wg.Wait()
 	}
+	if p.storage != nil {
+		// Persist the aggregates left if the final export failed, or clear the
+		// persisted ones if it succeeded
+		return errors.Join(p.checkpoint(ctx), p.storage.Close(ctx))
+	}
 	return nil
 }
 
@@ -127,8 +163,13 @@ func (p *logDedupProcessor) ConsumeLogs(ctx context.Context, pl plog.Logs) error
 }
 
 func (p *logDedupProcessor) aggregateLog(logRecord plog.LogRecord, scope pcommon.InstrumentationScope, resource pcommon.Resource) {
+	var samples map[string]string
+	if p.sampleFields {
+		samples = p.remover.FieldValues(logRecord)
+	}
 	p.remover.RemoveFields(logRecord)
-	p.aggregator.Add(resource, scope, logRecord)
+	p.aggregator.Add(resource, scope, logRecord, samples)
+	p.dirty = true
 }
 
 // handleExportInterval sends metrics at the configured interval.
@@ -138,33 +179,93 @@ func (p *logDedupProcessor) handleExportInterval(ctx context.Context) {
 	ticker := time.NewTicker(p.emitInterval)
 	defer ticker.Stop()
 
+	// checkpoints is only ticking if aggregates are persisted
+	var checkpoints <-chan time.Time
+	if p.storage != nil {
+		checkpointTicker := time.NewTicker(p.checkpointInterval)
+		defer checkpointTicker.Stop()
+		checkpoints = checkpointTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			// Export any remaining logs
-			p.exportLogs(ctx)
+			// Export any remaining logs, they are kept to be persisted on shutdown
+			// if the export fails
+			if err := p.exportLogs(context.WithoutCancel(ctx), p.storage == nil); err != nil {
+				p.logger.Error("failed to consume logs", zap.Error(err))
+			}
 			if err := ctx.Err(); err != context.Canceled {
 				p.logger.Error("context error", zap.Error(err))
 			}
 			return
 		case <-ticker.C:
-			p.exportLogs(ctx)
+			if err := p.exportLogs(ctx, true); err != nil {
+				p.logger.Error("failed to consume logs", zap.Error(err))
+			}
+			if p.storage == nil {
+				continue
+			}
+			// The exported aggregates must not be restored after a crash
+			if err := p.checkpoint(ctx); err != nil {
+				p.logger.Error("failed to persist aggregates", zap.Error(err))
+			}
+		case <-checkpoints:
+			if err := p.checkpoint(ctx); err != nil {
+				p.logger.Error("failed to persist aggregates", zap.Error(err))
+			}
 		}
 	}
 }
 
-// exportLogs exports the logs to the next consumer.
-func (p *logDedupProcessor) exportLogs(ctx context.Context) {
+// exportLogs exports the logs to the next consumer and resets the aggregates.
+// If the export fails, the aggregates are only reset if dropOnError is true.
+func (p *logDedupProcessor) exportLogs(ctx context.Context, dropOnError bool) error {
 	p.mux.Lock()
 	defer p.mux.Unlock()
 
+	var err error
 	logs := p.aggregator.Export(ctx)
 	// Only send logs if we have some
 	if logs.LogRecordCount() > 0 {
-		err := p.nextConsumer.ConsumeLogs(ctx, logs)
-		if err != nil {
-			p.logger.Error("failed to consume logs", zap.Error(err))
-		}
+		err = p.nextConsumer.ConsumeLogs(ctx, logs)
+	}
+	if err != nil && !dropOnError {
+		return err
 	}
 	p.aggregator.Reset()
+	p.dirty = true
+	return err
+}
+
+// checkpoint persists the in-flight aggregates if they changed since the last checkpoint.
+func (p *logDedupProcessor) checkpoint(ctx context.Context) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if !p.dirty {
+		return nil
+	}
+
+	buf, err := p.aggregator.marshalState()
+	if err != nil {
+		return err
+	}
+	if err := p.storage.Set(ctx, storageKey, buf); err != nil {
+		return err
+	}
+	p.dirty = false
+	return nil
+}
+
+// restoreAggregates loads the aggregates persisted by a previous run.
+func (p *logDedupProcessor) restoreAggregates(ctx context.Context) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	buf, err := p.storage.Get(ctx, storageKey)
+	if err != nil || buf == nil {
+		return err
+	}
+	return p.aggregator.unmarshalState(buf)
 }
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// storageKey is the key under which in-flight aggregates are persisted
+const storageKey = "aggregates"
+
+// aggregatorState is the persisted form of the in-flight aggregates.
+// Logs holds one log record per aggregate, Counters holds the counter of each of
+// those log records in the order they appear in Logs.
+type aggregatorState struct {
+	Logs     []byte         `json:"logs"`
+	Counters []counterState `json:"counters"`
+}
+
+// counterState is the persisted form of a logCounter
+type counterState struct {
+	Count          int64               `json:"count"`
+	FirstObserved  int64               `json:"first_observed"`
+	LastObserved   int64               `json:"last_observed"`
+	ExcludeSamples map[string][]string `json:"exclude_samples,omitempty"`
+}
+
+// getStorageClient returns the storage client of the configured storage extension
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// marshalState encodes the in-flight aggregates of the aggregator
+func (l *logAggregator) marshalState() ([]byte, error) {
+	logs := plog.NewLogs()
+	state := aggregatorState{}
+
+	for _, resourceAggregator := range l.resources {
+		rl := logs.ResourceLogs().AppendEmpty()
+		resourceAggregator.resource.CopyTo(rl.Resource())
+
+		for _, scopeAggregator := range resourceAggregator.scopeCounters {
+			sl := rl.ScopeLogs().AppendEmpty()
+			scopeAggregator.scope.CopyTo(sl.Scope())
+
+			for _, lc := range scopeAggregator.logCounters {
+				lc.logRecord.CopyTo(sl.LogRecords().AppendEmpty())
+				state.Counters = append(state.Counters, counterState{
+					Count:          lc.count,
+					FirstObserved:  lc.firstObservedTimestamp.UnixNano(),
+					LastObserved:   lc.lastObservedTimestamp.UnixNano(),
+					ExcludeSamples: lc.samples,
+				})
+			}
+		}
+	}
+
+	var err error
+	if state.Logs, err = (&plog.ProtoMarshaler{}).MarshalLogs(logs); err != nil {
+		return nil, err
+	}
+	return json.Marshal(state)
+}
+
+// unmarshalState restores in-flight aggregates encoded by marshalState into the aggregator
+func (l *logAggregator) unmarshalState(buf []byte) error {
+	var state aggregatorState
+	if err := json.Unmarshal(buf, &state); err != nil {
+		return err
+	}
+
+	logs, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(state.Logs)
+	if err != nil {
+		return err
+	}
+	if logs.LogRecordCount() != len(state.Counters) {
+		return fmt.Errorf("persisted state holds %d log records but %d counters", logs.LogRecordCount(), len(state.Counters))
+	}
+
+	i := 0
+	for _, rl := range logs.ResourceLogs().All() {
+		resourceAggregator := l.resourceAggregator(rl.Resource())
+		for _, sl := range rl.ScopeLogs().All() {
+			scopeAggregator := resourceAggregator.scopeAggregator(sl.Scope())
+			for _, logRecord := range sl.LogRecords().All() {
+				counter := state.Counters[i]
+				i++
+
+				key := getLogKey(logRecord, l.dedupFields)
+				scopeAggregator.logCounters[key] = &logCounter{
+					logRecord:              logRecord,
+					key:                    key,
+					firstObservedTimestamp: time.Unix(0, counter.FirstObserved).UTC(),
+					lastObservedTimestamp:  time.Unix(0, counter.LastObserved).UTC(),
+					count:                  counter.Count,
+					samples:                counter.ExcludeSamples,
+				}
+			}
+		}
+	}
+	return nil
+}
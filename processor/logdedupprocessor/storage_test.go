@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/metadata"
+)
+
+func newStorageTestProcessor(t *testing.T, cfg *Config, sink *consumertest.LogsSink) *logDedupProcessor {
+	t.Helper()
+	p, err := newProcessor(cfg, sink, processortest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	return p
+}
+
+func TestProcessorRestoresPersistedAggregates(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("dedup", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = time.Hour
+	cfg.ExcludeFields = []string{"attributes.id"}
+	cfg.ExcludedFieldSamples = 5
+	cfg.StorageID = &ext.ID
+
+	logs := func(id string) plog.Logs {
+		ld := plog.NewLogs()
+		lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.Body().SetStr("connection refused")
+		lr.Attributes().PutStr("id", id)
+		return ld
+	}
+
+	// first run crashes after a checkpoint, without exporting its aggregates
+	first := newStorageTestProcessor(t, cfg, &consumertest.LogsSink{})
+	require.NoError(t, first.Start(ctx, host))
+	require.NoError(t, first.ConsumeLogs(ctx, logs("1")))
+	require.NoError(t, first.ConsumeLogs(ctx, logs("2")))
+	require.NoError(t, first.checkpoint(ctx))
+	first.cancel()
+	first.wg.Wait()
+	require.NoError(t, first.storage.Close(ctx))
+
+	sink := &consumertest.LogsSink{}
+	second := newStorageTestProcessor(t, cfg, sink)
+	require.NoError(t, second.Start(ctx, host))
+	require.NoError(t, second.ConsumeLogs(ctx, logs("3")))
+	require.NoError(t, second.Shutdown(ctx))
+
+	require.Equal(t, 1, sink.LogRecordCount())
+	attrs := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	require.Equal(t, int64(3), attrs[defaultLogCountAttribute])
+	require.Equal(t, map[string]any{"attributes.id": []any{"1", "2", "3"}}, attrs[excludedFieldSamplesAttr])
+}
+
+func TestProcessorClearsPersistedAggregatesOnExport(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("dedup", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = time.Hour
+	cfg.StorageID = &ext.ID
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("boom")
+
+	first := newStorageTestProcessor(t, cfg, &consumertest.LogsSink{})
+	require.NoError(t, first.Start(ctx, host))
+	require.NoError(t, first.ConsumeLogs(ctx, ld))
+	require.NoError(t, first.Shutdown(ctx))
+
+	sink := &consumertest.LogsSink{}
+	second := newStorageTestProcessor(t, cfg, sink)
+	require.NoError(t, second.Start(ctx, host))
+	require.NoError(t, second.Shutdown(ctx))
+	require.Zero(t, sink.LogRecordCount())
+}
+
+func TestProcessorPersistsAggregatesOnFailedExport(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("dedup", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = time.Hour
+	cfg.StorageID = &ext.ID
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("boom")
+
+	first, err := newProcessor(cfg, consumertest.NewErr(errors.New("unavailable")), processortest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	require.NoError(t, first.Start(ctx, host))
+	require.NoError(t, first.ConsumeLogs(ctx, ld))
+	require.NoError(t, first.Shutdown(ctx))
+
+	sink := &consumertest.LogsSink{}
+	second := newStorageTestProcessor(t, cfg, sink)
+	require.NoError(t, second.Start(ctx, host))
+	require.NoError(t, second.Shutdown(ctx))
+	require.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestProcessorCheckpointsAfterExport(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("dedup", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+	cfg.CheckpointInterval = time.Hour
+	cfg.StorageID = &ext.ID
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("boom")
+
+	// first run crashes after exporting its aggregates
+	sink := &consumertest.LogsSink{}
+	first := newStorageTestProcessor(t, cfg, sink)
+	require.NoError(t, first.Start(ctx, host))
+	require.NoError(t, first.ConsumeLogs(ctx, ld))
+	require.NoError(t, first.checkpoint(ctx))
+	require.Eventually(t, func() bool {
+		first.mux.Lock()
+		defer first.mux.Unlock()
+		return sink.LogRecordCount() == 1 && !first.dirty
+	}, 5*time.Second, 10*time.Millisecond)
+	first.cancel()
+	first.wg.Wait()
+	require.NoError(t, first.storage.Close(ctx))
+
+	// the exported aggregates are not restored
+	sink = &consumertest.LogsSink{}
+	second := newStorageTestProcessor(t, cfg, sink)
+	require.NoError(t, second.Start(ctx, host))
+	require.NoError(t, second.Shutdown(ctx))
+	require.Zero(t, sink.LogRecordCount())
+}
+
+func TestProcessorCheckpointsAtInterval(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("dedup", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = time.Hour
+	cfg.CheckpointInterval = 10 * time.Millisecond
+	cfg.StorageID = &ext.ID
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("boom")
+
+	p := newStorageTestProcessor(t, cfg, &consumertest.LogsSink{})
+	require.NoError(t, p.Start(ctx, host))
+	require.NoError(t, p.ConsumeLogs(ctx, ld))
+	require.Eventually(t, func() bool {
+		p.mux.Lock()
+		defer p.mux.Unlock()
+		return !p.dirty
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(ctx))
+}
+
+func TestProcessorStartMissingStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	p := newStorageTestProcessor(t, cfg, &consumertest.LogsSink{})
+	err := p.Start(context.Background(), storagetest.NewStorageHost())
+	require.ErrorContains(t, err, "storage extension 'test_storage/missing' not found")
+	require.NoError(t, p.Shutdown(context.Background()))
+}
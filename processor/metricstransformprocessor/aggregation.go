@@ -0,0 +1,399 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricstransformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/aggregateutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// aggregation is the parsed form of an AggregationConfig.
+type aggregation struct {
+	conditions      *ottl.ConditionSequence[ottldatapoint.TransformContext]
+	groupBy         []groupByExpression
+	resourceGroupBy []groupByExpression
+	aggregationType aggregateutil.AggregationType
+	newName         string
+}
+
+// groupByExpression is an attribute set to the value of an OTTL expression.
+type groupByExpression struct {
+	key  string
+	expr *ottl.ValueExpression[ottldatapoint.TransformContext]
+}
+
+// aggregatedMetric collects the data points of a group before they are merged.
+type aggregatedMetric struct {
+	metric pmetric.Metric
+	// sm is the destination of the metric when aggregating within a resource.
+	sm pmetric.ScopeMetrics
+	// resourceKey identifies the destination resource when aggregating across resources.
+	resourceKey   string
+	resourceAttrs pcommon.Map
+	scope         pcommon.InstrumentationScope
+}
+
+func newAggregations(configs []AggregationConfig, set component.TelemetrySettings) ([]*aggregation, error) {
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	parser, err := ottldatapoint.NewParser(ottlfuncs.StandardConverters[ottldatapoint.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregations := make([]*aggregation, len(configs))
+	for i, cfg := range configs {
+		conditions, err := parser.ParseConditions(cfg.Conditions)
+		if err != nil {
+			return nil, fmt.Errorf("aggregation %v: %q, %w", i+1, conditionsFieldName, err)
+		}
+		cs := ottldatapoint.NewConditionSequence(conditions, set, ottldatapoint.WithConditionSequenceErrorMode(ottl.IgnoreError))
+
+		groupBy, err := parseGroupBy(&parser, cfg.GroupBy)
+		if err != nil {
+			return nil, fmt.Errorf("aggregation %v: %q, %w", i+1, "group_by", err)
+		}
+		resourceGroupBy, err := parseGroupBy(&parser, cfg.ResourceGroupBy)
+		if err != nil {
+			return nil, fmt.Errorf("aggregation %v: %q, %w", i+1, "resource_group_by", err)
+		}
+
+		aggType := cfg.AggregationType
+		if aggType == "" {
+			aggType = aggregateutil.Sum
+		}
+		aggregations[i] = &aggregation{
+			conditions:      &cs,
+			groupBy:         groupBy,
+			resourceGroupBy: resourceGroupBy,
+			aggregationType: aggType,
+			newName:         cfg.NewName,
+		}
+	}
+	return aggregations, nil
+}
+
+// parseGroupBy parses the expressions of the group by attributes, sorted by attribute for a stable output.
+func parseGroupBy(parser *ottl.Parser[ottldatapoint.TransformContext], groupBy map[string]string) ([]groupByExpression, error) {
+	expressions := make([]groupByExpression, 0, len(groupBy))
+	for key, raw := range groupBy {
+		expr, err := parser.ParseValueExpression(raw)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, groupByExpression{key: key, expr: expr})
+	}
+	sort.Slice(expressions, func(i, j int) bool {
+		return expressions[i].key < expressions[j].key
+	})
+	return expressions, nil
+}
+
+// apply replaces the data points selected by the aggregation with the aggregated
+// data points of their group.
+func (a *aggregation) apply(ctx context.Context, md pmetric.Metrics, logger *zap.Logger) {
+	groups := map[string]*aggregatedMetric{}
+	var order []*aggregatedMetric
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			sm.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+				if metric.Type() == pmetric.MetricTypeEmpty || metric.Type() == pmetric.MetricTypeSummary {
+					return false
+				}
+
+				removed := false
+				destination := func(dp any, attrs pcommon.Map) (pmetric.Metric, bool) {
+					tCtx := ottldatapoint.NewTransformContext(dp, metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)
+					matched, err := a.conditions.Eval(ctx, tCtx)
+					if err != nil || !matched {
+						return pmetric.Metric{}, false
+					}
+					groupAttrs, err := evalGroupBy(ctx, tCtx, a.groupBy)
+					if err != nil {
+						// TODO: report via trace / metric instead
+						logger.Warn("failed to evaluate the group_by of an aggregation", zap.Error(err))
+						return pmetric.Metric{}, false
+					}
+					resourceAttrs, err := evalGroupBy(ctx, tCtx, a.resourceGroupBy)
+					if err != nil {
+						logger.Warn("failed to evaluate the resource_group_by of an aggregation", zap.Error(err))
+						return pmetric.Metric{}, false
+					}
+
+					group := a.group(groups, &order, metric, sm, i, j, resourceAttrs)
+					groupAttrs.MoveTo(attrs)
+					removed = true
+					return group.metric, true
+				}
+
+				//exhaustive:enforce
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					metric.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						return moveDataPoint(dp, destination, func(to pmetric.Metric) pmetric.NumberDataPoint {
+							return to.Gauge().DataPoints().AppendEmpty()
+						})
+					})
+					return removed && metric.Gauge().DataPoints().Len() == 0
+				case pmetric.MetricTypeSum:
+					metric.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						return moveDataPoint(dp, destination, func(to pmetric.Metric) pmetric.NumberDataPoint {
+							return to.Sum().DataPoints().AppendEmpty()
+						})
+					})
+					return removed && metric.Sum().DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					metric.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+						return moveDataPoint(dp, destination, func(to pmetric.Metric) pmetric.HistogramDataPoint {
+							return to.Histogram().DataPoints().AppendEmpty()
+						})
+					})
+					return removed && metric.Histogram().DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					metric.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+						return moveDataPoint(dp, destination, func(to pmetric.Metric) pmetric.ExponentialHistogramDataPoint {
+							return to.ExponentialHistogram().DataPoints().AppendEmpty()
+						})
+					})
+					return removed && metric.ExponentialHistogram().DataPoints().Len() == 0
+				case pmetric.MetricTypeEmpty, pmetric.MetricTypeSummary:
+				}
+				return false
+			})
+		}
+	}
+
+	if len(order) == 0 {
+		return
+	}
+
+	resources := map[string]pmetric.ResourceMetrics{}
+	scopes := map[string]pmetric.ScopeMetrics{}
+	for _, group := range order {
+		sm := group.sm
+		if group.resourceKey != "" {
+			rm, ok := resources[group.resourceKey]
+			if !ok {
+				rm = rms.AppendEmpty()
+				group.resourceAttrs.CopyTo(rm.Resource().Attributes())
+				resources[group.resourceKey] = rm
+			}
+			scopeKey := group.resourceKey + "\x00" + group.scope.Name() + "\x00" + group.scope.Version()
+			if sm, ok = scopes[scopeKey]; !ok {
+				sm = rm.ScopeMetrics().AppendEmpty()
+				group.scope.CopyTo(sm.Scope())
+				scopes[scopeKey] = sm
+			}
+		}
+		merged := mergeAggregatedMetric(group.metric, a.aggregationType)
+		if group.resourceKey == "" {
+			// The data points that weren't selected stay in their metric, the aggregated
+			// data points join them rather than adding a metric with the same name.
+			if existing, ok := findMetric(sm.Metrics(), merged); ok {
+				moveDataPoints(merged, existing)
+				continue
+			}
+		}
+		merged.MoveTo(sm.Metrics().AppendEmpty())
+	}
+
+	rms.RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// group returns the aggregated metric collecting the data points of the metric
+// belonging to the group identified by the resource attributes.
+func (a *aggregation) group(groups map[string]*aggregatedMetric, order *[]*aggregatedMetric, metric pmetric.Metric,
+	sm pmetric.ScopeMetrics, rmIdx, smIdx int, resourceAttrs pcommon.Map,
+) *aggregatedMetric {
+	name := metric.Name()
+	if a.newName != "" {
+		name = a.newName
+	}
+
+	var resourceKey, key string
+	metricKey := metricIdentity(name, metric)
+	if len(a.resourceGroupBy) > 0 {
+		raw, _ := json.Marshal(resourceAttrs.AsRaw())
+		resourceKey = string(raw)
+		key = fmt.Sprintf("%s\x00%s\x00%s\x00%s", resourceKey, sm.Scope().Name(), sm.Scope().Version(), metricKey)
+	} else {
+		key = fmt.Sprintf("%d\x00%d\x00%s", rmIdx, smIdx, metricKey)
+	}
+
+	if group, ok := groups[key]; ok {
+		return group
+	}
+
+	group := &aggregatedMetric{
+		metric:        pmetric.NewMetric(),
+		resourceKey:   resourceKey,
+		resourceAttrs: resourceAttrs,
+		scope:         sm.Scope(),
+	}
+	if resourceKey == "" {
+		group.sm = sm
+	} else {
+		// The resource of the data points must not be shared with the aggregated resource.
+		group.resourceAttrs = pcommon.NewMap()
+		resourceAttrs.CopyTo(group.resourceAttrs)
+		group.scope = pcommon.NewInstrumentationScope()
+		sm.Scope().CopyTo(group.scope)
+	}
+	aggregateutil.CopyMetricDetails(metric, group.metric)
+	group.metric.SetName(name)
+	groups[key] = group
+	*order = append(*order, group)
+	return group
+}
+
+// metricIdentity returns a key identifying the metrics of the given name that can be aggregated together.
+func metricIdentity(name string, metric pmetric.Metric) string {
+	key := fmt.Sprintf("%s\x00%s\x00%s", name, metric.Type(), metric.Unit())
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		key += fmt.Sprintf("\x00%s\x00%t", metric.Sum().AggregationTemporality(), metric.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		key += fmt.Sprintf("\x00%s", metric.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		key += fmt.Sprintf("\x00%s", metric.ExponentialHistogram().AggregationTemporality())
+	}
+	return key
+}
+
+// findMetric returns the metric of the slice having the same identity as the given metric.
+func findMetric(metrics pmetric.MetricSlice, like pmetric.Metric) (pmetric.Metric, bool) {
+	identity := metricIdentity(like.Name(), like)
+	for i := 0; i < metrics.Len(); i++ {
+		if metricIdentity(metrics.At(i).Name(), metrics.At(i)) == identity {
+			return metrics.At(i), true
+		}
+	}
+	return pmetric.Metric{}, false
+}
+
+// moveDataPoints moves the data points of a metric to another metric of the same type.
+func moveDataPoints(from, to pmetric.Metric) {
+	//exhaustive:enforce
+	switch from.Type() {
+	case pmetric.MetricTypeGauge:
+		from.Gauge().DataPoints().MoveAndAppendTo(to.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		from.Sum().DataPoints().MoveAndAppendTo(to.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		from.Histogram().DataPoints().MoveAndAppendTo(to.Histogram().DataPoints())
+	case pmetric.MetricTypeExponentialHistogram:
+		from.ExponentialHistogram().DataPoints().MoveAndAppendTo(to.ExponentialHistogram().DataPoints())
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeSummary:
+	}
+}
+
+// moveDataPoint moves the data point to the metric returned by destination, if any.
+func moveDataPoint[DP interface {
+	Attributes() pcommon.Map
+	MoveTo(DP)
+}](dp DP, destination func(any, pcommon.Map) (pmetric.Metric, bool), appendEmpty func(pmetric.Metric) DP) bool {
+	attrs := pcommon.NewMap()
+	to, ok := destination(dp, attrs)
+	if !ok {
+		return false
+	}
+	attrs.MoveTo(dp.Attributes())
+	dp.MoveTo(appendEmpty(to))
+	return true
+}
+
+// evalGroupBy returns the attributes set to the values of the group by expressions.
+// An expression evaluating to nil doesn't set its attribute.
+func evalGroupBy(ctx context.Context, tCtx ottldatapoint.TransformContext, groupBy []groupByExpression) (pcommon.Map, error) {
+	attrs := pcommon.NewMap()
+	for _, g := range groupBy {
+		val, err := g.expr.Eval(ctx, tCtx)
+		if err != nil {
+			return pcommon.Map{}, err
+		}
+		switch v := val.(type) {
+		case nil:
+		case pcommon.Value:
+			v.CopyTo(attrs.PutEmpty(g.key))
+		case pcommon.Map:
+			v.CopyTo(attrs.PutEmptyMap(g.key))
+		case pcommon.Slice:
+			v.CopyTo(attrs.PutEmptySlice(g.key))
+		default:
+			if err := attrs.PutEmpty(g.key).FromRaw(v); err != nil {
+				return pcommon.Map{}, fmt.Errorf("attribute %q: %w", g.key, err)
+			}
+		}
+	}
+	return attrs, nil
+}
+
+// mergeAggregatedMetric returns the metric with the data points of each group
+// merged, after aligning their timestamps to the latest one.
+func mergeAggregatedMetric(metric pmetric.Metric, aggType aggregateutil.AggregationType) pmetric.Metric {
+	alignTimestamps(metric)
+
+	var ag aggregateutil.AggGroups
+	aggregateutil.GroupDataPoints(metric, &ag)
+	merged := pmetric.NewMetric()
+	aggregateutil.CopyMetricDetails(metric, merged)
+	aggregateutil.MergeDataPoints(merged, aggType, ag)
+	return merged
+}
+
+// alignTimestamps sets the timestamp of all the data points of the metric to the latest one.
+func alignTimestamps(metric pmetric.Metric) {
+	//exhaustive:enforce
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		alignDataPointTimestamps(dps.Len(), dps.At)
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		alignDataPointTimestamps(dps.Len(), dps.At)
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		alignDataPointTimestamps(dps.Len(), dps.At)
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		alignDataPointTimestamps(dps.Len(), dps.At)
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeSummary:
+	}
+}
+
+func alignDataPointTimestamps[DP interface {
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}](n int, at func(int) DP) {
+	var latest pcommon.Timestamp
+	for i := 0; i < n; i++ {
+		latest = max(latest, at(i).Timestamp())
+	}
+	for i := 0; i < n; i++ {
+		at(i).SetTimestamp(latest)
+	}
+}
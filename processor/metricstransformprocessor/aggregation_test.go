@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor/internal/metadata"
+)
+
+func TestMetricsAggregation(t *testing.T) {
+	tests := []struct {
+		name         string
+		aggregations []AggregationConfig
+	}{
+		{
+			name: "across_resources",
+			aggregations: []AggregationConfig{
+				{
+					Conditions: []string{`metric.name == "http.server.request.duration"`},
+					GroupBy: map[string]string{
+						"http.route": `attributes["http.route"]`,
+					},
+					ResourceGroupBy: map[string]string{
+						"k8s.deployment.name": `resource.attributes["k8s.deployment.name"]`,
+					},
+				},
+			},
+		},
+		{
+			name: "within_resource",
+			aggregations: []AggregationConfig{
+				{
+					Conditions:      []string{`metric.name == "container.cpu.usage" and attributes["k8s.container.name"] != "init"`},
+					AggregationType: "max",
+					NewName:         "k8s.pod.cpu.usage",
+				},
+			},
+		},
+		{
+			name: "within_resource_keep_name",
+			aggregations: []AggregationConfig{
+				{
+					Conditions:      []string{`metric.name == "container.cpu.usage" and attributes["k8s.container.name"] != "init"`},
+					AggregationType: "max",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := new(consumertest.MetricsSink)
+
+			aggregations, err := newAggregations(test.aggregations, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			p := &metricsTransformProcessor{
+				aggregations: aggregations,
+				logger:       zap.NewExample(),
+			}
+
+			mtp, err := processorhelper.NewMetrics(
+				context.Background(),
+				processortest.NewNopSettings(metadata.Type),
+				&Config{},
+				next, p.processMetrics, processorhelper.WithCapabilities(consumerCapabilities))
+			require.NoError(t, err)
+
+			input, err := golden.ReadMetrics(filepath.Join("testdata", "aggregation", test.name+"_in.yaml"))
+			require.NoError(t, err)
+			assert.NoError(t, mtp.ConsumeMetrics(context.Background(), input))
+
+			expected, err := golden.ReadMetrics(filepath.Join("testdata", "aggregation", test.name+"_out.yaml"))
+			require.NoError(t, err)
+			got := next.AllMetrics()
+			require.Len(t, got, 1)
+			require.NoError(t, pmetrictest.CompareMetrics(expected, got[0], pmetrictest.IgnoreMetricDataPointsOrder()))
+
+			assert.NoError(t, mtp.Shutdown(context.Background()))
+		})
+	}
+}
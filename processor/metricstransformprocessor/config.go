@@ -38,6 +38,9 @@ const (
 
 	// submatchCaseFieldName is the mapstructure field name for submatchCase field
 	submatchCaseFieldName = "submatch_case"
+
+	// conditionsFieldName is the mapstructure field name for Conditions field
+	conditionsFieldName = "conditions"
 )
 
 // Config defines configuration for Resource processor.
@@ -45,6 +48,10 @@ type Config struct {
 	// transform specifies a list of transforms on metrics with each transform focusing on one metric.
 	Transforms []transform `mapstructure:"transforms"`
 
+	// Aggregations specifies a list of aggregations of the data points selected and grouped with OTTL expressions.
+	// They are applied in order after the transforms.
+	Aggregations []AggregationConfig `mapstructure:"aggregations"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -87,6 +94,35 @@ type transform struct {
 	Operations []Operation `mapstructure:"operations"`
 }
 
+// AggregationConfig defines the aggregation of the data points selected by OTTL conditions
+// into the data points of the groups defined by OTTL value expressions.
+type AggregationConfig struct {
+	// Conditions is a list of OTTL conditions in the datapoint context selecting the data points to aggregate.
+	// A data point is selected if any of the conditions is true.
+	// REQUIRED
+	Conditions []string `mapstructure:"conditions"`
+
+	// GroupBy maps the attributes of the aggregated data points to the OTTL value expressions,
+	// in the datapoint context, they are set to. All other attributes are aggregated away.
+	GroupBy map[string]string `mapstructure:"group_by"`
+
+	// ResourceGroupBy maps the attributes of the resource of the aggregated data points to the OTTL
+	// value expressions, in the datapoint context, they are set to. If set, data points are aggregated
+	// across resources and all other resource attributes are dropped; otherwise, data points are
+	// aggregated within their resource and scope.
+	ResourceGroupBy map[string]string `mapstructure:"resource_group_by"`
+
+	// AggregationType specifies how to aggregate the values of gauges and sums, defaults to sum.
+	// Histograms and exponential histograms are always merged bucket-wise.
+	AggregationType aggregateutil.AggregationType `mapstructure:"aggregation_type"`
+
+	// NewName specifies the name of the aggregated metrics, defaults to the name of the selected metrics.
+	NewName string `mapstructure:"new_name"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 type FilterConfig struct {
 	// Include specifies the metric(s) to operate on.
 	Include string `mapstructure:"include"`
@@ -143,6 +143,31 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configFile: "config_full.yaml",
+			id:         component.NewIDWithName(metadata.Type, "aggregations"),
+			expected: &Config{
+				Aggregations: []AggregationConfig{
+					{
+						Conditions: []string{`metric.name == "http.server.request.duration"`},
+						GroupBy: map[string]string{
+							"http.route": `attributes["http.route"]`,
+						},
+						ResourceGroupBy: map[string]string{
+							"k8s.deployment.name": `resource.attributes["k8s.deployment.name"]`,
+						},
+						NewName: "http.server.request.duration.by_deployment",
+					},
+					{
+						Conditions: []string{
+							`metric.name == "k8s.pod.cpu.usage"`,
+							`metric.name == "k8s.pod.memory.usage"`,
+						},
+						AggregationType: "max",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
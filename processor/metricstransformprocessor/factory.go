@@ -48,6 +48,10 @@ func createMetricsProcessor(
 		return nil, err
 	}
 	metricsProcessor := newMetricsTransformProcessor(set.Logger, hCfg)
+	metricsProcessor.aggregations, err = newAggregations(oCfg.Aggregations, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
 
 	return processorhelper.NewMetrics(
 		ctx,
@@ -120,6 +124,16 @@ func validateConfiguration(config *Config) error {
 			}
 		}
 	}
+
+	for i, agg := range config.Aggregations {
+		if len(agg.Conditions) == 0 {
+			return fmt.Errorf("aggregation %v: missing required field %q", i+1, conditionsFieldName)
+		}
+
+		if agg.AggregationType != "" && !agg.AggregationType.IsValid() {
+			return fmt.Errorf("aggregation %v: %q must be in %q", i+1, aggregationTypeFieldName, aggregateutil.AggregationTypes)
+		}
+	}
 	return nil
 }
 
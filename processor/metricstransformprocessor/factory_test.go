@@ -105,6 +105,22 @@ func TestCreateProcessors(t *testing.T) {
 			succeed:      false,
 			errorMessage: fmt.Sprintf("%q must be in %q", submatchCaseFieldName, submatchCases),
 		},
+		{
+			configName:   "config_invalid_aggregation_conditions.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("aggregation %v: missing required field %q", 1, conditionsFieldName),
+		},
+		{
+			configName:   "config_invalid_aggregation_aggregationtype.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("aggregation %v: %q must be in %q", 1, aggregationTypeFieldName, aggregateutil.AggregationTypes),
+		},
+		{
+			configName: "config_invalid_aggregation_group_by.yaml",
+			succeed:    false,
+			errorMessage: fmt.Sprintf("aggregation %v: %q, %s", 1, "group_by",
+				`expression has invalid syntax: 1:21: unexpected token "<EOF>" (expected Field ("." Field)*)`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -16,6 +16,7 @@ import (
 
 type metricsTransformProcessor struct {
 	transforms               []internalTransform
+	aggregations             []*aggregation
 	logger                   *zap.Logger
 	otlpDataModelGateEnabled bool
 }
@@ -227,7 +227,7 @@ func matchAttrs(attrMatchers map[string]StringMatcher, attrs pcommon.Map) bool {
 	return true
 }
 
-func (mtp *metricsTransformProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (mtp *metricsTransformProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	rms := md.ResourceMetrics()
 	groupedRMs := pmetric.NewResourceMetricsSlice()
 
@@ -296,6 +296,10 @@ func (mtp *metricsTransformProcessor) processMetrics(_ context.Context, md pmetr
 
 	groupedRMs.MoveAndAppendTo(rms)
 
+	for _, agg := range mtp.aggregations {
+		agg.apply(ctx, md, mtp.logger)
+	}
+
 	return md, nil
 }
 
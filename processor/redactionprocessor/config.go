@@ -7,7 +7,10 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+
+	"go.opentelemetry.io/collector/config/configopaque"
 )
 
 var _ encoding.TextUnmarshaler = (*HashFunction)(nil)
@@ -19,8 +22,23 @@ const (
 	SHA1 HashFunction = "sha1"
 	SHA3 HashFunction = "sha3"
 	MD5  HashFunction = "md5"
+	// HMACSHA256 and HMACSHA512 are keyed hash functions, the values cannot be
+	// brute-forced without the key.
+	HMACSHA256 HashFunction = "hmac-sha256"
+	HMACSHA512 HashFunction = "hmac-sha512"
+	// AES replaces values with a deterministic AES-GCM encryption of the value,
+	// which can be decrypted with the key.
+	AES HashFunction = "aes"
 )
 
+// hashFunctions lists the supported hash functions.
+var hashFunctions = []HashFunction{SHA1, SHA3, MD5, HMACSHA256, HMACSHA512, AES}
+
+// isKeyed returns whether the hash function requires a key.
+func (u HashFunction) isKeyed() bool {
+	return u == HMACSHA256 || u == HMACSHA512 || u == AES
+}
+
 type Config struct {
 	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
 	// to true disables the AllowedKeys list. The list of BlockedValues is
@@ -41,6 +59,11 @@ type Config struct {
 	// and masking with a fixed string is performed.
 	HashFunction HashFunction `mapstructure:"hash_function"`
 
+	// HashKey is the secret key of the keyed hash functions `hmac-sha256`,
+	// `hmac-sha512` and `aes`. It is required by these functions and should
+	// be provided from the environment, e.g. `${env:REDACTION_KEY}`.
+	HashKey configopaque.String `mapstructure:"hash_key"`
+
 	// IgnoredKeys is a list of span attribute keys that are not redacted.
 	// Span attributes in this list are allowed to pass through the filter
 	// without being changed or removed.
@@ -54,6 +77,15 @@ type Config struct {
 	// blocked span attributes. Values that match are not masked.
 	AllowedValues []string `mapstructure:"allowed_values"`
 
+	// Detectors is a list of built-in detectors of sensitive values, applied
+	// like BlockedValues. See the README for the available detectors.
+	Detectors []string `mapstructure:"detectors"`
+
+	// Sanitizers is a list of value-aware sanitizers redacting the sensitive
+	// parts of the values of the given attribute keys, span names or span
+	// event names, instead of masking the whole value.
+	Sanitizers []SanitizerConfig `mapstructure:"sanitizers"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -62,6 +94,70 @@ type Config struct {
 	Summary string `mapstructure:"summary"`
 }
 
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.HashFunction.isKeyed() && cfg.HashKey == "" {
+		return fmt.Errorf("hash_key must be set when using the %s hash function", cfg.HashFunction)
+	}
+	if !cfg.HashFunction.isKeyed() && cfg.HashKey != "" {
+		return fmt.Errorf("hash_key is only supported by the %s, %s and %s hash functions", HMACSHA256, HMACSHA512, AES)
+	}
+	for _, name := range cfg.Detectors {
+		if _, ok := detectors[name]; !ok {
+			return fmt.Errorf("unknown detector %q, available detectors are %s", name, strings.Join(detectorNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// SanitizerType is the kind of values a sanitizer redacts.
+type SanitizerType string
+
+const (
+	// URLSanitizer masks the password and the query parameter values of URLs.
+	URLSanitizer SanitizerType = "url"
+	// SQLSanitizer replaces the literals of SQL statements with a placeholder.
+	SQLSanitizer SanitizerType = "sql"
+	// HTTPHeaderSanitizer masks the credentials of HTTP header values.
+	HTTPHeaderSanitizer SanitizerType = "http_header"
+)
+
+// SanitizerConfig defines a sanitizer and the values it applies to.
+type SanitizerConfig struct {
+	// Type is the sanitizer type, one of `url`, `sql` and `http_header`.
+	Type SanitizerType `mapstructure:"type"`
+
+	// Keys is a list of attribute keys whose values are sanitized.
+	Keys []string `mapstructure:"keys"`
+
+	// SpanNames sanitizes the span names.
+	SpanNames bool `mapstructure:"span_names"`
+
+	// EventNames sanitizes the span event names.
+	EventNames bool `mapstructure:"event_names"`
+
+	// QueryParams is a list of query parameters whose values are masked by
+	// the url sanitizer. The values of all the parameters are masked if the
+	// list is empty.
+	QueryParams []string `mapstructure:"query_params"`
+}
+
+// Validate checks if the sanitizer configuration is valid.
+func (cfg *SanitizerConfig) Validate() error {
+	switch cfg.Type {
+	case URLSanitizer, SQLSanitizer, HTTPHeaderSanitizer:
+	default:
+		return fmt.Errorf("unknown sanitizer type %q, available types are %s, %s and %s", cfg.Type, URLSanitizer, SQLSanitizer, HTTPHeaderSanitizer)
+	}
+	if len(cfg.Keys) == 0 && !cfg.SpanNames && !cfg.EventNames {
+		return fmt.Errorf("the %s sanitizer must apply to keys, span names or event names", cfg.Type)
+	}
+	if len(cfg.QueryParams) > 0 && cfg.Type != URLSanitizer {
+		return fmt.Errorf("query_params is only supported by the %s sanitizer", URLSanitizer)
+	}
+	return nil
+}
+
 func (u HashFunction) String() string {
 	return string(u)
 }
@@ -73,19 +169,13 @@ func (u *HashFunction) UnmarshalText(text []byte) error {
 	}
 
 	str := strings.ToLower(string(text))
-	switch str {
-	case strings.ToLower(SHA1.String()):
-		*u = SHA1
-		return nil
-	case strings.ToLower(MD5.String()):
-		*u = MD5
-		return nil
-	case strings.ToLower(SHA3.String()):
-		*u = SHA3
-		return nil
-	case strings.ToLower(None.String()):
+	if str == None.String() {
 		*u = None
 		return nil
 	}
-	return fmt.Errorf("unknown HashFunction %s, allowed functions are %s, %s and %s", str, SHA1, SHA3, MD5)
+	if i := slices.Index(hashFunctions, HashFunction(str)); i >= 0 {
+		*u = hashFunctions[i]
+		return nil
+	}
+	return fmt.Errorf("unknown HashFunction %s, allowed functions are %s, %s, %s, %s, %s and %s", str, SHA1, SHA3, MD5, HMACSHA256, HMACSHA512, AES)
 }
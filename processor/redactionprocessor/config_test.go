@@ -41,6 +41,26 @@ func TestLoadConfig(t *testing.T) {
 			id:       component.NewIDWithName(metadata.Type, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "detectors"),
+			expected: &Config{
+				AllowAllKeys: true,
+				Detectors:    []string{"email", "credit_card", "iban"},
+				HashFunction: HMACSHA256,
+				HashKey:      "my-secret-key",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "sanitizers"),
+			expected: &Config{
+				AllowAllKeys: true,
+				Sanitizers: []SanitizerConfig{
+					{Type: URLSanitizer, Keys: []string{"url.full", "http.url"}, QueryParams: []string{"token", "password"}},
+					{Type: SQLSanitizer, Keys: []string{"db.statement", "db.query.text"}, SpanNames: true},
+					{Type: HTTPHeaderSanitizer, Keys: []string{"http.request.header.authorization"}},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -75,10 +95,14 @@ func TestValidateConfig(t *testing.T) {
 			name: "empty",
 			hash: None,
 		},
+		{
+			name: "keyed",
+			hash: HMACSHA512,
+		},
 		{
 			name:     "invalid",
 			hash:     "hash",
-			expected: errors.New("unknown HashFunction hash, allowed functions are sha1, sha3 and md5"),
+			expected: errors.New("unknown HashFunction hash, allowed functions are sha1, sha3, md5, hmac-sha256, hmac-sha512 and aes"),
 		},
 	}
 
@@ -95,3 +119,94 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		expected string
+	}{
+		{
+			name:   "keyed hash function",
+			config: &Config{HashFunction: AES, HashKey: "secret"},
+		},
+		{
+			name:     "keyed hash function without key",
+			config:   &Config{HashFunction: HMACSHA256},
+			expected: "hash_key must be set when using the hmac-sha256 hash function",
+		},
+		{
+			name:     "key without keyed hash function",
+			config:   &Config{HashFunction: SHA3, HashKey: "secret"},
+			expected: "hash_key is only supported by the hmac-sha256, hmac-sha512 and aes hash functions",
+		},
+		{
+			name:   "detectors",
+			config: &Config{Detectors: []string{"email", "phone"}},
+		},
+		{
+			name:   "sanitizer",
+			config: &Config{Sanitizers: []SanitizerConfig{{Type: SQLSanitizer, SpanNames: true}}},
+		},
+		{
+			name:     "unknown detector",
+			config:   &Config{Detectors: []string{"email", "ssn"}},
+			expected: `unknown detector "ssn", available detectors are aws_access_key, aws_secret_key, credit_card, email, iban, ipv4, ipv6, jwt, phone`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expected != "" {
+				assert.EqualError(t, err, tt.expected)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSanitizer(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   SanitizerConfig
+		expected string
+	}{
+		{
+			name:   "valid",
+			config: SanitizerConfig{Type: URLSanitizer, Keys: []string{"url.full"}, QueryParams: []string{"token"}},
+		},
+		{
+			name:     "unknown type",
+			config:   SanitizerConfig{Type: "xml", Keys: []string{"body"}},
+			expected: `unknown sanitizer type "xml", available types are url, sql and http_header`,
+		},
+		{
+			name:     "nothing to sanitize",
+			config:   SanitizerConfig{Type: SQLSanitizer},
+			expected: "the sql sanitizer must apply to keys, span names or event names",
+		},
+		{
+			name:     "query parameters of another type",
+			config:   SanitizerConfig{Type: SQLSanitizer, Keys: []string{"db.statement"}, QueryParams: []string{"token"}},
+			expected: "query_params is only supported by the url sanitizer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expected != "" {
+				assert.EqualError(t, err, tt.expected)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateNestedSanitizer(t *testing.T) {
+	cfg := &Config{Sanitizers: []SanitizerConfig{{Type: "xml", Keys: []string{"body"}}}}
+	assert.ErrorContains(t, xconfmap.Validate(cfg), `unknown sanitizer type "xml"`)
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"math/big"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// detector finds sensitive values of a kind in strings.
+type detector struct {
+	// regex matches the candidate values
+	regex *regexp.Regexp
+	// group is the index of the regex group holding the sensitive value,
+	// 0 masks the whole match.
+	group int
+	// validate discards the candidate values that are not sensitive, e.g.
+	// numbers failing a checksum. Nil accepts all the candidates.
+	validate func(string) bool
+}
+
+// detectors holds the built-in detectors by name.
+var detectors = map[string]*detector{
+	"email": {
+		regex: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`),
+	},
+	"credit_card": {
+		regex:    regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`),
+		validate: isValidCreditCard,
+	},
+	"iban": {
+		regex:    regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`),
+		validate: isValidIBAN,
+	},
+	"ipv4": {
+		regex: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`),
+	},
+	"ipv6": {
+		regex:    regexp.MustCompile(`(?i)(?:[0-9a-f]{1,4}:|:){2,7}(?:[0-9a-f]{1,4}|:)`),
+		validate: isValidIPv6,
+	},
+	"jwt": {
+		regex: regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`),
+	},
+	"aws_access_key": {
+		regex: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[A-Z0-9]{16}\b`),
+	},
+	"aws_secret_key": {
+		// secret keys are random strings, they are only detected next to their name
+		regex: regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`),
+		group: 1,
+	},
+	"phone": {
+		regex:    regexp.MustCompile(`(?:\+\d{1,3}[ \-]?)?(?:\(\d{1,4}\)|\d{1,4})(?:[ \-]?\d{2,4}){2,4}\b`),
+		validate: isValidPhone,
+	},
+}
+
+// detectorNames returns the sorted names of the built-in detectors.
+func detectorNames() []string {
+	names := make([]string, 0, len(detectors))
+	for name := range detectors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// replaceAll replaces the sensitive values found in val with the result of mask.
+func (d *detector) replaceAll(val string, mask func(string) string) string {
+	matches := d.regex.FindAllStringSubmatchIndex(val, -1)
+	if len(matches) == 0 {
+		return val
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[2*d.group], match[2*d.group+1]
+		if start < 0 || (d.validate != nil && !d.validate(val[start:end])) {
+			continue
+		}
+		b.WriteString(val[last:start])
+		b.WriteString(mask(val[start:end]))
+		last = end
+	}
+	if last == 0 {
+		return val
+	}
+	b.WriteString(val[last:])
+	return b.String()
+}
+
+// digits returns the digits of s, skipping the separators.
+func digits(s string) []int {
+	ds := make([]int, 0, len(s))
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			ds = append(ds, int(r-'0'))
+		}
+	}
+	return ds
+}
+
+// isValidCreditCard checks the length and the Luhn checksum of a card number.
+func isValidCreditCard(s string) bool {
+	ds := digits(s)
+	if len(ds) < 13 || len(ds) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range ds {
+		d := ds[len(ds)-1-i]
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// isValidIBAN checks the ISO 13616 mod-97 checksum of an IBAN.
+func isValidIBAN(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 15 || len(s) > 34 {
+		return false
+	}
+
+	// move the country code and the check digits to the end and convert
+	// the letters to numbers, A being 10
+	var numeric strings.Builder
+	for _, r := range s[4:] + s[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			numeric.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		default:
+			return false
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && n.Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+func isValidIPv6(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is6()
+}
+
+// isValidPhone accepts numbers of up to 15 digits, as per E.164, written with
+// an international prefix, or of at least 10 digits written with separators,
+// to tell them from other numbers such as dates.
+func isValidPhone(s string) bool {
+	n := len(digits(s))
+	if strings.HasPrefix(s, "+") {
+		return n >= 8 && n <= 15
+	}
+	return n >= 10 && n <= 15 && strings.ContainsAny(s, " -()")
+}
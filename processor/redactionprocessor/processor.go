@@ -6,8 +6,11 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 //nolint:gosec
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
 	"hash"
@@ -21,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/sha3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/token"
 )
 
 const attrValuesSeparator = ","
@@ -36,8 +41,20 @@ type redaction struct {
 	allowRegexList map[string]*regexp.Regexp
 	// Attribute keys blocked in a span
 	blockKeyRegexList map[string]*regexp.Regexp
+	// Built-in detectors of sensitive values
+	detectors []*detector
+	// Sanitizers of attribute values by attribute key
+	keySanitizers map[string][]sanitizer
+	// Sanitizers of span names
+	spanNameSanitizers []sanitizer
+	// Sanitizers of span event names
+	eventNameSanitizers []sanitizer
 	// Hash function to hash blocked values
 	hashFunction HashFunction
+	// Key of the keyed hash functions
+	hashKey []byte
+	// Tokenizer of the aes hash function
+	tokenizer *token.Tokenizer
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -65,15 +82,49 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 		return nil, fmt.Errorf("failed to process allow list: %w", err)
 	}
 
+	detectorList := make([]*detector, 0, len(config.Detectors))
+	for _, name := range config.Detectors {
+		detectorList = append(detectorList, detectors[name])
+	}
+
+	keySanitizers := make(map[string][]sanitizer)
+	var spanNameSanitizers, eventNameSanitizers []sanitizer
+	for _, sanitizerConfig := range config.Sanitizers {
+		sanitize := newSanitizer(sanitizerConfig)
+		for _, key := range sanitizerConfig.Keys {
+			keySanitizers[key] = append(keySanitizers[key], sanitize)
+		}
+		if sanitizerConfig.SpanNames {
+			spanNameSanitizers = append(spanNameSanitizers, sanitize)
+		}
+		if sanitizerConfig.EventNames {
+			eventNameSanitizers = append(eventNameSanitizers, sanitize)
+		}
+	}
+
+	var tok *token.Tokenizer
+	if config.HashFunction == AES {
+		tok, err = token.NewTokenizer([]byte(config.HashKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the aes tokenizer: %w", err)
+		}
+	}
+
 	return &redaction{
-		allowList:         allowList,
-		ignoreList:        ignoreList,
-		blockRegexList:    blockRegexList,
-		allowRegexList:    allowRegexList,
-		blockKeyRegexList: blockKeysRegexList,
-		hashFunction:      config.HashFunction,
-		config:            config,
-		logger:            logger,
+		allowList:           allowList,
+		ignoreList:          ignoreList,
+		blockRegexList:      blockRegexList,
+		allowRegexList:      allowRegexList,
+		blockKeyRegexList:   blockKeysRegexList,
+		detectors:           detectorList,
+		keySanitizers:       keySanitizers,
+		spanNameSanitizers:  spanNameSanitizers,
+		eventNameSanitizers: eventNameSanitizers,
+		hashFunction:        config.HashFunction,
+		hashKey:             []byte(config.HashKey),
+		tokenizer:           tok,
+		config:              config,
+		logger:              logger,
 	}, nil
 }
 
@@ -117,6 +168,11 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 			span := ils.Spans().At(k)
 			spanAttrs := span.Attributes()
 
+			// Names can hold sensitive values too, e.g. SQL statements
+			if len(s.spanNameSanitizers) > 0 {
+				span.SetName(s.sanitize(s.spanNameSanitizers, span.Name()))
+			}
+
 			// Attributes can also be part of span
 			s.processAttrs(ctx, spanAttrs)
 
@@ -128,7 +184,11 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 
 func (s *redaction) processSpanEvents(ctx context.Context, events ptrace.SpanEventSlice) {
 	for i := 0; i < events.Len(); i++ {
-		s.processAttrs(ctx, events.At(i).Attributes())
+		event := events.At(i)
+		if len(s.eventNameSanitizers) > 0 {
+			event.SetName(s.sanitize(s.eventNameSanitizers, event.Name()))
+		}
+		s.processAttrs(ctx, event.Attributes())
 	}
 }
 
@@ -320,7 +380,11 @@ AttributeLoop:
 			value.SetStr(maskedValue)
 			continue AttributeLoop
 		}
-		processedString := s.processStringValue(strVal)
+		processedString := strVal
+		if sanitizers, ok := s.keySanitizers[k]; ok {
+			processedString = s.sanitize(sanitizers, processedString)
+		}
+		processedString = s.processStringValue(processedString)
 		if processedString != strVal {
 			maskedKeys = append(maskedKeys, k)
 			value.SetStr(processedString)
@@ -338,21 +402,38 @@ AttributeLoop:
 	s.addMetaAttrs(ignoredKeys, attributes, "", redactionIgnoredCount)
 }
 
-//nolint:gosec
 func (s *redaction) maskValue(val string, regex *regexp.Regexp) string {
-	hashFunc := func(match string) string {
-		switch s.hashFunction {
-		case SHA1:
-			return hashString(match, sha1.New())
-		case SHA3:
-			return hashString(match, sha3.New256())
-		case MD5:
-			return hashString(match, md5.New())
-		default:
-			return "****"
-		}
+	return regex.ReplaceAllStringFunc(val, s.mask)
+}
+
+// mask replaces a sensitive value according to the configured hash function
+//
+//nolint:gosec
+func (s *redaction) mask(match string) string {
+	switch s.hashFunction {
+	case SHA1:
+		return hashString(match, sha1.New())
+	case SHA3:
+		return hashString(match, sha3.New256())
+	case MD5:
+		return hashString(match, md5.New())
+	case HMACSHA256:
+		return hashString(match, hmac.New(sha256.New, s.hashKey))
+	case HMACSHA512:
+		return hashString(match, hmac.New(sha512.New, s.hashKey))
+	case AES:
+		return s.tokenizer.Tokenize(match)
+	default:
+		return "****"
+	}
+}
+
+// sanitize applies the sanitizers to the value
+func (s *redaction) sanitize(sanitizers []sanitizer, value string) string {
+	for _, sanitize := range sanitizers {
+		value = sanitize(value, s.mask)
 	}
-	return regex.ReplaceAllStringFunc(val, hashFunc)
+	return value
 }
 
 func hashString(input string, hasher hash.Hash) string {
@@ -391,6 +472,10 @@ func (s *redaction) processStringValue(strVal string) string {
 			strVal = s.maskValue(strVal, compiledRE)
 		}
 	}
+	// Mask the sensitive values found by the built-in detectors
+	for _, d := range s.detectors {
+		strVal = d.replaceAll(strVal, s.mask)
+	}
 	return strVal
 }
 
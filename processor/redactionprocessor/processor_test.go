@@ -5,6 +5,11 @@ package redactionprocessor
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
 	"sort"
 	"strings"
 	"testing"
@@ -16,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/token"
 )
 
 type TestConfig struct {
@@ -664,6 +671,145 @@ func TestMultipleBlockValues(t *testing.T) {
 	}
 }
 
+// TestDetectorsMaskValues validates that the values found by the built-in
+// detectors are masked
+func TestDetectorsMaskValues(t *testing.T) {
+	testConfig := TestConfig{
+		config: &Config{
+			AllowAllKeys: true,
+			Detectors:    []string{"email", "credit_card", "ipv4"},
+		},
+		allowed: map[string]pcommon.Value{
+			"id":    pcommon.NewValueInt(5),
+			"order": pcommon.NewValueStr("order 4111111111111112"),
+		},
+		masked: map[string]pcommon.Value{
+			"message": pcommon.NewValueStr("user john@example.com paid with 4111 1111 1111 1111 from 10.1.2.3"),
+		},
+	}
+
+	outTraces := runTest(t, testConfig)
+	outLogs := runLogsTest(t, testConfig)
+	outMetricsGauge := runMetricsTest(t, testConfig, pmetric.MetricTypeGauge)
+
+	attrs := []pcommon.Map{
+		outTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes(),
+		outLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes(),
+		outLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map(),
+		outMetricsGauge.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes(),
+	}
+
+	for _, attr := range attrs {
+		value, _ := attr.Get("message")
+		assert.Equal(t, "user **** paid with **** from ****", value.Str())
+		// the number fails the Luhn check
+		value, _ = attr.Get("order")
+		assert.Equal(t, "order 4111111111111112", value.Str())
+	}
+}
+
+// TestKeyedHashFunctions validates the values masked with the keyed hash functions
+func TestKeyedHashFunctions(t *testing.T) {
+	tests := []struct {
+		name         string
+		hashFunction HashFunction
+	}{
+		{
+			name:         "hmac-sha256",
+			hashFunction: HMACSHA256,
+		},
+		{
+			name:         "hmac-sha512",
+			hashFunction: HMACSHA512,
+		},
+		{
+			name:         "aes",
+			hashFunction: AES,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				AllowAllKeys: true,
+				Detectors:    []string{"email"},
+				HashFunction: tt.hashFunction,
+				HashKey:      "secret",
+			}
+			processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			attrs := pcommon.NewMap()
+			attrs.PutStr("first", "user john@example.com")
+			attrs.PutStr("second", "john@example.com")
+			processor.processAttrs(context.Background(), attrs)
+
+			first, _ := attrs.Get("first")
+			second, _ := attrs.Get("second")
+			require.True(t, strings.HasPrefix(first.Str(), "user "))
+			masked := strings.TrimPrefix(first.Str(), "user ")
+			// the same value is always masked the same way
+			assert.Equal(t, second.Str(), masked)
+			assert.NotContains(t, masked, "john")
+
+			switch tt.hashFunction {
+			case HMACSHA256:
+				assert.Equal(t, hmacHex(sha256.New, "secret", "john@example.com"), masked)
+			case HMACSHA512:
+				assert.Equal(t, hmacHex(sha512.New, "secret", "john@example.com"), masked)
+			case AES:
+				tok, err := token.NewTokenizer([]byte("secret"))
+				require.NoError(t, err)
+				value, err := tok.Detokenize(masked)
+				require.NoError(t, err)
+				assert.Equal(t, "john@example.com", value)
+			}
+		})
+	}
+}
+
+func hmacHex(h func() hash.Hash, key, value string) string {
+	mac := hmac.New(h, []byte(key))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestSanitizers validates that the sanitizers redact the sensitive parts of
+// the values of the configured keys, span names and event names
+func TestSanitizers(t *testing.T) {
+	config := &Config{
+		AllowAllKeys: true,
+		Summary:      debug,
+		Sanitizers: []SanitizerConfig{
+			{Type: URLSanitizer, Keys: []string{"url.full"}, QueryParams: []string{"token"}},
+			{Type: SQLSanitizer, Keys: []string{"db.query.text"}, SpanNames: true, EventNames: true},
+		},
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("SELECT * FROM users WHERE id = 42")
+	span.Attributes().PutStr("url.full", "https://example.com/?token=abc&page=2")
+	span.Attributes().PutStr("db.query.text", "SELECT * FROM users WHERE email = 'john@example.com'")
+	span.Attributes().PutStr("url.path", "/?token=abc")
+	span.Events().AppendEmpty().SetName("DELETE FROM sessions WHERE token = 'abc'")
+
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	assert.Equal(t, "SELECT * FROM users WHERE id = ?", span.Name())
+	assert.Equal(t, "DELETE FROM sessions WHERE token = ?", span.Events().At(0).Name())
+	assert.Equal(t, map[string]any{
+		"url.full":           "https://example.com/?token=****&page=2",
+		"db.query.text":      "SELECT * FROM users WHERE email = ?",
+		"url.path":           "/?token=abc",
+		redactionMaskedKeys:  "db.query.text,url.full",
+		redactionMaskedCount: int64(2),
+	}, span.Attributes().AsRaw())
+}
+
 // TestProcessAttrsAppliedTwice validates a use case when data is coming through redaction processor more than once.
 // Existing attributes must be updated, not overridden or ignored.
 func TestProcessAttrsAppliedTwice(t *testing.T) {
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package token creates and reverses the tokens the redaction processor replaces values with when
+// its hash_function is aes, so that the holders of its hash_key can recover the original values.
+package token // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/token"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	encryptionKeyInfo = "redaction aes encryption key"
+	nonceKeyInfo      = "redaction aes nonce key"
+)
+
+// Tokenizer replaces values with reversible tokens. A token is the base64 URL encoding (without
+// padding) of the AES-256-GCM nonce followed by the ciphertext. The nonce is the HMAC-SHA256 of
+// the value, truncated to the nonce size, so that a value is always replaced by the same token.
+// The encryption and the nonce keys are derived from the configured key with HKDF-SHA256.
+type Tokenizer struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+// NewTokenizer creates a Tokenizer whose keys are derived from key, the hash_key of the processor.
+func NewTokenizer(key []byte) (*Tokenizer, error) {
+	encryptionKey, err := deriveKey(key, encryptionKeyInfo)
+	if err != nil {
+		return nil, err
+	}
+	nonceKey, err := deriveKey(key, nonceKeyInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Tokenizer{aead: aead, nonceKey: nonceKey}, nil
+}
+
+func deriveKey(key []byte, info string) ([]byte, error) {
+	derived := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, nil, []byte(info)), derived); err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return derived, nil
+}
+
+// Tokenize returns the token of value.
+func (t *Tokenizer) Tokenize(value string) string {
+	mac := hmac.New(sha256.New, t.nonceKey)
+	mac.Write([]byte(value))
+	nonce := mac.Sum(nil)[:t.aead.NonceSize()]
+
+	token := t.aead.Seal(nonce, nonce, []byte(value), nil)
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// Detokenize returns the value of a token. It fails if the token wasn't created with the same key.
+func (t *Tokenizer) Detokenize(token string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid token: %w", err)
+	}
+	if len(raw) < t.aead.NonceSize() {
+		return "", errors.New("invalid token: too short")
+	}
+	value, err := t.aead.Open(nil, raw[:t.aead.NonceSize()], raw[t.aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid token: %w", err)
+	}
+	return string(value), nil
+}
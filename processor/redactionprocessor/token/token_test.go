@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenizer(t *testing.T) {
+	tok, err := NewTokenizer([]byte("secret"))
+	require.NoError(t, err)
+
+	token := tok.Tokenize("john@example.com")
+	assert.NotContains(t, token, "john")
+	// A value is always replaced by the same token
+	assert.Equal(t, token, tok.Tokenize("john@example.com"))
+	assert.NotEqual(t, token, tok.Tokenize("jane@example.com"))
+
+	value, err := tok.Detokenize(token)
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", value)
+}
+
+func TestDetokenizeInvalidToken(t *testing.T) {
+	tok, err := NewTokenizer([]byte("secret"))
+	require.NoError(t, err)
+	other, err := NewTokenizer([]byte("other secret"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "other key",
+			token: other.Tokenize("john@example.com"),
+		},
+		{
+			name:  "not base64",
+			token: "not a token!",
+		},
+		{
+			name:  "too short",
+			token: "dG9v",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tok.Detokenize(tt.token)
+			assert.ErrorContains(t, err, "invalid token")
+		})
+	}
+}
@@ -4,9 +4,13 @@
 package resourcedetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibaba"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ecs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/eks"
@@ -15,12 +19,17 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/hetzner"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/kubeadm"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/nomad"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oci"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
@@ -32,6 +41,10 @@ type Config struct {
 	// Override indicates whether any existing resource attributes
 	// should be overridden or preserved. Defaults to true.
 	Override bool `mapstructure:"override"`
+	// RefreshInterval is the interval at which the detectors are run again
+	// to update the detected resource. The last detected resource is kept
+	// if the detection fails. Defaults to 0, which disables the refresh.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 	// DetectorConfig is a list of settings specific to all detectors
 	DetectorConfig DetectorConfig `mapstructure:",squash"`
 	// HTTP client settings for the detector
@@ -43,6 +56,14 @@ type Config struct {
 	Attributes []string `mapstructure:"attributes"`
 }
 
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.RefreshInterval < 0 {
+		return errors.New("refresh_interval must not be negative")
+	}
+	return nil
+}
+
 // DetectorConfig contains user-specified configurations unique to all individual detectors
 type DetectorConfig struct {
 	// EC2Config contains user-specified configurations for the EC2 detector
@@ -89,6 +110,24 @@ type DetectorConfig struct {
 
 	// Kubeadm contains user-specified configurations for the Kubeadm detector
 	KubeadmConfig kubeadm.Config `mapstructure:"kubeadm"`
+
+	// OCIConfig contains user-specified configurations for the OCI detector
+	OCIConfig oci.Config `mapstructure:"oci"`
+
+	// OpenStackConfig contains user-specified configurations for the OpenStack detector
+	OpenStackConfig openstack.Config `mapstructure:"openstack"`
+
+	// DigitalOceanConfig contains user-specified configurations for the DigitalOcean detector
+	DigitalOceanConfig digitalocean.Config `mapstructure:"digitalocean"`
+
+	// HetznerConfig contains user-specified configurations for the Hetzner detector
+	HetznerConfig hetzner.Config `mapstructure:"hetzner"`
+
+	// AlibabaConfig contains user-specified configurations for the Alibaba Cloud detector
+	AlibabaConfig alibaba.Config `mapstructure:"alibaba"`
+
+	// NomadConfig contains user-specified configurations for the Nomad detector
+	NomadConfig nomad.Config `mapstructure:"nomad"`
 }
 
 func detectorCreateDefaultConfig() DetectorConfig {
@@ -108,6 +147,12 @@ func detectorCreateDefaultConfig() DetectorConfig {
 		OpenShiftConfig:        openshift.CreateDefaultConfig(),
 		K8SNodeConfig:          k8snode.CreateDefaultConfig(),
 		KubeadmConfig:          kubeadm.CreateDefaultConfig(),
+		OCIConfig:              oci.CreateDefaultConfig(),
+		OpenStackConfig:        openstack.CreateDefaultConfig(),
+		DigitalOceanConfig:     digitalocean.CreateDefaultConfig(),
+		HetznerConfig:          hetzner.CreateDefaultConfig(),
+		AlibabaConfig:          alibaba.CreateDefaultConfig(),
+		NomadConfig:            nomad.CreateDefaultConfig(),
 	}
 }
 
@@ -143,6 +188,18 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.K8SNodeConfig
 	case kubeadm.TypeStr:
 		return d.KubeadmConfig
+	case oci.TypeStr:
+		return d.OCIConfig
+	case openstack.TypeStr:
+		return d.OpenStackConfig
+	case digitalocean.TypeStr:
+		return d.DigitalOceanConfig
+	case hetzner.TypeStr:
+		return d.HetznerConfig
+	case alibaba.TypeStr:
+		return d.AlibabaConfig
+	case nomad.TypeStr:
+		return d.NomadConfig
 	default:
 		return nil
 	}
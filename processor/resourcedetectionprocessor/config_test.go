@@ -133,6 +133,20 @@ func TestLoadConfig(t *testing.T) {
 				DetectorConfig: resourceAttributesConfig,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "refresh"),
+			expected: &Config{
+				Detectors:       []string{"env", "consul"},
+				ClientConfig:    cfg,
+				Override:        false,
+				RefreshInterval: 5 * time.Minute,
+				DetectorConfig:  detectorCreateDefaultConfig(),
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_refresh_interval"),
+			errorMessage: "refresh_interval must not be negative",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "invalid"),
 			errorMessage: "hostname_sources contains invalid value: \"invalid_source\"",
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibaba // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibaba"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/alibaba"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibaba/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "alibaba"
+
+	cloudProviderAlibabaCloud    = "alibaba_cloud"
+	cloudPlatformAlibabaCloudECS = "alibaba_cloud_ecs"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an Alibaba Cloud ECS metadata detector
+type Detector struct {
+	provider alibaba.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new Alibaba Cloud metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: alibaba.NewProvider(),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects the instance metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	md, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Alibaba Cloud detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProviderAlibabaCloud)
+	d.rb.SetCloudPlatform(cloudPlatformAlibabaCloudECS)
+	d.rb.SetCloudRegion(md.RegionID)
+	d.rb.SetCloudAvailabilityZone(md.ZoneID)
+	d.rb.SetCloudAccountID(md.AccountID)
+	d.rb.SetHostID(md.InstanceID)
+	d.rb.SetHostName(md.Hostname)
+	d.rb.SetHostType(md.InstanceType)
+	d.rb.SetHostImageID(md.ImageID)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibaba
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/alibaba"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibaba/internal/metadata"
+)
+
+type fakeProvider struct {
+	metadata *alibaba.InstanceMetadata
+	err      error
+}
+
+func (p *fakeProvider) Metadata(context.Context) (*alibaba.InstanceMetadata, error) {
+	return p.metadata, p.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{metadata: &alibaba.InstanceMetadata{
+			AccountID:    "1609235876243251",
+			InstanceID:   "i-bp1ecr1fdlsl11n4aw0l",
+			InstanceType: "ecs.g7.large",
+			ImageID:      "aliyun_3_x64_20G_alibase_20230727.vhd",
+			RegionID:     "cn-hangzhou",
+			ZoneID:       "cn-hangzhou-i",
+			Hostname:     "iZbp1ecr1fdlsl11n4aw0lZ",
+		}},
+		logger: zap.NewNop(),
+		rb:     metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, schemaURL, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "alibaba_cloud",
+		"cloud.platform":          "alibaba_cloud_ecs",
+		"cloud.region":            "cn-hangzhou",
+		"cloud.availability_zone": "cn-hangzhou-i",
+		"cloud.account.id":        "1609235876243251",
+		"host.id":                 "i-bp1ecr1fdlsl11n4aw0l",
+		"host.name":               "iZbp1ecr1fdlsl11n4aw0lZ",
+		"host.type":               "ecs.g7.large",
+		"host.image.id":           "aliyun_3_x64_20G_alibase_20230727.vhd",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectError(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{err: errors.New("mock error")},
+		logger:   zap.NewNop(),
+		rb:       metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, _, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
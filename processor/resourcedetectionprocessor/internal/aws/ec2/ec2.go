@@ -120,16 +120,14 @@ func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schem
 		httpClient := getClientConfig(ctx, d.logger)
 		ec2Client, err := d.ec2ClientBuilder.buildClient(ctx, meta.Region, httpClient)
 		if err != nil {
-			d.logger.Warn("failed to build ec2 client", zap.Error(err))
-			return res, conventions.SchemaURL, nil
+			return res, conventions.SchemaURL, &internal.PartialError{Err: fmt.Errorf("failed to build ec2 client: %w", err), Prefixes: []string{tagPrefix}}
 		}
 		tags, err := fetchEC2Tags(ctx, ec2Client, meta.InstanceID, d.tagKeyRegexes)
 		if err != nil {
-			d.logger.Warn("failed fetching ec2 instance tags", zap.Error(err))
-		} else {
-			for key, val := range tags {
-				res.Attributes().PutStr(tagPrefix+key, val)
-			}
+			return res, conventions.SchemaURL, &internal.PartialError{Err: fmt.Errorf("failed fetching ec2 instance tags: %w", err), Prefixes: []string{tagPrefix}}
+		}
+		for key, val := range tags {
+			res.Attributes().PutStr(tagPrefix+key, val)
 		}
 	}
 	return res, conventions.SchemaURL, nil
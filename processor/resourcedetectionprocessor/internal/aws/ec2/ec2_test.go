@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	ec2provider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2/internal/metadata"
 )
 
@@ -159,6 +160,7 @@ func TestDetector_Detect(t *testing.T) {
 		args                  args
 		want                  pcommon.Resource
 		wantErr               bool
+		wantPartialErr        bool
 		tagsProvider          ec2ifaceBuilder
 		failOnMissingMetadata bool
 	}{
@@ -257,6 +259,39 @@ func TestDetector_Detect(t *testing.T) {
 			}(),
 			tagsProvider: &mockClientBuilderError{},
 		},
+		{
+			name: "tags fail",
+			fields: fields{metadataProvider: &mockMetadata{
+				retIDDoc: imds.InstanceIdentityDocument{
+					Region:           "us-west-2",
+					AccountID:        "account1234",
+					AvailabilityZone: "us-west-2a",
+					InstanceID:       "error",
+					ImageID:          "abcdef",
+					InstanceType:     "c4.xlarge",
+				},
+				retHostname: "example-hostname",
+				isAvailable: true,
+			}},
+			tagKeyRegexes: []*regexp.Regexp{regexp.MustCompile("^tag1$")},
+			args:          args{ctx: context.Background()},
+			want: func() pcommon.Resource {
+				res := pcommon.NewResource()
+				attr := res.Attributes()
+				attr.PutStr("cloud.account.id", "account1234")
+				attr.PutStr("cloud.provider", "aws")
+				attr.PutStr("cloud.platform", "aws_ec2")
+				attr.PutStr("cloud.region", "us-west-2")
+				attr.PutStr("cloud.availability_zone", "us-west-2a")
+				attr.PutStr("host.id", "error")
+				attr.PutStr("host.image.id", "abcdef")
+				attr.PutStr("host.type", "c4.xlarge")
+				attr.PutStr("host.name", "example-hostname")
+				return res
+			}(),
+			wantPartialErr: true,
+			tagsProvider:   &mockClientBuilder{},
+		},
 		{
 			name: "endpoint not available",
 			fields: fields{metadataProvider: &mockMetadata{
@@ -316,9 +351,15 @@ func TestDetector_Detect(t *testing.T) {
 			}
 			got, _, err := d.Detect(tt.args.ctx)
 
-			if tt.wantErr {
+			switch {
+			case tt.wantErr:
 				require.Error(t, err)
-			} else {
+			case tt.wantPartialErr:
+				var partialErr *internal.PartialError
+				require.ErrorAs(t, err, &partialErr)
+				assert.Equal(t, []string{tagPrefix}, partialErr.Prefixes)
+				assert.Equal(t, tt.want.Attributes().AsRaw(), got.Attributes().AsRaw())
+			default:
 				require.NoError(t, err)
 				require.NotNil(t, got)
 				assert.Equal(t, tt.want.Attributes().AsRaw(), got.Attributes().AsRaw())
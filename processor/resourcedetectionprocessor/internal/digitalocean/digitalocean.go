@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean"
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/digitalocean"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "digitalocean"
+
+	cloudProviderDigitalOcean = "digitalocean"
+	cloudPlatformDroplet      = "digitalocean_droplet"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a DigitalOcean droplet metadata detector
+type Detector struct {
+	provider digitalocean.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new DigitalOcean metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: digitalocean.NewProvider(),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects the droplet metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	md, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("DigitalOcean detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProviderDigitalOcean)
+	d.rb.SetCloudPlatform(cloudPlatformDroplet)
+	d.rb.SetCloudRegion(md.Region)
+	d.rb.SetHostID(strconv.FormatInt(md.DropletID, 10))
+	d.rb.SetHostName(md.Hostname)
+	tags := make([]any, len(md.Tags))
+	for i, tag := range md.Tags {
+		tags[i] = tag
+	}
+	d.rb.SetDigitaloceanDropletTags(tags)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
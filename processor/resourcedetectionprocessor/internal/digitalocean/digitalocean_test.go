@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/digitalocean"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean/internal/metadata"
+)
+
+type fakeProvider struct {
+	metadata *digitalocean.DropletMetadata
+	err      error
+}
+
+func (p *fakeProvider) Metadata(context.Context) (*digitalocean.DropletMetadata, error) {
+	return p.metadata, p.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	resourceAttributes := metadata.DefaultResourceAttributesConfig()
+	resourceAttributes.DigitaloceanDropletTags.Enabled = true
+	detector := &Detector{
+		provider: &fakeProvider{metadata: &digitalocean.DropletMetadata{
+			DropletID: 2756294,
+			Hostname:  "sample-droplet",
+			Region:    "nyc3",
+			Tags:      []string{"web", "production"},
+		}},
+		logger: zap.NewNop(),
+		rb:     metadata.NewResourceBuilder(resourceAttributes),
+	}
+	res, schemaURL, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":            "digitalocean",
+		"cloud.platform":            "digitalocean_droplet",
+		"cloud.region":              "nyc3",
+		"host.id":                   "2756294",
+		"host.name":                 "sample-droplet",
+		"digitalocean.droplet.tags": []any{"web", "production"},
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectError(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{err: errors.New("mock error")},
+		logger:   zap.NewNop(),
+		rb:       metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, _, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/hetzner"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/hetzner"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/hetzner/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "hetzner"
+
+	cloudProviderHetzner = "hetzner"
+	cloudPlatformServer  = "hetzner_cloud_server"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Hetzner Cloud server metadata detector
+type Detector struct {
+	provider hetzner.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new Hetzner metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: hetzner.NewProvider(),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects the server metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	md, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Hetzner detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProviderHetzner)
+	d.rb.SetCloudPlatform(cloudPlatformServer)
+	d.rb.SetCloudRegion(md.Region)
+	d.rb.SetCloudAvailabilityZone(md.AvailabilityZone)
+	d.rb.SetHostID(md.InstanceID)
+	d.rb.SetHostName(md.Hostname)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/hetzner"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/hetzner/internal/metadata"
+)
+
+type fakeProvider struct {
+	metadata *hetzner.ServerMetadata
+	err      error
+}
+
+func (p *fakeProvider) Metadata(context.Context) (*hetzner.ServerMetadata, error) {
+	return p.metadata, p.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{metadata: &hetzner.ServerMetadata{
+			InstanceID:       "42",
+			Hostname:         "my-server",
+			Region:           "eu-central",
+			AvailabilityZone: "fsn1-dc14",
+		}},
+		logger: zap.NewNop(),
+		rb:     metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, schemaURL, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "hetzner",
+		"cloud.platform":          "hetzner_cloud_server",
+		"cloud.region":            "eu-central",
+		"cloud.availability_zone": "fsn1-dc14",
+		"host.id":                 "42",
+		"host.name":               "my-server",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectError(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{err: errors.New("mock error")},
+		logger:   zap.NewNop(),
+		rb:       metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, _, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
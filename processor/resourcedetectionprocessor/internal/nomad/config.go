@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nomad // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/nomad"
+
+import (
+	"go.opentelemetry.io/collector/config/configopaque"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/nomad/internal/metadata"
+)
+
+// Config defines user-specified configurations unique to the Nomad detector
+type Config struct {
+	// Address is the address of the local Nomad agent. If not provided, the
+	// NOMAD_ADDR environment variable is used, then http://127.0.0.1:4646.
+	Address string `mapstructure:"address"`
+
+	// Token is the ACL token used to query the agent. If not provided, the
+	// NOMAD_TOKEN environment variable is used.
+	Token configopaque.String `mapstructure:"token"`
+
+	// ResourceAttributes configuration for Nomad detector
+	ResourceAttributes metadata.ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		ResourceAttributes: metadata.DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,79 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for resourcedetectionprocessor/nomad resource attributes.
+type ResourceAttributesConfig struct {
+	HostID          ResourceAttributeConfig `mapstructure:"host.id"`
+	HostName        ResourceAttributeConfig `mapstructure:"host.name"`
+	NomadAllocID    ResourceAttributeConfig `mapstructure:"nomad.alloc.id"`
+	NomadAllocName  ResourceAttributeConfig `mapstructure:"nomad.alloc.name"`
+	NomadDatacenter ResourceAttributeConfig `mapstructure:"nomad.datacenter"`
+	NomadGroupName  ResourceAttributeConfig `mapstructure:"nomad.group.name"`
+	NomadJobID      ResourceAttributeConfig `mapstructure:"nomad.job.id"`
+	NomadJobName    ResourceAttributeConfig `mapstructure:"nomad.job.name"`
+	NomadNamespace  ResourceAttributeConfig `mapstructure:"nomad.namespace"`
+	NomadRegion     ResourceAttributeConfig `mapstructure:"nomad.region"`
+	NomadTaskName   ResourceAttributeConfig `mapstructure:"nomad.task.name"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		HostID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		HostName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadAllocID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadAllocName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadDatacenter: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadGroupName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadJobID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadJobName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadNamespace: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadRegion: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		NomadTaskName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
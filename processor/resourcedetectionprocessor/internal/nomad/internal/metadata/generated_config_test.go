@@ -0,0 +1,77 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestResourceAttributesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want ResourceAttributesConfig
+	}{
+		{
+			name: "default",
+			want: DefaultResourceAttributesConfig(),
+		},
+		{
+			name: "all_set",
+			want: ResourceAttributesConfig{
+				HostID:          ResourceAttributeConfig{Enabled: true},
+				HostName:        ResourceAttributeConfig{Enabled: true},
+				NomadAllocID:    ResourceAttributeConfig{Enabled: true},
+				NomadAllocName:  ResourceAttributeConfig{Enabled: true},
+				NomadDatacenter: ResourceAttributeConfig{Enabled: true},
+				NomadGroupName:  ResourceAttributeConfig{Enabled: true},
+				NomadJobID:      ResourceAttributeConfig{Enabled: true},
+				NomadJobName:    ResourceAttributeConfig{Enabled: true},
+				NomadNamespace:  ResourceAttributeConfig{Enabled: true},
+				NomadRegion:     ResourceAttributeConfig{Enabled: true},
+				NomadTaskName:   ResourceAttributeConfig{Enabled: true},
+			},
+		},
+		{
+			name: "none_set",
+			want: ResourceAttributesConfig{
+				HostID:          ResourceAttributeConfig{Enabled: false},
+				HostName:        ResourceAttributeConfig{Enabled: false},
+				NomadAllocID:    ResourceAttributeConfig{Enabled: false},
+				NomadAllocName:  ResourceAttributeConfig{Enabled: false},
+				NomadDatacenter: ResourceAttributeConfig{Enabled: false},
+				NomadGroupName:  ResourceAttributeConfig{Enabled: false},
+				NomadJobID:      ResourceAttributeConfig{Enabled: false},
+				NomadJobName:    ResourceAttributeConfig{Enabled: false},
+				NomadNamespace:  ResourceAttributeConfig{Enabled: false},
+				NomadRegion:     ResourceAttributeConfig{Enabled: false},
+				NomadTaskName:   ResourceAttributeConfig{Enabled: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(ResourceAttributeConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadResourceAttributesConfig(t *testing.T, name string) ResourceAttributesConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	sub, err = sub.Sub("resource_attributes")
+	require.NoError(t, err)
+	cfg := DefaultResourceAttributesConfig()
+	require.NoError(t, sub.Unmarshal(&cfg))
+	return cfg
+}
@@ -0,0 +1,106 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ResourceBuilder is a helper struct to build resources predefined in metadata.yaml.
+// The ResourceBuilder is not thread-safe and must not to be used in multiple goroutines.
+type ResourceBuilder struct {
+	config ResourceAttributesConfig
+	res    pcommon.Resource
+}
+
+// NewResourceBuilder creates a new ResourceBuilder. This method should be called on the start of the application.
+func NewResourceBuilder(rac ResourceAttributesConfig) *ResourceBuilder {
+	return &ResourceBuilder{
+		config: rac,
+		res:    pcommon.NewResource(),
+	}
+}
+
+// SetHostID sets provided value as "host.id" attribute.
+func (rb *ResourceBuilder) SetHostID(val string) {
+	if rb.config.HostID.Enabled {
+		rb.res.Attributes().PutStr("host.id", val)
+	}
+}
+
+// SetHostName sets provided value as "host.name" attribute.
+func (rb *ResourceBuilder) SetHostName(val string) {
+	if rb.config.HostName.Enabled {
+		rb.res.Attributes().PutStr("host.name", val)
+	}
+}
+
+// SetNomadAllocID sets provided value as "nomad.alloc.id" attribute.
+func (rb *ResourceBuilder) SetNomadAllocID(val string) {
+	if rb.config.NomadAllocID.Enabled {
+		rb.res.Attributes().PutStr("nomad.alloc.id", val)
+	}
+}
+
+// SetNomadAllocName sets provided value as "nomad.alloc.name" attribute.
+func (rb *ResourceBuilder) SetNomadAllocName(val string) {
+	if rb.config.NomadAllocName.Enabled {
+		rb.res.Attributes().PutStr("nomad.alloc.name", val)
+	}
+}
+
+// SetNomadDatacenter sets provided value as "nomad.datacenter" attribute.
+func (rb *ResourceBuilder) SetNomadDatacenter(val string) {
+	if rb.config.NomadDatacenter.Enabled {
+		rb.res.Attributes().PutStr("nomad.datacenter", val)
+	}
+}
+
+// SetNomadGroupName sets provided value as "nomad.group.name" attribute.
+func (rb *ResourceBuilder) SetNomadGroupName(val string) {
+	if rb.config.NomadGroupName.Enabled {
+		rb.res.Attributes().PutStr("nomad.group.name", val)
+	}
+}
+
+// SetNomadJobID sets provided value as "nomad.job.id" attribute.
+func (rb *ResourceBuilder) SetNomadJobID(val string) {
+	if rb.config.NomadJobID.Enabled {
+		rb.res.Attributes().PutStr("nomad.job.id", val)
+	}
+}
+
+// SetNomadJobName sets provided value as "nomad.job.name" attribute.
+func (rb *ResourceBuilder) SetNomadJobName(val string) {
+	if rb.config.NomadJobName.Enabled {
+		rb.res.Attributes().PutStr("nomad.job.name", val)
+	}
+}
+
+// SetNomadNamespace sets provided value as "nomad.namespace" attribute.
+func (rb *ResourceBuilder) SetNomadNamespace(val string) {
+	if rb.config.NomadNamespace.Enabled {
+		rb.res.Attributes().PutStr("nomad.namespace", val)
+	}
+}
+
+// SetNomadRegion sets provided value as "nomad.region" attribute.
+func (rb *ResourceBuilder) SetNomadRegion(val string) {
+	if rb.config.NomadRegion.Enabled {
+		rb.res.Attributes().PutStr("nomad.region", val)
+	}
+}
+
+// SetNomadTaskName sets provided value as "nomad.task.name" attribute.
+func (rb *ResourceBuilder) SetNomadTaskName(val string) {
+	if rb.config.NomadTaskName.Enabled {
+		rb.res.Attributes().PutStr("nomad.task.name", val)
+	}
+}
+
+// Emit returns the built resource and resets the internal builder state.
+func (rb *ResourceBuilder) Emit() pcommon.Resource {
+	r := rb.res
+	rb.res = pcommon.NewResource()
+	return r
+}
@@ -0,0 +1,100 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceBuilder(t *testing.T) {
+	for _, tt := range []string{"default", "all_set", "none_set"} {
+		t.Run(tt, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt)
+			rb := NewResourceBuilder(cfg)
+			rb.SetHostID("host.id-val")
+			rb.SetHostName("host.name-val")
+			rb.SetNomadAllocID("nomad.alloc.id-val")
+			rb.SetNomadAllocName("nomad.alloc.name-val")
+			rb.SetNomadDatacenter("nomad.datacenter-val")
+			rb.SetNomadGroupName("nomad.group.name-val")
+			rb.SetNomadJobID("nomad.job.id-val")
+			rb.SetNomadJobName("nomad.job.name-val")
+			rb.SetNomadNamespace("nomad.namespace-val")
+			rb.SetNomadRegion("nomad.region-val")
+			rb.SetNomadTaskName("nomad.task.name-val")
+
+			res := rb.Emit()
+			assert.Equal(t, 0, rb.Emit().Attributes().Len()) // Second call should return empty Resource
+
+			switch tt {
+			case "default":
+				assert.Equal(t, 11, res.Attributes().Len())
+			case "all_set":
+				assert.Equal(t, 11, res.Attributes().Len())
+			case "none_set":
+				assert.Equal(t, 0, res.Attributes().Len())
+				return
+			default:
+				assert.Failf(t, "unexpected test case: %s", tt)
+			}
+
+			val, ok := res.Attributes().Get("host.id")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "host.id-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("host.name")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "host.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.alloc.id")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.alloc.id-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.alloc.name")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.alloc.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.datacenter")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.datacenter-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.group.name")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.group.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.job.id")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.job.id-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.job.name")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.job.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.namespace")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.namespace-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.region")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.region-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("nomad.task.name")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "nomad.task.name-val", val.Str())
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nomad // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/nomad"
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/nomad"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/nomad/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "nomad"
+
+	// environment variables set by Nomad in the task environment, see
+	// https://developer.hashicorp.com/nomad/docs/runtime/environment
+	addrEnvVar      = "NOMAD_ADDR"
+	tokenEnvVar     = "NOMAD_TOKEN"
+	regionEnvVar    = "NOMAD_REGION"
+	dcEnvVar        = "NOMAD_DC"
+	namespaceEnvVar = "NOMAD_NAMESPACE"
+	jobIDEnvVar     = "NOMAD_JOB_ID"
+	jobNameEnvVar   = "NOMAD_JOB_NAME"
+	groupEnvVar     = "NOMAD_GROUP_NAME"
+	taskEnvVar      = "NOMAD_TASK_NAME"
+	allocIDEnvVar   = "NOMAD_ALLOC_ID"
+	allocNameEnvVar = "NOMAD_ALLOC_NAME"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Nomad metadata detector. The allocation metadata is read from
+// the task environment and the node metadata is queried from the local agent.
+type Detector struct {
+	provider nomad.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new Nomad metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+
+	address := cfg.Address
+	if address == "" {
+		address = os.Getenv(addrEnvVar)
+	}
+	token := string(cfg.Token)
+	if token == "" {
+		token = os.Getenv(tokenEnvVar)
+	}
+
+	return &Detector{
+		provider: nomad.NewProvider(address, token),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects the allocation and node metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	allocID := os.Getenv(allocIDEnvVar)
+
+	node, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Nomad detector node metadata retrieval failed", zap.Error(err))
+		if allocID == "" {
+			// not running in Nomad, return an empty Resource and no error
+			return pcommon.NewResource(), "", nil
+		}
+		node = &nomad.NodeMetadata{}
+	}
+
+	d.rb.SetHostID(node.NodeID)
+	d.rb.SetHostName(node.NodeName)
+	d.rb.SetNomadRegion(firstNonEmpty(os.Getenv(regionEnvVar), node.Region))
+	d.rb.SetNomadDatacenter(firstNonEmpty(os.Getenv(dcEnvVar), node.Datacenter))
+	if allocID != "" {
+		d.rb.SetNomadNamespace(os.Getenv(namespaceEnvVar))
+		d.rb.SetNomadJobID(os.Getenv(jobIDEnvVar))
+		d.rb.SetNomadJobName(os.Getenv(jobNameEnvVar))
+		d.rb.SetNomadGroupName(os.Getenv(groupEnvVar))
+		d.rb.SetNomadTaskName(os.Getenv(taskEnvVar))
+		d.rb.SetNomadAllocID(allocID)
+		d.rb.SetNomadAllocName(os.Getenv(allocNameEnvVar))
+	}
+
+	res := d.rb.Emit()
+	removeEmptyAttributes(res)
+	return res, conventions.SchemaURL, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// removeEmptyAttributes drops the attributes that are not known, e.g. the
+// node metadata when the agent cannot be queried.
+func removeEmptyAttributes(res pcommon.Resource) {
+	res.Attributes().RemoveIf(func(_ string, v pcommon.Value) bool {
+		return v.Type() == pcommon.ValueTypeStr && v.Str() == ""
+	})
+}
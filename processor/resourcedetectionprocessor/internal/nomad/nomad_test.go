@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/nomad"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/nomad/internal/metadata"
+)
+
+type fakeProvider struct {
+	metadata *nomad.NodeMetadata
+	err      error
+}
+
+func (p *fakeProvider) Metadata(context.Context) (*nomad.NodeMetadata, error) {
+	return p.metadata, p.err
+}
+
+func setTaskEnv(t *testing.T) {
+	t.Setenv(regionEnvVar, "eu")
+	t.Setenv(dcEnvVar, "eu-west-1")
+	t.Setenv(namespaceEnvVar, "default")
+	t.Setenv(jobIDEnvVar, "web/dispatch-1")
+	t.Setenv(jobNameEnvVar, "web")
+	t.Setenv(groupEnvVar, "frontend")
+	t.Setenv(taskEnvVar, "server")
+	t.Setenv(allocIDEnvVar, "5456bd7a-9fc0-c0dd-6131-cbee77f57577")
+	t.Setenv(allocNameEnvVar, "web.frontend[0]")
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	setTaskEnv(t)
+	detector := &Detector{
+		provider: &fakeProvider{metadata: &nomad.NodeMetadata{
+			NodeID:     "8a5e2a24-3c3b-4f56-b2a0-3e2c1c1d5e60",
+			NodeName:   "node-1",
+			Datacenter: "dc1",
+			Region:     "global",
+		}},
+		logger: zap.NewNop(),
+		rb:     metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, schemaURL, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"host.id":          "8a5e2a24-3c3b-4f56-b2a0-3e2c1c1d5e60",
+		"host.name":        "node-1",
+		"nomad.region":     "eu",
+		"nomad.datacenter": "eu-west-1",
+		"nomad.namespace":  "default",
+		"nomad.job.id":     "web/dispatch-1",
+		"nomad.job.name":   "web",
+		"nomad.group.name": "frontend",
+		"nomad.task.name":  "server",
+		"nomad.alloc.id":   "5456bd7a-9fc0-c0dd-6131-cbee77f57577",
+		"nomad.alloc.name": "web.frontend[0]",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectAgentOnly(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{metadata: &nomad.NodeMetadata{
+			NodeID:     "8a5e2a24-3c3b-4f56-b2a0-3e2c1c1d5e60",
+			NodeName:   "node-1",
+			Datacenter: "dc1",
+			Region:     "global",
+		}},
+		logger: zap.NewNop(),
+		rb:     metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, _, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"host.id":          "8a5e2a24-3c3b-4f56-b2a0-3e2c1c1d5e60",
+		"host.name":        "node-1",
+		"nomad.region":     "global",
+		"nomad.datacenter": "dc1",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectTaskEnvOnly(t *testing.T) {
+	setTaskEnv(t)
+	detector := &Detector{
+		provider: &fakeProvider{err: errors.New("mock error")},
+		logger:   zap.NewNop(),
+		rb:       metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, _, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "web", res.Attributes().AsRaw()["nomad.job.name"])
+	assert.NotContains(t, res.Attributes().AsRaw(), "host.id")
+}
+
+func TestDetectError(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{err: errors.New("mock error")},
+		logger:   zap.NewNop(),
+		rb:       metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, _, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
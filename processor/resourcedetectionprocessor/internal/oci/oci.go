@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oci // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oci"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/oci"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oci/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "oci"
+
+	cloudProviderOracleCloud        = "oracle_cloud"
+	cloudPlatformOracleCloudCompute = "oracle_cloud_compute"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an Oracle Cloud Infrastructure metadata detector
+type Detector struct {
+	provider oci.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new OCI metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: oci.NewProvider(),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects the instance metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	md, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("OCI detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProviderOracleCloud)
+	d.rb.SetCloudPlatform(cloudPlatformOracleCloudCompute)
+	d.rb.SetCloudRegion(md.CanonicalRegionName)
+	d.rb.SetCloudAvailabilityZone(md.AvailabilityDomain)
+	d.rb.SetCloudAccountID(md.TenantID)
+	d.rb.SetHostID(md.ID)
+	d.rb.SetHostName(md.Hostname)
+	d.rb.SetHostType(md.Shape)
+	d.rb.SetHostImageID(md.Image)
+	d.rb.SetOciCompartmentID(md.CompartmentID)
+	d.rb.SetOciFaultDomain(md.FaultDomain)
+	d.rb.SetOciInstanceDisplayName(md.DisplayName)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/oci"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oci/internal/metadata"
+)
+
+type fakeProvider struct {
+	metadata *oci.ComputeMetadata
+	err      error
+}
+
+func (p *fakeProvider) Metadata(context.Context) (*oci.ComputeMetadata, error) {
+	return p.metadata, p.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	resourceAttributes := metadata.DefaultResourceAttributesConfig()
+	resourceAttributes.OciInstanceDisplayName.Enabled = true
+	detector := &Detector{
+		provider: &fakeProvider{metadata: &oci.ComputeMetadata{
+			ID:                  "ocid1.instance.oc1.phx.example",
+			DisplayName:         "my-instance",
+			Hostname:            "my-hostname",
+			CompartmentID:       "ocid1.compartment.oc1..example",
+			TenantID:            "ocid1.tenancy.oc1..example",
+			Region:              "phx",
+			CanonicalRegionName: "us-phoenix-1",
+			AvailabilityDomain:  "EMIr:PHX-AD-1",
+			FaultDomain:         "FAULT-DOMAIN-3",
+			Shape:               "VM.Standard.E4.Flex",
+			Image:               "ocid1.image.oc1.phx.example",
+		}},
+		logger: zap.NewNop(),
+		rb:     metadata.NewResourceBuilder(resourceAttributes),
+	}
+	res, schemaURL, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":            "oracle_cloud",
+		"cloud.platform":            "oracle_cloud_compute",
+		"cloud.region":              "us-phoenix-1",
+		"cloud.availability_zone":   "EMIr:PHX-AD-1",
+		"cloud.account.id":          "ocid1.tenancy.oc1..example",
+		"host.id":                   "ocid1.instance.oc1.phx.example",
+		"host.name":                 "my-hostname",
+		"host.type":                 "VM.Standard.E4.Flex",
+		"host.image.id":             "ocid1.image.oc1.phx.example",
+		"oci.compartment.id":        "ocid1.compartment.oc1..example",
+		"oci.fault_domain":          "FAULT-DOMAIN-3",
+		"oci.instance.display_name": "my-instance",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectError(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{err: errors.New("mock error")},
+		logger:   zap.NewNop(),
+		rb:       metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, _, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
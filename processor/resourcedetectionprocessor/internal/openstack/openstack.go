@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "openstack"
+
+	cloudProviderOpenStack = "openstack"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an OpenStack metadata detector
+type Detector struct {
+	provider openstack.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new OpenStack metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: openstack.NewProvider(),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects the instance metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	md, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("OpenStack detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProviderOpenStack)
+	d.rb.SetCloudAvailabilityZone(md.AvailabilityZone)
+	d.rb.SetCloudAccountID(md.ProjectID)
+	d.rb.SetHostID(md.UUID)
+	d.rb.SetHostName(md.Hostname)
+	d.rb.SetOpenstackInstanceName(md.Name)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
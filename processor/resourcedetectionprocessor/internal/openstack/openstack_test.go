@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack/internal/metadata"
+)
+
+type fakeProvider struct {
+	metadata *openstack.InstanceMetadata
+	err      error
+}
+
+func (p *fakeProvider) Metadata(context.Context) (*openstack.InstanceMetadata, error) {
+	return p.metadata, p.err
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{metadata: &openstack.InstanceMetadata{
+			UUID:             "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+			Name:             "test",
+			Hostname:         "test.novalocal",
+			AvailabilityZone: "nova",
+			ProjectID:        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		}},
+		logger: zap.NewNop(),
+		rb:     metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, schemaURL, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "openstack",
+		"cloud.availability_zone": "nova",
+		"cloud.account.id":        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		"host.id":                 "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		"host.name":               "test.novalocal",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectError(t *testing.T) {
+	detector := &Detector{
+		provider: &fakeProvider{err: errors.New("mock error")},
+		logger:   zap.NewNop(),
+		rb:       metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, _, err := detector.Detect(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(res))
+}
@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v5"
@@ -100,12 +102,42 @@ type ResourceProvider struct {
 	detectedResource *resourceResult
 	once             sync.Once
 	attributesToKeep map[string]struct{}
+
+	// current holds the last successfully detected resource, it is replaced
+	// by the periodic refresh.
+	current atomic.Pointer[resourceResult]
+
+	refreshLock        sync.Mutex
+	refreshSubscribers map[int]func(error)
+	nextSubscriberID   int
+	stopRefresh        context.CancelFunc
+	refreshDone        chan struct{}
 }
 
 type resourceResult struct {
 	resource  pcommon.Resource
 	schemaURL string
 	err       error
+	// missingPrefixes are the prefixes of the keys of the attributes the
+	// detectors failed to fetch, reported by a PartialError.
+	missingPrefixes []string
+}
+
+// PartialError is returned by a detector, along with the detected resource,
+// when it fails to fetch some optional metadata, e.g. the tags of an EC2
+// instance. The resource is used, and a refresh keeps the previous values of
+// the attributes whose keys start with one of the prefixes.
+type PartialError struct {
+	Err      error
+	Prefixes []string
+}
+
+func (e *PartialError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
 }
 
 func NewResourceProvider(logger *zap.Logger, timeout time.Duration, attributesToKeep map[string]struct{}, detectors ...Detector) *ResourceProvider {
@@ -122,14 +154,142 @@ func (p *ResourceProvider) Get(ctx context.Context, client *http.Client) (resour
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
 		defer cancel()
-		p.detectResource(ctx, client.Timeout)
+		p.detectedResource = p.detectResource(ctx, client.Timeout)
+		p.current.Store(p.detectedResource)
+		if !allowErrorPropagationFeatureGate.IsEnabled() {
+			p.detectedResource.err = nil
+		}
 	})
 
 	return p.detectedResource.resource, p.detectedResource.schemaURL, p.detectedResource.err
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Duration) {
-	p.detectedResource = &resourceResult{}
+// Current returns the last successfully detected resource, updated by the
+// periodic refresh. It returns an empty resource until Get is called.
+func (p *ResourceProvider) Current() (resource pcommon.Resource, schemaURL string) {
+	result := p.current.Load()
+	if result == nil {
+		return pcommon.NewResource(), ""
+	}
+	return result.resource, result.schemaURL
+}
+
+// Refresh runs the detectors again and replaces the current resource with the
+// result. If any of the detectors fails, the last good resource is kept and
+// the detection error is returned. If a detector fails to fetch some optional
+// metadata and returns a PartialError, the attributes of the last good
+// resource which it failed to fetch are kept.
+func (p *ResourceProvider) Refresh(ctx context.Context, client *http.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, client.Timeout)
+	defer cancel()
+
+	result := p.detectResource(ctx, client.Timeout)
+	if result.err != nil {
+		return result.err
+	}
+
+	if previous := p.current.Load(); previous != nil && len(result.missingPrefixes) > 0 {
+		var keptAttributes []string
+		attrs := result.resource.Attributes()
+		for k, v := range previous.resource.Attributes().All() {
+			if _, ok := attrs.Get(k); ok || !hasAnyPrefix(k, result.missingPrefixes) {
+				continue
+			}
+			v.CopyTo(attrs.PutEmpty(k))
+			keptAttributes = append(keptAttributes, k)
+		}
+		if len(keptAttributes) > 0 {
+			p.logger.Info("kept previously detected resource information missing from the refresh", zap.Strings("resource keys", keptAttributes))
+		}
+	}
+	p.current.Store(result)
+	return nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartRefresh refreshes the resource every interval until all the returned
+// stop functions are called. The loop is shared by all the callers, the
+// first one starting it, and report is called with the result of each
+// refresh. Stop functions must not be called from report.
+func (p *ResourceProvider) StartRefresh(client *http.Client, interval time.Duration, report func(error)) (stop func()) {
+	p.refreshLock.Lock()
+	defer p.refreshLock.Unlock()
+
+	if p.refreshSubscribers == nil {
+		p.refreshSubscribers = map[int]func(error){}
+	}
+	id := p.nextSubscriberID
+	p.nextSubscriberID++
+	p.refreshSubscribers[id] = report
+
+	if p.stopRefresh == nil {
+		var ctx context.Context
+		ctx, p.stopRefresh = context.WithCancel(ContextWithClient(context.Background(), client))
+		p.refreshDone = make(chan struct{})
+		go p.refreshLoop(ctx, client, interval, p.refreshDone)
+	}
+
+	var stopOnce sync.Once
+	return func() {
+		stopOnce.Do(func() { p.unsubscribeRefresh(id) })
+	}
+}
+
+func (p *ResourceProvider) unsubscribeRefresh(id int) {
+	p.refreshLock.Lock()
+	delete(p.refreshSubscribers, id)
+	if len(p.refreshSubscribers) > 0 || p.stopRefresh == nil {
+		p.refreshLock.Unlock()
+		return
+	}
+	stopRefresh, done := p.stopRefresh, p.refreshDone
+	p.stopRefresh, p.refreshDone = nil, nil
+	p.refreshLock.Unlock()
+
+	stopRefresh()
+	<-done
+}
+
+func (p *ResourceProvider) refreshLoop(ctx context.Context, client *http.Client, interval time.Duration, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := p.Refresh(ctx, client)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			p.logger.Warn("failed to refresh resource information, keeping the last detected one", zap.Error(err))
+		}
+
+		p.refreshLock.Lock()
+		for _, report := range p.refreshSubscribers {
+			report(err)
+		}
+		p.refreshLock.Unlock()
+	}
+}
+
+// detectResource runs all the detectors in parallel and merges their results.
+// The returned error joins the errors of the detectors that failed.
+func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Duration) *resourceResult {
+	detected := &resourceResult{}
 
 	res := pcommon.NewResource()
 	mergedSchemaURL := ""
@@ -156,6 +316,12 @@ func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Dura
 					resultsChan[i] <- resourceResult{resource: r, schemaURL: schemaURL, err: nil}
 					return
 				}
+				var partialErr *PartialError
+				if errors.As(err, &partialErr) {
+					p.logger.Warn("failed to detect part of the resource", zap.Error(err))
+					resultsChan[i] <- resourceResult{resource: r, schemaURL: schemaURL, missingPrefixes: partialErr.Prefixes}
+					return
+				}
 				p.logger.Warn("failed to detect resource", zap.Error(err))
 
 				timer := time.NewTimer(sleep.NextBackOff())
@@ -174,12 +340,11 @@ func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Dura
 	for _, ch := range resultsChan {
 		result := <-ch
 		if result.err != nil {
-			if allowErrorPropagationFeatureGate.IsEnabled() {
-				p.detectedResource.err = errors.Join(p.detectedResource.err, result.err)
-			}
+			detected.err = errors.Join(detected.err, result.err)
 		} else {
 			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, result.schemaURL)
 			MergeResource(res, result.resource, false)
+			detected.missingPrefixes = append(detected.missingPrefixes, result.missingPrefixes...)
 		}
 	}
 
@@ -190,8 +355,9 @@ func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Dura
 		p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
 	}
 
-	p.detectedResource.resource = res
-	p.detectedResource.schemaURL = mergedSchemaURL
+	detected.resource = res
+	detected.schemaURL = mergedSchemaURL
+	return detected
 }
 
 func MergeSchemaURL(currentSchemaURL string, newSchemaURL string) string {
@@ -368,3 +368,113 @@ func TestFilterAttributes_NoAttributes(t *testing.T) {
 
 	assert.Empty(t, droppedAttributes)
 }
+
+// switchableDetector returns the resource or the error it is set to, and
+// records the HTTP client passed through the context.
+type switchableDetector struct {
+	mu     sync.Mutex
+	attrs  map[string]any
+	err    error
+	client *http.Client
+}
+
+func (d *switchableDetector) set(attrs map[string]any, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.attrs, d.err = attrs, err
+}
+
+func (d *switchableDetector) Detect(ctx context.Context) (pcommon.Resource, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.client, _ = ClientFromContext(ctx)
+	res := pcommon.NewResource()
+	if err := res.Attributes().FromRaw(d.attrs); err != nil {
+		return res, "", err
+	}
+	return res, "", d.err
+}
+
+func TestResourceProvider_Refresh(t *testing.T) {
+	md1 := &switchableDetector{attrs: map[string]any{"a": "1"}}
+	md2 := &switchableDetector{attrs: map[string]any{"b": "1"}}
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
+	client := &http.Client{Timeout: 100 * time.Millisecond}
+
+	current, _ := p.Current()
+	assert.Empty(t, current.Attributes().AsRaw())
+
+	detected, _, err := p.Get(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1", "b": "1"}, detected.Attributes().AsRaw())
+
+	md1.set(map[string]any{"a": "2"}, nil)
+	md2.set(nil, errors.New("detection failed"))
+	require.ErrorContains(t, p.Refresh(context.Background(), client), "detection failed")
+	current, _ = p.Current()
+	assert.Equal(t, map[string]any{"a": "1", "b": "1"}, current.Attributes().AsRaw())
+
+	md2.set(map[string]any{"b": "2"}, nil)
+	require.NoError(t, p.Refresh(context.Background(), client))
+	current, _ = p.Current()
+	assert.Equal(t, map[string]any{"a": "2", "b": "2"}, current.Attributes().AsRaw())
+
+	// the attributes missing from a refresh are removed
+	md2.set(map[string]any{"c": "1", "tag.x": "1"}, nil)
+	require.NoError(t, p.Refresh(context.Background(), client))
+	current, _ = p.Current()
+	assert.Equal(t, map[string]any{"a": "2", "c": "1", "tag.x": "1"}, current.Attributes().AsRaw())
+
+	// unless a detector failed to fetch them
+	md2.set(map[string]any{"c": "2"}, &PartialError{Err: errors.New("tags unavailable"), Prefixes: []string{"tag."}})
+	require.NoError(t, p.Refresh(context.Background(), client))
+	current, _ = p.Current()
+	assert.Equal(t, map[string]any{"a": "2", "c": "2", "tag.x": "1"}, current.Attributes().AsRaw())
+
+	md2.set(map[string]any{"c": "2"}, nil)
+	require.NoError(t, p.Refresh(context.Background(), client))
+	current, _ = p.Current()
+	assert.Equal(t, map[string]any{"a": "2", "c": "2"}, current.Attributes().AsRaw())
+
+	// Get keeps returning the result of the first detection
+	detected, _, err = p.Get(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1", "b": "1"}, detected.Attributes().AsRaw())
+}
+
+func TestResourceProvider_StartRefresh(t *testing.T) {
+	md := &switchableDetector{attrs: map[string]any{"a": "1"}}
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	client := &http.Client{Timeout: 100 * time.Millisecond}
+	_, _, err := p.Get(context.Background(), client)
+	require.NoError(t, err)
+
+	reports1 := make(chan error, 100)
+	reports2 := make(chan error, 100)
+	stop1 := p.StartRefresh(client, 10*time.Millisecond, func(err error) { reports1 <- err })
+	stop2 := p.StartRefresh(client, 10*time.Millisecond, func(err error) { reports2 <- err })
+
+	md.set(nil, errors.New("detection failed"))
+	assert.ErrorContains(t, <-reports1, "detection failed")
+	assert.ErrorContains(t, <-reports2, "detection failed")
+	current, _ := p.Current()
+	assert.Equal(t, map[string]any{"a": "1"}, current.Attributes().AsRaw())
+
+	md.set(map[string]any{"a": "2"}, nil)
+	assert.Eventually(t, func() bool {
+		current, _ = p.Current()
+		return assert.ObjectsAreEqual(map[string]any{"a": "2"}, current.Attributes().AsRaw())
+	}, 5*time.Second, 10*time.Millisecond)
+	md.mu.Lock()
+	assert.Same(t, client, md.client)
+	md.mu.Unlock()
+
+	// the loop keeps running until all the subscribers stop
+	stop1()
+	stop1()
+	for len(reports2) > 0 {
+		<-reports2
+	}
+	assert.NoError(t, <-reports2)
+	stop2()
+}
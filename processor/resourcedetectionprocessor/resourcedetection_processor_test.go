@@ -6,6 +6,7 @@ package resourcedetectionprocessor
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -297,6 +299,80 @@ func TestResourceProcessor(t *testing.T) {
 	}
 }
 
+type detectorFunc func() (pcommon.Resource, error)
+
+func (f detectorFunc) Detect(context.Context) (pcommon.Resource, string, error) {
+	res, err := f()
+	return res, "", err
+}
+
+type statusHost struct {
+	component.Host
+	events chan *componentstatus.Event
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	h.events <- event
+}
+
+func TestResourceProcessorRefresh(t *testing.T) {
+	factory := &factory{providers: map[component.ID]*internal.ResourceProvider{}}
+
+	var hostName atomic.Value
+	hostName.Store("host-1")
+	detect := func() (pcommon.Resource, error) {
+		res := pcommon.NewResource()
+		name := hostName.Load().(string)
+		if name == "" {
+			return res, errors.New("detection failed")
+		}
+		res.Attributes().PutStr("host.name", name)
+		return res, nil
+	}
+	factory.resourceProviderFactory = internal.NewProviderFactory(
+		map[internal.DetectorType]internal.DetectorFactory{"mock": func(processor.Settings, internal.DetectorConfig) (internal.Detector, error) {
+			return detectorFunc(detect), nil
+		}})
+
+	cfg := &Config{
+		Override:        true,
+		Detectors:       []string{"mock"},
+		ClientConfig:    confighttp.ClientConfig{Timeout: 10 * time.Millisecond},
+		RefreshInterval: 10 * time.Millisecond,
+	}
+	sink := new(consumertest.TracesSink)
+	rtp, err := factory.createTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+
+	host := &statusHost{Host: componenttest.NewNopHost(), events: make(chan *componentstatus.Event, 100)}
+	require.NoError(t, rtp.Start(context.Background(), host))
+	defer func() { assert.NoError(t, rtp.Shutdown(context.Background())) }()
+
+	consumeHostName := func() any {
+		td := ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty()
+		require.NoError(t, rtp.ConsumeTraces(context.Background(), td))
+		traces := sink.AllTraces()
+		return traces[len(traces)-1].ResourceSpans().At(0).Resource().Attributes().AsRaw()["host.name"]
+	}
+	assert.Equal(t, "host-1", consumeHostName())
+	hostName.Store("")
+
+	// the failed refresh is reported and the last detected resource is kept
+	event := <-host.events
+	assert.Equal(t, componentstatus.StatusRecoverableError, event.Status())
+	assert.ErrorContains(t, event.Err(), "detection failed")
+	assert.Equal(t, "host-1", consumeHostName())
+	hostName.Store("host-2")
+
+	// the next successful refresh recovers the status and updates the resource
+	for event.Status() == componentstatus.StatusRecoverableError {
+		event = <-host.events
+	}
+	assert.Equal(t, componentstatus.StatusOK, event.Status())
+	assert.Equal(t, "host-2", consumeHostName())
+}
+
 func benchmarkConsumeTraces(b *testing.B, cfg *Config) {
 	factory := NewFactory()
 	sink := new(consumertest.TracesSink)
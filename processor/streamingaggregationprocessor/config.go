@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	ErrInvalidIntervalValue   = errors.New("invalid interval value")
+	ErrInvalidMaxSeriesValue  = errors.New("invalid max_series value")
+	ErrInvalidStaleAfterValue = errors.New("invalid stale_after value")
+	ErrNoRules                = errors.New("at least one rule must be configured")
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// Interval is the time interval at which the aggregated metrics are exported.
+	Interval time.Duration `mapstructure:"interval"`
+	// MaxSeries is the maximum number of input series tracked by the processor.
+	// Data points of new series are not aggregated once the limit is reached.
+	// 0 means no limit.
+	MaxSeries int `mapstructure:"max_series"`
+	// StaleAfter is the time after which a series which received no data point
+	// is removed from the state and stops contributing to the aggregated metrics.
+	StaleAfter time.Duration `mapstructure:"stale_after"`
+	// Rules is the list of aggregations, each one exporting a new metric.
+	Rules []Rule `mapstructure:"rules"`
+}
+
+// Rule defines the aggregation of the series of the matching metrics into a new metric.
+type Rule struct {
+	// Name is the name of the exported metric.
+	Name string `mapstructure:"name"`
+	// Description is the description of the exported metric.
+	Description string `mapstructure:"description"`
+	// Unit is the unit of the exported metric, defaults to the unit of the matching metrics.
+	Unit string `mapstructure:"unit"`
+	// Include is the name of the metrics to aggregate.
+	Include string `mapstructure:"include"`
+	// MatchType determines how Include is matched: <strict|regexp>, defaults to strict.
+	MatchType MatchType `mapstructure:"match_type"`
+	// KeepDimensions is the list of the data point, or else resource, attributes kept
+	// as attributes of the exported data points. The series are aggregated across
+	// all their other attributes.
+	KeepDimensions []string `mapstructure:"keep_dimensions"`
+	// Aggregation is the aggregation function: <sum|count|min|max|avg|quantile>.
+	Aggregation AggregationType `mapstructure:"aggregation"`
+	// Quantile is the quantile estimated from the histogram buckets, in the ]0, 1[ range.
+	// REQUIRED only if Aggregation is quantile.
+	Quantile float64 `mapstructure:"quantile"`
+}
+
+// MatchType is the enum of the ways the metric names are matched.
+type MatchType string
+
+const (
+	// Strict matches the metric name exactly.
+	Strict MatchType = "strict"
+	// Regexp matches the metric name with a regular expression.
+	Regexp MatchType = "regexp"
+)
+
+var matchTypes = []MatchType{Strict, Regexp}
+
+// AggregationType is the enum of the aggregation functions.
+type AggregationType string
+
+const (
+	// Sum is the sum of the values of the series, or of the observations of histograms.
+	Sum AggregationType = "sum"
+	// Count is the number of series, or of the observations of histograms.
+	Count AggregationType = "count"
+	// Min is the minimum value of the series.
+	Min AggregationType = "min"
+	// Max is the maximum value of the series.
+	Max AggregationType = "max"
+	// Avg is the average value of the series, or of the observations of histograms.
+	Avg AggregationType = "avg"
+	// Quantile is the quantile estimated from the buckets of histograms.
+	Quantile AggregationType = "quantile"
+)
+
+var aggregationTypes = []AggregationType{Sum, Count, Min, Max, Avg, Quantile}
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (config *Config) Validate() error {
+	if config.Interval <= 0 {
+		return ErrInvalidIntervalValue
+	}
+
+	if config.MaxSeries < 0 {
+		return ErrInvalidMaxSeriesValue
+	}
+
+	if config.StaleAfter <= 0 {
+		return ErrInvalidStaleAfterValue
+	}
+
+	if len(config.Rules) == 0 {
+		return ErrNoRules
+	}
+
+	names := map[string]bool{}
+	for i, rule := range config.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d: missing required field %q", i+1, "name")
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("rule %d: duplicate name %q", i+1, rule.Name)
+		}
+		names[rule.Name] = true
+
+		if rule.Include == "" {
+			return fmt.Errorf("rule %d: missing required field %q", i+1, "include")
+		}
+
+		switch rule.MatchType {
+		case "", Strict:
+		case Regexp:
+			if _, err := regexp.Compile(rule.Include); err != nil {
+				return fmt.Errorf("rule %d: %q, %w", i+1, "include", err)
+			}
+		default:
+			return fmt.Errorf("rule %d: %q must be in %q", i+1, "match_type", matchTypes)
+		}
+
+		switch rule.Aggregation {
+		case Sum, Count, Min, Max, Avg:
+		case Quantile:
+			if rule.Quantile <= 0 || rule.Quantile >= 1 {
+				return fmt.Errorf("rule %d: %q must be in ]0, 1[ while %q is %v", i+1, "quantile", "aggregation", Quantile)
+			}
+		default:
+			return fmt.Errorf("rule %d: %q must be in %q", i+1, "aggregation", aggregationTypes)
+		}
+	}
+
+	return nil
+}
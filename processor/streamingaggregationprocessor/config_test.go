@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				Interval:   30 * time.Second,
+				MaxSeries:  1000,
+				StaleAfter: 2 * time.Minute,
+				Rules: []Rule{
+					{
+						Name:           "http.server.request.duration.p99",
+						Include:        "http.server.request.duration",
+						KeepDimensions: []string{"service.name", "http.route"},
+						Aggregation:    Quantile,
+						Quantile:       0.99,
+					},
+					{
+						Name:           "k8s.deployment.cpu.usage",
+						Description:    "CPU usage of the pods of a deployment.",
+						Unit:           "{cpu}",
+						Include:        `^k8s\.pod\.cpu\.(usage|time)$`,
+						MatchType:      Regexp,
+						KeepDimensions: []string{"k8s.namespace.name", "k8s.deployment.name"},
+						Aggregation:    Sum,
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_rules"),
+			errorMessage: ErrNoRules.Error(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_interval"),
+			errorMessage: ErrInvalidIntervalValue.Error(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_max_series"),
+			errorMessage: ErrInvalidMaxSeriesValue.Error(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_name"),
+			errorMessage: `rule 1: missing required field "name"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "duplicate_name"),
+			errorMessage: `rule 2: duplicate name "requests"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_regexp"),
+			errorMessage: "rule 1: \"include\", error parsing regexp: missing closing ]: `[a-z`",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_aggregation"),
+			errorMessage: `rule 1: "aggregation" must be in ["sum" "count" "min" "max" "avg" "quantile"]`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_quantile"),
+			errorMessage: `rule 1: "quantile" must be in ]0, 1[ while "aggregation" is quantile`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package streamingaggregationprocessor implements a processor which aggregates
+// the series of the matching metrics by a set of kept dimensions over time, and
+// periodically exports the results as new metrics, similarly to Prometheus
+// recording rules.
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
@@ -0,0 +1,499 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/staleness"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor/internal/metadata"
+)
+
+var _ processor.Metrics = (*streamingAggregationProcessor)(nil)
+
+type streamingAggregationProcessor struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	logger *zap.Logger
+
+	stateLock sync.Mutex
+
+	rules         []*rule
+	series        map[identity.Stream]*series
+	tracker       staleness.Tracker
+	intervalStart pcommon.Timestamp
+	droppedSeries int
+
+	config  *Config
+	version string
+
+	nextConsumer consumer.Metrics
+}
+
+// rule is the state of a Rule.
+type rule struct {
+	Rule
+	match func(string) bool
+	// kind is the kind of the series aggregated by the rule, set by the first
+	// matching metric.
+	kind      seriesKind
+	unit      string
+	monotonic bool
+	// cumulatives are the last exported data points of the groups of a rule
+	// summing counters, by group key.
+	cumulatives map[string]cumulative
+}
+
+// cumulative is the last exported data point of a group summing counters.
+type cumulative struct {
+	start pcommon.Timestamp
+	value float64
+}
+
+// group is the output data point of a rule aggregating the series of a group.
+type group struct {
+	attrs pcommon.Map
+	start pcommon.Timestamp
+	n     int
+	sum   float64
+	min   float64
+	max   float64
+	hist  *histogram
+}
+
+func newProcessor(config *Config, set processor.Settings, nextConsumer consumer.Metrics) *streamingAggregationProcessor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	rules := make([]*rule, len(config.Rules))
+	for i, r := range config.Rules {
+		rules[i] = &rule{Rule: r, match: newMatcher(r), cumulatives: map[string]cumulative{}}
+		if r.Aggregation == Quantile {
+			// Quantiles can only be estimated from histograms.
+			rules[i].kind = kindHistogram
+		}
+	}
+
+	return &streamingAggregationProcessor{
+		ctx:    ctx,
+		cancel: cancel,
+		logger: set.Logger,
+
+		stateLock: sync.Mutex{},
+
+		rules:         rules,
+		series:        map[identity.Stream]*series{},
+		tracker:       staleness.NewTracker(),
+		intervalStart: pcommon.NewTimestampFromTime(time.Now()),
+
+		config:  config,
+		version: set.BuildInfo.Version,
+
+		nextConsumer: nextConsumer,
+	}
+}
+
+func newMatcher(r Rule) func(string) bool {
+	if r.MatchType == Regexp {
+		return regexp.MustCompile(r.Include).MatchString
+	}
+	return func(name string) bool {
+		return name == r.Include
+	}
+}
+
+func (p *streamingAggregationProcessor) Start(_ context.Context, _ component.Host) error {
+	exportTicker := time.NewTicker(p.config.Interval)
+	go func() {
+		for {
+			select {
+			case <-p.ctx.Done():
+				exportTicker.Stop()
+				return
+			case <-exportTicker.C:
+				p.exportMetrics()
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (p *streamingAggregationProcessor) Shutdown(_ context.Context) error {
+	p.cancel()
+	return nil
+}
+
+func (p *streamingAggregationProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeMetrics records the data points of the metrics matched by the rules
+// and passes all the metrics through.
+func (p *streamingAggregationProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	p.stateLock.Lock()
+	now := time.Now()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resID := identity.OfResource(rm.Resource())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			scopeID := identity.OfScope(resID, sm.Scope())
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				rules := p.matchingRules(m)
+				if len(rules) == 0 {
+					continue
+				}
+				metricID := identity.OfMetric(scopeID, m)
+
+				//exhaustive:enforce
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						if s := p.getOrCreateSeries(metricID, dps.At(l), rm.Resource(), rules, kindGauge, now); s != nil {
+							s.addNumber(dps.At(l))
+						}
+					}
+				case pmetric.MetricTypeSum:
+					dps := m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						if s := p.getOrCreateSeries(metricID, dps.At(l), rm.Resource(), rules, kindOf(m), now); s != nil {
+							s.addNumber(dps.At(l))
+						}
+					}
+				case pmetric.MetricTypeHistogram:
+					delta := m.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+					dps := m.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						if s := p.getOrCreateSeries(metricID, dps.At(l), rm.Resource(), rules, kindHistogram, now); s != nil {
+							s.addHistogram(dps.At(l), delta, p.intervalStart)
+						}
+					}
+				case pmetric.MetricTypeEmpty, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
+				}
+			}
+		}
+	}
+	p.stateLock.Unlock()
+
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+// matchingRules returns the indexes of the rules aggregating the metric.
+func (p *streamingAggregationProcessor) matchingRules(m pmetric.Metric) []int {
+	var matching []int
+	for i, r := range p.rules {
+		if !r.match(m.Name()) {
+			continue
+		}
+		kind := kindOf(m)
+		if kind == 0 {
+			continue
+		}
+		if r.kind == 0 {
+			r.kind = kind
+		}
+		if r.kind != kind {
+			// All the series aggregated by a rule must be of the same kind.
+			p.logger.Debug("metric ignored by rule aggregating another kind of metrics",
+				zap.String("metric", m.Name()), zap.String("rule", r.Name))
+			continue
+		}
+		if r.unit == "" {
+			r.unit = m.Unit()
+			r.monotonic = m.Type() == pmetric.MetricTypeSum && m.Sum().IsMonotonic()
+		}
+		matching = append(matching, i)
+	}
+	return matching
+}
+
+// getOrCreateSeries returns the series of the data point, or nil if it can't be
+// tracked because the maximum number of series is reached.
+func (p *streamingAggregationProcessor) getOrCreateSeries(metricID identity.Metric, dp interface{ Attributes() pcommon.Map },
+	resource pcommon.Resource, rules []int, kind seriesKind, now time.Time,
+) *series {
+	streamID := identity.OfStream(metricID, dp)
+	s, ok := p.series[streamID]
+	if !ok {
+		if p.config.MaxSeries > 0 && len(p.series) >= p.config.MaxSeries {
+			p.droppedSeries++
+			return nil
+		}
+		s = &series{kind: kind}
+		for _, i := range rules {
+			attrs, key := p.rules[i].dimensions(dp.Attributes(), resource.Attributes())
+			s.groups = append(s.groups, seriesGroup{rule: i, key: key, attrs: attrs})
+		}
+		p.series[streamID] = s
+	}
+	p.tracker.Refresh(now, streamID)
+	return s
+}
+
+// dimensions returns the kept dimensions of a series and the key of its group.
+// Data point attributes take precedence over resource attributes.
+func (r *rule) dimensions(attrs, resourceAttrs pcommon.Map) (pcommon.Map, string) {
+	dims := pcommon.NewMap()
+	var key strings.Builder
+	for _, d := range r.KeepDimensions {
+		v, ok := attrs.Get(d)
+		if !ok {
+			v, ok = resourceAttrs.Get(d)
+		}
+		if !ok {
+			continue
+		}
+		v.CopyTo(dims.PutEmpty(d))
+		key.WriteString(d)
+		key.WriteByte('=')
+		key.WriteString(v.AsString())
+		key.WriteByte(0)
+	}
+	return dims, key.String()
+}
+
+func (p *streamingAggregationProcessor) exportMetrics() {
+	md := func() pmetric.Metrics {
+		p.stateLock.Lock()
+		defer p.stateLock.Unlock()
+
+		for _, id := range p.tracker.Collect(p.config.StaleAfter) {
+			delete(p.series, id)
+		}
+
+		groups := make([]map[string]*group, len(p.rules))
+		for i := range groups {
+			groups[i] = map[string]*group{}
+		}
+		for _, s := range p.series {
+			p.aggregateSeries(s, groups)
+			s.reset()
+		}
+
+		now := pcommon.NewTimestampFromTime(time.Now())
+		out := p.buildMetrics(groups, now)
+		p.intervalStart = now
+
+		if p.droppedSeries > 0 {
+			p.logger.Warn("Series not aggregated because the maximum number of series is reached",
+				zap.Int("max_series", p.config.MaxSeries), zap.Int("dropped_series", p.droppedSeries))
+			p.droppedSeries = 0
+		}
+
+		return out
+	}()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return
+	}
+	if err := p.nextConsumer.ConsumeMetrics(p.ctx, md); err != nil {
+		p.logger.Error("Metrics export failed", zap.Error(err))
+	}
+}
+
+// aggregateSeries adds the value of the series during the interval to its groups.
+func (p *streamingAggregationProcessor) aggregateSeries(s *series, groups []map[string]*group) {
+	if s.kind == kindDelta && !s.updated {
+		return
+	}
+
+	var increase histogram
+	if s.kind == kindHistogram {
+		if s.delta && !s.updated {
+			return
+		}
+		increase = s.increase()
+	}
+
+	for _, sg := range s.groups {
+		g, ok := groups[sg.rule][sg.key]
+		if !ok {
+			g = &group{attrs: sg.attrs, start: s.start, min: math.Inf(1), max: math.Inf(-1)}
+			groups[sg.rule][sg.key] = g
+		}
+
+		if s.kind == kindHistogram {
+			if g.hist == nil {
+				h := increase.clone()
+				g.hist = &h
+			} else if !g.hist.merge(increase) {
+				p.logger.Debug("histogram with different buckets ignored", zap.String("rule", p.rules[sg.rule].Name))
+				continue
+			}
+			g.n++
+			continue
+		}
+
+		g.n++
+		g.sum += s.value
+		g.min = min(g.min, s.value)
+		g.max = max(g.max, s.value)
+		if s.start != 0 && (g.start == 0 || s.start < g.start) {
+			g.start = s.start
+		}
+	}
+}
+
+// buildMetrics returns the metrics exported for the groups of each rule.
+func (p *streamingAggregationProcessor) buildMetrics(groups []map[string]*group, now pcommon.Timestamp) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	var sm pmetric.ScopeMetrics
+	for i, r := range p.rules {
+		if len(groups[i]) == 0 {
+			continue
+		}
+
+		m := pmetric.NewMetric()
+		m.SetName(r.Name)
+		m.SetDescription(r.Description)
+		m.SetUnit(r.unit)
+		if r.Unit != "" {
+			m.SetUnit(r.Unit)
+		}
+		if r.Aggregation == Count && r.kind != kindHistogram {
+			m.SetUnit("{series}")
+		}
+
+		var dps pmetric.NumberDataPointSlice
+		startTime := pcommon.Timestamp(0)
+		switch {
+		case r.Aggregation == Sum && r.kind == kindCounter:
+			sum := m.SetEmptySum()
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			sum.SetIsMonotonic(true)
+			dps = sum.DataPoints()
+		case r.Aggregation == Sum && r.kind == kindDelta,
+			(r.Aggregation == Sum || r.Aggregation == Count) && r.kind == kindHistogram:
+			sum := m.SetEmptySum()
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			sum.SetIsMonotonic(r.monotonic || r.Aggregation == Count)
+			dps = sum.DataPoints()
+			startTime = p.intervalStart
+		default:
+			dps = m.SetEmptyGauge().DataPoints()
+		}
+
+		keys := make([]string, 0, len(groups[i]))
+		for key := range groups[i] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for key := range r.cumulatives {
+			if _, ok := groups[i][key]; !ok {
+				delete(r.cumulatives, key)
+			}
+		}
+		for _, key := range keys {
+			g := groups[i][key]
+			dp := pmetric.NewNumberDataPoint()
+			if !r.setValue(dp, g) {
+				continue
+			}
+			g.attrs.CopyTo(dp.Attributes())
+			dp.SetTimestamp(now)
+			switch {
+			case startTime != 0:
+				dp.SetStartTimestamp(startTime)
+			case r.kind == kindCounter && r.Aggregation == Sum:
+				dp.SetStartTimestamp(r.cumulativeStart(key, g, p.intervalStart))
+			}
+			dp.MoveTo(dps.AppendEmpty())
+		}
+		if dps.Len() == 0 {
+			continue
+		}
+
+		if sm == (pmetric.ScopeMetrics{}) {
+			sm = md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+			sm.Scope().SetName(metadata.ScopeName)
+			sm.Scope().SetVersion(p.version)
+		}
+		m.MoveTo(sm.Metrics().AppendEmpty())
+	}
+	return md
+}
+
+// cumulativeStart returns the start timestamp of the sum of the counters of the
+// group. The sum goes down when one of the counters is reset or goes stale: it is
+// then restarted at the previous export, so that the output stays monotonic.
+func (r *rule) cumulativeStart(key string, g *group, previousExport pcommon.Timestamp) pcommon.Timestamp {
+	c, ok := r.cumulatives[key]
+	switch {
+	case !ok:
+		c.start = g.start
+	case g.sum < c.value:
+		c.start = previousExport
+	}
+	c.value = g.sum
+	r.cumulatives[key] = c
+	return c.start
+}
+
+// setValue sets the value of the data point of the group, it returns false if
+// the group has no value.
+func (r *rule) setValue(dp pmetric.NumberDataPoint, g *group) bool {
+	if r.kind == kindHistogram {
+		h := g.hist
+		switch r.Aggregation {
+		case Sum:
+			dp.SetDoubleValue(h.sum)
+		case Count:
+			dp.SetIntValue(int64(h.count))
+		case Min:
+			if !h.hasMin {
+				return false
+			}
+			dp.SetDoubleValue(h.min)
+		case Max:
+			if !h.hasMax {
+				return false
+			}
+			dp.SetDoubleValue(h.max)
+		case Avg:
+			if h.count == 0 {
+				return false
+			}
+			dp.SetDoubleValue(h.sum / float64(h.count))
+		case Quantile:
+			q, ok := h.quantile(r.Quantile)
+			if !ok {
+				return false
+			}
+			dp.SetDoubleValue(q)
+		}
+		return true
+	}
+
+	switch r.Aggregation {
+	case Sum:
+		dp.SetDoubleValue(g.sum)
+	case Count:
+		dp.SetIntValue(int64(g.n))
+	case Min:
+		dp.SetDoubleValue(g.min)
+	case Max:
+		dp.SetDoubleValue(g.max)
+	case Avg:
+		dp.SetDoubleValue(g.sum / float64(g.n))
+	case Quantile:
+		return false
+	}
+	return true
+}
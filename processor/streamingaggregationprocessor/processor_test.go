@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor/internal/metadata"
+)
+
+func TestAggregation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		rules []Rule
+	}{
+		{
+			name: "counters_are_summed",
+			rules: []Rule{
+				{Name: "k8s.deployment.network.io", Include: "k8s.pod.network.io", KeepDimensions: []string{"k8s.deployment.name", "direction"}, Aggregation: Sum},
+			},
+		},
+		{
+			name: "gauges_are_averaged",
+			rules: []Rule{
+				{Name: "system.cpu.utilization.avg", Include: "system.cpu.utilization", KeepDimensions: []string{"state"}, Aggregation: Avg},
+				{Name: "system.cpu.utilization.max", Include: "system.cpu.utilization", KeepDimensions: []string{"host.name"}, Aggregation: Max},
+			},
+		},
+		{
+			name: "deltas_are_accumulated",
+			rules: []Rule{
+				{Name: "http.server.requests.by_route", Include: "http.server.requests", KeepDimensions: []string{"http.route"}, Aggregation: Sum},
+				{Name: "http.server.requests.instances", Include: "http.server.requests", KeepDimensions: []string{"http.route"}, Aggregation: Count},
+			},
+		},
+		{
+			name: "histogram_quantile",
+			rules: []Rule{
+				{Name: "http.server.request.duration.p50", Include: "^http\\.server\\.request\\.duration$", MatchType: Regexp, KeepDimensions: []string{"service.name"}, Aggregation: Quantile, Quantile: 0.5},
+				{Name: "http.server.request.duration.avg", Include: "http.server.request.duration", KeepDimensions: []string{"service.name"}, Aggregation: Avg},
+				{Name: "http.server.request.count", Include: "http.server.request.duration", KeepDimensions: []string{"service.name", "http.route"}, Aggregation: Count, Unit: "{request}"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			next := &consumertest.MetricsSink{}
+			processor := newTestProcessor(t, &Config{Interval: time.Second, StaleAfter: time.Minute, Rules: tc.rules}, next)
+
+			dir := filepath.Join("testdata", tc.name)
+			md, err := golden.ReadMetrics(filepath.Join(dir, "input.yaml"))
+			require.NoError(t, err)
+			require.NoError(t, processor.ConsumeMetrics(context.Background(), md))
+
+			// Pretend we hit the interval timer and call export
+			processor.exportMetrics()
+
+			// Next should have gotten the input metrics passed through and the aggregated metrics
+			allMetrics := next.AllMetrics()
+			require.Len(t, allMetrics, 2)
+
+			input, err := golden.ReadMetrics(filepath.Join(dir, "input.yaml"))
+			require.NoError(t, err)
+			require.NoError(t, pmetrictest.CompareMetrics(input, allMetrics[0]))
+
+			expected, err := golden.ReadMetrics(filepath.Join(dir, "output.yaml"))
+			require.NoError(t, err)
+			require.NoError(t, pmetrictest.CompareMetrics(expected, allMetrics[1],
+				pmetrictest.IgnoreTimestamp(), pmetrictest.IgnoreStartTimestamp()))
+		})
+	}
+}
+
+func TestCumulativeHistogramIncrease(t *testing.T) {
+	next := &consumertest.MetricsSink{}
+	processor := newTestProcessor(t, &Config{
+		Interval:   time.Second,
+		StaleAfter: time.Minute,
+		Rules: []Rule{
+			{Name: "requests", Include: "http.server.request.duration", Aggregation: Count},
+			{Name: "p90", Include: "http.server.request.duration", Aggregation: Quantile, Quantile: 0.9},
+		},
+	}, next)
+
+	histogram := func(start pcommon.Timestamp, buckets ...uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("http.server.request.duration")
+		h := m.SetEmptyHistogram()
+		h.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := h.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dp.ExplicitBounds().FromRaw([]float64{1, 2})
+		dp.BucketCounts().FromRaw(buckets)
+		for _, c := range buckets {
+			dp.SetCount(dp.Count() + c)
+		}
+		return md
+	}
+	values := func(md pmetric.Metrics) map[string]float64 {
+		values := map[string]float64{}
+		metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			m := metrics.At(i)
+			switch m.Type() {
+			case pmetric.MetricTypeSum:
+				values[m.Name()] = float64(m.Sum().DataPoints().At(0).IntValue())
+			case pmetric.MetricTypeGauge:
+				values[m.Name()] = m.Gauge().DataPoints().At(0).DoubleValue()
+			}
+		}
+		return values
+	}
+
+	// The observations of a series started before it was tracked are the baseline.
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), histogram(1, 10, 0, 0)))
+	processor.exportMetrics()
+	require.Len(t, next.AllMetrics(), 2)
+	assert.Equal(t, map[string]float64{"requests": 0}, values(next.AllMetrics()[1]))
+
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), histogram(1, 10, 10, 0)))
+	processor.exportMetrics()
+	require.Len(t, next.AllMetrics(), 4)
+	assert.Equal(t, map[string]float64{"requests": 10, "p90": 1.9}, values(next.AllMetrics()[3]))
+
+	// A reset restarts the increase from zero.
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), histogram(2, 1, 0, 1)))
+	processor.exportMetrics()
+	require.Len(t, next.AllMetrics(), 6)
+	assert.Equal(t, map[string]float64{"requests": 2, "p90": 2}, values(next.AllMetrics()[5]))
+}
+
+func TestCounterSumReset(t *testing.T) {
+	next := &consumertest.MetricsSink{}
+	processor := newTestProcessor(t, &Config{
+		Interval:   time.Second,
+		StaleAfter: time.Minute,
+		Rules:      []Rule{{Name: "requests", Include: "http.server.requests", Aggregation: Sum}},
+	}, next)
+
+	counters := func(values map[string]int64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("http.server.requests")
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(true)
+		for instance, v := range values {
+			dp := sum.DataPoints().AppendEmpty()
+			dp.Attributes().PutStr("instance", instance)
+			dp.SetStartTimestamp(1)
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+			dp.SetIntValue(v)
+		}
+		return md
+	}
+	export := func() pmetric.NumberDataPoint {
+		processor.exportMetrics()
+		all := next.AllMetrics()
+		return all[len(all)-1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	}
+
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), counters(map[string]int64{"a": 10, "b": 5})))
+	dp := export()
+	assert.Equal(t, 15.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+
+	// The reset of a counter makes the sum go down, it is restarted at the previous export.
+	previousExport := processor.intervalStart
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), counters(map[string]int64{"a": 11, "b": 1})))
+	dp = export()
+	assert.Equal(t, 12.0, dp.DoubleValue())
+	assert.Equal(t, previousExport, dp.StartTimestamp())
+
+	// The sum keeps its start as long as it goes up.
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), counters(map[string]int64{"a": 12, "b": 2})))
+	dp = export()
+	assert.Equal(t, 14.0, dp.DoubleValue())
+	assert.Equal(t, previousExport, dp.StartTimestamp())
+}
+
+func TestMaxSeries(t *testing.T) {
+	next := &consumertest.MetricsSink{}
+	processor := newTestProcessor(t, &Config{
+		Interval:   time.Second,
+		MaxSeries:  2,
+		StaleAfter: time.Minute,
+		Rules:      []Rule{{Name: "series", Include: "queue.size", Aggregation: Count}},
+	}, next)
+
+	md := pmetric.NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("queue.size")
+	for _, queue := range []string{"a", "b", "c"} {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutStr("queue", queue)
+		dp.SetIntValue(1)
+	}
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, processor.series, 2)
+	assert.Equal(t, 1, processor.droppedSeries)
+
+	processor.exportMetrics()
+	assert.Zero(t, processor.droppedSeries)
+	out := next.AllMetrics()[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, int64(2), out.Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestStaleSeries(t *testing.T) {
+	next := &consumertest.MetricsSink{}
+	processor := newTestProcessor(t, &Config{
+		Interval:   time.Second,
+		StaleAfter: time.Millisecond,
+		Rules:      []Rule{{Name: "queue.size.max", Include: "queue.size", Aggregation: Max}},
+	}, next)
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("queue.size")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(10)
+	require.NoError(t, processor.ConsumeMetrics(context.Background(), md))
+	require.Len(t, processor.series, 1)
+
+	time.Sleep(10 * time.Millisecond)
+	processor.exportMetrics()
+	assert.Empty(t, processor.series)
+	// Only the input metrics were passed through, there was nothing to export.
+	assert.Len(t, next.AllMetrics(), 1)
+}
+
+func TestQuantile(t *testing.T) {
+	tests := []struct {
+		name     string
+		bounds   []float64
+		buckets  []uint64
+		q        float64
+		expected float64
+		ok       bool
+	}{
+		{name: "empty", bounds: []float64{1}, buckets: []uint64{0, 0}, q: 0.5},
+		{name: "first bucket", bounds: []float64{1, 2}, buckets: []uint64{10, 0, 0}, q: 0.5, expected: 0.5, ok: true},
+		{name: "interpolated", bounds: []float64{1, 2}, buckets: []uint64{5, 5, 0}, q: 0.9, expected: 1.8, ok: true},
+		{name: "infinite bucket", bounds: []float64{1, 2}, buckets: []uint64{1, 1, 8}, q: 0.99, expected: 2, ok: true},
+		{name: "negative bound", bounds: []float64{-1, 0}, buckets: []uint64{4, 4, 0}, q: 0.25, expected: -1, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, ok := histogram{bounds: tt.bounds, buckets: tt.buckets}.quantile(tt.q)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.expected, q, 1e-9)
+		})
+	}
+}
+
+func newTestProcessor(t *testing.T, config *Config, next *consumertest.MetricsSink) *streamingAggregationProcessor {
+	require.NoError(t, config.Validate())
+	p, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(metadata.Type), config, next)
+	require.NoError(t, err)
+	require.IsType(t, &streamingAggregationProcessor{}, p)
+	return p.(*streamingAggregationProcessor)
+}
@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
+
+import (
+	"math"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// seriesKind is the kind of the input series, all the series aggregated by a
+// rule are of the same kind.
+type seriesKind int
+
+const (
+	// kindGauge is a gauge or a non-monotonic cumulative sum, whose last value is aggregated.
+	kindGauge seriesKind = iota + 1
+	// kindCounter is a monotonic cumulative sum, whose last value is aggregated.
+	kindCounter
+	// kindDelta is a delta sum, whose values received during the interval are aggregated.
+	kindDelta
+	// kindHistogram is an explicit bucket histogram, whose observations during the interval are aggregated.
+	kindHistogram
+)
+
+// kindOf returns the kind of the series of the metric, or 0 if the metric can't be aggregated.
+func kindOf(m pmetric.Metric) seriesKind {
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return kindGauge
+	case pmetric.MetricTypeSum:
+		switch {
+		case m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta:
+			return kindDelta
+		case m.Sum().IsMonotonic():
+			return kindCounter
+		default:
+			return kindGauge
+		}
+	case pmetric.MetricTypeHistogram:
+		return kindHistogram
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
+	}
+	return 0
+}
+
+// series is the state of an input series.
+type series struct {
+	kind      seriesKind
+	start     pcommon.Timestamp
+	timestamp pcommon.Timestamp
+	// value is the last value of gauges and counters, or the sum of the deltas
+	// received during the interval.
+	value float64
+	// hist is the last value of cumulative histograms, or the sum of the deltas
+	// received during the interval.
+	hist histogram
+	// baseline is the value of cumulative histograms at the previous export.
+	baseline *histogram
+	delta    bool
+	// updated is true if the series received data points during the interval.
+	updated bool
+	// groups are the groups of the rules the series contributes to.
+	groups []seriesGroup
+}
+
+// seriesGroup identifies the output data point of a rule a series contributes to.
+type seriesGroup struct {
+	rule  int
+	key   string
+	attrs pcommon.Map
+}
+
+func (s *series) addNumber(dp pmetric.NumberDataPoint) {
+	var v float64
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		v = float64(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		v = dp.DoubleValue()
+	default:
+		return
+	}
+
+	if s.kind == kindDelta {
+		s.value += v
+		s.updated = true
+		return
+	}
+
+	if dp.Timestamp() < s.timestamp {
+		return
+	}
+	s.value = v
+	s.start = dp.StartTimestamp()
+	s.timestamp = dp.Timestamp()
+	s.updated = true
+}
+
+// addHistogram records the histogram data point, intervalStart being the start
+// of the current interval.
+func (s *series) addHistogram(dp pmetric.HistogramDataPoint, delta bool, intervalStart pcommon.Timestamp) {
+	h := histogramOf(dp)
+	s.delta = delta
+
+	if delta {
+		if !s.updated || !s.hist.merge(h) {
+			s.hist = h
+		}
+		s.updated = true
+		return
+	}
+
+	if dp.Timestamp() < s.timestamp {
+		return
+	}
+	if s.baseline == nil {
+		// The observations of a series started before the interval, or at an
+		// unknown time, were made before it was tracked.
+		baseline := histogram{bounds: h.bounds, buckets: make([]uint64, len(h.buckets))}
+		if dp.StartTimestamp() == 0 || dp.StartTimestamp() < intervalStart {
+			baseline = h.clone()
+		}
+		s.baseline = &baseline
+	}
+	s.hist = h
+	s.start = dp.StartTimestamp()
+	s.timestamp = dp.Timestamp()
+	s.updated = true
+}
+
+// increase returns the observations of the histogram during the interval.
+func (s *series) increase() histogram {
+	if s.delta || s.baseline == nil {
+		return s.hist
+	}
+	return s.hist.sub(*s.baseline)
+}
+
+// reset prepares the series for the next interval.
+func (s *series) reset() {
+	switch s.kind {
+	case kindDelta:
+		s.value = 0
+	case kindHistogram:
+		if s.delta {
+			s.hist = histogram{}
+		} else {
+			baseline := s.hist.clone()
+			s.baseline = &baseline
+		}
+	}
+	s.updated = false
+}
+
+// histogram is an explicit bucket histogram.
+type histogram struct {
+	bounds  []float64
+	buckets []uint64
+	count   uint64
+	sum     float64
+	min     float64
+	max     float64
+	hasMin  bool
+	hasMax  bool
+}
+
+func histogramOf(dp pmetric.HistogramDataPoint) histogram {
+	return histogram{
+		bounds:  dp.ExplicitBounds().AsRaw(),
+		buckets: dp.BucketCounts().AsRaw(),
+		count:   dp.Count(),
+		sum:     dp.Sum(),
+		min:     dp.Min(),
+		max:     dp.Max(),
+		hasMin:  dp.HasMin(),
+		hasMax:  dp.HasMax(),
+	}
+}
+
+func (h histogram) clone() histogram {
+	h.bounds = slices.Clone(h.bounds)
+	h.buckets = slices.Clone(h.buckets)
+	return h
+}
+
+// merge adds the observations of o to h, it returns false if their buckets differ.
+func (h *histogram) merge(o histogram) bool {
+	if !slices.Equal(h.bounds, o.bounds) || len(h.buckets) != len(o.buckets) {
+		return false
+	}
+	for i := range h.buckets {
+		h.buckets[i] += o.buckets[i]
+	}
+	h.count += o.count
+	h.sum += o.sum
+	if o.hasMin && (!h.hasMin || o.min < h.min) {
+		h.min, h.hasMin = o.min, true
+	}
+	if o.hasMax && (!h.hasMax || o.max > h.max) {
+		h.max, h.hasMax = o.max, true
+	}
+	return true
+}
+
+// sub returns the observations of the cumulative histogram h made since o,
+// or h if it was reset.
+func (h histogram) sub(o histogram) histogram {
+	if !slices.Equal(h.bounds, o.bounds) || len(h.buckets) != len(o.buckets) || h.count < o.count {
+		return h
+	}
+	d := h.clone()
+	for i := range d.buckets {
+		if d.buckets[i] < o.buckets[i] {
+			return h
+		}
+		d.buckets[i] -= o.buckets[i]
+	}
+	d.count -= o.count
+	d.sum -= o.sum
+	return d
+}
+
+// quantile estimates the q quantile of the observations of the histogram by
+// linear interpolation within the bucket it falls in, like the Prometheus
+// histogram_quantile function. It returns false if there are no observations.
+func (h histogram) quantile(q float64) (float64, bool) {
+	var total uint64
+	for _, c := range h.buckets {
+		total += c
+	}
+	if total == 0 || len(h.buckets) == 0 {
+		return 0, false
+	}
+
+	rank := q * float64(total)
+	var cumulative uint64
+	for i, c := range h.buckets {
+		if float64(cumulative+c) < rank {
+			cumulative += c
+			continue
+		}
+		if i == len(h.bounds) {
+			// The quantile falls in the +Inf bucket, its lower bound is the best estimate.
+			if i == 0 {
+				return math.Inf(1), true
+			}
+			return h.bounds[i-1], true
+		}
+		upper := h.bounds[i]
+		lower := 0.0
+		if i > 0 {
+			lower = h.bounds[i-1]
+		} else if upper <= 0 {
+			return upper, true
+		}
+		if c == 0 {
+			return upper, true
+		}
+		return lower + (upper-lower)*(rank-float64(cumulative))/float64(c), true
+	}
+	return 0, false
+}
@@ -0,0 +1,515 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// Types of the assertions, recorded in the httpcheck.assertion.type attribute
+const (
+	assertionTypeStatusCode = "status_code"
+	assertionTypeLatency    = "latency"
+	assertionTypeBody       = "body"
+	assertionTypeJSON       = "json"
+	assertionTypeHeader     = "header"
+	assertionTypeExtract    = "extract"
+)
+
+// assertionID identifies an assertion of a request by its type and its index among
+// the assertions of this type, in the order of the configuration
+type assertionID struct {
+	assertionType string
+	index         int
+}
+
+// assertionFailure is an assertion that failed for a response
+type assertionFailure struct {
+	// assertionType is the type of the assertion
+	assertionType string
+	// index is the index of the assertion among the assertions of its type
+	index int
+	// description describes the assertion, and doesn't depend on the response
+	description string
+	// actual is the value of the response that failed the assertion
+	actual string
+}
+
+// message returns the message of the failure for the log events
+func (f assertionFailure) message() string {
+	return fmt.Sprintf("assertion %s failed: got %s", f.description, f.actual)
+}
+
+// response is the part of the response the assertions are evaluated against
+type response struct {
+	statusCode int
+	latency    time.Duration
+	header     http.Header
+	body       []byte
+
+	// json is the decoded body, decoded on first use
+	json    any
+	jsonErr error
+	decoded bool
+}
+
+// decodeJSON returns the decoded body of the response
+func (r *response) decodeJSON() (any, error) {
+	if !r.decoded {
+		r.decoded = true
+		decoder := json.NewDecoder(bytes.NewReader(r.body))
+		decoder.UseNumber()
+		r.jsonErr = decoder.Decode(&r.json)
+	}
+	return r.json, r.jsonErr
+}
+
+// assertions are the compiled assertions of a request
+type assertions struct {
+	statusCodes []statusCodeRange
+	maxLatency  time.Duration
+	bodyMatches []*regexp.Regexp
+	json        []*jsonAssertion
+	headers     []*headerAssertion
+}
+
+func newAssertions(cfg assertionsConfig) (*assertions, error) {
+	var err error
+	a := &assertions{maxLatency: cfg.MaxLatency}
+
+	for _, code := range cfg.StatusCodes {
+		r, parseErr := parseStatusCodeRange(code)
+		if parseErr != nil {
+			err = multierr.Append(err, parseErr)
+			continue
+		}
+		a.statusCodes = append(a.statusCodes, r)
+	}
+	if cfg.MaxLatency < 0 {
+		err = multierr.Append(err, errNegativeLatency)
+	}
+	for _, expr := range cfg.BodyMatches {
+		re, compileErr := regexp.Compile(expr)
+		if compileErr != nil {
+			err = multierr.Append(err, fmt.Errorf("invalid body_matches %q: %w", expr, compileErr))
+			continue
+		}
+		a.bodyMatches = append(a.bodyMatches, re)
+	}
+	for _, jsonCfg := range cfg.JSON {
+		ja, jsonErr := newJSONAssertion(jsonCfg)
+		if jsonErr != nil {
+			err = multierr.Append(err, jsonErr)
+			continue
+		}
+		a.json = append(a.json, ja)
+	}
+	for _, headerCfg := range cfg.Headers {
+		ha, headerErr := newHeaderAssertion(headerCfg)
+		if headerErr != nil {
+			err = multierr.Append(err, headerErr)
+			continue
+		}
+		a.headers = append(a.headers, ha)
+	}
+
+	return a, err
+}
+
+// ids returns the identifiers of the assertions
+func (a *assertions) ids() []assertionID {
+	var ids []assertionID
+	if len(a.statusCodes) > 0 {
+		ids = append(ids, assertionID{assertionType: assertionTypeStatusCode})
+	}
+	if a.maxLatency > 0 {
+		ids = append(ids, assertionID{assertionType: assertionTypeLatency})
+	}
+	for i := range a.bodyMatches {
+		ids = append(ids, assertionID{assertionType: assertionTypeBody, index: i})
+	}
+	for i := range a.json {
+		ids = append(ids, assertionID{assertionType: assertionTypeJSON, index: i})
+	}
+	for i := range a.headers {
+		ids = append(ids, assertionID{assertionType: assertionTypeHeader, index: i})
+	}
+	return ids
+}
+
+// evaluate returns the assertions that failed for the response
+func (a *assertions) evaluate(resp *response) []assertionFailure {
+	var failures []assertionFailure
+
+	if len(a.statusCodes) > 0 && !a.matchStatusCode(resp.statusCode) {
+		ranges := make([]string, len(a.statusCodes))
+		for i, r := range a.statusCodes {
+			ranges[i] = r.text
+		}
+		failures = append(failures, assertionFailure{
+			assertionType: assertionTypeStatusCode,
+			description:   fmt.Sprintf("status_code in (%s)", strings.Join(ranges, ", ")),
+			actual:        strconv.Itoa(resp.statusCode),
+		})
+	}
+
+	if a.maxLatency > 0 && resp.latency > a.maxLatency {
+		failures = append(failures, assertionFailure{
+			assertionType: assertionTypeLatency,
+			description:   fmt.Sprintf("latency <= %s", a.maxLatency),
+			actual:        resp.latency.String(),
+		})
+	}
+
+	for i, re := range a.bodyMatches {
+		if !re.Match(resp.body) {
+			failures = append(failures, assertionFailure{
+				assertionType: assertionTypeBody,
+				index:         i,
+				description:   fmt.Sprintf("body matches %q", re.String()),
+				actual:        fmt.Sprintf("%d bytes not matching", len(resp.body)),
+			})
+		}
+	}
+
+	for i, ja := range a.json {
+		if failure, ok := ja.evaluate(resp); !ok {
+			failure.index = i
+			failures = append(failures, failure)
+		}
+	}
+
+	for i, ha := range a.headers {
+		if failure, ok := ha.evaluate(resp); !ok {
+			failure.index = i
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
+
+func (a *assertions) matchStatusCode(statusCode int) bool {
+	for _, r := range a.statusCodes {
+		if statusCode >= r.low && statusCode <= r.high {
+			return true
+		}
+	}
+	return false
+}
+
+// statusCodeRange is an inclusive range of status codes
+type statusCodeRange struct {
+	text      string
+	low, high int
+}
+
+// parseStatusCodeRange parses a status code (200), a class (2xx) or a range (200-299)
+func parseStatusCodeRange(s string) (statusCodeRange, error) {
+	errInvalid := fmt.Errorf("invalid status code %q: must be a code (200), a class (2xx) or a range (200-299)", s)
+
+	if len(s) == 3 && s[1:] == "xx" && s[0] >= '1' && s[0] <= '5' {
+		class := int(s[0]-'0') * 100
+		return statusCodeRange{text: s, low: class, high: class + 99}, nil
+	}
+
+	lowText, highText, isRange := strings.Cut(s, "-")
+	low, err := strconv.Atoi(strings.TrimSpace(lowText))
+	if err != nil || low < 100 || low > 599 {
+		return statusCodeRange{}, errInvalid
+	}
+	high := low
+	if isRange {
+		high, err = strconv.Atoi(strings.TrimSpace(highText))
+		if err != nil || high < low || high > 599 {
+			return statusCodeRange{}, errInvalid
+		}
+	}
+	return statusCodeRange{text: s, low: low, high: high}, nil
+}
+
+// valueMatcher checks a value against either an exact value or a regular expression.
+// If neither is set, any value matches.
+type valueMatcher struct {
+	value string
+	regex *regexp.Regexp
+}
+
+func newValueMatcher(value, regex string) (valueMatcher, error) {
+	if value != "" && regex != "" {
+		return valueMatcher{}, errValueAndRegex
+	}
+	m := valueMatcher{value: value}
+	if regex != "" {
+		re, err := regexp.Compile(regex)
+		if err != nil {
+			return valueMatcher{}, fmt.Errorf("invalid regex %q: %w", regex, err)
+		}
+		m.regex = re
+	}
+	return m, nil
+}
+
+func (m valueMatcher) match(v string) bool {
+	switch {
+	case m.regex != nil:
+		return m.regex.MatchString(v)
+	case m.value != "":
+		return v == m.value
+	default:
+		return true
+	}
+}
+
+// describe returns the description of an assertion on the subject
+func (m valueMatcher) describe(subject string) string {
+	switch {
+	case m.regex != nil:
+		return fmt.Sprintf("%s matches %q", subject, m.regex.String())
+	case m.value != "":
+		return fmt.Sprintf("%s == %q", subject, m.value)
+	default:
+		return subject + " exists"
+	}
+}
+
+// jsonAssertion is an assertion on a value of the JSON body
+type jsonAssertion struct {
+	path    *jsonPath
+	matcher valueMatcher
+}
+
+func newJSONAssertion(cfg jsonAssertionConfig) (*jsonAssertion, error) {
+	path, err := parseJSONPath(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	matcher, err := newValueMatcher(cfg.Value, cfg.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("json assertion on %q: %w", cfg.Path, err)
+	}
+	return &jsonAssertion{path: path, matcher: matcher}, nil
+}
+
+func (a *jsonAssertion) evaluate(resp *response) (assertionFailure, bool) {
+	failure := assertionFailure{
+		assertionType: assertionTypeJSON,
+		description:   a.matcher.describe(a.path.text),
+	}
+	v, err := a.path.lookup(resp)
+	if err != nil {
+		failure.actual = err.Error()
+		return failure, false
+	}
+	if !a.matcher.match(v) {
+		failure.actual = strconv.Quote(v)
+		return failure, false
+	}
+	return failure, true
+}
+
+// headerAssertion is an assertion on a header of the response
+type headerAssertion struct {
+	name    string
+	matcher valueMatcher
+}
+
+func newHeaderAssertion(cfg headerAssertionConfig) (*headerAssertion, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("header assertion: 'name' must be specified")
+	}
+	matcher, err := newValueMatcher(cfg.Value, cfg.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("header assertion on %q: %w", cfg.Name, err)
+	}
+	return &headerAssertion{name: http.CanonicalHeaderKey(cfg.Name), matcher: matcher}, nil
+}
+
+func (a *headerAssertion) evaluate(resp *response) (assertionFailure, bool) {
+	failure := assertionFailure{
+		assertionType: assertionTypeHeader,
+		description:   a.matcher.describe(a.name),
+	}
+	values, ok := resp.header[a.name]
+	if !ok {
+		failure.actual = "no header"
+		return failure, false
+	}
+	if !a.matcher.match(strings.Join(values, ", ")) {
+		failure.actual = strconv.Quote(strings.Join(values, ", "))
+		return failure, false
+	}
+	return failure, true
+}
+
+// extractor extracts the value of a variable from a response
+type extractor struct {
+	name     string
+	jsonPath *jsonPath
+	header   string
+	regex    *regexp.Regexp
+}
+
+func newExtractor(name string, cfg extractConfig) (*extractor, error) {
+	sources := 0
+	e := &extractor{name: name}
+	if cfg.JSONPath != "" {
+		sources++
+		path, err := parseJSONPath(cfg.JSONPath)
+		if err != nil {
+			return nil, fmt.Errorf("extract %q: %w", name, err)
+		}
+		e.jsonPath = path
+	}
+	if cfg.Header != "" {
+		sources++
+		e.header = http.CanonicalHeaderKey(cfg.Header)
+	}
+	if cfg.Regex != "" {
+		sources++
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("extract %q: invalid regex %q: %w", name, cfg.Regex, err)
+		}
+		if re.NumSubexp() < 1 {
+			return nil, fmt.Errorf("extract %q: regex %q must have a group", name, cfg.Regex)
+		}
+		e.regex = re
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("extract %q: %w", name, errExtractSource)
+	}
+	return e, nil
+}
+
+// extract returns the value of the variable, or the failure if it can't be extracted
+func (e *extractor) extract(resp *response) (string, *assertionFailure) {
+	failure := &assertionFailure{assertionType: assertionTypeExtract}
+	switch {
+	case e.jsonPath != nil:
+		failure.description = fmt.Sprintf("%s from %s", e.name, e.jsonPath.text)
+		v, err := e.jsonPath.lookup(resp)
+		if err != nil {
+			failure.actual = err.Error()
+			return "", failure
+		}
+		return v, nil
+	case e.header != "":
+		failure.description = fmt.Sprintf("%s from header %s", e.name, e.header)
+		if v := resp.header.Get(e.header); v != "" {
+			return v, nil
+		}
+		failure.actual = "no header"
+		return "", failure
+	default:
+		failure.description = fmt.Sprintf("%s from body matching %q", e.name, e.regex.String())
+		if m := e.regex.FindSubmatch(resp.body); m != nil {
+			return string(m[1]), nil
+		}
+		failure.actual = fmt.Sprintf("%d bytes not matching", len(resp.body))
+		return "", failure
+	}
+}
+
+// jsonPath is a path to a value of a JSON document, made of object keys and array
+// indexes, such as $.items[0].status or $["content-type"].
+type jsonPath struct {
+	text     string
+	segments []any // string keys and int indexes
+}
+
+func parseJSONPath(s string) (*jsonPath, error) {
+	errInvalid := func(reason string) error {
+		return fmt.Errorf("invalid JSON path %q: %s", s, reason)
+	}
+
+	p := &jsonPath{text: s}
+	rest := strings.TrimPrefix(s, "$")
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		// The leading $. is optional
+		rest = "." + rest
+	}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, errInvalid("empty key")
+			}
+			p.segments = append(p.segments, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, errInvalid("missing ]")
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			if key, err := strconv.Unquote(inner); err == nil && len(inner) > 0 && inner[0] == '"' {
+				p.segments = append(p.segments, key)
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, errInvalid("index must be a non-negative integer or a quoted key")
+			}
+			p.segments = append(p.segments, index)
+		default:
+			return nil, errInvalid("expected . or [")
+		}
+	}
+	if len(p.segments) == 0 {
+		return nil, errInvalid("empty path")
+	}
+	return p, nil
+}
+
+// lookup returns the value at the path in the JSON body of the response. Strings
+// are returned as is, other values as JSON.
+func (p *jsonPath) lookup(resp *response) (string, error) {
+	v, err := resp.decodeJSON()
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON body: %w", err)
+	}
+	for _, segment := range p.segments {
+		switch s := segment.(type) {
+		case string:
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return "", fmt.Errorf("no value at %s", p.text)
+			}
+			if v, ok = obj[s]; !ok {
+				return "", fmt.Errorf("no value at %s", p.text)
+			}
+		case int:
+			arr, ok := v.([]any)
+			if !ok || s >= len(arr) {
+				return "", fmt.Errorf("no value at %s", p.text)
+			}
+			v = arr[s]
+		}
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
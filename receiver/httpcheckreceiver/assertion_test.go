@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatusCodeRange(t *testing.T) {
+	testCases := []struct {
+		in        string
+		low, high int
+		expectErr bool
+	}{
+		{in: "200", low: 200, high: 200},
+		{in: "2xx", low: 200, high: 299},
+		{in: "5xx", low: 500, high: 599},
+		{in: "200-204", low: 200, high: 204},
+		{in: "6xx", expectErr: true},
+		{in: "204-200", expectErr: true},
+		{in: "abc", expectErr: true},
+		{in: "99", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			r, err := parseStatusCodeRange(tc.in)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.low, r.low)
+			assert.Equal(t, tc.high, r.high)
+		})
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	resp := &response{body: []byte(`{"status":"ok","items":[{"id":1},{"id":2.5}],"content-type":"json","ready":true,"meta":{"n":null}}`)}
+
+	testCases := []struct {
+		path      string
+		expected  string
+		expectErr string
+	}{
+		{path: "$.status", expected: "ok"},
+		{path: "status", expected: "ok"},
+		{path: "$.items[1].id", expected: "2.5"},
+		{path: `$["content-type"]`, expected: "json"},
+		{path: "$.ready", expected: "true"},
+		{path: "$.meta", expected: `{"n":null}`},
+		{path: "$.items[0]", expected: `{"id":1}`},
+		{path: "$.items[2]", expectErr: "no value at $.items[2]"},
+		{path: "$.status.code", expectErr: "no value at $.status.code"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := parseJSONPath(tc.path)
+			require.NoError(t, err)
+			v, err := p.lookup(resp)
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+
+	for _, invalid := range []string{"", "$", "$..a", "$.a[", "$.a[-1]", "$.a[x]"} {
+		_, err := parseJSONPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	_, err := (&jsonPath{text: "$.a", segments: []any{"a"}}).lookup(&response{body: []byte("not json")})
+	assert.ErrorContains(t, err, "invalid JSON body")
+}
+
+func TestAssertionsEvaluate(t *testing.T) {
+	a, err := newAssertions(assertionsConfig{
+		StatusCodes: []string{"2xx", "304"},
+		MaxLatency:  100 * time.Millisecond,
+		BodyMatches: []string{`"status":\s*"ok"`},
+		JSON: []jsonAssertionConfig{
+			{Path: "$.status", Value: "ok"},
+			{Path: "$.version", Regex: `^1\.`},
+			{Path: "$.build"},
+		},
+		Headers: []headerAssertionConfig{
+			{Name: "content-type", Regex: "^application/json"},
+			{Name: "X-Request-Id"},
+		},
+	})
+	require.NoError(t, err)
+
+	passing := &response{
+		statusCode: 200,
+		latency:    10 * time.Millisecond,
+		header:     http.Header{"Content-Type": {"application/json"}, "X-Request-Id": {"1"}},
+		body:       []byte(`{"status": "ok", "version": "1.2.0", "build": 42}`),
+	}
+	assert.Empty(t, a.evaluate(passing))
+
+	failing := &response{
+		statusCode: 503,
+		latency:    time.Second,
+		header:     http.Header{"Content-Type": {"text/plain"}},
+		body:       []byte(`{"status": "degraded", "version": "2.0.0"}`),
+	}
+	assert.Equal(t, []assertionFailure{
+		{assertionType: assertionTypeStatusCode, description: "status_code in (2xx, 304)", actual: "503"},
+		{assertionType: assertionTypeLatency, description: "latency <= 100ms", actual: "1s"},
+		{assertionType: assertionTypeBody, description: `body matches "\"status\":\\s*\"ok\""`, actual: "42 bytes not matching"},
+		{assertionType: assertionTypeJSON, description: `$.status == "ok"`, actual: `"degraded"`},
+		{assertionType: assertionTypeJSON, index: 1, description: `$.version matches "^1\\."`, actual: `"2.0.0"`},
+		{assertionType: assertionTypeJSON, index: 2, description: "$.build exists", actual: "no value at $.build"},
+		{assertionType: assertionTypeHeader, description: `Content-Type matches "^application/json"`, actual: `"text/plain"`},
+		{assertionType: assertionTypeHeader, index: 1, description: "X-Request-Id exists", actual: "no header"},
+	}, a.evaluate(failing))
+}
+
+func TestNewAssertionsErrors(t *testing.T) {
+	_, err := newAssertions(assertionsConfig{
+		StatusCodes: []string{"2xx", "700"},
+		MaxLatency:  -time.Second,
+		BodyMatches: []string{"("},
+		JSON:        []jsonAssertionConfig{{Path: "$.a", Value: "b", Regex: "c"}},
+		Headers:     []headerAssertionConfig{{Value: "b"}},
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `invalid status code "700"`)
+	assert.ErrorContains(t, err, errNegativeLatency.Error())
+	assert.ErrorContains(t, err, `invalid body_matches "("`)
+	assert.ErrorContains(t, err, `json assertion on "$.a": `+errValueAndRegex.Error())
+	assert.ErrorContains(t, err, "header assertion: 'name' must be specified")
+}
+
+func TestExtractor(t *testing.T) {
+	resp := &response{
+		header: http.Header{"X-Session": {"abc"}},
+		body:   []byte(`{"token":"t0k3n","id":7}`),
+	}
+
+	testCases := []struct {
+		desc     string
+		cfg      extractConfig
+		expected string
+		failure  *assertionFailure
+	}{
+		{desc: "json path", cfg: extractConfig{JSONPath: "$.token"}, expected: "t0k3n"},
+		{desc: "json number", cfg: extractConfig{JSONPath: "$.id"}, expected: "7"},
+		{desc: "header", cfg: extractConfig{Header: "x-session"}, expected: "abc"},
+		{desc: "regex", cfg: extractConfig{Regex: `"token":"(\w+)"`}, expected: "t0k3n"},
+		{
+			desc:    "missing json value",
+			cfg:     extractConfig{JSONPath: "$.missing"},
+			failure: &assertionFailure{assertionType: assertionTypeExtract, description: "v from $.missing", actual: "no value at $.missing"},
+		},
+		{
+			desc:    "missing header",
+			cfg:     extractConfig{Header: "X-Token"},
+			failure: &assertionFailure{assertionType: assertionTypeExtract, description: "v from header X-Token", actual: "no header"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			e, err := newExtractor("v", tc.cfg)
+			require.NoError(t, err)
+			v, failure := e.extract(resp)
+			assert.Equal(t, tc.failure, failure)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+
+	_, err := newExtractor("v", extractConfig{JSONPath: "$.a", Header: "b"})
+	assert.ErrorIs(t, err, errExtractSource)
+	_, err = newExtractor("v", extractConfig{})
+	assert.ErrorIs(t, err, errExtractSource)
+	_, err = newExtractor("v", extractConfig{Regex: "no group"})
+	assert.ErrorContains(t, err, "must have a group")
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
+)
+
+// maxBodySize is the maximum number of bytes of a response body the assertions and
+// extractors are evaluated against
+const maxBodySize = 1 << 20
+
+var (
+	// variableName matches the valid names of variables
+	variableName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	// variablePattern matches a reference to a variable, such as {{token}}
+	variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+)
+
+// check is the sequence of requests of a target to one of its endpoints, or of a scenario
+type check struct {
+	client *http.Client
+	steps  []*step
+}
+
+// step is a request of a check, the assertions on its response, and the variables
+// extracted from it
+type step struct {
+	name       string
+	method     string
+	endpoint   string
+	headers    map[string]configopaque.String
+	body       string
+	assertions *assertions
+	extractors []*extractor
+	// assertionIDs identify the assertions and the extractors of the step
+	assertionIDs []assertionID
+	// readBody is true if the assertions or the extractors need the body of the response
+	readBody bool
+}
+
+// stepResult is the result of a step of a check
+type stepResult struct {
+	step       *step
+	timestamp  pcommon.Timestamp
+	duration   time.Duration
+	statusCode int
+	err        error
+	failures   []assertionFailure
+}
+
+// failed returns true if the request failed or any assertion failed
+func (r *stepResult) failed() bool {
+	return r.err != nil || len(r.failures) > 0
+}
+
+func newStep(name, method, endpoint string, headers map[string]configopaque.String, body string, assertionsCfg assertionsConfig, extract map[string]extractConfig) (*step, error) {
+	a, err := newAssertions(assertionsCfg)
+	s := &step{
+		name:       name,
+		method:     method,
+		endpoint:   endpoint,
+		headers:    headers,
+		body:       body,
+		assertions: a,
+		readBody:   len(assertionsCfg.BodyMatches) > 0 || len(assertionsCfg.JSON) > 0,
+	}
+
+	// Extract the variables in a stable order
+	names := make([]string, 0, len(extract))
+	for name := range extract {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !variableName.MatchString(name) {
+			err = multierr.Append(err, fmt.Errorf("invalid variable name %q", name))
+			continue
+		}
+		e, extractErr := newExtractor(name, extract[name])
+		if extractErr != nil {
+			err = multierr.Append(err, extractErr)
+			continue
+		}
+		s.extractors = append(s.extractors, e)
+		s.readBody = s.readBody || e.header == ""
+	}
+
+	s.assertionIDs = a.ids()
+	for i := range s.extractors {
+		s.assertionIDs = append(s.assertionIDs, assertionID{assertionType: assertionTypeExtract, index: i})
+	}
+
+	return s, err
+}
+
+// newSteps returns the steps of the scenario of the target, checking that the steps
+// only use the variables extracted by the previous steps
+func newSteps(cfg *targetConfig) ([]*step, error) {
+	var err error
+	var steps []*step
+	var variables []string
+
+	for i, stepCfg := range cfg.Steps {
+		var stepErr error
+		if stepCfg.Endpoint == "" {
+			stepErr = multierr.Append(stepErr, errStepEndpoint)
+		} else if _, parseErr := url.ParseRequestURI(stepCfg.Endpoint); parseErr != nil {
+			stepErr = multierr.Append(stepErr, fmt.Errorf("%s: %w", errInvalidEndpoint.Error(), parseErr))
+		}
+
+		templates := []string{stepCfg.Endpoint, stepCfg.Body}
+		for _, v := range stepCfg.Headers {
+			templates = append(templates, string(v))
+		}
+		for _, template := range templates {
+			for _, m := range variablePattern.FindAllStringSubmatch(template, -1) {
+				if !slices.Contains(variables, m[1]) {
+					stepErr = multierr.Append(stepErr, fmt.Errorf("variable %q is not extracted by a previous step", m[1]))
+				}
+			}
+		}
+
+		s, newErr := newStep(stepCfg.Name, stepCfg.Method, stepCfg.Endpoint, stepCfg.Headers, stepCfg.Body, stepCfg.Assertions, stepCfg.Extract)
+		stepErr = multierr.Append(stepErr, newErr)
+		if stepErr != nil {
+			err = multierr.Append(err, fmt.Errorf("step %d: %w", i+1, stepErr))
+			continue
+		}
+		for _, e := range s.extractors {
+			variables = append(variables, e.name)
+		}
+		steps = append(steps, s)
+	}
+
+	return steps, err
+}
+
+// run sends the requests of the steps in order, and stops at the first failed step
+func (c *check) run(ctx context.Context) []*stepResult {
+	variables := map[string]string{}
+	results := make([]*stepResult, 0, len(c.steps))
+	for _, s := range c.steps {
+		r := s.run(ctx, c.client, variables)
+		results = append(results, r)
+		if r.failed() {
+			break
+		}
+	}
+	return results
+}
+
+// run sends the request of the step, evaluates the assertions on the response, and
+// adds the extracted variables to variables
+func (s *step) run(ctx context.Context, client *http.Client, variables map[string]string) *stepResult {
+	r := &stepResult{step: s, timestamp: pcommon.NewTimestampFromTime(time.Now())}
+
+	var body io.Reader = http.NoBody
+	if s.body != "" {
+		body = strings.NewReader(expandVariables(s.body, variables))
+	}
+	req, err := http.NewRequestWithContext(ctx, s.method, expandVariables(s.endpoint, variables), body)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	for key, value := range s.headers {
+		req.Header.Set(key, expandVariables(string(value), variables))
+	}
+
+	// Send the request and measure response time
+	start := time.Now()
+	resp, err := client.Do(req)
+	r.duration = time.Since(start)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	defer resp.Body.Close()
+	r.statusCode = resp.StatusCode
+
+	checked := &response{statusCode: resp.StatusCode, latency: r.duration, header: resp.Header}
+	if s.readBody {
+		if checked.body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodySize)); err != nil {
+			r.err = fmt.Errorf("failed to read the response body: %w", err)
+			return r
+		}
+	}
+
+	r.failures = s.assertions.evaluate(checked)
+	for i, e := range s.extractors {
+		v, failure := e.extract(checked)
+		if failure != nil {
+			failure.index = i
+			r.failures = append(r.failures, *failure)
+			continue
+		}
+		variables[e.name] = v
+	}
+	return r
+}
+
+// expandVariables replaces the references to variables in s by their values
+func expandVariables(s string, variables map[string]string) string {
+	if len(variables) == 0 {
+		return s
+	}
+	return variablePattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if v, ok := variables[variablePattern.FindStringSubmatch(ref)[1]]; ok {
+			return v
+		}
+		return ref
+	})
+}
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 	"go.uber.org/multierr"
 
@@ -19,6 +21,11 @@ import (
 var (
 	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>[:<port>]`)
 	errMissingEndpoint = errors.New("at least one of 'endpoint' or 'endpoints' must be specified")
+	errStepsEndpoint   = errors.New("'endpoint' and 'endpoints' cannot be specified with 'steps'")
+	errStepEndpoint    = errors.New("'endpoint' must be specified for each step")
+	errNegativeLatency = errors.New("'max_latency' must not be negative")
+	errValueAndRegex   = errors.New("only one of 'value' or 'regex' can be specified")
+	errExtractSource   = errors.New("exactly one of 'json_path', 'header' or 'regex' must be specified")
 )
 
 // Config defines the configuration for the various elements of the receiver agent.
@@ -36,12 +43,86 @@ type targetConfig struct {
 	confighttp.ClientConfig `mapstructure:",squash"`
 	Method                  string   `mapstructure:"method"`
 	Endpoints               []string `mapstructure:"endpoints"` // Field for a list of endpoints
+	// Body is the body of the request.
+	Body string `mapstructure:"body"`
+	// Assertions are the assertions on the response, a failed assertion fails the check.
+	Assertions assertionsConfig `mapstructure:"assertions"`
+	// Steps are the requests of a multi-step scenario, which are sent in order instead
+	// of a single request to the endpoint. The headers and the client configuration of
+	// the target apply to every step.
+	Steps []*stepConfig `mapstructure:"steps"`
+}
+
+// stepConfig defines a request of a multi-step scenario.
+type stepConfig struct {
+	// Name identifies the step in the log events of its failed assertions.
+	Name     string `mapstructure:"name"`
+	Method   string `mapstructure:"method"`
+	Endpoint string `mapstructure:"endpoint"`
+	// Headers are the headers of the request, in addition to the headers of the target.
+	Headers map[string]configopaque.String `mapstructure:"headers"`
+	// Body is the body of the request.
+	Body string `mapstructure:"body"`
+	// Assertions are the assertions on the response, a failed assertion stops the scenario.
+	Assertions assertionsConfig `mapstructure:"assertions"`
+	// Extract defines the variables extracted from the response. The later steps
+	// can use a variable with {{name}} in their endpoint, headers and body.
+	Extract map[string]extractConfig `mapstructure:"extract"`
+}
+
+// assertionsConfig defines the assertions on the response of a request.
+type assertionsConfig struct {
+	// StatusCodes are the expected status codes, as a code (200), a class (2xx) or a range (200-299).
+	StatusCodes []string `mapstructure:"status_codes"`
+	// MaxLatency is the maximum duration until the response headers are received.
+	MaxLatency time.Duration `mapstructure:"max_latency"`
+	// BodyMatches are the regular expressions the body must match.
+	BodyMatches []string `mapstructure:"body_matches"`
+	// JSON are the assertions on the values of the JSON body.
+	JSON []jsonAssertionConfig `mapstructure:"json"`
+	// Headers are the assertions on the headers of the response.
+	Headers []headerAssertionConfig `mapstructure:"headers"`
+}
+
+// jsonAssertionConfig defines an assertion on a value of the JSON body. If neither
+// Value nor Regex is specified, the value must exist.
+type jsonAssertionConfig struct {
+	// Path is the path of the value, such as $.items[0].status.
+	Path  string `mapstructure:"path"`
+	Value string `mapstructure:"value"`
+	Regex string `mapstructure:"regex"`
+}
+
+// headerAssertionConfig defines an assertion on a header of the response. If neither
+// Value nor Regex is specified, the header must exist.
+type headerAssertionConfig struct {
+	Name  string `mapstructure:"name"`
+	Value string `mapstructure:"value"`
+	Regex string `mapstructure:"regex"`
+}
+
+// extractConfig defines where the value of a variable is extracted from. The value
+// is either a value of the JSON body, a header, or the first group of a regular
+// expression matching the body.
+type extractConfig struct {
+	JSONPath string `mapstructure:"json_path"`
+	Header   string `mapstructure:"header"`
+	Regex    string `mapstructure:"regex"`
 }
 
 // Validate validates an individual targetConfig.
 func (cfg *targetConfig) Validate() error {
 	var err error
 
+	// A scenario sends its steps instead of requests to the endpoints.
+	if len(cfg.Steps) > 0 {
+		if cfg.Endpoint != "" || len(cfg.Endpoints) > 0 {
+			err = multierr.Append(err, errStepsEndpoint)
+		}
+		_, stepsErr := newSteps(cfg)
+		return multierr.Append(err, stepsErr)
+	}
+
 	// Ensure at least one of 'endpoint' or 'endpoints' is specified.
 	if cfg.Endpoint == "" && len(cfg.Endpoints) == 0 {
 		err = multierr.Append(err, errMissingEndpoint)
@@ -61,6 +142,10 @@ func (cfg *targetConfig) Validate() error {
 		}
 	}
 
+	if _, assertionsErr := newAssertions(cfg.Assertions); assertionsErr != nil {
+		err = multierr.Append(err, assertionsErr)
+	}
+
 	return err
 }
 
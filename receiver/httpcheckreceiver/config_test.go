@@ -4,11 +4,13 @@
 package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 	"go.uber.org/multierr"
 )
@@ -197,6 +199,102 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			desc: "valid scenario",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						Steps: []*stepConfig{
+							{
+								Method:   "POST",
+								Endpoint: "https://opentelemetry.io/login",
+								Extract: map[string]extractConfig{
+									"token": {JSONPath: "$.token"},
+								},
+							},
+							{
+								Endpoint: "https://opentelemetry.io/docs",
+								Headers: map[string]configopaque.String{
+									"Authorization": "Bearer {{token}}",
+								},
+								Assertions: assertionsConfig{
+									StatusCodes: []string{"2xx"},
+									BodyMatches: []string{"OpenTelemetry"},
+								},
+							},
+						},
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "scenario with endpoint",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						ClientConfig: confighttp.ClientConfig{
+							Endpoint: "https://opentelemetry.io",
+						},
+						Steps: []*stepConfig{
+							{
+								Endpoint: "https://opentelemetry.io/docs",
+							},
+						},
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: multierr.Combine(
+				errStepsEndpoint,
+			),
+		},
+		{
+			desc: "scenario with invalid steps",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						Steps: []*stepConfig{
+							{},
+							{
+								Endpoint: "https://opentelemetry.io/{{id}}",
+								Extract: map[string]extractConfig{
+									"id": {},
+								},
+							},
+						},
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: multierr.Combine(
+				fmt.Errorf("step 1: %w", errStepEndpoint),
+				fmt.Errorf("step 2: %w", multierr.Combine(
+					errors.New(`variable "id" is not extracted by a previous step`),
+					fmt.Errorf(`extract "id": %w`, errExtractSource),
+				)),
+			),
+		},
+		{
+			desc: "invalid assertions",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						ClientConfig: confighttp.ClientConfig{
+							Endpoint: "https://opentelemetry.io",
+						},
+						Assertions: assertionsConfig{
+							StatusCodes: []string{"2xx", "20x"},
+						},
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`invalid status code "20x": must be a code (200), a class (2xx) or a range (200-299)`),
+			),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -11,20 +11,26 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
-	"go.opentelemetry.io/collector/scraper"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
 
 var errConfigNotHTTPCheck = errors.New("config was not a HTTP check receiver config")
 
+// receivers are the HTTP check receivers of the configurations, shared by the metrics and
+// logs pipelines so that the checks run once for both. A receiver is removed from the map
+// when it is shut down.
+var receivers = sharedcomponent.NewSharedComponents()
+
 // NewFactory creates a new receiver factory
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
 }
 
 func createDefaultConfig() component.Config {
@@ -44,11 +50,22 @@ func createMetricsReceiver(_ context.Context, params receiver.Settings, rConf co
 		return nil, errConfigNotHTTPCheck
 	}
 
-	httpcheckScraper := newScraper(cfg, params)
-	s, err := scraper.NewMetrics(httpcheckScraper.scrape, scraper.WithStart(httpcheckScraper.start))
-	if err != nil {
-		return nil, err
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReceiver(cfg, params)
+	})
+	r.Unwrap().(*httpcheckReceiver).metricsConsumer = consumer
+	return r, nil
+}
+
+func createLogsReceiver(_ context.Context, params receiver.Settings, rConf component.Config, consumer consumer.Logs) (receiver.Logs, error) {
+	cfg, ok := rConf.(*Config)
+	if !ok {
+		return nil, errConfigNotHTTPCheck
 	}
 
-	return scraperhelper.NewMetricsController(&cfg.ControllerConfig, params, consumer, scraperhelper.AddScraper(metadata.Type, s))
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReceiver(cfg, params)
+	})
+	r.Unwrap().(*httpcheckReceiver).logsConsumer = consumer
+	return r, nil
 }
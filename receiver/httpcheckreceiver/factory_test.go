@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
 
@@ -79,3 +80,21 @@ func TestNewFactory(t *testing.T) {
 		t.Run(tc.desc, tc.testFunc)
 	}
 }
+
+func TestCreateSharedReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := receivertest.NewNopSettings(metadata.Type)
+	metricsConsumer, logsConsumer := new(consumertest.MetricsSink), new(consumertest.LogsSink)
+	metricsReceiver, err := factory.CreateMetrics(context.Background(), set, cfg, metricsConsumer)
+	require.NoError(t, err)
+	logsReceiver, err := factory.CreateLogs(context.Background(), set, cfg, logsConsumer)
+	require.NoError(t, err)
+
+	// The checks of the configuration run once for both pipelines
+	require.Same(t, metricsReceiver, logsReceiver)
+	r := metricsReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*httpcheckReceiver)
+	require.Same(t, metricsConsumer, r.metricsConsumer)
+	require.Same(t, logsConsumer, r.logsConsumer)
+	require.NoError(t, metricsReceiver.Shutdown(context.Background()))
+}
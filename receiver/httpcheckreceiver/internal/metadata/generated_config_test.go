@@ -27,9 +27,10 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "all_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					HttpcheckDuration: MetricConfig{Enabled: true},
-					HttpcheckError:    MetricConfig{Enabled: true},
-					HttpcheckStatus:   MetricConfig{Enabled: true},
+					HttpcheckAssertionFailed: MetricConfig{Enabled: true},
+					HttpcheckDuration:        MetricConfig{Enabled: true},
+					HttpcheckError:           MetricConfig{Enabled: true},
+					HttpcheckStatus:          MetricConfig{Enabled: true},
 				},
 			},
 		},
@@ -37,9 +38,10 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "none_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					HttpcheckDuration: MetricConfig{Enabled: false},
-					HttpcheckError:    MetricConfig{Enabled: false},
-					HttpcheckStatus:   MetricConfig{Enabled: false},
+					HttpcheckAssertionFailed: MetricConfig{Enabled: false},
+					HttpcheckDuration:        MetricConfig{Enabled: false},
+					HttpcheckError:           MetricConfig{Enabled: false},
+					HttpcheckStatus:          MetricConfig{Enabled: false},
 				},
 			},
 		},
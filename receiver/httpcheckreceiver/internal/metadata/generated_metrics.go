@@ -11,7 +11,52 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 )
 
+// AttributeHttpcheckAssertionType specifies the value httpcheck.assertion.type attribute.
+type AttributeHttpcheckAssertionType int
+
+const (
+	_ AttributeHttpcheckAssertionType = iota
+	AttributeHttpcheckAssertionTypeStatusCode
+	AttributeHttpcheckAssertionTypeLatency
+	AttributeHttpcheckAssertionTypeBody
+	AttributeHttpcheckAssertionTypeJson
+	AttributeHttpcheckAssertionTypeHeader
+	AttributeHttpcheckAssertionTypeExtract
+)
+
+// String returns the string representation of the AttributeHttpcheckAssertionType.
+func (av AttributeHttpcheckAssertionType) String() string {
+	switch av {
+	case AttributeHttpcheckAssertionTypeStatusCode:
+		return "status_code"
+	case AttributeHttpcheckAssertionTypeLatency:
+		return "latency"
+	case AttributeHttpcheckAssertionTypeBody:
+		return "body"
+	case AttributeHttpcheckAssertionTypeJson:
+		return "json"
+	case AttributeHttpcheckAssertionTypeHeader:
+		return "header"
+	case AttributeHttpcheckAssertionTypeExtract:
+		return "extract"
+	}
+	return ""
+}
+
+// MapAttributeHttpcheckAssertionType is a helper map of string to AttributeHttpcheckAssertionType attribute value.
+var MapAttributeHttpcheckAssertionType = map[string]AttributeHttpcheckAssertionType{
+	"status_code": AttributeHttpcheckAssertionTypeStatusCode,
+	"latency":     AttributeHttpcheckAssertionTypeLatency,
+	"body":        AttributeHttpcheckAssertionTypeBody,
+	"json":        AttributeHttpcheckAssertionTypeJson,
+	"header":      AttributeHttpcheckAssertionTypeHeader,
+	"extract":     AttributeHttpcheckAssertionTypeExtract,
+}
+
 var MetricsInfo = metricsInfo{
+	HttpcheckAssertionFailed: metricInfo{
+		Name: "httpcheck.assertion.failed",
+	},
 	HttpcheckDuration: metricInfo{
 		Name: "httpcheck.duration",
 	},
@@ -24,15 +69,72 @@ var MetricsInfo = metricsInfo{
 }
 
 type metricsInfo struct {
-	HttpcheckDuration metricInfo
-	HttpcheckError    metricInfo
-	HttpcheckStatus   metricInfo
+	HttpcheckAssertionFailed metricInfo
+	HttpcheckDuration        metricInfo
+	HttpcheckError           metricInfo
+	HttpcheckStatus          metricInfo
 }
 
 type metricInfo struct {
 	Name string
 }
 
+type metricHttpcheckAssertionFailed struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.assertion.failed metric with initial data.
+func (m *metricHttpcheckAssertionFailed) init() {
+	m.data.SetName("httpcheck.assertion.failed")
+	m.data.SetDescription("1 if the assertion failed during HTTP check, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckAssertionFailed) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, httpcheckAssertionTypeAttributeValue string, httpcheckAssertionIndexAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.method", httpMethodAttributeValue)
+	dp.Attributes().PutStr("httpcheck.assertion.type", httpcheckAssertionTypeAttributeValue)
+	dp.Attributes().PutInt("httpcheck.assertion.index", httpcheckAssertionIndexAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckAssertionFailed) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckAssertionFailed) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckAssertionFailed(cfg MetricConfig) metricHttpcheckAssertionFailed {
+	m := metricHttpcheckAssertionFailed{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckDuration struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -197,14 +299,15 @@ func newMetricHttpcheckStatus(cfg MetricConfig) metricHttpcheckStatus {
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user config.
 type MetricsBuilder struct {
-	config                  MetricsBuilderConfig // config of the metrics builder.
-	startTime               pcommon.Timestamp    // start time that will be applied to all recorded data points.
-	metricsCapacity         int                  // maximum observed number of metrics per resource.
-	metricsBuffer           pmetric.Metrics      // accumulates metrics data before emitting.
-	buildInfo               component.BuildInfo  // contains version information.
-	metricHttpcheckDuration metricHttpcheckDuration
-	metricHttpcheckError    metricHttpcheckError
-	metricHttpcheckStatus   metricHttpcheckStatus
+	config                         MetricsBuilderConfig // config of the metrics builder.
+	startTime                      pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity                int                  // maximum observed number of metrics per resource.
+	metricsBuffer                  pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                      component.BuildInfo  // contains version information.
+	metricHttpcheckAssertionFailed metricHttpcheckAssertionFailed
+	metricHttpcheckDuration        metricHttpcheckDuration
+	metricHttpcheckError           metricHttpcheckError
+	metricHttpcheckStatus          metricHttpcheckStatus
 }
 
 // MetricBuilderOption applies changes to default metrics builder.
@@ -226,13 +329,14 @@ func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
 }
 func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, options ...MetricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		config:                  mbc,
-		startTime:               pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:           pmetric.NewMetrics(),
-		buildInfo:               settings.BuildInfo,
-		metricHttpcheckDuration: newMetricHttpcheckDuration(mbc.Metrics.HttpcheckDuration),
-		metricHttpcheckError:    newMetricHttpcheckError(mbc.Metrics.HttpcheckError),
-		metricHttpcheckStatus:   newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
+		config:                         mbc,
+		startTime:                      pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                  pmetric.NewMetrics(),
+		buildInfo:                      settings.BuildInfo,
+		metricHttpcheckAssertionFailed: newMetricHttpcheckAssertionFailed(mbc.Metrics.HttpcheckAssertionFailed),
+		metricHttpcheckDuration:        newMetricHttpcheckDuration(mbc.Metrics.HttpcheckDuration),
+		metricHttpcheckError:           newMetricHttpcheckError(mbc.Metrics.HttpcheckError),
+		metricHttpcheckStatus:          newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
 	}
 
 	for _, op := range options {
@@ -298,6 +402,7 @@ func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
 	ils.Scope().SetName(ScopeName)
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricHttpcheckAssertionFailed.emit(ils.Metrics())
 	mb.metricHttpcheckDuration.emit(ils.Metrics())
 	mb.metricHttpcheckError.emit(ils.Metrics())
 	mb.metricHttpcheckStatus.emit(ils.Metrics())
@@ -322,6 +427,11 @@ func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics
 	return metrics
 }
 
+// RecordHttpcheckAssertionFailedDataPoint adds a data point to httpcheck.assertion.failed metric.
+func (mb *MetricsBuilder) RecordHttpcheckAssertionFailedDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, httpcheckAssertionTypeAttributeValue AttributeHttpcheckAssertionType, httpcheckAssertionIndexAttributeValue int64) {
+	mb.metricHttpcheckAssertionFailed.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpMethodAttributeValue, httpcheckAssertionTypeAttributeValue.String(), httpcheckAssertionIndexAttributeValue)
+}
+
 // RecordHttpcheckDurationDataPoint adds a data point to httpcheck.duration metric.
 func (mb *MetricsBuilder) RecordHttpcheckDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
 	mb.metricHttpcheckDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
@@ -59,6 +59,10 @@ func TestMetricsBuilder(t *testing.T) {
 			defaultMetricsCount := 0
 			allMetricsCount := 0
 
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordHttpcheckAssertionFailedDataPoint(ts, 1, "http.url-val", "http.method-val", AttributeHttpcheckAssertionTypeStatusCode, 25)
+
 			defaultMetricsCount++
 			allMetricsCount++
 			mb.RecordHttpcheckDurationDataPoint(ts, 1, "http.url-val")
@@ -93,6 +97,32 @@ func TestMetricsBuilder(t *testing.T) {
 			validatedMetrics := make(map[string]bool)
 			for i := 0; i < ms.Len(); i++ {
 				switch ms.At(i).Name() {
+				case "httpcheck.assertion.failed":
+					assert.False(t, validatedMetrics["httpcheck.assertion.failed"], "Found a duplicate in the metrics slice: httpcheck.assertion.failed")
+					validatedMetrics["httpcheck.assertion.failed"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "1 if the assertion failed during HTTP check, otherwise 0.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					assert.False(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("http.url")
+					assert.True(t, ok)
+					assert.Equal(t, "http.url-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("http.method")
+					assert.True(t, ok)
+					assert.Equal(t, "http.method-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("httpcheck.assertion.type")
+					assert.True(t, ok)
+					assert.Equal(t, "status_code", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("httpcheck.assertion.index")
+					assert.True(t, ok)
+					assert.EqualValues(t, 25, attrVal.Int())
 				case "httpcheck.duration":
 					assert.False(t, validatedMetrics["httpcheck.duration"], "Found a duplicate in the metrics slice: httpcheck.duration")
 					validatedMetrics["httpcheck.duration"] = true
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+// httpcheckReceiver runs the checks of a configuration for the metrics and logs pipelines it
+// is part of. The checks are scraped by a metrics controller which also sends their log events
+// when the receiver is in both, or by a logs controller when it is only in a logs pipeline.
+type httpcheckReceiver struct {
+	cfg             *Config
+	settings        receiver.Settings
+	metricsConsumer consumer.Metrics
+	logsConsumer    consumer.Logs
+
+	controller component.Component
+}
+
+func newReceiver(cfg *Config, settings receiver.Settings) *httpcheckReceiver {
+	return &httpcheckReceiver{
+		cfg:      cfg,
+		settings: settings,
+	}
+}
+
+func (r *httpcheckReceiver) Start(ctx context.Context, host component.Host) error {
+	controller, err := r.newController()
+	if err != nil {
+		return err
+	}
+	r.controller = controller
+	return r.controller.Start(ctx, host)
+}
+
+func (r *httpcheckReceiver) Shutdown(ctx context.Context) error {
+	if r.controller == nil {
+		return nil
+	}
+	return r.controller.Shutdown(ctx)
+}
+
+func (r *httpcheckReceiver) newController() (component.Component, error) {
+	httpcheckScraper := newScraper(r.cfg, r.settings)
+
+	if r.metricsConsumer != nil {
+		httpcheckScraper.logsConsumer = r.logsConsumer
+		s, err := scraper.NewMetrics(httpcheckScraper.scrape, scraper.WithStart(httpcheckScraper.start))
+		if err != nil {
+			return nil, err
+		}
+		return scraperhelper.NewMetricsController(&r.cfg.ControllerConfig, r.settings, r.metricsConsumer, scraperhelper.AddScraper(metadata.Type, s))
+	}
+
+	s, err := scraper.NewLogs(httpcheckScraper.scrapeLogs, scraper.WithStart(httpcheckScraper.start))
+	if err != nil {
+		return nil, err
+	}
+
+	opt := scraperhelper.AddFactoryWithConfig(
+		scraper.NewFactory(metadata.Type, nil,
+			scraper.WithLogs(func(context.Context, scraper.Settings, component.Config) (scraper.Logs, error) {
+				return s, nil
+			}, metadata.LogsStability)), nil)
+
+	return scraperhelper.NewLogsController(&r.cfg.ControllerConfig, r.settings, r.logsConsumer, opt)
+}
@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/multierr"
@@ -25,18 +27,44 @@ var (
 	httpResponseClasses = map[string]int{"1xx": 1, "2xx": 2, "3xx": 3, "4xx": 4, "5xx": 5}
 )
 
+// Names of the log events of the failed checks
+const (
+	eventAssertionFailed = "httpcheck.assertion.failed"
+	eventError           = "httpcheck.error"
+)
+
 type httpcheckScraper struct {
-	clients  []*http.Client
+	checks   []*check
 	cfg      *Config
 	settings component.TelemetrySettings
 	mb       *metadata.MetricsBuilder
+	lb       *metadata.LogsBuilder
+
+	// logsConsumer is set when the receiver is in both a metrics and a logs pipeline,
+	// scrape then sends the log events of the checks to it
+	logsConsumer consumer.Logs
 }
 
 // start initializes the scraper by creating HTTP clients for each endpoint.
 func (h *httpcheckScraper) start(ctx context.Context, host component.Host) (err error) {
-	var expandedTargets []*targetConfig
-
 	for _, target := range h.cfg.Targets {
+		// A scenario is a single check of all its steps
+		if len(target.Steps) > 0 {
+			client, clientErr := target.ToClient(ctx, host, h.settings)
+			if clientErr != nil {
+				h.settings.Logger.Error("failed to initialize HTTP client", zap.Error(clientErr))
+				err = multierr.Append(err, clientErr)
+				continue
+			}
+			steps, stepsErr := newSteps(target)
+			if stepsErr != nil {
+				err = multierr.Append(err, stepsErr)
+				continue
+			}
+			h.checks = append(h.checks, &check{client: client, steps: steps})
+			continue
+		}
+
 		// Create a unified list of endpoints
 		var allEndpoints []string
 		if len(target.Endpoints) > 0 {
@@ -55,111 +83,172 @@ func (h *httpcheckScraper) start(ctx context.Context, host component.Host) (err
 				continue
 			}
 
-			// Clone the target and assign the specific endpoint
-			targetClone := *target
-			targetClone.Endpoint = endpoint
-
-			h.clients = append(h.clients, client)
-			expandedTargets = append(expandedTargets, &targetClone) // Add the cloned target to expanded targets
+			s, stepErr := newStep("", target.Method, endpoint, target.Headers, target.Body, target.Assertions, nil)
+			if stepErr != nil {
+				err = multierr.Append(err, stepErr)
+				continue
+			}
+			h.checks = append(h.checks, &check{client: client, steps: []*step{s}})
 		}
 	}
 
-	h.cfg.Targets = expandedTargets // Replace targets with expanded targets
 	return
 }
 
-// scrape performs the HTTP checks and records metrics based on responses.
-func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
-	if len(h.clients) == 0 {
-		return pmetric.NewMetrics(), errClientNotInit
+// runChecks runs the checks concurrently and calls record with the results of each check.
+func (h *httpcheckScraper) runChecks(ctx context.Context, record func([]*stepResult)) error {
+	if len(h.checks) == 0 {
+		return errClientNotInit
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(len(h.clients))
+	wg.Add(len(h.checks))
 	var mux sync.Mutex
 
-	for idx, client := range h.clients {
-		go func(targetClient *http.Client, targetIndex int) {
+	for _, c := range h.checks {
+		go func(c *check) {
 			defer wg.Done()
 
-			now := pcommon.NewTimestampFromTime(time.Now())
-
-			req, err := http.NewRequestWithContext(
-				ctx,
-				h.cfg.Targets[targetIndex].Method,
-				h.cfg.Targets[targetIndex].Endpoint, // Use the ClientConfig.Endpoint
-				http.NoBody,
-			)
-			if err != nil {
-				h.settings.Logger.Error("failed to create request", zap.Error(err))
-				return
-			}
+			results := c.run(ctx)
 
-			// Add headers to the request
-			for key, value := range h.cfg.Targets[targetIndex].Headers {
-				req.Header.Set(key, value.String()) // Convert configopaque.String to string
-			}
+			mux.Lock()
+			record(results)
+			mux.Unlock()
+		}(c)
+	}
 
-			// Send the request and measure response time
-			start := time.Now()
-			resp, err := targetClient.Do(req)
-			if err == nil {
-				defer resp.Body.Close()
-			}
+	wg.Wait()
+	return nil
+}
 
-			mux.Lock()
-			h.mb.RecordHttpcheckDurationDataPoint(
-				now,
-				time.Since(start).Milliseconds(),
-				h.cfg.Targets[targetIndex].Endpoint, // Use the correct endpoint
-			)
-
-			statusCode := 0
-			if err != nil {
-				h.mb.RecordHttpcheckErrorDataPoint(
-					now,
-					int64(1),
-					h.cfg.Targets[targetIndex].Endpoint,
-					err.Error(),
-				)
-			} else {
-				statusCode = resp.StatusCode
+// scrape performs the HTTP checks and records metrics based on responses. The log events
+// of the checks are sent to the logs consumer, if any, so that the checks run once for both.
+func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	err := h.runChecks(ctx, func(results []*stepResult) {
+		for _, r := range results {
+			h.recordMetrics(r)
+			if h.logsConsumer != nil {
+				h.appendLogRecords(r)
 			}
+		}
+	})
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
 
-			// Record HTTP status class metrics
-			for class, intVal := range httpResponseClasses {
-				if statusCode/100 == intVal {
-					h.mb.RecordHttpcheckStatusDataPoint(
-						now,
-						int64(1),
-						h.cfg.Targets[targetIndex].Endpoint,
-						int64(statusCode),
-						req.Method,
-						class,
-					)
-				} else {
-					h.mb.RecordHttpcheckStatusDataPoint(
-						now,
-						int64(0),
-						h.cfg.Targets[targetIndex].Endpoint,
-						int64(statusCode),
-						req.Method,
-						class,
-					)
-				}
+	if h.logsConsumer != nil {
+		if logs := h.lb.Emit(); logs.LogRecordCount() > 0 {
+			if err := h.logsConsumer.ConsumeLogs(ctx, logs); err != nil {
+				h.settings.Logger.Error("failed to consume the log events of the checks", zap.Error(err))
 			}
-			mux.Unlock()
-		}(client, idx)
+		}
 	}
-
-	wg.Wait()
 	return h.mb.Emit(), nil
 }
 
+func (h *httpcheckScraper) recordMetrics(r *stepResult) {
+	endpoint, method := r.step.endpoint, requestMethod(r.step)
+
+	h.mb.RecordHttpcheckDurationDataPoint(r.timestamp, r.duration.Milliseconds(), endpoint)
+
+	if r.err != nil {
+		h.mb.RecordHttpcheckErrorDataPoint(r.timestamp, int64(1), endpoint, r.err.Error())
+	}
+
+	// Record HTTP status class metrics
+	for class, intVal := range httpResponseClasses {
+		if r.statusCode/100 == intVal {
+			h.mb.RecordHttpcheckStatusDataPoint(r.timestamp, int64(1), endpoint, int64(r.statusCode), method, class)
+		} else {
+			h.mb.RecordHttpcheckStatusDataPoint(r.timestamp, int64(0), endpoint, int64(r.statusCode), method, class)
+		}
+	}
+
+	// The assertions are only evaluated if the response was received
+	if r.err != nil {
+		return
+	}
+	failed := make(map[assertionID]bool, len(r.failures))
+	for _, f := range r.failures {
+		failed[assertionID{assertionType: f.assertionType, index: f.index}] = true
+	}
+	for _, id := range r.step.assertionIDs {
+		var value int64
+		if failed[id] {
+			value = 1
+		}
+		h.mb.RecordHttpcheckAssertionFailedDataPoint(
+			r.timestamp,
+			value,
+			endpoint,
+			method,
+			metadata.MapAttributeHttpcheckAssertionType[id.assertionType],
+			int64(id.index),
+		)
+	}
+}
+
+// scrapeLogs performs the HTTP checks and emits a log event for each failed request
+// and failed assertion.
+func (h *httpcheckScraper) scrapeLogs(ctx context.Context) (plog.Logs, error) {
+	err := h.runChecks(ctx, func(results []*stepResult) {
+		for _, r := range results {
+			h.appendLogRecords(r)
+		}
+	})
+	if err != nil {
+		return plog.NewLogs(), err
+	}
+	return h.lb.Emit(), nil
+}
+
+func (h *httpcheckScraper) appendLogRecords(r *stepResult) {
+	observed := pcommon.NewTimestampFromTime(time.Now())
+	newLogRecord := func(eventName, body string) plog.LogRecord {
+		lr := plog.NewLogRecord()
+		lr.SetTimestamp(r.timestamp)
+		lr.SetObservedTimestamp(observed)
+		lr.SetEventName(eventName)
+		lr.SetSeverityNumber(plog.SeverityNumberError)
+		lr.SetSeverityText(plog.SeverityNumberError.String())
+		lr.Body().SetStr(body)
+		lr.Attributes().PutStr("http.url", r.step.endpoint)
+		lr.Attributes().PutStr("http.method", requestMethod(r.step))
+		if r.step.name != "" {
+			lr.Attributes().PutStr("httpcheck.step", r.step.name)
+		}
+		return lr
+	}
+
+	if r.err != nil {
+		lr := newLogRecord(eventError, r.err.Error())
+		lr.Attributes().PutStr("error.message", r.err.Error())
+		h.lb.AppendLogRecord(lr)
+		return
+	}
+
+	for _, f := range r.failures {
+		lr := newLogRecord(eventAssertionFailed, f.message())
+		lr.Attributes().PutInt("http.status_code", int64(r.statusCode))
+		lr.Attributes().PutStr("httpcheck.assertion.type", f.assertionType)
+		lr.Attributes().PutStr("httpcheck.assertion.description", f.description)
+		h.lb.AppendLogRecord(lr)
+	}
+}
+
+// requestMethod returns the method of the request of the step
+func requestMethod(s *step) string {
+	if s.method == "" {
+		return http.MethodGet
+	}
+	return s.method
+}
+
 func newScraper(conf *Config, settings receiver.Settings) *httpcheckScraper {
 	return &httpcheckScraper{
 		cfg:      conf,
 		settings: settings.TelemetrySettings,
 		mb:       metadata.NewMetricsBuilder(conf.MetricsBuilderConfig, settings),
+		lb:       metadata.NewLogsBuilder(settings),
 	}
 }
@@ -5,16 +5,21 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
@@ -238,3 +243,241 @@ func TestScraperMultipleTargets(t *testing.T) {
 		pmetrictest.IgnoreTimestamp(),
 	))
 }
+
+func TestScraperAssertions(t *testing.T) {
+	ms := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		_, err := rw.Write([]byte(`{"status":"degraded"}`))
+		assert.NoError(t, err)
+	}))
+	defer ms.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{
+			ClientConfig: confighttp.ClientConfig{
+				Endpoint: ms.URL,
+			},
+			Assertions: assertionsConfig{
+				StatusCodes: []string{"2xx"},
+				JSON:        []jsonAssertionConfig{{Path: "$.status", Value: "ok"}},
+				Headers:     []headerAssertionConfig{{Name: "Content-Type", Value: "application/json"}},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	failed := assertionFailures(actualMetrics)
+	require.Len(t, failed, 1)
+	assert.Equal(t, map[string]any{
+		"http.url":                  ms.URL,
+		"http.method":               "GET",
+		"httpcheck.assertion.type":  "json",
+		"httpcheck.assertion.index": int64(0),
+	}, failed[0])
+
+	// Every assertion is recorded, with 0 if it passed
+	values := map[string]int64{}
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() != "httpcheck.assertion.failed" {
+			continue
+		}
+		dps := metrics.At(i).Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			assertionType, _ := dps.At(j).Attributes().Get("httpcheck.assertion.type")
+			values[assertionType.Str()] = dps.At(j).IntValue()
+		}
+	}
+	assert.Equal(t, map[string]int64{"status_code": 0, "json": 1, "header": 0}, values)
+}
+
+func TestScraperScenario(t *testing.T) {
+	var requests []string
+	ms := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+req.Header.Get("Authorization")+" "+string(body))
+
+		switch req.URL.Path {
+		case "/login":
+			_, err = rw.Write([]byte(`{"token":"s3cr3t","user":{"id":42}}`))
+			assert.NoError(t, err)
+		case "/users/42":
+			if req.Header.Get("Authorization") != "Bearer s3cr3t" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, err = rw.Write([]byte(`{"name":"alice"}`))
+			assert.NoError(t, err)
+		case "/health":
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ms.Close()
+
+	newConfig := func(lastPath string) *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Targets = []*targetConfig{
+			{
+				Steps: []*stepConfig{
+					{
+						Name:     "login",
+						Method:   http.MethodPost,
+						Endpoint: ms.URL + "/login",
+						Body:     `{"user":"alice"}`,
+						Assertions: assertionsConfig{
+							StatusCodes: []string{"200"},
+						},
+						Extract: map[string]extractConfig{
+							"token": {JSONPath: "$.token"},
+							"id":    {JSONPath: "$.user.id"},
+						},
+					},
+					{
+						Name:     "profile",
+						Endpoint: ms.URL + "/users/{{id}}",
+						Headers: map[string]configopaque.String{
+							"Authorization": "Bearer {{token}}",
+						},
+						Assertions: assertionsConfig{
+							StatusCodes: []string{"2xx"},
+							BodyMatches: []string{"alice"},
+						},
+					},
+					{
+						Name:     "last",
+						Endpoint: ms.URL + lastPath,
+						Assertions: assertionsConfig{
+							StatusCodes: []string{"2xx"},
+						},
+					},
+				},
+			},
+		}
+		require.NoError(t, cfg.Validate())
+		return cfg
+	}
+
+	t.Run("passing", func(t *testing.T) {
+		requests = nil
+		scraper := newScraper(newConfig("/health"), receivertest.NewNopSettings(metadata.Type))
+		require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+		actualMetrics, err := scraper.scrape(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, assertionFailures(actualMetrics))
+		assert.Equal(t, []string{
+			`POST /login  {"user":"alice"}`,
+			"GET /users/42 Bearer s3cr3t ",
+			"GET /health  ",
+		}, requests)
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		requests = nil
+		scraper := newScraper(newConfig("/missing"), receivertest.NewNopSettings(metadata.Type))
+		require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+		actualLogs, err := scraper.scrapeLogs(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, actualLogs.LogRecordCount())
+
+		lr := actualLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, eventAssertionFailed, lr.EventName())
+		assert.Equal(t, plog.SeverityNumberError, lr.SeverityNumber())
+		assert.Equal(t, "assertion status_code in (2xx) failed: got 404", lr.Body().Str())
+		assert.Equal(t, map[string]any{
+			"http.url":                        ms.URL + "/missing",
+			"http.method":                     "GET",
+			"http.status_code":                int64(404),
+			"httpcheck.step":                  "last",
+			"httpcheck.assertion.type":        "status_code",
+			"httpcheck.assertion.description": "status_code in (2xx)",
+		}, lr.Attributes().AsRaw())
+	})
+}
+
+func TestScraperLogsError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{
+			ClientConfig: confighttp.ClientConfig{
+				Endpoint: "http://invalid-endpoint",
+			},
+		},
+	}
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualLogs, err := scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, actualLogs.LogRecordCount())
+
+	lr := actualLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, eventError, lr.EventName())
+	errorMessage, ok := lr.Attributes().Get("error.message")
+	require.True(t, ok)
+	assert.Equal(t, lr.Body().Str(), errorMessage.Str())
+}
+
+func TestScraperMetricsAndLogs(t *testing.T) {
+	var requests atomic.Int64
+	ms := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ms.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{
+			ClientConfig: confighttp.ClientConfig{
+				Endpoint: ms.URL,
+			},
+			Assertions: assertionsConfig{StatusCodes: []string{"2xx"}},
+		},
+	}
+
+	sink := new(consumertest.LogsSink)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	scraper.logsConsumer = sink
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	// A single request gives both the metrics and the log events
+	assert.Equal(t, int64(1), requests.Load())
+	assert.Len(t, assertionFailures(actualMetrics), 1)
+	require.Equal(t, 1, sink.LogRecordCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, eventAssertionFailed, lr.EventName())
+}
+
+// assertionFailures returns the attributes of the data points of httpcheck.assertion.failed
+// of the assertions which failed
+func assertionFailures(md pmetric.Metrics) []map[string]any {
+	var failures []map[string]any
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() != "httpcheck.assertion.failed" {
+			continue
+		}
+		dps := metrics.At(i).Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			if dps.At(j).IntValue() == 1 {
+				failures = append(failures, dps.At(j).Attributes().AsRaw())
+			}
+		}
+	}
+	return failures
+}
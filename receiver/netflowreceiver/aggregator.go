@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"cmp"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
+)
+
+// otherValue is the value of the keys of the data point the flows beyond the top N are summed into
+const otherValue = "other"
+
+// flowAggregator rolls the flows up into bytes, packets and flows counts per combination of the key values
+type flowAggregator struct {
+	cfg AggregationConfig
+	// mb is only used by flush
+	mb *metadata.MetricsBuilder
+
+	mu     sync.Mutex
+	start  time.Time
+	groups map[string]*flowGroup
+}
+
+// flowGroup holds the counts of the flows with the same key values
+type flowGroup struct {
+	key     string
+	values  []any
+	bytes   uint64
+	packets uint64
+	flows   uint64
+}
+
+func newFlowAggregator(cfg AggregationConfig, mbc metadata.MetricsBuilderConfig, settings receiver.Settings, start time.Time) *flowAggregator {
+	return &flowAggregator{
+		cfg:    cfg,
+		mb:     metadata.NewMetricsBuilder(mbc, settings),
+		start:  start,
+		groups: map[string]*flowGroup{},
+	}
+}
+
+// add counts the flow in the group of its key values, the bytes and packets of sampled flows are
+// scaled by their sampling rate
+func (a *flowAggregator) add(pm *protoproducer.ProtoProducerMessage) {
+	values := make([]any, len(a.cfg.Keys))
+	var key strings.Builder
+	for i, k := range a.cfg.Keys {
+		values[i] = a.keyValue(k, pm)
+		if i > 0 {
+			key.WriteByte('|')
+		}
+		switch v := values[i].(type) {
+		case string:
+			key.WriteString(v)
+		case int64:
+			key.WriteString(strconv.FormatInt(v, 10))
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	g, ok := a.groups[key.String()]
+	if !ok {
+		g = &flowGroup{key: key.String(), values: values}
+		a.groups[g.key] = g
+	}
+	samplingRate := max(pm.SamplingRate, 1)
+	g.bytes += pm.Bytes * samplingRate
+	g.packets += pm.Packets * samplingRate
+	g.flows++
+}
+
+// keyValue returns the value of the aggregation key for the flow, either a string or an int64
+func (a *flowAggregator) keyValue(key string, pm *protoproducer.ProtoProducerMessage) any {
+	switch key {
+	case "source.address":
+		return addrString(pm.SrcAddr)
+	case "source.prefix":
+		return a.prefixString(pm.SrcAddr)
+	case "source.port":
+		return int64(pm.SrcPort)
+	case "source.as":
+		return int64(pm.SrcAs)
+	case "destination.address":
+		return addrString(pm.DstAddr)
+	case "destination.prefix":
+		return a.prefixString(pm.DstAddr)
+	case "destination.port":
+		return int64(pm.DstPort)
+	case "destination.as":
+		return int64(pm.DstAs)
+	case "network.transport":
+		return getTransportName(pm.Proto)
+	case "network.type":
+		return getEtypeName(pm.Etype)
+	case "flow.type":
+		return getFlowTypeName(int32(pm.Type))
+	case "flow.sampler_address":
+		return addrString(pm.SamplerAddress)
+	case "flow.in_interface":
+		return int64(pm.InIf)
+	case "flow.out_interface":
+		return int64(pm.OutIf)
+	}
+	return ""
+}
+
+func addrString(b []byte) string {
+	addr, _ := netip.AddrFromSlice(b)
+	return addr.Unmap().String()
+}
+
+// prefixString returns the network of the address with the configured prefix length
+func (a *flowAggregator) prefixString(b []byte) string {
+	addr, ok := netip.AddrFromSlice(b)
+	if !ok {
+		return addr.String()
+	}
+	addr = addr.Unmap()
+	bits := a.cfg.IPv6PrefixLength
+	if addr.Is4() {
+		bits = a.cfg.IPv4PrefixLength
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return addr.String()
+	}
+	return prefix.String()
+}
+
+// flush returns the metrics of the flows since the previous flush, or since the aggregator
+// was created, and starts a new window
+func (a *flowAggregator) flush(now time.Time) pmetric.Metrics {
+	a.mu.Lock()
+	groups, start := a.groups, a.start
+	a.groups, a.start = map[string]*flowGroup{}, now
+	a.mu.Unlock()
+
+	md := pmetric.NewMetrics()
+	if len(groups) == 0 {
+		return md
+	}
+
+	sorted := make([]*flowGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	// The groups are sorted by bytes, then by key to keep the same order across windows
+	slices.SortFunc(sorted, func(x, y *flowGroup) int {
+		if c := cmp.Compare(y.bytes, x.bytes); c != 0 {
+			return c
+		}
+		return strings.Compare(x.key, y.key)
+	})
+
+	if a.cfg.TopN > 0 && len(sorted) > a.cfg.TopN {
+		other := &flowGroup{values: make([]any, len(a.cfg.Keys))}
+		for i := range other.values {
+			other.values[i] = otherValue
+		}
+		for _, g := range sorted[a.cfg.TopN:] {
+			other.bytes += g.bytes
+			other.packets += g.packets
+			other.flows += g.flows
+		}
+		sorted = append(sorted[:a.cfg.TopN], other)
+	}
+
+	a.mb.Reset(metadata.WithStartTime(pcommon.NewTimestampFromTime(start)))
+	timestamp := pcommon.NewTimestampFromTime(now)
+	for _, g := range sorted {
+		a.mb.RecordFlowIoBytesDataPoint(timestamp, int64(g.bytes))
+		a.mb.RecordFlowIoPacketsDataPoint(timestamp, int64(g.packets))
+		a.mb.RecordFlowCountDataPoint(timestamp, int64(g.flows))
+	}
+	md = a.mb.Emit()
+	if md.ResourceMetrics().Len() == 0 {
+		return md
+	}
+
+	sm := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	sm.Scope().Attributes().PutStr("receiver", metadata.Type.String())
+	// The keys are configurable, so they are set as attributes of the data points once built,
+	// every metric has a data point per group in the same order
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		dps := sm.Metrics().At(i).Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			attrs := dps.At(j).Attributes()
+			for k, key := range a.cfg.Keys {
+				switch v := sorted[j].values[k].(type) {
+				case string:
+					attrs.PutStr(key, v)
+				case int64:
+					attrs.PutInt(key, v)
+				}
+			}
+		}
+	}
+	return md
+}
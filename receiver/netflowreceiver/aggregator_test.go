@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	flowpb "github.com/netsampler/goflow2/v2/pb"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
+)
+
+func newFlow(src, dst string, proto, dstPort uint32, bytes, packets uint64) *protoproducer.ProtoProducerMessage {
+	return &protoproducer.ProtoProducerMessage{
+		FlowMessage: flowpb.FlowMessage{
+			SrcAddr: netip.MustParseAddr(src).AsSlice(),
+			DstAddr: netip.MustParseAddr(dst).AsSlice(),
+			Proto:   proto,
+			DstPort: dstPort,
+			Bytes:   bytes,
+			Packets: packets,
+			SrcAs:   64500,
+			DstAs:   64501,
+		},
+	}
+}
+
+// dataPoints returns the values of the data points of the metric by their attributes
+func dataPoints(t *testing.T, md pmetric.Metrics, name string) []map[string]any {
+	var points []map[string]any
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != name {
+			continue
+		}
+		require.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+		require.True(t, m.Sum().IsMonotonic())
+		for j := 0; j < m.Sum().DataPoints().Len(); j++ {
+			dp := m.Sum().DataPoints().At(j)
+			point := dp.Attributes().AsRaw()
+			point["value"] = dp.IntValue()
+			points = append(points, point)
+		}
+	}
+	return points
+}
+
+func TestAggregate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config).Aggregation
+	start := time.Unix(1000, 0)
+	a := newFlowAggregator(cfg, metadata.DefaultMetricsBuilderConfig(), receivertest.NewNopSettings(metadata.Type), start)
+
+	a.add(newFlow("10.0.0.1", "192.168.1.5", 6, 443, 100, 2))
+	a.add(newFlow("10.0.0.2", "192.168.1.6", 6, 443, 300, 3))
+	a.add(newFlow("10.0.1.1", "192.168.1.5", 17, 53, 50, 1))
+	a.add(newFlow("2001:db8:0:1::1", "2001:db8:0:2::1", 6, 443, 1000, 10))
+
+	now := start.Add(time.Minute)
+	md := a.flush(now)
+
+	assert.Equal(t, []map[string]any{
+		{"source.prefix": "2001:db8:0:1::/64", "destination.prefix": "2001:db8:0:2::/64", "network.transport": "tcp", "destination.port": int64(443), "value": int64(1000)},
+		{"source.prefix": "10.0.0.0/24", "destination.prefix": "192.168.1.0/24", "network.transport": "tcp", "destination.port": int64(443), "value": int64(400)},
+		{"source.prefix": "10.0.1.0/24", "destination.prefix": "192.168.1.0/24", "network.transport": "udp", "destination.port": int64(53), "value": int64(50)},
+	}, dataPoints(t, md, "flow.io.bytes"))
+	assert.Equal(t, []int64{10, 5, 1}, values(dataPoints(t, md, "flow.io.packets")))
+	assert.Equal(t, []int64{1, 2, 1}, values(dataPoints(t, md, "flow.count")))
+
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), dp.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), dp.Timestamp())
+
+	// The next window starts empty where the previous one ended
+	assert.Equal(t, 0, a.flush(now.Add(time.Minute)).DataPointCount())
+	a.add(newFlow("10.0.0.1", "192.168.1.5", 6, 443, 100, 2))
+	md = a.flush(now.Add(2 * time.Minute))
+	dp = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(now.Add(time.Minute)), dp.StartTimestamp())
+}
+
+func TestAggregateTopN(t *testing.T) {
+	cfg := AggregationConfig{
+		Interval: time.Minute,
+		Keys:     []string{"source.address", "destination.as"},
+		TopN:     2,
+	}
+	a := newFlowAggregator(cfg, metadata.DefaultMetricsBuilderConfig(), receivertest.NewNopSettings(metadata.Type), time.Now())
+
+	a.add(newFlow("10.0.0.1", "192.168.1.5", 6, 443, 100, 1))
+	a.add(newFlow("10.0.0.2", "192.168.1.5", 6, 443, 400, 1))
+	a.add(newFlow("10.0.0.3", "192.168.1.5", 6, 443, 300, 1))
+	a.add(newFlow("10.0.0.4", "192.168.1.5", 6, 443, 50, 1))
+
+	md := a.flush(time.Now())
+	assert.Equal(t, []map[string]any{
+		{"source.address": "10.0.0.2", "destination.as": int64(64501), "value": int64(400)},
+		{"source.address": "10.0.0.3", "destination.as": int64(64501), "value": int64(300)},
+		{"source.address": "other", "destination.as": "other", "value": int64(150)},
+	}, dataPoints(t, md, "flow.io.bytes"))
+	assert.Equal(t, []int64{1, 1, 2}, values(dataPoints(t, md, "flow.count")))
+}
+
+func TestAggregateSampled(t *testing.T) {
+	cfg := createDefaultConfig().(*Config).Aggregation
+	a := newFlowAggregator(cfg, metadata.DefaultMetricsBuilderConfig(), receivertest.NewNopSettings(metadata.Type), time.Now())
+
+	sampled := newFlow("10.0.0.1", "192.168.1.5", 6, 443, 100, 2)
+	sampled.SamplingRate = 512
+	a.add(sampled)
+	a.add(newFlow("10.0.0.2", "192.168.1.5", 6, 443, 300, 3))
+
+	md := a.flush(time.Now())
+	assert.Equal(t, []int64{51_500}, values(dataPoints(t, md, "flow.io.bytes")))
+	assert.Equal(t, []int64{1_027}, values(dataPoints(t, md, "flow.io.packets")))
+	assert.Equal(t, []int64{2}, values(dataPoints(t, md, "flow.count")))
+}
+
+func TestAggregateDisabledMetric(t *testing.T) {
+	cfg := createDefaultConfig().(*Config).Aggregation
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.FlowIoPackets.Enabled = false
+	a := newFlowAggregator(cfg, mbc, receivertest.NewNopSettings(metadata.Type), time.Now())
+
+	a.add(newFlow("10.0.0.1", "192.168.1.5", 6, 443, 100, 2))
+	a.add(newFlow("10.0.1.1", "192.168.1.5", 17, 53, 50, 1))
+
+	md := a.flush(time.Now())
+	assert.Equal(t, 2, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+	assert.Empty(t, dataPoints(t, md, "flow.io.packets"))
+	assert.Equal(t, []map[string]any{
+		{"source.prefix": "10.0.0.0/24", "destination.prefix": "192.168.1.0/24", "network.transport": "tcp", "destination.port": int64(443), "value": int64(1)},
+		{"source.prefix": "10.0.1.0/24", "destination.prefix": "192.168.1.0/24", "network.transport": "udp", "destination.port": int64(53), "value": int64(1)},
+	}, dataPoints(t, md, "flow.count"))
+}
+
+func values(points []map[string]any) []int64 {
+	var v []int64
+	for _, p := range points {
+		v = append(v, p["value"].(int64))
+	}
+	return v
+}
@@ -5,8 +5,31 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
+// aggregationKeys are the fields the flows can be aggregated by
+var aggregationKeys = []string{
+	"source.address",
+	"source.prefix",
+	"source.port",
+	"source.as",
+	"destination.address",
+	"destination.prefix",
+	"destination.port",
+	"destination.as",
+	"network.transport",
+	"network.type",
+	"flow.type",
+	"flow.sampler_address",
+	"flow.in_interface",
+	"flow.out_interface",
+}
+
 // Config represents the receiver config settings within the collector's config.yaml
 type Config struct {
 	// The scheme defines the type of flow data that the listener will receive
@@ -33,6 +56,33 @@ type Config struct {
 
 	// SendRaw determines whether to send raw flow messages instead of parsing them
 	SendRaw bool `mapstructure:"send_raw"`
+
+	// Aggregation configures how the flows are aggregated into metrics
+	// It is only used when the receiver is part of a metrics pipeline
+	Aggregation AggregationConfig `mapstructure:"aggregation"`
+
+	// MetricsBuilderConfig enables or disables the metrics the flows are aggregated into
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+}
+
+// AggregationConfig represents how the flows are rolled up into metrics
+type AggregationConfig struct {
+	// The window over which the flows are aggregated before the metrics are sent
+	Interval time.Duration `mapstructure:"interval"`
+
+	// The fields the flows are aggregated by, each combination of their values is a data point
+	Keys []string `mapstructure:"keys"`
+
+	// The length of the prefixes the IPv4 addresses are truncated to for the prefix keys
+	IPv4PrefixLength int `mapstructure:"ipv4_prefix_length"`
+
+	// The length of the prefixes the IPv6 addresses are truncated to for the prefix keys
+	IPv6PrefixLength int `mapstructure:"ipv6_prefix_length"`
+
+	// The maximum number of data points per window, the ones with the most bytes are kept
+	// and the other ones are summed into a single data point whose keys are "other"
+	// Zero means no limit
+	TopN int `mapstructure:"top_n"`
 }
 
 // Validate checks if the receiver configuration is valid
@@ -66,5 +116,35 @@ func (cfg *Config) Validate() error {
 		return errors.New("port must be greater than 0")
 	}
 
+	return cfg.Aggregation.Validate()
+}
+
+// Validate checks if the aggregation configuration is valid
+func (cfg *AggregationConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("aggregation interval must be greater than 0")
+	}
+
+	if len(cfg.Keys) == 0 {
+		return errors.New("aggregation keys must not be empty")
+	}
+	for _, key := range cfg.Keys {
+		if !slices.Contains(aggregationKeys, key) {
+			return fmt.Errorf("aggregation key %q must be one of %v", key, aggregationKeys)
+		}
+	}
+
+	if cfg.IPv4PrefixLength < 0 || cfg.IPv4PrefixLength > 32 {
+		return errors.New("aggregation ipv4_prefix_length must be between 0 and 32")
+	}
+
+	if cfg.IPv6PrefixLength < 0 || cfg.IPv6PrefixLength > 128 {
+		return errors.New("aggregation ipv6_prefix_length must be between 0 and 128")
+	}
+
+	if cfg.TopN < 0 {
+		return errors.New("aggregation top_n must not be negative")
+	}
+
 	return nil
 }
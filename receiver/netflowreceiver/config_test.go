@@ -6,6 +6,7 @@ package netflowreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,6 +23,8 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	defaultAggregation := createDefaultConfig().(*Config).Aggregation
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -33,42 +36,68 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "one_listener"),
 			expected: &Config{
-				Scheme:    "netflow",
-				Port:      2055,
-				Sockets:   1,
-				Workers:   1,
-				QueueSize: 1000,
+				Scheme:               "netflow",
+				Port:                 2055,
+				Sockets:              1,
+				Workers:              1,
+				QueueSize:            1000,
+				Aggregation:          defaultAggregation,
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "zero_queue"),
 			expected: &Config{
-				Scheme:    "netflow",
-				Port:      2055,
-				Sockets:   1,
-				Workers:   1,
-				QueueSize: 1000,
+				Scheme:               "netflow",
+				Port:                 2055,
+				Sockets:              1,
+				Workers:              1,
+				QueueSize:            1000,
+				Aggregation:          defaultAggregation,
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "sflow"),
 			expected: &Config{
-				Scheme:    "sflow",
-				Port:      6343,
-				Sockets:   1,
-				Workers:   1,
-				QueueSize: 1000,
+				Scheme:               "sflow",
+				Port:                 6343,
+				Sockets:              1,
+				Workers:              1,
+				QueueSize:            1000,
+				Aggregation:          defaultAggregation,
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "raw_logs"),
 			expected: &Config{
-				Scheme:    "netflow",
-				Port:      2055,
+				Scheme:               "netflow",
+				Port:                 2055,
+				Sockets:              1,
+				Workers:              1,
+				QueueSize:            1000,
+				SendRaw:              true,
+				Aggregation:          defaultAggregation,
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "aggregation"),
+			expected: &Config{
+				Scheme:    "sflow",
+				Port:      6343,
 				Sockets:   1,
-				Workers:   1,
+				Workers:   2,
 				QueueSize: 1000,
-				SendRaw:   true,
+				Aggregation: AggregationConfig{
+					Interval:         30 * time.Second,
+					Keys:             []string{"source.as", "destination.as", "network.transport"},
+					IPv4PrefixLength: 16,
+					IPv6PrefixLength: 48,
+					TopN:             10,
+				},
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 			},
 		},
 	}
@@ -114,6 +143,18 @@ func TestInvalidConfig(t *testing.T) {
 			id:  component.NewIDWithName(metadata.Type, "zero_workers"),
 			err: "workers must be greater than 0",
 		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_aggregation_key"),
+			err: `aggregation key "source.mac" must be one of`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_aggregation_prefix_length"),
+			err: "aggregation ipv4_prefix_length must be between 0 and 32",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "zero_aggregation_interval"),
+			err: "aggregation interval must be greater than 0",
+		},
 	}
 
 	for _, tt := range tests {
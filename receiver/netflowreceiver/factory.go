@@ -5,11 +5,13 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -20,14 +22,26 @@ const (
 	// that for a full queue of 1000 messages, the size in memory will be 9MB.
 	// Source: https://github.com/netsampler/goflow2/blob/v2.2.1/README.md#security-notes-and-assumptions
 	defaultQueueSize = 1_000
+	// By default flows are aggregated by source and destination /24 or /64
+	// networks, transport protocol and destination port every minute.
+	defaultAggregationInterval = time.Minute
+	defaultIPv4PrefixLength    = 24
+	defaultIPv6PrefixLength    = 64
+	defaultTopN                = 1_000
 )
 
+// receivers are the netflow receivers of the configurations, shared by the logs and metrics
+// pipelines as they listen on the same port. A receiver is removed from the map when it is
+// shut down.
+var receivers = sharedcomponent.NewSharedComponents()
+
 // NewFactory creates a factory for netflow receiver.
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
 }
 
 // Config defines configuration for netflow receiver.
@@ -39,19 +53,45 @@ func createDefaultConfig() component.Config {
 		Sockets:   defaultSockets,
 		Workers:   defaultWorkers,
 		QueueSize: defaultQueueSize,
+		Aggregation: AggregationConfig{
+			Interval:         defaultAggregationInterval,
+			Keys:             []string{"source.prefix", "destination.prefix", "network.transport", "destination.port"},
+			IPv4PrefixLength: defaultIPv4PrefixLength,
+			IPv6PrefixLength: defaultIPv6PrefixLength,
+			TopN:             defaultTopN,
+		},
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 	}
 }
 
-// createLogsReceiver creates a netflow receiver.
+// createLogsReceiver creates a netflow receiver which sends the flows as logs.
 // We also create the UDP receiver, which is the piece of software that actually listens
 // for incoming netflow traffic on an UDP port.
 func createLogsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
-	conf := *(cfg.(*Config))
+	r, err := getOrAddReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*netflowReceiver).registerLogsConsumer(consumer)
+	return r, nil
+}
 
-	nr, err := newNetflowLogsReceiver(params, conf, consumer)
+// createMetricsReceiver creates a netflow receiver which aggregates the flows into metrics.
+func createMetricsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+	r, err := getOrAddReceiver(params, cfg.(*Config))
 	if err != nil {
 		return nil, err
 	}
+	r.Unwrap().(*netflowReceiver).registerMetricsConsumer(consumer)
+	return r, nil
+}
 
-	return nr, nil
+func getOrAddReceiver(params receiver.Settings, cfg *Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var nr component.Component
+		nr, err = newNetflowReceiver(params, *cfg)
+		return nr
+	})
+	return r, err
 }
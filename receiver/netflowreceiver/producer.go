@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
@@ -88,3 +89,53 @@ func newOtelLogsProducer(wrapped producer.ProducerInterface, logConsumer consume
 		sendRaw:     sendRaw,
 	}
 }
+
+// otelMetricsProducerWrapper is a wrapper around a producer.ProducerInterface that adds the messages to a flow aggregator
+type otelMetricsProducerWrapper struct {
+	wrapped    producer.ProducerInterface
+	aggregator *flowAggregator
+	logger     *zap.Logger
+}
+
+// Produce adds the flow messages to the aggregator, which periodically sends them as metrics
+func (o *otelMetricsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) ([]producer.ProducerMessage, error) {
+	defer func() {
+		if pErr := recover(); pErr != nil {
+			errMessage, _ := pErr.(string)
+			o.logger.Error("unexpected error processing the message", zap.String("error", errMessage))
+		}
+	}()
+
+	flowMessageSet, err := o.wrapped.Produce(msg, args)
+	if err != nil {
+		return flowMessageSet, err
+	}
+
+	for _, msg := range flowMessageSet {
+		// we know msg is ProtoProducerMessage because that is the parent producer
+		pm, ok := msg.(*protoproducer.ProtoProducerMessage)
+		if !ok {
+			o.logger.Error("this flow message is not ProtoProducerMessage, this is not expected")
+			continue
+		}
+		o.aggregator.add(pm)
+	}
+
+	return flowMessageSet, nil
+}
+
+func (o *otelMetricsProducerWrapper) Close() {
+	o.wrapped.Close()
+}
+
+func (o *otelMetricsProducerWrapper) Commit(flowMessageSet []producer.ProducerMessage) {
+	o.wrapped.Commit(flowMessageSet)
+}
+
+func newOtelMetricsProducer(wrapped producer.ProducerInterface, aggregator *flowAggregator, logger *zap.Logger) producer.ProducerInterface {
+	return &otelMetricsProducerWrapper{
+		wrapped:    wrapped,
+		aggregator: aggregator,
+		logger:     logger,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/netip"
 	"testing"
+	"time"
 
 	"github.com/netsampler/goflow2/v2/decoders/netflow"
 	flowpb "github.com/netsampler/goflow2/v2/pb"
@@ -15,8 +16,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
 func TestProduce(t *testing.T) {
@@ -150,6 +154,62 @@ func TestProduceRaw(t *testing.T) {
 	}
 }
 
+func TestProduceMetrics(t *testing.T) {
+	message := &netflow.NFv9Packet{
+		Version:        9,
+		Count:          1,
+		SystemUptime:   0xb3bff683,
+		UnixSeconds:    0x618aa3a8,
+		SequenceNumber: 838987416,
+		SourceId:       256,
+		FlowSets: []any{
+			netflow.DataFlowSet{
+				FlowSetHeader: netflow.FlowSetHeader{
+					Id:     260,
+					Length: 1372,
+				},
+				Records: []netflow.DataRecord{
+					{
+						Values: []netflow.DataField{
+							{
+								Type:  1, // IN_BYTES
+								Value: []uint8{0x00, 0x00, 0x00, 0x40},
+							},
+							{
+								Type:  2, // IN_PKTS
+								Value: []uint8{0x00, 0x00, 0x00, 0x01},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfgProducer := &protoproducer.ProducerConfig{}
+	cfgm, err := cfgProducer.Compile()
+	require.NoError(t, err)
+	protoProducer, err := protoproducer.CreateProtoProducer(cfgm, protoproducer.CreateSamplingSystem)
+	require.NoError(t, err)
+
+	aggregator := newFlowAggregator(createDefaultConfig().(*Config).Aggregation, metadata.DefaultMetricsBuilderConfig(), receivertest.NewNopSettings(metadata.Type), time.Now())
+	otelMetricsProducer := newOtelMetricsProducer(protoProducer, aggregator, zap.NewNop())
+	messages, err := otelMetricsProducer.Produce(message, &producer.ProduceArgs{})
+	require.NoError(t, err)
+	assert.Len(t, messages, 1)
+
+	// The flow is only sent once the aggregation window is flushed
+	md := aggregator.flush(time.Now())
+	require.Equal(t, 3, md.DataPointCount())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, "flow.count", metrics.At(0).Name())
+	assert.Equal(t, int64(1), metrics.At(0).Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, "flow.io.bytes", metrics.At(1).Name())
+	assert.Equal(t, int64(64), metrics.At(1).Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, "flow.io.packets", metrics.At(2).Name())
+	assert.Equal(t, int64(1), metrics.At(2).Sum().DataPoints().At(0).IntValue())
+}
+
 // This panicProducer replaces the ProtoProducer, to simulate it producing a panic
 type panicProducer struct{}
 
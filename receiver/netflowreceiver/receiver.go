@@ -8,12 +8,16 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/netsampler/goflow2/v2/decoders/netflow"
+	"github.com/netsampler/goflow2/v2/producer"
 	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
 	"github.com/netsampler/goflow2/v2/utils"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 )
@@ -28,14 +32,25 @@ func (d dropHandler) Dropped(msg utils.Message) {
 	d.logger.Warn("Dropped netflow message", zap.Any("msg", msg))
 }
 
+// netflowReceiver listens for the flows of a configuration, and sends them as logs and aggregated metrics
+// to the consumers of the logs and metrics pipelines it is part of
 type netflowReceiver struct {
-	config      Config
-	logger      *zap.Logger
-	udpReceiver *utils.UDPReceiver
-	logConsumer consumer.Logs
+	settings       receiver.Settings
+	config         Config
+	logger         *zap.Logger
+	udpReceiver    *utils.UDPReceiver
+	logConsumer    consumer.Logs
+	metricConsumer consumer.Metrics
+
+	// The aggregator is only used when there is a metrics consumer
+	aggregator *flowAggregator
+
+	// done is closed to stop the goroutines started by the receiver
+	done chan struct{}
+	wg   sync.WaitGroup
 }
 
-func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
+func newNetflowReceiver(params receiver.Settings, cfg Config) (*netflowReceiver, error) {
 	// UDP receiver configuration
 	udpCfg := &utils.UDPReceiverConfig{
 		Sockets:   cfg.Sockets,
@@ -52,15 +67,23 @@ func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consu
 	}
 
 	nr := &netflowReceiver{
+		settings:    params,
 		logger:      params.Logger,
 		config:      cfg,
-		logConsumer: consumer,
 		udpReceiver: udpReceiver,
 	}
 
 	return nr, nil
 }
 
+func (nr *netflowReceiver) registerLogsConsumer(logConsumer consumer.Logs) {
+	nr.logConsumer = logConsumer
+}
+
+func (nr *netflowReceiver) registerMetricsConsumer(metricConsumer consumer.Metrics) {
+	nr.metricConsumer = metricConsumer
+}
+
 func (nr *netflowReceiver) Start(_ context.Context, _ component.Host) error {
 	// The function that will decode packets
 	decodeFunc, err := nr.buildDecodeFunc()
@@ -73,9 +96,17 @@ func (nr *netflowReceiver) Start(_ context.Context, _ component.Host) error {
 		return err
 	}
 
+	nr.done = make(chan struct{})
+
 	// This runs until the receiver is stoppped, consuming from an error channel
+	nr.wg.Add(1)
 	go nr.handleErrors()
 
+	if nr.aggregator != nil {
+		nr.wg.Add(1)
+		go nr.flushMetrics()
+	}
+
 	return nil
 }
 
@@ -87,9 +118,42 @@ func (nr *netflowReceiver) Shutdown(context.Context) error {
 	if err != nil {
 		nr.logger.Warn("Error stopping UDP receiver", zap.Error(err))
 	}
+
+	if nr.done != nil {
+		close(nr.done)
+		nr.wg.Wait()
+		nr.done = nil
+	}
 	return nil
 }
 
+// flushMetrics sends the aggregated flows as metrics at every interval, and once more when the receiver is stopped
+func (nr *netflowReceiver) flushMetrics() {
+	defer nr.wg.Done()
+
+	ticker := time.NewTicker(nr.config.Aggregation.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			nr.consumeMetrics(nr.aggregator.flush(now))
+		case <-nr.done:
+			nr.consumeMetrics(nr.aggregator.flush(time.Now()))
+			return
+		}
+	}
+}
+
+func (nr *netflowReceiver) consumeMetrics(md pmetric.Metrics) {
+	if md.DataPointCount() == 0 {
+		return
+	}
+	if err := nr.metricConsumer.ConsumeMetrics(context.Background(), md); err != nil {
+		nr.logger.Error("error sending the aggregated flow metrics", zap.Error(err))
+	}
+}
+
 // buildDecodeFunc creates a decode function based on the scheme
 // This is the fuction that will be invoked for every netflow packet received
 // The function depends on the type of schema (netflow, sflow, flow)
@@ -106,12 +170,21 @@ func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
 		return nil, err
 	}
 
-	// the otel log producer converts those messages into OpenTelemetry logs
-	// it is a wrapper around the protobuf producer
-	otelLogsProducer := newOtelLogsProducer(protoProducer, nr.logConsumer, nr.logger, nr.config.SendRaw)
+	// The otel producers wrap each other, so that every flow message is both
+	// aggregated and sent as a log when the receiver is part of both pipelines
+	otelProducer := producer.ProducerInterface(protoProducer)
+	if nr.metricConsumer != nil {
+		// the otel metrics producer aggregates those messages into OpenTelemetry metrics
+		nr.aggregator = newFlowAggregator(nr.config.Aggregation, nr.config.MetricsBuilderConfig, nr.settings, time.Now())
+		otelProducer = newOtelMetricsProducer(otelProducer, nr.aggregator, nr.logger)
+	}
+	if nr.logConsumer != nil {
+		// the otel log producer converts those messages into OpenTelemetry logs
+		otelProducer = newOtelLogsProducer(otelProducer, nr.logConsumer, nr.logger, nr.config.SendRaw)
+	}
 
 	cfgPipe := &utils.PipeConfig{
-		Producer: otelLogsProducer,
+		Producer: otelProducer,
 	}
 
 	var p utils.FlowPipe
@@ -129,7 +202,18 @@ func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
 // handleErrors handles errors from the listener
 // We don't want the receiver to stop if there is an error processing a packet
 func (nr *netflowReceiver) handleErrors() {
-	for err := range nr.udpReceiver.Errors() {
+	defer nr.wg.Done()
+
+	for {
+		var err error
+		// The receiver drops its errors when they are not consumed, so the error
+		// signaling it was closed can be missed, and the done channel is needed
+		select {
+		case err = <-nr.udpReceiver.Errors():
+		case <-nr.done:
+			return
+		}
+
 		switch {
 		case errors.Is(err, net.ErrClosed):
 			nr.logger.Info("UDP receiver closed, exiting error handler")
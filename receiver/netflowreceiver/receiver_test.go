@@ -8,9 +8,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -21,5 +23,33 @@ func TestCreateValidDefaultReceiver(t *testing.T) {
 	receiver, err := factory.CreateLogs(context.Background(), set, cfg, consumertest.NewNop())
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, receiver, "receiver creation failed")
-	assert.NotNil(t, receiver.(*netflowReceiver).udpReceiver)
+	assert.NotNil(t, receiver.(*sharedcomponent.SharedComponent).Unwrap().(*netflowReceiver).udpReceiver)
+}
+
+func TestCreateValidMetricsReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := receivertest.NewNopSettings(metadata.Type)
+	receiver, err := factory.CreateMetrics(context.Background(), set, cfg, consumertest.NewNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.NotNil(t, receiver, "receiver creation failed")
+	assert.NotNil(t, receiver.(*sharedcomponent.SharedComponent).Unwrap().(*netflowReceiver).metricConsumer)
+}
+
+func TestCreateSharedReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := receivertest.NewNopSettings(metadata.Type)
+	logsConsumer, metricsConsumer := new(consumertest.LogsSink), new(consumertest.MetricsSink)
+	logsReceiver, err := factory.CreateLogs(context.Background(), set, cfg, logsConsumer)
+	require.NoError(t, err)
+	metricsReceiver, err := factory.CreateMetrics(context.Background(), set, cfg, metricsConsumer)
+	require.NoError(t, err)
+
+	// A single receiver listens for the flows of both pipelines
+	assert.Same(t, logsReceiver, metricsReceiver)
+	nr := logsReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*netflowReceiver)
+	assert.Same(t, logsConsumer, nr.logConsumer)
+	assert.Same(t, metricsConsumer, nr.metricConsumer)
+	require.NoError(t, logsReceiver.Shutdown(context.Background()))
 }
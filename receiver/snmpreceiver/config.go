@@ -5,6 +5,7 @@ package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"errors"
 	"fmt"
+	"net/netip"
 	"net/url"
 	"strings"
 	"time"
@@ -23,6 +24,14 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultDiscoveryInterval  = time.Hour
+	defaultDiscoveryRateLimit = 50 // In probes per second
+
+	defaultDiscoveryMaxMissedSweeps      = 3
+	defaultDiscoveryMaxConcurrentScrapes = 16
+
+	// maxDiscoveryNetworkHostBits limits the number of addresses of a single network to sweep to 65536
+	maxDiscoveryNetworkHostBits = 16
 )
 
 var (
@@ -52,22 +61,32 @@ var (
 	errMsgMultipleKeysSetOnResourceAttribute        = `resource attribute '%s' must have only one of oid, scalar_oid, or indexed_value_prefix`
 	errScalarOIDResourceAttributeEndsInNonzeroDigit = `resource attribute '%s' has scalar_oid '%s' that ends in a nonzero digit (scalar oids should not be indexed)`
 	errColumnOIDResourceAttributeEndsInZero         = `resource attribute '%s' has oid '%s' that ends in a zero (column oids should be indexed)`
+	errMsgDiscoveryInvalidNetwork                   = `discovery network '%s' must be a CIDR range: %w`
+	errMsgDiscoveryNetworkTooLarge                  = `discovery network '%s' must not contain more than %d addresses`
+	errMsgDiscoveryProfileNoSysObjectIDPrefix       = `discovery profile '%s' must have at least one sys_object_id_prefix`
+	errMsgDiscoveryProfileBadMetric                 = `discovery profile '%s' metric '%s' must match a metric config`
 
 	// Config errors
-	errEmptyEndpoint        = errors.New("endpoint must be specified")
-	errEndpointBadScheme    = errors.New("endpoint scheme must be either tcp, tcp4, tcp6, udp, udp4, or udp6")
-	errEmptyVersion         = errors.New("version must specified")
-	errBadVersion           = errors.New("version must be either v1, v2c, or v3")
-	errEmptyUser            = errors.New("user must be specified when version is v3")
-	errEmptySecurityLevel   = errors.New("security_level must be specified when version is v3")
-	errBadSecurityLevel     = errors.New("security_level must be either no_auth_no_priv, auth_no_priv, or auth_priv")
-	errEmptyAuthType        = errors.New("auth_type must be specified when security_level is auth_no_priv or auth_priv")
-	errBadAuthType          = errors.New("auth_type must be either MD5, SHA, SHA224, SHA256, SHA384, SHA512")
-	errEmptyAuthPassword    = errors.New("auth_password must be specified when security_level is auth_no_priv or auth_priv")
-	errEmptyPrivacyType     = errors.New("privacy_type must be specified when security_level is auth_priv")
-	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
-	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
-	errMetricRequired       = errors.New("must have at least one config under metrics")
+	errEmptyEndpoint          = errors.New("endpoint must be specified")
+	errEndpointBadScheme      = errors.New("endpoint scheme must be either tcp, tcp4, tcp6, udp, udp4, or udp6")
+	errEmptyVersion           = errors.New("version must specified")
+	errBadVersion             = errors.New("version must be either v1, v2c, or v3")
+	errEmptyUser              = errors.New("user must be specified when version is v3")
+	errEmptySecurityLevel     = errors.New("security_level must be specified when version is v3")
+	errBadSecurityLevel       = errors.New("security_level must be either no_auth_no_priv, auth_no_priv, or auth_priv")
+	errEmptyAuthType          = errors.New("auth_type must be specified when security_level is auth_no_priv or auth_priv")
+	errBadAuthType            = errors.New("auth_type must be either MD5, SHA, SHA224, SHA256, SHA384, SHA512")
+	errEmptyAuthPassword      = errors.New("auth_password must be specified when security_level is auth_no_priv or auth_priv")
+	errEmptyPrivacyType       = errors.New("privacy_type must be specified when security_level is auth_priv")
+	errBadPrivacyType         = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
+	errEmptyPrivacyPassword   = errors.New("privacy_password must be specified when security_level is auth_priv")
+	errMetricRequired         = errors.New("must have at least one config under metrics")
+	errEmptyDiscoveryNetworks = errors.New("discovery must have at least one network")
+	errBadDiscoveryInterval   = errors.New("discovery interval must not be negative")
+	errBadDiscoveryRateLimit  = errors.New("discovery rate_limit must not be negative")
+
+	errBadDiscoveryMaxMissedSweeps      = errors.New("discovery max_missed_sweeps must not be negative")
+	errBadDiscoveryMaxConcurrentScrapes = errors.New("discovery max_concurrent_scrapes must not be negative")
 )
 
 // Config defines the configuration for the various elements of the receiver.
@@ -131,6 +150,43 @@ type Config struct {
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
 	// names along with their metric configurations
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
+
+	// Discovery is optional and sweeps networks for SNMP devices to scrape. When it is set, the
+	// discovered devices are scraped instead of the Endpoint, whose scheme and port are used to reach them.
+	Discovery *DiscoveryConfig `mapstructure:"discovery"`
+}
+
+// DiscoveryConfig contains config info about the discovery of the SNMP devices to scrape
+type DiscoveryConfig struct {
+	// Networks is required and contains the CIDR ranges to sweep, e.g. 10.0.0.0/24
+	Networks []string `mapstructure:"networks"`
+	// Interval is the time between the starts of two sweeps of the networks.
+	// Default: 1h
+	Interval time.Duration `mapstructure:"interval"`
+	// RateLimit is the maximum number of addresses probed per second during a sweep.
+	// Default: 50
+	RateLimit int `mapstructure:"rate_limit"`
+	// MaxMissedSweeps is the number of consecutive sweeps a discovered device doesn't respond to
+	// before it stops being scraped.
+	// Default: 3
+	MaxMissedSweeps int `mapstructure:"max_missed_sweeps"`
+	// MaxConcurrentScrapes is the maximum number of discovered devices scraped at the same time.
+	// Default: 16
+	MaxConcurrentScrapes int `mapstructure:"max_concurrent_scrapes"`
+	// Profiles is optional and matches the discovered devices to the metrics scraped from them.
+	// When there are profiles, the devices that match none of them aren't scraped. When there are none,
+	// all of the metrics are scraped from every discovered device.
+	Profiles map[string]*ProfileConfig `mapstructure:"profiles"`
+}
+
+// ProfileConfig contains config info about a kind of devices and the metrics to scrape from them
+type ProfileConfig struct {
+	// SysObjectIDPrefixes is required and contains the OID prefixes, typically the enterprise OID of a vendor,
+	// matched against the sysObjectID of the devices. The profile with the longest matching prefix is used.
+	SysObjectIDPrefixes []string `mapstructure:"sys_object_id_prefixes"`
+	// Metrics is optional and contains the names of the metric configs to scrape from the matched devices.
+	// All of the metrics are scraped when it is empty.
+	Metrics []string `mapstructure:"metrics"`
 }
 
 // ResourceAttributeConfig contains config info about all of the resource attributes that will be used by this receiver.
@@ -262,6 +318,9 @@ func (cfg *Config) Validate() error {
 		combinedErr = errors.Join(combinedErr, validateSecurity(cfg))
 	}
 	combinedErr = errors.Join(combinedErr, validateMetricConfigs(cfg))
+	if cfg.Discovery != nil {
+		combinedErr = errors.Join(combinedErr, validateDiscovery(cfg))
+	}
 
 	return combinedErr
 }
@@ -291,6 +350,60 @@ func validateEndpoint(cfg *Config) error {
 	return nil
 }
 
+// validateDiscovery validates the DiscoveryConfig and its ProfileConfigs
+func validateDiscovery(cfg *Config) error {
+	var combinedErr error
+
+	discovery := cfg.Discovery
+	if len(discovery.Networks) == 0 {
+		combinedErr = errors.Join(combinedErr, errEmptyDiscoveryNetworks)
+	}
+
+	// Ensure valid networks that aren't too large to sweep
+	for _, network := range discovery.Networks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgDiscoveryInvalidNetwork, network, err))
+			continue
+		}
+		if hostBits := prefix.Addr().BitLen() - prefix.Bits(); hostBits > maxDiscoveryNetworkHostBits {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgDiscoveryNetworkTooLarge, network, 1<<maxDiscoveryNetworkHostBits))
+		}
+	}
+
+	if discovery.Interval < 0 {
+		combinedErr = errors.Join(combinedErr, errBadDiscoveryInterval)
+	}
+
+	if discovery.RateLimit < 0 {
+		combinedErr = errors.Join(combinedErr, errBadDiscoveryRateLimit)
+	}
+
+	if discovery.MaxMissedSweeps < 0 {
+		combinedErr = errors.Join(combinedErr, errBadDiscoveryMaxMissedSweeps)
+	}
+
+	if discovery.MaxConcurrentScrapes < 0 {
+		combinedErr = errors.Join(combinedErr, errBadDiscoveryMaxConcurrentScrapes)
+	}
+
+	// Make sure each profile matches devices and scrapes existing metrics
+	for profileName, profileCfg := range discovery.Profiles {
+		if profileCfg == nil || len(profileCfg.SysObjectIDPrefixes) == 0 {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgDiscoveryProfileNoSysObjectIDPrefix, profileName))
+			continue
+		}
+
+		for _, metricName := range profileCfg.Metrics {
+			if _, ok := cfg.Metrics[metricName]; !ok {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgDiscoveryProfileBadMetric, profileName, metricName))
+			}
+		}
+	}
+
+	return combinedErr
+}
+
 // validateVersion validates the Version
 func validateVersion(cfg *Config) error {
 	if cfg.Version == "" {
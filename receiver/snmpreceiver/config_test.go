@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -131,6 +132,26 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 	expectedConfigV3NoPrivacyPassword.AuthPassword = "p"
 	expectedConfigV3NoPrivacyPassword.Metrics = metrics
 
+	expectedConfigDiscovery := factory.CreateDefaultConfig().(*Config)
+	expectedConfigDiscovery.Discovery = &DiscoveryConfig{
+		Networks:  []string{"10.0.0.0/24"},
+		Interval:  30 * time.Minute,
+		RateLimit: 10,
+		Profiles: map[string]*ProfileConfig{
+			"cisco": {
+				SysObjectIDPrefixes: []string{"1.3.6.1.4.1.9"},
+				Metrics:             []string{"m3"},
+			},
+		},
+	}
+	expectedConfigDiscovery.Metrics = metrics
+
+	expectedConfigDiscoveryBadNetwork := factory.CreateDefaultConfig().(*Config)
+	expectedConfigDiscoveryBadNetwork.Discovery = &DiscoveryConfig{
+		Networks: []string{"10.0.0.1"},
+	}
+	expectedConfigDiscoveryBadNetwork.Metrics = metrics
+
 	testCases := []testCase{
 		{
 			name:        "NoEndpointUsesDefault",
@@ -246,6 +267,18 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 			expectedCfg: expectedConfigV3Simple,
 			expectedErr: "",
 		},
+		{
+			name:        "GoodDiscoveryNoErrors",
+			nameVal:     "discovery_good",
+			expectedCfg: expectedConfigDiscovery,
+			expectedErr: "",
+		},
+		{
+			name:        "DiscoveryBadNetworkErrors",
+			nameVal:     "discovery_bad_network",
+			expectedCfg: expectedConfigDiscoveryBadNetwork,
+			expectedErr: fmt.Sprintf(errMsgDiscoveryInvalidNetwork[:len(errMsgDiscoveryInvalidNetwork)-4], "10.0.0.1"),
+		},
 	}
 
 	for _, test := range testCases {
@@ -1133,6 +1166,191 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: errEmptyPrivacyType.Error(),
 		},
+		{
+			name: "DiscoveryNoNetworksErrors",
+			cfg: &Config{
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+				Discovery: &DiscoveryConfig{},
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ScalarOIDs: []ScalarOID{
+							{
+								OID: "1",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errEmptyDiscoveryNetworks.Error(),
+		},
+		{
+			name: "DiscoveryNetworkTooLargeErrors",
+			cfg: &Config{
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+				Discovery: &DiscoveryConfig{
+					Networks: []string{"10.0.0.0/8"},
+				},
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ScalarOIDs: []ScalarOID{
+							{
+								OID: "1",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf(errMsgDiscoveryNetworkTooLarge, "10.0.0.0/8", 1<<maxDiscoveryNetworkHostBits),
+		},
+		{
+			name: "DiscoveryNegativeRateLimitErrors",
+			cfg: &Config{
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+				Discovery: &DiscoveryConfig{
+					Networks:  []string{"10.0.0.0/24"},
+					RateLimit: -1,
+				},
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ScalarOIDs: []ScalarOID{
+							{
+								OID: "1",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errBadDiscoveryRateLimit.Error(),
+		},
+		{
+			name: "DiscoveryNegativeMaxMissedSweepsErrors",
+			cfg: &Config{
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+				Discovery: &DiscoveryConfig{
+					Networks:        []string{"10.0.0.0/24"},
+					MaxMissedSweeps: -1,
+				},
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ScalarOIDs: []ScalarOID{
+							{
+								OID: "1",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errBadDiscoveryMaxMissedSweeps.Error(),
+		},
+		{
+			name: "DiscoveryNegativeMaxConcurrentScrapesErrors",
+			cfg: &Config{
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+				Discovery: &DiscoveryConfig{
+					Networks:             []string{"10.0.0.0/24"},
+					MaxConcurrentScrapes: -1,
+				},
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ScalarOIDs: []ScalarOID{
+							{
+								OID: "1",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errBadDiscoveryMaxConcurrentScrapes.Error(),
+		},
+		{
+			name: "DiscoveryProfileNoSysObjectIDPrefixErrors",
+			cfg: &Config{
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+				Discovery: &DiscoveryConfig{
+					Networks: []string{"10.0.0.0/24"},
+					Profiles: map[string]*ProfileConfig{
+						"cisco": {},
+					},
+				},
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ScalarOIDs: []ScalarOID{
+							{
+								OID: "1",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf(errMsgDiscoveryProfileNoSysObjectIDPrefix, "cisco"),
+		},
+		{
+			name: "DiscoveryProfileBadMetricErrors",
+			cfg: &Config{
+				Endpoint:  "udp://localhost:161",
+				Version:   "v2c",
+				Community: "public",
+				Discovery: &DiscoveryConfig{
+					Networks: []string{"10.0.0.0/24"},
+					Profiles: map[string]*ProfileConfig{
+						"cisco": {
+							SysObjectIDPrefixes: []string{"1.3.6.1.4.1.9"},
+							Metrics:             []string{"m4"},
+						},
+					},
+				},
+				Metrics: map[string]*MetricConfig{
+					"m3": {
+						Unit: "By",
+						Gauge: &GaugeMetric{
+							ValueType: "double",
+						},
+						ScalarOIDs: []ScalarOID{
+							{
+								OID: "1",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf(errMsgDiscoveryProfileBadMetric, "cisco", "m4"),
+		},
 	}
 
 	for _, test := range testCases {
@@ -0,0 +1,371 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+)
+
+// sysObjectIDOID is the scalar OID of SNMPv2-MIB::sysObjectID, which identifies the vendor and model of a device
+const sysObjectIDOID = ".1.3.6.1.2.1.1.2.0"
+
+// Resource attributes added to the resources of the discovered devices
+const (
+	deviceAddressResourceAttribute     = "snmp.device.address"
+	deviceSysObjectIDResourceAttribute = "snmp.device.sys_object_id"
+	deviceProfileResourceAttribute     = "snmp.device.profile"
+)
+
+// discoveredDevice is a device found by a sweep of the discovery networks, along with the scraper of its metrics
+type discoveredDevice struct {
+	address     string
+	sysObjectID string
+	profile     string
+	scraper     *snmpScraper
+
+	// missedSweeps is the number of consecutive sweeps the device didn't respond to, guarded by the discoverer
+	missedSweeps int
+}
+
+// discoverer periodically sweeps the discovery networks for SNMP devices
+type discoverer struct {
+	cfg       *Config
+	logger    *zap.Logger
+	settings  receiver.Settings
+	newClient func(cfg *Config, logger *zap.Logger) (client, error)
+
+	mu      sync.Mutex
+	devices map[string]*discoveredDevice
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newDiscoverer creates an initialized discoverer
+func newDiscoverer(logger *zap.Logger, cfg *Config, settings receiver.Settings) *discoverer {
+	return &discoverer{
+		cfg:       cfg,
+		logger:    logger,
+		settings:  settings,
+		newClient: newClient,
+		devices:   map[string]*discoveredDevice{},
+	}
+}
+
+// start sweeps the networks right away, then once every discovery interval
+func (d *discoverer) start() {
+	// The scrapers of the devices share the metric configs, so the OIDs are prefixed up front
+	// to only read the configs afterwards
+	newConfigHelper(d.cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		ticker := time.NewTicker(d.cfg.Discovery.Interval)
+		defer ticker.Stop()
+		for {
+			d.sweep(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// shutdown stops sweeping the networks
+func (d *discoverer) shutdown() {
+	if d.cancel == nil {
+		return
+	}
+	d.cancel()
+	d.wg.Wait()
+}
+
+// getDevices returns the devices found by the sweeps, ordered by address
+func (d *discoverer) getDevices() []*discoveredDevice {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	devices := make([]*discoveredDevice, 0, len(d.devices))
+	for _, device := range d.devices {
+		devices = append(devices, device)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].address < devices[j].address
+	})
+	return devices
+}
+
+// sweep probes every address of the networks, starting at most rate_limit probes per second.
+// The devices are available to scrape as soon as they are found, and the devices which didn't
+// respond to max_missed_sweeps consecutive sweeps are forgotten at the end of the sweep.
+func (d *discoverer) sweep(ctx context.Context) {
+	limiter := time.NewTicker(max(time.Second/time.Duration(d.cfg.Discovery.RateLimit), time.Nanosecond))
+	defer limiter.Stop()
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		found     = map[string]*discoveredDevice{}
+		responded = map[string]bool{}
+	)
+	for _, network := range d.cfg.Discovery.Networks {
+		// Checked in config
+		prefix, _ := netip.ParsePrefix(network)
+		for _, addr := range hostAddresses(prefix) {
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			case <-limiter.C:
+			}
+
+			wg.Add(1)
+			go func(address string) {
+				defer wg.Done()
+				sysObjectID, ok := d.probe(address)
+				if !ok {
+					return
+				}
+				mu.Lock()
+				responded[address] = true
+				mu.Unlock()
+				device := d.addDevice(address, sysObjectID)
+				if device == nil {
+					return
+				}
+				mu.Lock()
+				found[address] = device
+				mu.Unlock()
+			}(addr.String())
+		}
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	d.mu.Lock()
+	for _, device := range found {
+		device.missedSweeps = 0
+	}
+	// A device may miss a probe while it is busy or the request is lost, so it is only forgotten
+	// after missing several sweeps in a row
+	for address, device := range d.devices {
+		if responded[address] {
+			continue
+		}
+		device.missedSweeps++
+		if device.missedSweeps < d.cfg.Discovery.MaxMissedSweeps {
+			found[address] = device
+			continue
+		}
+		d.logger.Debug("Forgetting SNMP device which stopped responding", zap.String("address", address), zap.Int("missed_sweeps", device.missedSweeps))
+	}
+	d.devices = found
+	d.mu.Unlock()
+	d.logger.Debug("Completed SNMP discovery sweep", zap.Int("devices", len(found)))
+}
+
+// probe requests the sysObjectID of the device at the address, and reports whether it responded
+func (d *discoverer) probe(address string) (string, bool) {
+	c, err := d.newClient(d.deviceConfig(address, nil), d.logger)
+	if err != nil {
+		d.logger.Debug("Failed to create SNMP client", zap.String("address", address), zap.Error(err))
+		return "", false
+	}
+	if err = c.Connect(); err != nil {
+		return "", false
+	}
+	defer c.Close()
+
+	// The errors only tell that there is no SNMP device at the address
+	var scraperErrors scrapererror.ScrapeErrors
+	data := c.GetScalarData([]string{sysObjectIDOID}, &scraperErrors)
+	if len(data) == 0 || data[0].valueType != stringVal {
+		return "", false
+	}
+
+	return data[0].value.(string), true
+}
+
+// addDevice makes the device at the address available to scrape, if it matches a profile. A device
+// which was already found with the same sysObjectID is kept as it is.
+func (d *discoverer) addDevice(address string, sysObjectID string) *discoveredDevice {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if device, ok := d.devices[address]; ok && device.sysObjectID == sysObjectID {
+		return device
+	}
+
+	profileName, profileCfg, ok := matchProfile(d.cfg.Discovery.Profiles, sysObjectID)
+	if !ok {
+		d.logger.Debug("Discovered SNMP device matches no profile", zap.String("address", address), zap.String("sys_object_id", sysObjectID))
+		return nil
+	}
+
+	deviceCfg := d.deviceConfig(address, profileCfg)
+	c, err := d.newClient(deviceCfg, d.logger)
+	if err != nil {
+		d.logger.Warn("Failed to create SNMP client for discovered device", zap.String("address", address), zap.Error(err))
+		return nil
+	}
+
+	d.logger.Debug("Discovered SNMP device", zap.String("address", address), zap.String("sys_object_id", sysObjectID), zap.String("profile", profileName))
+	device := &discoveredDevice{
+		address:     address,
+		sysObjectID: sysObjectID,
+		profile:     profileName,
+		scraper: &snmpScraper{
+			client:    c,
+			logger:    d.logger,
+			cfg:       deviceCfg,
+			settings:  d.settings,
+			startTime: pcommon.NewTimestampFromTime(time.Now()),
+		},
+	}
+	d.devices[address] = device
+
+	return device
+}
+
+// deviceConfig returns the config to scrape the device at the address, with the metrics of the profile
+func (d *discoverer) deviceConfig(address string, profileCfg *ProfileConfig) *Config {
+	cfg := *d.cfg
+	cfg.Discovery = nil
+
+	// Checked in config
+	endpointURL, _ := url.Parse(d.cfg.Endpoint)
+	cfg.Endpoint = endpointURL.Scheme + "://" + net.JoinHostPort(address, endpointURL.Port())
+
+	if profileCfg != nil && len(profileCfg.Metrics) > 0 {
+		cfg.Metrics = make(map[string]*MetricConfig, len(profileCfg.Metrics))
+		for _, name := range profileCfg.Metrics {
+			cfg.Metrics[name] = d.cfg.Metrics[name]
+		}
+	}
+
+	return &cfg
+}
+
+// matchProfile returns the profile with the longest sys_object_id_prefix matching the sysObjectID.
+// Any sysObjectID matches when there are no profiles.
+func matchProfile(profiles map[string]*ProfileConfig, sysObjectID string) (string, *ProfileConfig, bool) {
+	if len(profiles) == 0 {
+		return "", nil, true
+	}
+
+	sysObjectID = "." + strings.TrimPrefix(sysObjectID, ".")
+
+	var (
+		matchName   string
+		matchCfg    *ProfileConfig
+		matchLength int
+	)
+	for name, profileCfg := range profiles {
+		for _, prefix := range profileCfg.SysObjectIDPrefixes {
+			prefix = "." + strings.Trim(prefix, ".")
+			// Only match whole sub-identifiers, e.g. 1.3.6.1.4.1.9 doesn't match 1.3.6.1.4.1.99
+			if sysObjectID != prefix && !strings.HasPrefix(sysObjectID, prefix+".") {
+				continue
+			}
+			if len(prefix) > matchLength || (len(prefix) == matchLength && name < matchName) {
+				matchName, matchCfg, matchLength = name, profileCfg, len(prefix)
+			}
+		}
+	}
+
+	return matchName, matchCfg, matchCfg != nil
+}
+
+// hostAddresses returns the addresses of the network to probe, without the network and broadcast
+// addresses of IPv4 networks which have them
+func hostAddresses(prefix netip.Prefix) []netip.Addr {
+	prefix = prefix.Masked()
+
+	var addresses []netip.Addr
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		addresses = append(addresses, addr)
+	}
+
+	if prefix.Addr().Is4() && prefix.Bits() < 31 {
+		addresses = addresses[1 : len(addresses)-1]
+	}
+	return addresses
+}
+
+// scrapeDevices scrapes the discovered devices, at most max_concurrent_scrapes at the same time,
+// adding the device info to their resources
+func (s *snmpScraper) scrapeDevices(ctx context.Context) (pmetric.Metrics, error) {
+	devices := s.discoverer.getDevices()
+
+	results := make([]pmetric.Metrics, len(devices))
+	errs := make([]error, len(devices))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, max(s.cfg.Discovery.MaxConcurrentScrapes, 1))
+	for i, device := range devices {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			results[i], errs[i] = device.scraper.scrape(ctx)
+		}()
+	}
+	wg.Wait()
+
+	metrics := pmetric.NewMetrics()
+	var scraperErrors scrapererror.ScrapeErrors
+	for i, device := range devices {
+		resourceMetrics := results[i].ResourceMetrics()
+		for j := 0; j < resourceMetrics.Len(); j++ {
+			attributes := resourceMetrics.At(j).Resource().Attributes()
+			attributes.PutStr(deviceAddressResourceAttribute, device.address)
+			attributes.PutStr(deviceSysObjectIDResourceAttribute, device.sysObjectID)
+			if device.profile != "" {
+				attributes.PutStr(deviceProfileResourceAttribute, device.profile)
+			}
+		}
+		resourceMetrics.MoveAndAppendTo(metrics.ResourceMetrics())
+
+		if errs[i] == nil {
+			continue
+		}
+		// A device which can't be scraped doesn't prevent the metrics of the others to be reported
+		failed := 1
+		var partialErr scrapererror.PartialScrapeError
+		if errors.As(errs[i], &partialErr) {
+			failed = partialErr.Failed
+		}
+		scraperErrors.AddPartial(failed, fmt.Errorf("problem scraping SNMP device '%s': %w", device.address, errs[i]))
+	}
+
+	return metrics, scraperErrors.Combine()
+}
@@ -0,0 +1,366 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
+)
+
+func TestHostAddresses(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		network  string
+		expected []string
+	}{
+		{
+			desc:     "IPv4 network skips network and broadcast addresses",
+			network:  "192.168.1.0/29",
+			expected: []string{"192.168.1.1", "192.168.1.2", "192.168.1.3", "192.168.1.4", "192.168.1.5", "192.168.1.6"},
+		},
+		{
+			desc:     "IPv4 network is masked",
+			network:  "192.168.1.5/30",
+			expected: []string{"192.168.1.5", "192.168.1.6"},
+		},
+		{
+			desc:     "IPv4 point to point network keeps all addresses",
+			network:  "192.168.1.0/31",
+			expected: []string{"192.168.1.0", "192.168.1.1"},
+		},
+		{
+			desc:     "IPv4 single address",
+			network:  "192.168.1.7/32",
+			expected: []string{"192.168.1.7"},
+		},
+		{
+			desc:     "IPv6 network keeps all addresses",
+			network:  "2001:db8::/126",
+			expected: []string{"2001:db8::", "2001:db8::1", "2001:db8::2", "2001:db8::3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var addresses []string
+			for _, addr := range hostAddresses(netip.MustParsePrefix(tc.network)) {
+				addresses = append(addresses, addr.String())
+			}
+			require.Equal(t, tc.expected, addresses)
+		})
+	}
+}
+
+func TestMatchProfile(t *testing.T) {
+	profiles := map[string]*ProfileConfig{
+		"cisco": {
+			SysObjectIDPrefixes: []string{"1.3.6.1.4.1.9"},
+		},
+		"cisco_catalyst": {
+			SysObjectIDPrefixes: []string{".1.3.6.1.4.1.9.1.", "1.3.6.1.4.1.9.5"},
+		},
+		"juniper": {
+			SysObjectIDPrefixes: []string{"1.3.6.1.4.1.2636"},
+		},
+		"juniper_copy": {
+			SysObjectIDPrefixes: []string{"1.3.6.1.4.1.2636"},
+		},
+	}
+
+	testCases := []struct {
+		desc            string
+		profiles        map[string]*ProfileConfig
+		sysObjectID     string
+		expectedProfile string
+		expectedMatch   bool
+	}{
+		{
+			desc:          "No profiles matches any device",
+			sysObjectID:   ".1.3.6.1.4.1.8072.3.2.10",
+			expectedMatch: true,
+		},
+		{
+			desc:            "Prefix matches",
+			profiles:        profiles,
+			sysObjectID:     ".1.3.6.1.4.1.9.12.3",
+			expectedProfile: "cisco",
+			expectedMatch:   true,
+		},
+		{
+			desc:            "Prefix matches without leading dot",
+			profiles:        profiles,
+			sysObjectID:     "1.3.6.1.4.1.9",
+			expectedProfile: "cisco",
+			expectedMatch:   true,
+		},
+		{
+			desc:            "Longest prefix matches",
+			profiles:        profiles,
+			sysObjectID:     ".1.3.6.1.4.1.9.1.1208",
+			expectedProfile: "cisco_catalyst",
+			expectedMatch:   true,
+		},
+		{
+			desc:            "Same prefixes match the first profile by name",
+			profiles:        profiles,
+			sysObjectID:     ".1.3.6.1.4.1.2636.1.1.1.2.29",
+			expectedProfile: "juniper",
+			expectedMatch:   true,
+		},
+		{
+			desc:          "Partial sub-identifier doesn't match",
+			profiles:      profiles,
+			sysObjectID:   ".1.3.6.1.4.1.99.1",
+			expectedMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			name, profileCfg, ok := matchProfile(tc.profiles, tc.sysObjectID)
+			require.Equal(t, tc.expectedMatch, ok)
+			require.Equal(t, tc.expectedProfile, name)
+			if tc.expectedProfile != "" {
+				require.Same(t, tc.profiles[tc.expectedProfile], profileCfg)
+			}
+		})
+	}
+}
+
+func newDiscoveryTestConfig() *Config {
+	return &Config{
+		Endpoint:  "udp://localhost:1161",
+		Version:   "v2c",
+		Community: "public",
+		Discovery: &DiscoveryConfig{
+			Networks:             []string{"10.0.0.0/30"},
+			Interval:             time.Hour,
+			RateLimit:            1000,
+			MaxMissedSweeps:      2,
+			MaxConcurrentScrapes: 16,
+			Profiles: map[string]*ProfileConfig{
+				"cisco": {
+					SysObjectIDPrefixes: []string{"1.3.6.1.4.1.9"},
+					Metrics:             []string{"metric1"},
+				},
+			},
+		},
+		Metrics: map[string]*MetricConfig{
+			"metric1": {
+				Unit:  "1",
+				Gauge: &GaugeMetric{ValueType: "int"},
+				ScalarOIDs: []ScalarOID{
+					{
+						OID: ".1",
+					},
+				},
+			},
+			"metric2": {
+				Unit:  "1",
+				Gauge: &GaugeMetric{ValueType: "int"},
+				ScalarOIDs: []ScalarOID{
+					{
+						OID: ".2",
+					},
+				},
+			},
+		},
+	}
+}
+
+// newTestDiscoverer creates a discoverer whose clients are the mock clients of the endpoints
+func newTestDiscoverer(cfg *Config, clients map[string]*mockClient) *discoverer {
+	d := newDiscoverer(zap.NewNop(), cfg, receivertest.NewNopSettings(metadata.Type))
+	d.newClient = func(cfg *Config, _ *zap.Logger) (client, error) {
+		c, ok := clients[cfg.Endpoint]
+		if !ok {
+			return nil, errors.New("no client for endpoint")
+		}
+		return c, nil
+	}
+	return d
+}
+
+func newDeviceMockClient(sysObjectID string) *mockClient {
+	c := new(mockClient)
+	c.On("Connect").Return(nil)
+	c.On("Close").Return(nil)
+	c.On("GetScalarData", []string{sysObjectIDOID}, mock.Anything).Return([]snmpData{
+		{
+			oid:       sysObjectIDOID,
+			value:     sysObjectID,
+			valueType: stringVal,
+		},
+	})
+	return c
+}
+
+func TestDiscovererSweep(t *testing.T) {
+	cfg := newDiscoveryTestConfig()
+
+	unreachableClient := new(mockClient)
+	unreachableClient.On("Connect").Return(errors.New("unreachable"))
+	clients := map[string]*mockClient{
+		"udp://10.0.0.1:1161": newDeviceMockClient(".1.3.6.1.4.1.9.1.1208"),
+		"udp://10.0.0.2:1161": newDeviceMockClient(".1.3.6.1.4.1.2636.1.1.1.2.29"),
+	}
+	d := newTestDiscoverer(cfg, clients)
+
+	d.sweep(context.Background())
+
+	devices := d.getDevices()
+	require.Len(t, devices, 1)
+	device := devices[0]
+	require.Equal(t, "10.0.0.1", device.address)
+	require.Equal(t, ".1.3.6.1.4.1.9.1.1208", device.sysObjectID)
+	require.Equal(t, "cisco", device.profile)
+	require.Equal(t, "udp://10.0.0.1:1161", device.scraper.cfg.Endpoint)
+	require.Nil(t, device.scraper.cfg.Discovery)
+	require.Equal(t, map[string]*MetricConfig{"metric1": cfg.Metrics["metric1"]}, device.scraper.cfg.Metrics)
+
+	// A device found again is kept as it is
+	d.sweep(context.Background())
+	devices = d.getDevices()
+	require.Len(t, devices, 1)
+	require.Same(t, device, devices[0])
+
+	// A device which misses a sweep is kept, until it misses max_missed_sweeps sweeps in a row
+	clients["udp://10.0.0.1:1161"] = unreachableClient
+	d.sweep(context.Background())
+	devices = d.getDevices()
+	require.Len(t, devices, 1)
+	require.Same(t, device, devices[0])
+
+	// Responding again resets the missed sweeps
+	clients["udp://10.0.0.1:1161"] = newDeviceMockClient(".1.3.6.1.4.1.9.1.1208")
+	d.sweep(context.Background())
+	clients["udp://10.0.0.1:1161"] = unreachableClient
+	d.sweep(context.Background())
+	devices = d.getDevices()
+	require.Len(t, devices, 1)
+	require.Same(t, device, devices[0])
+
+	d.sweep(context.Background())
+	require.Empty(t, d.getDevices())
+}
+
+func TestDiscovererSweepNoProfiles(t *testing.T) {
+	cfg := newDiscoveryTestConfig()
+	cfg.Discovery.Profiles = nil
+	d := newTestDiscoverer(cfg, map[string]*mockClient{
+		"udp://10.0.0.1:1161": newDeviceMockClient(".1.3.6.1.4.1.9.1.1208"),
+		"udp://10.0.0.2:1161": newDeviceMockClient(".1.3.6.1.4.1.2636.1.1.1.2.29"),
+	})
+
+	d.sweep(context.Background())
+
+	devices := d.getDevices()
+	require.Len(t, devices, 2)
+	for i, address := range []string{"10.0.0.1", "10.0.0.2"} {
+		require.Equal(t, address, devices[i].address)
+		require.Empty(t, devices[i].profile)
+		require.Equal(t, cfg.Metrics, devices[i].scraper.cfg.Metrics)
+	}
+}
+
+func TestDiscovererStartShutdown(t *testing.T) {
+	cfg := newDiscoveryTestConfig()
+	scraper := newScraper(zap.NewNop(), cfg, receivertest.NewNopSettings(metadata.Type))
+	scraper.discoverer = newTestDiscoverer(cfg, map[string]*mockClient{
+		"udp://10.0.0.1:1161": newDeviceMockClient(".1.3.6.1.4.1.9.1.1208"),
+	})
+
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(scraper.discoverer.getDevices()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, scraper.shutdown(context.Background()))
+}
+
+func TestScrapeDevices(t *testing.T) {
+	cfg := newDiscoveryTestConfig()
+
+	goodClient := newDeviceMockClient(".1.3.6.1.4.1.9.1.1208")
+	goodClient.On("GetScalarData", []string{".1"}, mock.Anything).Return([]snmpData{
+		{
+			oid:       ".1",
+			value:     int64(5),
+			valueType: integerVal,
+		},
+	})
+	badClient := new(mockClient)
+	badClient.On("Connect").Return(errors.New("unreachable"))
+
+	scraper := newScraper(zap.NewNop(), cfg, receivertest.NewNopSettings(metadata.Type))
+	scraper.discoverer = newTestDiscoverer(cfg, map[string]*mockClient{
+		"udp://10.0.0.1:1161": goodClient,
+		"udp://10.0.0.2:1161": badClient,
+	})
+	scraper.discoverer.addDevice("10.0.0.1", ".1.3.6.1.4.1.9.1.1208")
+	scraper.discoverer.addDevice("10.0.0.2", ".1.3.6.1.4.1.9.1.1")
+
+	metrics, err := scraper.scrape(context.Background())
+	require.EqualError(t, err, "problem scraping SNMP device '10.0.0.2': problem connecting to SNMP host: unreachable")
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	attributes := metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw()
+	require.Equal(t, map[string]any{
+		deviceAddressResourceAttribute:     "10.0.0.1",
+		deviceSysObjectIDResourceAttribute: ".1.3.6.1.4.1.9.1.1208",
+		deviceProfileResourceAttribute:     "cisco",
+	}, attributes)
+	require.Equal(t, 1, metrics.MetricCount())
+	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, "metric1", metric.Name())
+	require.Equal(t, int64(5), metric.Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeDevicesMaxConcurrentScrapes(t *testing.T) {
+	cfg := newDiscoveryTestConfig()
+	cfg.Discovery.MaxConcurrentScrapes = 2
+
+	var scrapes, maxScrapes atomic.Int32
+	clients := map[string]*mockClient{}
+	for i := 1; i <= 6; i++ {
+		c := new(mockClient)
+		c.On("Connect").Return(func() error {
+			current := scrapes.Add(1)
+			for {
+				previous := maxScrapes.Load()
+				if current <= previous || maxScrapes.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			scrapes.Add(-1)
+			return errors.New("unreachable")
+		})
+		clients[fmt.Sprintf("udp://10.0.0.%d:1161", i)] = c
+	}
+
+	scraper := newScraper(zap.NewNop(), cfg, receivertest.NewNopSettings(metadata.Type))
+	scraper.discoverer = newTestDiscoverer(cfg, clients)
+	for i := 1; i <= 6; i++ {
+		scraper.discoverer.addDevice(fmt.Sprintf("10.0.0.%d", i), ".1.3.6.1.4.1.9.1.1208")
+	}
+
+	_, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	require.Equal(t, int32(2), maxScrapes.Load())
+}
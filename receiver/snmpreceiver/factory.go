@@ -63,7 +63,7 @@ func createMetricsReceiver(
 	}
 
 	snmpScraper := newScraper(params.Logger, snmpConfig, params)
-	s, err := scraper.NewMetrics(snmpScraper.scrape, scraper.WithStart(snmpScraper.start))
+	s, err := scraper.NewMetrics(snmpScraper.scrape, scraper.WithStart(snmpScraper.start), scraper.WithShutdown(snmpScraper.shutdown))
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +88,22 @@ func addMissingConfigDefaults(cfg *Config) error {
 		cfg.Endpoint += portSuffix
 	}
 
+	// Set defaults for discovery configs
+	if cfg.Discovery != nil {
+		if cfg.Discovery.Interval == 0 {
+			cfg.Discovery.Interval = defaultDiscoveryInterval
+		}
+		if cfg.Discovery.RateLimit == 0 {
+			cfg.Discovery.RateLimit = defaultDiscoveryRateLimit
+		}
+		if cfg.Discovery.MaxMissedSweeps == 0 {
+			cfg.Discovery.MaxMissedSweeps = defaultDiscoveryMaxMissedSweeps
+		}
+		if cfg.Discovery.MaxConcurrentScrapes == 0 {
+			cfg.Discovery.MaxConcurrentScrapes = defaultDiscoveryMaxConcurrentScrapes
+		}
+	}
+
 	// Set defaults for metric configs
 	for _, metricCfg := range cfg.Metrics {
 		if metricCfg.Unit == "" {
@@ -41,28 +41,50 @@ type snmpScraper struct {
 	cfg       *Config
 	settings  receiver.Settings
 	startTime pcommon.Timestamp
+	// discoverer is only set when the devices to scrape are discovered
+	discoverer *discoverer
 }
 
 type indexedAttributeValues map[string]string
 
 // newScraper creates an initialized snmpScraper
 func newScraper(logger *zap.Logger, cfg *Config, settings receiver.Settings) *snmpScraper {
-	return &snmpScraper{
+	s := &snmpScraper{
 		logger:   logger,
 		cfg:      cfg,
 		settings: settings,
 	}
+	if cfg.Discovery != nil {
+		s.discoverer = newDiscoverer(logger, cfg, settings)
+	}
+	return s
 }
 
-// start gets the client ready
+// start gets the client ready, or starts discovering the devices
 func (s *snmpScraper) start(_ context.Context, _ component.Host) (err error) {
-	s.client, err = newClient(s.cfg, s.logger)
 	s.startTime = pcommon.NewTimestampFromTime(time.Now())
+	if s.discoverer != nil {
+		s.discoverer.start()
+		return nil
+	}
+	s.client, err = newClient(s.cfg, s.logger)
 	return err
 }
 
+// shutdown stops discovering the devices
+func (s *snmpScraper) shutdown(_ context.Context) error {
+	if s.discoverer != nil {
+		s.discoverer.shutdown()
+	}
+	return nil
+}
+
 // scrape collects and creates OTEL metrics from a SNMP environment
-func (s *snmpScraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+func (s *snmpScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if s.discoverer != nil {
+		return s.scrapeDevices(ctx)
+	}
+
 	if err := s.client.Connect(); err != nil {
 		return pmetric.NewMetrics(), fmt.Errorf("problem connecting to SNMP host: %w", err)
 	}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// Config Defaults
+const (
+	defaultEndpoint      = "localhost:162"
+	defaultSecurityLevel = "no_auth_no_priv"
+	defaultAuthType      = "MD5"
+	defaultPrivacyType   = "DES"
+)
+
+var (
+	// Config error messages
+	errMsgInvalidEndpoint = `invalid endpoint '%s': must be in '[host]:[port]' format: %w`
+	errMsgInvalidEngineID = `invalid engine_id '%s': must be a hexadecimal string of 5 to 32 bytes`
+
+	// Config errors
+	errEmptyEndpoint        = errors.New("endpoint must be specified")
+	errEmptySecurityLevel   = errors.New("security_level must be specified when user is specified")
+	errBadSecurityLevel     = errors.New("security_level must be either no_auth_no_priv, auth_no_priv, or auth_priv")
+	errEmptyAuthType        = errors.New("auth_type must be specified when security_level is auth_no_priv or auth_priv")
+	errBadAuthType          = errors.New("auth_type must be either MD5, SHA, SHA224, SHA256, SHA384, SHA512")
+	errEmptyAuthPassword    = errors.New("auth_password must be specified when security_level is auth_no_priv or auth_priv")
+	errEmptyPrivacyType     = errors.New("privacy_type must be specified when security_level is auth_priv")
+	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
+	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
+)
+
+// Config defines the configuration for the SNMP trap receiver.
+type Config struct {
+	// Endpoint is the UDP address to listen for traps and informs on.
+	// Default: localhost:162
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Community is the community string that v1 and v2c traps and informs must have.
+	// If empty, traps and informs of any community are accepted.
+	Community string `mapstructure:"community"`
+
+	// User is the SNMP User that v3 traps and informs must have.
+	// If empty, v3 traps and informs are dropped.
+	User string `mapstructure:"user"`
+
+	// SecurityLevel is the minimum security level of v3 traps and informs.
+	// Only valid if User is set
+	// Valid options: “no_auth_no_priv”, “auth_no_priv”, “auth_priv”
+	// Default: "no_auth_no_priv"
+	SecurityLevel string `mapstructure:"security_level"`
+
+	// AuthType is the type of authentication protocol of v3 traps and informs.
+	// Only valid if User is set and if “no_auth_no_priv” is not selected for SecurityLevel
+	// Valid options: “md5”, “sha”, “sha224”, “sha256”, “sha384”, “sha512”
+	// Default: "md5"
+	AuthType string `mapstructure:"auth_type"`
+
+	// AuthPassword is the authentication password of v3 traps and informs.
+	// Only valid if User is set and if "no_auth_no_priv" is not selected for SecurityLevel
+	AuthPassword configopaque.String `mapstructure:"auth_password"`
+
+	// PrivacyType is the type of privacy protocol of v3 traps and informs.
+	// Only valid if User is set and if "auth_priv" is selected for SecurityLevel
+	// Valid options: “des”, “aes”, “aes192”, “aes256”, “aes192c”, “aes256c”
+	// Default: "des"
+	PrivacyType string `mapstructure:"privacy_type"`
+
+	// PrivacyPassword is the privacy password of v3 traps and informs.
+	// Only valid if User is set and if "auth_priv" is selected for SecurityLevel
+	PrivacyPassword configopaque.String `mapstructure:"privacy_password"`
+
+	// EngineID is the hexadecimal authoritative engine ID of the receiver, which
+	// senders of v3 informs must use to localize their keys.
+	// If empty, a random engine ID is generated at start.
+	EngineID string `mapstructure:"engine_id"`
+
+	// MIBDirectories are the directories of the MIB files used to translate the
+	// OIDs of the traps and of their variable bindings to names.
+	MIBDirectories []string `mapstructure:"mib_directories"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate validates the given config, returning an error specifying any issues with the config.
+func (cfg *Config) Validate() error {
+	var combinedErr error
+
+	combinedErr = errors.Join(combinedErr, validateEndpoint(cfg))
+	if cfg.User != "" {
+		combinedErr = errors.Join(combinedErr, validateSecurity(cfg))
+	}
+	if cfg.EngineID != "" {
+		if engineID, err := hex.DecodeString(cfg.EngineID); err != nil || len(engineID) < 5 || len(engineID) > 32 {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidEngineID, cfg.EngineID))
+		}
+	}
+
+	return combinedErr
+}
+
+// validateEndpoint validates the Endpoint
+func validateEndpoint(cfg *Config) error {
+	if cfg.Endpoint == "" {
+		return errEmptyEndpoint
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.Endpoint); err != nil {
+		return fmt.Errorf(errMsgInvalidEndpoint, cfg.Endpoint, err)
+	}
+
+	return nil
+}
+
+// validateSecurity validates all v3 related security configs
+func validateSecurity(cfg *Config) error {
+	if cfg.SecurityLevel == "" {
+		return errEmptySecurityLevel
+	}
+
+	// Ensure valid security level
+	switch strings.ToUpper(cfg.SecurityLevel) {
+	case "NO_AUTH_NO_PRIV":
+		return nil
+	case "AUTH_NO_PRIV":
+		// Ensure valid auth configs
+		return validateAuth(cfg)
+	case "AUTH_PRIV":
+		// Ensure valid auth and privacy configs
+		return errors.Join(validateAuth(cfg), validatePrivacy(cfg))
+	default:
+		return errBadSecurityLevel
+	}
+}
+
+// validateAuth validates the AuthType and AuthPassword
+func validateAuth(cfg *Config) error {
+	var combinedErr error
+
+	if cfg.AuthPassword == "" {
+		combinedErr = errors.Join(combinedErr, errEmptyAuthPassword)
+	}
+
+	if cfg.AuthType == "" {
+		return errors.Join(combinedErr, errEmptyAuthType)
+	}
+
+	// Ensure valid auth type
+	switch strings.ToUpper(cfg.AuthType) {
+	case "MD5", "SHA", "SHA224", "SHA256", "SHA384", "SHA512": // ok
+	default:
+		combinedErr = errors.Join(combinedErr, errBadAuthType)
+	}
+
+	return combinedErr
+}
+
+// validatePrivacy validates the PrivacyType and PrivacyPassword
+func validatePrivacy(cfg *Config) error {
+	var combinedErr error
+
+	if cfg.PrivacyPassword == "" {
+		combinedErr = errors.Join(combinedErr, errEmptyPrivacyPassword)
+	}
+
+	if cfg.PrivacyType == "" {
+		return errors.Join(combinedErr, errEmptyPrivacyType)
+	}
+
+	// Ensure valid privacy type
+	switch strings.ToUpper(cfg.PrivacyType) {
+	case "DES", "AES", "AES192", "AES256", "AES192C", "AES256C": // ok
+	default:
+		combinedErr = errors.Join(combinedErr, errBadPrivacyType)
+	}
+
+	return combinedErr
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, "defaults"),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "community"),
+			expected: &Config{
+				Endpoint:      "0.0.0.0:1162",
+				Community:     "private",
+				SecurityLevel: defaultSecurityLevel,
+				AuthType:      defaultAuthType,
+				PrivacyType:   defaultPrivacyType,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "v3"),
+			expected: &Config{
+				Endpoint:        "0.0.0.0:162",
+				User:            "otel",
+				SecurityLevel:   "auth_priv",
+				AuthType:        "SHA256",
+				AuthPassword:    "authpassword",
+				PrivacyType:     "AES",
+				PrivacyPassword: "privacypassword",
+				EngineID:        "8000000005aabbccddeeff",
+				MIBDirectories:  []string{"/usr/share/snmp/mibs"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id  component.ID
+		err string
+	}{
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_endpoint"),
+			err: "invalid endpoint 'localhost': must be in '[host]:[port]' format",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "empty_endpoint"),
+			err: errEmptyEndpoint.Error(),
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_security_level"),
+			err: errBadSecurityLevel.Error(),
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "missing_auth_password"),
+			err: errEmptyAuthPassword.Error(),
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_privacy_type"),
+			err: errBadPrivacyType.Error(),
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_engine_id"),
+			err: "invalid engine_id '80000000': must be a hexadecimal string of 5 to 32 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = xconfmap.Validate(cfg)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/metadata"
+)
+
+var errConfigNotSNMPTrap = errors.New("config was not a SNMP trap receiver config")
+
+// NewFactory creates a factory for the SNMP trap receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Endpoint:      defaultEndpoint,
+		SecurityLevel: defaultSecurityLevel,
+		AuthType:      defaultAuthType,
+		PrivacyType:   defaultPrivacyType,
+	}
+}
+
+func createLogsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
+	snmpTrapConfig, ok := cfg.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMPTrap
+	}
+
+	return newSNMPTrapReceiver(params, snmpTrapConfig, consumer)
+}
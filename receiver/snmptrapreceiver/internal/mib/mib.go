@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mib loads the OID assignments of SMIv1 and SMIv2 MIB modules, and
+// translates OIDs to the names of the MIB objects and notifications.
+//
+// Only the `::=` assignments of OIDs are parsed, the syntax of the objects and
+// the rest of the ASN.1 definitions are skipped, which is enough to translate
+// the OIDs of the variable bindings and notifications of SNMP traps.
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/mib"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// macros are the macros whose assignments are OIDs.
+var macros = map[string]bool{
+	"OBJECT-TYPE":        true,
+	"OBJECT-IDENTITY":    true,
+	"MODULE-IDENTITY":    true,
+	"NOTIFICATION-TYPE":  true,
+	"TRAP-TYPE":          true,
+	"OBJECT-GROUP":       true,
+	"NOTIFICATION-GROUP": true,
+	"MODULE-COMPLIANCE":  true,
+	"AGENT-CAPABILITIES": true,
+}
+
+// wellKnown are the OIDs of the nodes defined by the ASN.1 and SMI standards,
+// which the MIB modules usually import from modules which may not be available.
+var wellKnown = map[string]string{
+	"ccitt":           "0",
+	"zeroDotZero":     "0.0",
+	"iso":             "1",
+	"org":             "1.3",
+	"dod":             "1.3.6",
+	"internet":        "1.3.6.1",
+	"directory":       "1.3.6.1.1",
+	"mgmt":            "1.3.6.1.2",
+	"mib-2":           "1.3.6.1.2.1",
+	"transmission":    "1.3.6.1.2.1.10",
+	"experimental":    "1.3.6.1.3",
+	"private":         "1.3.6.1.4",
+	"enterprises":     "1.3.6.1.4.1",
+	"security":        "1.3.6.1.5",
+	"snmpV2":          "1.3.6.1.6",
+	"snmpDomains":     "1.3.6.1.6.1",
+	"snmpProxys":      "1.3.6.1.6.2",
+	"snmpModules":     "1.3.6.1.6.3",
+	"joint-iso-ccitt": "2",
+}
+
+// assignment is the OID of a name relative to its parent.
+type assignment struct {
+	parent string
+	subIDs []string
+}
+
+// Tree translates OIDs to names.
+type Tree struct {
+	names map[string]string
+}
+
+// Load parses the MIB modules in the files of the directories, and returns the
+// tree of the OIDs they assign.
+func Load(dirs []string) (*Tree, error) {
+	assignments := map[string]assignment{}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MIB directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read MIB file: %w", err)
+			}
+			parse(string(content), assignments)
+		}
+	}
+
+	oids := map[string]string{}
+	for name, oid := range wellKnown {
+		oids[name] = oid
+	}
+	var resolve func(name string, depth int) (string, bool)
+	resolve = func(name string, depth int) (string, bool) {
+		if oid, ok := oids[name]; ok {
+			return oid, true
+		}
+		a, ok := assignments[name]
+		// The depth bounds the resolution of cyclic assignments.
+		if !ok || depth > 128 {
+			return "", false
+		}
+		parent, ok := resolve(a.parent, depth+1)
+		if !ok {
+			return "", false
+		}
+		oid := parent + "." + strings.Join(a.subIDs, ".")
+		oids[name] = oid
+		return oid, true
+	}
+
+	t := &Tree{names: map[string]string{}}
+	for name := range assignments {
+		if oid, ok := resolve(name, 0); ok {
+			t.names[oid] = name
+		}
+	}
+	for name, oid := range wellKnown {
+		if _, ok := t.names[oid]; !ok {
+			t.names[oid] = name
+		}
+	}
+	return t, nil
+}
+
+// Translate returns the name of the longest known prefix of the OID followed by
+// the rest of the OID, such as ifIndex.3 for 1.3.6.1.2.1.2.2.1.1.3. It returns
+// false if no prefix of the OID is known.
+func (t *Tree) Translate(oid string) (string, bool) {
+	oid = strings.TrimPrefix(oid, ".")
+	for prefix := oid; prefix != ""; {
+		if name, ok := t.names[prefix]; ok {
+			return name + oid[len(prefix):], true
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return "", false
+}
+
+// Len returns the number of the names of the tree.
+func (t *Tree) Len() int {
+	return len(t.names)
+}
+
+// parse adds the OID assignments of the MIB module to assignments.
+func parse(content string, assignments map[string]assignment) {
+	tokens := tokenize(content)
+
+	// name is the object whose definition is being parsed, and enterprise the
+	// ENTERPRISE clause of SMIv1 TRAP-TYPE definitions.
+	var name, macro, enterprise string
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case isValueName(tok) && i+1 < len(tokens) && macros[tokens[i+1]]:
+			name, macro, enterprise = tok, tokens[i+1], ""
+			i++
+		case isValueName(tok) && i+2 < len(tokens) && tokens[i+1] == "OBJECT" && tokens[i+2] == "IDENTIFIER":
+			name, macro, enterprise = tok, "OBJECT IDENTIFIER", ""
+			i += 2
+		case tok == "ENTERPRISE" && macro == "TRAP-TYPE" && i+1 < len(tokens):
+			enterprise = tokens[i+1]
+			i++
+		case tok == "::=" && name != "" && i+1 < len(tokens):
+			if macro == "TRAP-TYPE" {
+				// The OID of SMIv1 traps is the enterprise, 0 and the trap number.
+				if enterprise != "" && isNumber(tokens[i+1]) {
+					assignments[name] = assignment{parent: enterprise, subIDs: []string{"0", tokens[i+1]}}
+				}
+			} else if tokens[i+1] == "{" {
+				end := i + 2
+				for end < len(tokens) && tokens[end] != "}" {
+					end++
+				}
+				addAssignment(name, tokens[i+2:min(end, len(tokens))], assignments)
+				i = end
+			}
+			name, macro, enterprise = "", "", ""
+		}
+	}
+}
+
+// addAssignment adds the assignment of the OID value, such as { ifEntry 1 } or
+// { iso org(3) dod(6) 1 }, to the name.
+func addAssignment(name string, components []string, assignments map[string]assignment) {
+	var parent string
+	var subIDs []string
+	for i := 0; i < len(components); i++ {
+		c := components[i]
+		switch {
+		case isNumber(c):
+			subIDs = append(subIDs, c)
+		case isValueName(c) && i+3 < len(components) && components[i+1] == "(" && isNumber(components[i+2]) && components[i+3] == ")":
+			// A named number defines the name too, except the root ones which
+			// are well known.
+			if parent != "" {
+				assignments[c] = assignment{parent: parent, subIDs: append(append([]string{}, subIDs...), components[i+2])}
+			}
+			parent, subIDs = c, nil
+			i += 3
+		case isValueName(c) && i == 0:
+			parent = c
+		default:
+			return
+		}
+	}
+	if parent != "" && len(subIDs) > 0 {
+		assignments[name] = assignment{parent: parent, subIDs: subIDs}
+	}
+}
+
+// tokenize splits the MIB module into identifiers, numbers and symbols, skipping
+// the comments and the strings.
+func tokenize(content string) []string {
+	var tokens []string
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '-' && i+1 < len(content) && content[i+1] == '-':
+			// Comments end at the end of the line or at the next --.
+			i += 2
+			for i < len(content) && content[i] != '\n' {
+				if content[i] == '-' && i+1 < len(content) && content[i+1] == '-' {
+					i++
+					break
+				}
+				i++
+			}
+			i++
+		case c == '"':
+			i++
+			for i < len(content) && content[i] != '"' {
+				i++
+			}
+			i++
+		case c == ':' && strings.HasPrefix(content[i:], "::="):
+			tokens = append(tokens, "::=")
+			i += 3
+		case isIdentifierByte(c):
+			start := i
+			for i < len(content) && (isIdentifierByte(content[i]) || content[i] == '-' && i+1 < len(content) && content[i+1] != '-') {
+				i++
+			}
+			tokens = append(tokens, content[start:i])
+		case unicode.IsSpace(rune(c)):
+			i++
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+func isIdentifierByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+// isValueName returns whether the token is the name of a value, which starts
+// with a lowercase letter, unlike the names of types and macros.
+func isValueName(tok string) bool {
+	return tok != "" && tok[0] >= 'a' && tok[0] <= 'z'
+}
+
+func isNumber(tok string) bool {
+	_, err := strconv.ParseUint(tok, 10, 32)
+	return err == nil
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+
+	tree, err := Load([]string{filepath.Join("..", "..", "testdata", "mibs")})
+	require.NoError(t, err)
+
+	tests := []struct {
+		oid      string
+		expected string
+	}{
+		{oid: "1.3.6.1.4.1.99999", expected: "upsTestMIB"},
+		{oid: "1.3.6.1.4.1.99999.0.1", expected: "upsBatteryLow"},
+		{oid: ".1.3.6.1.4.1.99999.1.1.1.2.4", expected: "upsBatteryStatus.4"},
+		{oid: "1.3.6.1.4.1.99999.1.1.1.3.4", expected: "upsBatteryName.4"},
+		{oid: "1.3.6.1.4.1.99999.0.2", expected: "upsOnBattery"},
+		{oid: "1.3.6.1.2.1.1.3.0", expected: "sysUpTime.0"},
+		{oid: "1.3.6.1.6.3.1.1.5.3", expected: "linkDown"},
+		{oid: "1.3.6.1.6.3.1.1.5.4", expected: "snmpTraps.4"},
+		{oid: "1.3.6.1.4.1.12345.1", expected: "enterprises.12345.1"},
+	}
+	for _, tt := range tests {
+		name, ok := tree.Translate(tt.oid)
+		assert.True(t, ok, tt.oid)
+		assert.Equal(t, tt.expected, name, tt.oid)
+	}
+
+	_, ok := tree.Translate("3.1")
+	assert.False(t, ok)
+}
+
+func TestParseOIDValues(t *testing.T) {
+	t.Parallel()
+
+	assignments := map[string]assignment{}
+	parse(`
+		-- ::= { commented 1 }
+		a OBJECT IDENTIFIER ::= { iso org(3) dod(6) custom(99) 1 }
+		b OBJECT IDENTIFIER ::= { a 2 3 }
+		c OBJECT-TYPE
+			DESCRIPTION "::= { quoted 1 }" -- inline -- SYNTAX Integer32
+			::= { b 4 }
+	`, assignments)
+
+	assert.Equal(t, map[string]assignment{
+		"org":    {parent: "iso", subIDs: []string{"3"}},
+		"dod":    {parent: "org", subIDs: []string{"6"}},
+		"custom": {parent: "dod", subIDs: []string{"99"}},
+		"a":      {parent: "custom", subIDs: []string{"1"}},
+		"b":      {parent: "a", subIDs: []string{"2", "3"}},
+		"c":      {parent: "b", subIDs: []string{"4"}},
+	}, assignments)
+}
+
+func TestLoadMissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load([]string{filepath.Join("testdata", "missing")})
+	assert.ErrorContains(t, err, "failed to read MIB directory")
+}
@@ -0,0 +1,477 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/mib"
+)
+
+const (
+	// sysUpTimeOID and snmpTrapOID are the OIDs of the first two variable
+	// bindings of v2c and v3 traps and informs, as defined in RFC 3416.
+	sysUpTimeOID   = "1.3.6.1.2.1.1.3.0"
+	snmpTrapOID    = "1.3.6.1.6.3.1.1.4.1.0"
+	snmpTrapsOID   = "1.3.6.1.6.3.1.1.5"
+	enterpriseTrap = 6
+	// usmStatsUnknownEngineIDsOID is the OID of the counter reported to the
+	// senders of v3 informs discovering the engine ID of the receiver.
+	usmStatsUnknownEngineIDsOID = "1.3.6.1.6.3.15.1.1.4.0"
+	maxPacketSize               = 65535
+
+	attributeNetworkPeerAddress = "network.peer.address"
+	attributeNetworkPeerPort    = "network.peer.port"
+	attributeVersion            = "snmp.version"
+	attributePDUType            = "snmp.pdu_type"
+	attributeTrapOID            = "snmp.trap.oid"
+	attributeTrapName           = "snmp.trap.name"
+	attributeUptime             = "snmp.uptime"
+	attributeAgentAddress       = "snmp.agent.address"
+)
+
+type snmpTrapReceiver struct {
+	config   *Config
+	logger   *zap.Logger
+	consumer consumer.Logs
+	obsrecv  *receiverhelper.ObsReport
+
+	// msgFlags is the minimum security level of v3 traps and informs.
+	msgFlags gosnmp.SnmpV3MsgFlags
+	mibs     *mib.Tree
+	// snmp decodes the traps and informs read from conn.
+	snmp *gosnmp.GoSNMP
+	conn *net.UDPConn
+	wg   sync.WaitGroup
+
+	// unknownEngineIDs counts the v3 packets sent to an unknown engine ID.
+	unknownEngineIDs atomic.Uint32
+}
+
+func newSNMPTrapReceiver(params receiver.Settings, config *Config, consumer consumer.Logs) (*snmpTrapReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              "udp",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &snmpTrapReceiver{
+		config:   config,
+		logger:   params.Logger,
+		consumer: consumer,
+		obsrecv:  obsrecv,
+	}, nil
+}
+
+func (r *snmpTrapReceiver) Start(_ context.Context, _ component.Host) error {
+	if len(r.config.MIBDirectories) > 0 {
+		mibs, err := mib.Load(r.config.MIBDirectories)
+		if err != nil {
+			return err
+		}
+		r.logger.Debug("Loaded MIB files", zap.Int("names", mibs.Len()))
+		r.mibs = mibs
+	}
+
+	params, err := r.params()
+	if err != nil {
+		return err
+	}
+
+	// The traps are read by the receiver rather than by the gosnmp trap
+	// listener, which acknowledges the informs the receiver drops. Connecting
+	// with an unconnected socket bound to the endpoint initializes the
+	// security parameters used to decode the traps and listens on the endpoint.
+	params.Transport = "udp"
+	params.LocalAddr = r.config.Endpoint
+	params.UseUnconnectedUDPSocket = true
+	if err := params.Connect(); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.config.Endpoint, err)
+	}
+	r.snmp = params
+	r.conn = params.Conn.(*net.UDPConn)
+
+	r.wg.Add(1)
+	go r.listen()
+	r.logger.Info("Listening for SNMP traps", zap.String("endpoint", r.config.Endpoint))
+	return nil
+}
+
+func (r *snmpTrapReceiver) Shutdown(context.Context) error {
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.wg.Wait()
+	return err
+}
+
+// listen reads the traps and informs until the connection is closed, and
+// acknowledges the informs once they have been consumed.
+func (r *snmpTrapReceiver) listen() {
+	defer r.wg.Done()
+
+	// The packet is handled before the next one is read, the buffer is reused.
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, addr, err := r.conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			r.logger.Debug("Failed to read SNMP packet", zap.Error(err))
+			continue
+		}
+
+		packet, err := r.snmp.UnmarshalTrap(buf[:n], false)
+		if err != nil {
+			r.logger.Debug("Failed to decode SNMP trap", zap.Stringer("remote", addr), zap.Error(err))
+			continue
+		}
+		if r.unknownEngineID(packet) {
+			r.reportEngineID(packet, addr)
+			continue
+		}
+		if !r.handleTrap(packet, addr) || packet.PDUType != gosnmp.InformRequest {
+			continue
+		}
+
+		// The response to an inform has the same variable bindings, as
+		// defined in RFC 3416.
+		packet.PDUType = gosnmp.GetResponse
+		packet.Error = gosnmp.NoError
+		packet.ErrorIndex = 0
+		r.send(packet, addr)
+	}
+}
+
+// unknownEngineID returns whether the v3 packet has an invalid authoritative
+// engine ID, which must be reported as defined in RFC 3414 3.2.3b.
+func (r *snmpTrapReceiver) unknownEngineID(packet *gosnmp.SnmpPacket) bool {
+	if packet.Version != gosnmp.Version3 || packet.SecurityModel != gosnmp.UserSecurityModel || r.snmp.SecurityModel != gosnmp.UserSecurityModel {
+		return false
+	}
+	securityParams, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if !ok {
+		return false
+	}
+	engineID := securityParams.AuthoritativeEngineID
+	return engineID != r.snmp.SecurityParameters.(*gosnmp.UsmSecurityParameters).AuthoritativeEngineID &&
+		(len(engineID) < 5 || len(engineID) > 32)
+}
+
+// reportEngineID reports the engine ID of the receiver to the sender of the
+// v3 inform, which discovers it this way.
+func (r *snmpTrapReceiver) reportEngineID(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	securityParams := packet.SecurityParameters.Copy().(*gosnmp.UsmSecurityParameters)
+	securityParams.AuthoritativeEngineID = r.snmp.SecurityParameters.(*gosnmp.UsmSecurityParameters).AuthoritativeEngineID
+	packet.PDUType = gosnmp.Report
+	packet.MsgFlags &= gosnmp.AuthPriv
+	packet.SecurityParameters = securityParams
+	packet.Variables = []gosnmp.SnmpPDU{{
+		Name:  usmStatsUnknownEngineIDsOID,
+		Type:  gosnmp.Integer,
+		Value: int(r.unknownEngineIDs.Add(1)),
+	}}
+	r.send(packet, addr)
+}
+
+func (r *snmpTrapReceiver) send(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	msg, err := packet.MarshalMsg()
+	if err == nil {
+		_, err = r.conn.WriteToUDP(msg, addr)
+	}
+	if err != nil {
+		r.logger.Warn("Failed to send SNMP response", zap.Stringer("remote", addr), zap.Error(err))
+	}
+}
+
+// params returns the gosnmp parameters used to decode the traps and informs.
+func (r *snmpTrapReceiver) params() (*gosnmp.GoSNMP, error) {
+	if r.config.User == "" {
+		return &gosnmp.GoSNMP{Version: gosnmp.Version2c}, nil
+	}
+
+	engineID, err := r.engineID()
+	if err != nil {
+		return nil, err
+	}
+
+	// Set security level & auth/privacy details based on config
+	securityParams := &gosnmp.UsmSecurityParameters{
+		UserName:              r.config.User,
+		AuthoritativeEngineID: string(engineID),
+	}
+	switch strings.ToUpper(r.config.SecurityLevel) {
+	case "AUTH_NO_PRIV":
+		r.msgFlags = gosnmp.AuthNoPriv
+		securityParams.AuthenticationProtocol = getAuthProtocol(r.config.AuthType)
+		securityParams.AuthenticationPassphrase = string(r.config.AuthPassword)
+	case "AUTH_PRIV":
+		r.msgFlags = gosnmp.AuthPriv
+		securityParams.AuthenticationProtocol = getAuthProtocol(r.config.AuthType)
+		securityParams.AuthenticationPassphrase = string(r.config.AuthPassword)
+		securityParams.PrivacyProtocol = getPrivacyProtocol(r.config.PrivacyType)
+		securityParams.PrivacyPassphrase = string(r.config.PrivacyPassword)
+	default:
+		r.msgFlags = gosnmp.NoAuthNoPriv
+		securityParams.AuthenticationProtocol = gosnmp.NoAuth
+		securityParams.PrivacyProtocol = gosnmp.NoPriv
+	}
+
+	return &gosnmp.GoSNMP{
+		Version:            gosnmp.Version3,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		MsgFlags:           r.msgFlags,
+		SecurityParameters: securityParams,
+	}, nil
+}
+
+// engineID returns the configured engine ID, or a random one in the octets
+// format of RFC 3411.
+func (r *snmpTrapReceiver) engineID() ([]byte, error) {
+	if r.config.EngineID != "" {
+		// Checked in config
+		return hex.DecodeString(r.config.EngineID)
+	}
+	engineID := []byte{0x80, 0x00, 0x00, 0x00, 0x05, 0, 0, 0, 0, 0, 0, 0, 0}
+	if _, err := rand.Read(engineID[5:]); err != nil {
+		return nil, fmt.Errorf("failed to generate engine ID: %w", err)
+	}
+	return engineID, nil
+}
+
+// getAuthProtocol gets gosnmp auth protocol based on config auth type
+func getAuthProtocol(authType string) gosnmp.SnmpV3AuthProtocol {
+	switch strings.ToUpper(authType) {
+	case "SHA":
+		return gosnmp.SHA
+	case "SHA224":
+		return gosnmp.SHA224
+	case "SHA256":
+		return gosnmp.SHA256
+	case "SHA384":
+		return gosnmp.SHA384
+	case "SHA512":
+		return gosnmp.SHA512
+	default:
+		return gosnmp.MD5
+	}
+}
+
+// getPrivacyProtocol gets gosnmp privacy protocol based on config privacy type
+func getPrivacyProtocol(privacyType string) gosnmp.SnmpV3PrivProtocol {
+	switch strings.ToUpper(privacyType) {
+	case "AES":
+		return gosnmp.AES
+	case "AES192":
+		return gosnmp.AES192
+	case "AES192C":
+		return gosnmp.AES192C
+	case "AES256":
+		return gosnmp.AES256
+	case "AES256C":
+		return gosnmp.AES256C
+	default:
+		return gosnmp.DES
+	}
+}
+
+// handleTrap converts the trap or inform to a log record and sends it to the
+// next consumer. It returns whether the trap was accepted and consumed, which
+// informs are acknowledged for.
+func (r *snmpTrapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) bool {
+	if !r.accept(packet) {
+		r.logger.Debug("Dropped SNMP trap not matching the configured credentials",
+			zap.Stringer("remote", addr), zap.String("version", version(packet.Version)))
+		return false
+	}
+
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	lr.Scope().SetName(metadata.ScopeName)
+	r.convertTrap(packet, addr, lr.LogRecords().AppendEmpty())
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	err := r.consumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(ctx, "snmp", 1, err)
+	if err != nil {
+		r.logger.Error("Failed to consume SNMP trap", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// accept returns whether the trap or inform matches the configured community
+// or user and minimum security level.
+func (r *snmpTrapReceiver) accept(packet *gosnmp.SnmpPacket) bool {
+	if packet.Version != gosnmp.Version3 {
+		return r.config.Community == "" || packet.Community == r.config.Community
+	}
+
+	if r.config.User == "" {
+		return false
+	}
+	securityParams, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if !ok || securityParams.UserName != r.config.User {
+		return false
+	}
+	// The authentication and the decryption are checked when decoding the trap.
+	return packet.MsgFlags&gosnmp.AuthPriv >= r.msgFlags
+}
+
+func (r *snmpTrapReceiver) convertTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr, lr plog.LogRecord) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+	lr.SetTimestamp(now)
+	lr.SetObservedTimestamp(now)
+
+	attrs := lr.Attributes()
+	attrs.PutStr(attributeNetworkPeerAddress, addr.IP.String())
+	attrs.PutInt(attributeNetworkPeerPort, int64(addr.Port))
+	attrs.PutStr(attributeVersion, version(packet.Version))
+	if packet.PDUType == gosnmp.InformRequest {
+		attrs.PutStr(attributePDUType, "inform")
+	} else {
+		attrs.PutStr(attributePDUType, "trap")
+	}
+
+	var trapOID string
+	if packet.PDUType == gosnmp.Trap {
+		trapOID = v1TrapOID(packet.SnmpTrap)
+		attrs.PutInt(attributeUptime, int64(packet.Timestamp))
+		attrs.PutStr(attributeAgentAddress, packet.AgentAddress)
+	}
+
+	for _, variable := range packet.Variables {
+		name := strings.TrimPrefix(variable.Name, ".")
+		switch {
+		case name == sysUpTimeOID:
+			r.putValue(attrs.PutEmpty(attributeUptime), variable)
+		case name == snmpTrapOID:
+			if oid, ok := variable.Value.(string); ok {
+				trapOID = strings.TrimPrefix(oid, ".")
+			}
+		default:
+			r.putValue(attrs.PutEmpty(r.translate(name)), variable)
+		}
+	}
+
+	attrs.PutStr(attributeTrapOID, trapOID)
+	if trapName := r.translate(trapOID); trapName != trapOID {
+		attrs.PutStr(attributeTrapName, trapName)
+		lr.Body().SetStr(trapName)
+	} else {
+		lr.Body().SetStr(trapOID)
+	}
+}
+
+// putValue sets the value of the variable binding to the attribute value.
+func (r *snmpTrapReceiver) putValue(value pcommon.Value, variable gosnmp.SnmpPDU) {
+	switch variable.Type {
+	case gosnmp.Integer:
+		if v, ok := variable.Value.(int); ok {
+			value.SetInt(int64(v))
+		}
+	case gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		if v := gosnmp.ToBigInt(variable.Value); v.IsInt64() {
+			value.SetInt(v.Int64())
+		} else {
+			value.SetStr(v.String())
+		}
+	case gosnmp.OctetString, gosnmp.BitString, gosnmp.Opaque, gosnmp.NsapAddress:
+		if v, ok := variable.Value.([]byte); ok {
+			if isPrintable(v) {
+				value.SetStr(string(v))
+			} else {
+				value.SetStr(hex.EncodeToString(v))
+			}
+		}
+	case gosnmp.ObjectIdentifier:
+		if v, ok := variable.Value.(string); ok {
+			value.SetStr(r.translate(strings.TrimPrefix(v, ".")))
+		}
+	case gosnmp.IPAddress:
+		if v, ok := variable.Value.(string); ok {
+			value.SetStr(v)
+		}
+	case gosnmp.OpaqueFloat:
+		if v, ok := variable.Value.(float32); ok {
+			value.SetDouble(float64(v))
+		}
+	case gosnmp.OpaqueDouble:
+		if v, ok := variable.Value.(float64); ok {
+			value.SetDouble(v)
+		}
+	}
+	// Null, NoSuchObject, NoSuchInstance and EndOfMibView are left empty.
+}
+
+// translate returns the name of the OID from the MIBs, or the OID if it is unknown.
+func (r *snmpTrapReceiver) translate(oid string) string {
+	if r.mibs == nil {
+		return oid
+	}
+	if name, ok := r.mibs.Translate(oid); ok {
+		return name
+	}
+	return oid
+}
+
+// v1TrapOID returns the OID of the v1 trap, as translated to SNMPv2 in RFC 3584.
+func v1TrapOID(trap gosnmp.SnmpTrap) string {
+	if trap.GenericTrap == enterpriseTrap {
+		return strings.TrimPrefix(trap.Enterprise, ".") + ".0." + strconv.Itoa(trap.SpecificTrap)
+	}
+	return snmpTrapsOID + "." + strconv.Itoa(trap.GenericTrap+1)
+}
+
+func version(v gosnmp.SnmpVersion) string {
+	switch v {
+	case gosnmp.Version1:
+		return "v1"
+	case gosnmp.Version3:
+		return "v3"
+	default:
+		return "v2c"
+	}
+}
+
+// isPrintable returns whether the octet string is printable text, rather than
+// binary data such as MAC addresses.
+func isPrintable(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, c := range string(b) {
+		if !unicode.IsPrint(c) && !unicode.IsSpace(c) {
+			return false
+		}
+	}
+	return true
+}
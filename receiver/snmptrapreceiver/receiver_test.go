@@ -0,0 +1,400 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/metadata"
+)
+
+const (
+	upsBatteryLowOID    = ".1.3.6.1.4.1.99999.0.1"
+	upsBatteryStatusOID = ".1.3.6.1.4.1.99999.1.1.1.2.4"
+	upsBatteryNameOID   = ".1.3.6.1.4.1.99999.1.1.1.3.4"
+)
+
+var upsBatteryLowVariables = []gosnmp.SnmpPDU{
+	{Name: sysUpTimeOID, Type: gosnmp.TimeTicks, Value: uint32(4200)},
+	{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: upsBatteryLowOID},
+	{Name: upsBatteryStatusOID, Type: gosnmp.Integer, Value: 2},
+	{Name: upsBatteryNameOID, Type: gosnmp.OctetString, Value: []byte("battery 4")},
+}
+
+func TestReceiveTraps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   func(*Config)
+		sender   func(*gosnmp.GoSNMP)
+		trap     gosnmp.SnmpTrap
+		expected map[string]any
+	}{
+		{
+			name:   "v2c_trap",
+			config: func(cfg *Config) { cfg.Community = "public" },
+			sender: func(g *gosnmp.GoSNMP) { g.Version, g.Community = gosnmp.Version2c, "public" },
+			trap:   gosnmp.SnmpTrap{Variables: upsBatteryLowVariables},
+			expected: map[string]any{
+				"snmp.version":       "v2c",
+				"snmp.pdu_type":      "trap",
+				"snmp.trap.oid":      "1.3.6.1.4.1.99999.0.1",
+				"snmp.uptime":        int64(4200),
+				"upsBatteryStatus.4": int64(2),
+				"upsBatteryName.4":   "battery 4",
+			},
+		},
+		{
+			name:   "v2c_inform",
+			sender: func(g *gosnmp.GoSNMP) { g.Version, g.Community = gosnmp.Version2c, "public" },
+			trap:   gosnmp.SnmpTrap{Variables: upsBatteryLowVariables, IsInform: true},
+			expected: map[string]any{
+				"snmp.version":       "v2c",
+				"snmp.pdu_type":      "inform",
+				"snmp.trap.oid":      "1.3.6.1.4.1.99999.0.1",
+				"snmp.uptime":        int64(4200),
+				"upsBatteryStatus.4": int64(2),
+				"upsBatteryName.4":   "battery 4",
+			},
+		},
+		{
+			name:   "v1_trap",
+			sender: func(g *gosnmp.GoSNMP) { g.Version, g.Community = gosnmp.Version1, "public" },
+			trap: gosnmp.SnmpTrap{
+				Enterprise:   ".1.3.6.1.4.1.99999",
+				AgentAddress: "192.0.2.1",
+				GenericTrap:  6,
+				SpecificTrap: 2,
+				Timestamp:    300,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: upsBatteryStatusOID, Type: gosnmp.Integer, Value: 3},
+					{Name: ".1.3.6.1.4.1.12345.1", Type: gosnmp.OctetString, Value: []byte{0x00, 0x1b, 0x21, 0xff}},
+				},
+			},
+			expected: map[string]any{
+				"snmp.version":         "v1",
+				"snmp.pdu_type":        "trap",
+				"snmp.trap.oid":        "1.3.6.1.4.1.99999.0.2",
+				"snmp.trap.name":       "upsOnBattery",
+				"snmp.uptime":          int64(300),
+				"snmp.agent.address":   "192.0.2.1",
+				"upsBatteryStatus.4":   int64(3),
+				"enterprises.12345.1":  "001b21ff",
+				"network.peer.address": "127.0.0.1",
+			},
+		},
+		{
+			name: "v3_trap",
+			config: func(cfg *Config) {
+				cfg.User = "otel"
+				cfg.SecurityLevel = "auth_priv"
+				cfg.AuthType = "SHA256"
+				cfg.AuthPassword = "authpassword"
+				cfg.PrivacyType = "AES"
+				cfg.PrivacyPassword = "privacypassword"
+			},
+			sender: func(g *gosnmp.GoSNMP) {
+				g.Version = gosnmp.Version3
+				g.SecurityModel = gosnmp.UserSecurityModel
+				g.MsgFlags = gosnmp.AuthPriv
+				g.SecurityParameters = &gosnmp.UsmSecurityParameters{
+					UserName:                 "otel",
+					AuthoritativeEngineID:    "\x80\x00\x00\x00\x05sender",
+					AuthenticationProtocol:   gosnmp.SHA256,
+					AuthenticationPassphrase: "authpassword",
+					PrivacyProtocol:          gosnmp.AES,
+					PrivacyPassphrase:        "privacypassword",
+				}
+			},
+			trap: gosnmp.SnmpTrap{Variables: upsBatteryLowVariables},
+			expected: map[string]any{
+				"snmp.version":       "v3",
+				"snmp.pdu_type":      "trap",
+				"snmp.trap.oid":      "1.3.6.1.4.1.99999.0.1",
+				"snmp.uptime":        int64(4200),
+				"upsBatteryStatus.4": int64(2),
+				"upsBatteryName.4":   "battery 4",
+			},
+		},
+		{
+			name: "v3_inform",
+			config: func(cfg *Config) {
+				cfg.User = "otel"
+				cfg.SecurityLevel = "auth_priv"
+				cfg.AuthType = "SHA"
+				cfg.AuthPassword = "authpassword"
+				cfg.PrivacyType = "AES"
+				cfg.PrivacyPassword = "privacypassword"
+			},
+			sender: func(g *gosnmp.GoSNMP) {
+				// the engine ID of the receiver is discovered
+				g.Version = gosnmp.Version3
+				g.SecurityModel = gosnmp.UserSecurityModel
+				g.MsgFlags = gosnmp.AuthPriv
+				g.SecurityParameters = &gosnmp.UsmSecurityParameters{
+					UserName:                 "otel",
+					AuthenticationProtocol:   gosnmp.SHA,
+					AuthenticationPassphrase: "authpassword",
+					PrivacyProtocol:          gosnmp.AES,
+					PrivacyPassphrase:        "privacypassword",
+				}
+			},
+			trap: gosnmp.SnmpTrap{Variables: upsBatteryLowVariables, IsInform: true},
+			expected: map[string]any{
+				"snmp.version":       "v3",
+				"snmp.pdu_type":      "inform",
+				"snmp.trap.oid":      "1.3.6.1.4.1.99999.0.1",
+				"snmp.uptime":        int64(4200),
+				"upsBatteryStatus.4": int64(2),
+				"upsBatteryName.4":   "battery 4",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sink := &consumertest.LogsSink{}
+			cfg := newTestConfig(t)
+			cfg.MIBDirectories = []string{filepath.Join("testdata", "mibs")}
+			if tt.config != nil {
+				tt.config(cfg)
+			}
+			startReceiver(t, cfg, sink)
+
+			g := newSender(t, cfg.Endpoint, tt.sender)
+			_, err := g.SendTrap(tt.trap)
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+			lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			attrs := lr.Attributes().AsRaw()
+			for k, v := range tt.expected {
+				assert.Equal(t, v, attrs[k], k)
+			}
+			assert.Equal(t, "127.0.0.1", attrs["network.peer.address"])
+			assert.NotZero(t, lr.Timestamp())
+			if name, ok := attrs["snmp.trap.name"]; ok {
+				assert.Equal(t, name, lr.Body().Str())
+			} else {
+				assert.Equal(t, "upsBatteryLow", lr.Body().Str())
+			}
+		})
+	}
+}
+
+func TestDropTraps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config func(*Config)
+		sender func(*gosnmp.GoSNMP)
+	}{
+		{
+			name:   "community_mismatch",
+			config: func(cfg *Config) { cfg.Community = "private" },
+			sender: func(g *gosnmp.GoSNMP) { g.Version, g.Community = gosnmp.Version2c, "public" },
+		},
+		{
+			name: "v3_without_user",
+			sender: func(g *gosnmp.GoSNMP) {
+				g.Version = gosnmp.Version3
+				g.SecurityModel = gosnmp.UserSecurityModel
+				g.MsgFlags = gosnmp.NoAuthNoPriv
+				g.SecurityParameters = &gosnmp.UsmSecurityParameters{
+					UserName:              "otel",
+					AuthoritativeEngineID: "\x80\x00\x00\x00\x05sender",
+				}
+			},
+		},
+		{
+			name: "v3_security_level_too_low",
+			config: func(cfg *Config) {
+				cfg.User = "otel"
+				cfg.SecurityLevel = "auth_no_priv"
+				cfg.AuthType = "SHA"
+				cfg.AuthPassword = "authpassword"
+			},
+			sender: func(g *gosnmp.GoSNMP) {
+				g.Version = gosnmp.Version3
+				g.SecurityModel = gosnmp.UserSecurityModel
+				g.MsgFlags = gosnmp.NoAuthNoPriv
+				g.SecurityParameters = &gosnmp.UsmSecurityParameters{
+					UserName:              "otel",
+					AuthoritativeEngineID: "\x80\x00\x00\x00\x05sender",
+				}
+			},
+		},
+		{
+			name: "v3_wrong_password",
+			config: func(cfg *Config) {
+				cfg.User = "otel"
+				cfg.SecurityLevel = "auth_no_priv"
+				cfg.AuthType = "SHA"
+				cfg.AuthPassword = "authpassword"
+			},
+			sender: func(g *gosnmp.GoSNMP) {
+				g.Version = gosnmp.Version3
+				g.SecurityModel = gosnmp.UserSecurityModel
+				g.MsgFlags = gosnmp.AuthNoPriv
+				g.SecurityParameters = &gosnmp.UsmSecurityParameters{
+					UserName:                 "otel",
+					AuthoritativeEngineID:    "\x80\x00\x00\x00\x05sender",
+					AuthenticationProtocol:   gosnmp.SHA,
+					AuthenticationPassphrase: "wrongpassword",
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sink := &consumertest.LogsSink{}
+			cfg := newTestConfig(t)
+			if tt.config != nil {
+				tt.config(cfg)
+			}
+			startReceiver(t, cfg, sink)
+
+			g := newSender(t, cfg.Endpoint, tt.sender)
+			_, err := g.SendTrap(gosnmp.SnmpTrap{Variables: upsBatteryLowVariables})
+			require.NoError(t, err)
+
+			// A trap sent afterwards with the right credentials is received alone
+			valid := newSender(t, cfg.Endpoint, func(g *gosnmp.GoSNMP) {
+				g.Version, g.Community = gosnmp.Version2c, cfg.Community
+			})
+			_, err = valid.SendTrap(gosnmp.SnmpTrap{Variables: upsBatteryLowVariables})
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool { return sink.LogRecordCount() > 0 }, 5*time.Second, 10*time.Millisecond)
+			time.Sleep(100 * time.Millisecond)
+			logs := sink.AllLogs()
+			require.Len(t, logs, 1)
+			assert.Equal(t, "v2c", attribute(t, logs[0], "snmp.version"))
+		})
+	}
+}
+
+func TestInformsNotAcknowledged(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   func(*Config)
+		consumer consumer.Logs
+	}{
+		{
+			name:     "community_mismatch",
+			config:   func(cfg *Config) { cfg.Community = "private" },
+			consumer: consumertest.NewNop(),
+		},
+		{
+			name:     "consume_error",
+			consumer: consumertest.NewErr(errors.New("consume failed")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newTestConfig(t)
+			if tt.config != nil {
+				tt.config(cfg)
+			}
+			r, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, tt.consumer)
+			require.NoError(t, err)
+			require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+			g := newSender(t, cfg.Endpoint, func(g *gosnmp.GoSNMP) {
+				g.Version, g.Community = gosnmp.Version2c, "public"
+				g.Timeout, g.Retries = 200*time.Millisecond, 0
+			})
+			_, err = g.SendTrap(gosnmp.SnmpTrap{Variables: upsBatteryLowVariables, IsInform: true})
+			assert.ErrorContains(t, err, "timeout")
+		})
+	}
+}
+
+func TestStartFailsOnUsedEndpoint(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "localhost:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = conn.LocalAddr().String()
+	r, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), "failed to listen on")
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+// newTestConfig returns the default config with an available endpoint.
+func newTestConfig(t *testing.T) *Config {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := conn.LocalAddr().String()
+	require.NoError(t, conn.Close())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	return cfg
+}
+
+func startReceiver(t *testing.T, cfg *Config, sink *consumertest.LogsSink) {
+	r, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+}
+
+func newSender(t *testing.T, endpoint string, configure func(*gosnmp.GoSNMP)) *gosnmp.GoSNMP {
+	host, portStr, err := net.SplitHostPort(endpoint)
+	require.NoError(t, err)
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	require.NoError(t, err)
+
+	g := &gosnmp.GoSNMP{
+		Target:    host,
+		Port:      uint16(port),
+		Transport: "udp",
+		Timeout:   2 * time.Second,
+		Retries:   1,
+		MaxOids:   gosnmp.MaxOids,
+	}
+	configure(g)
+	require.NoError(t, g.Connect())
+	t.Cleanup(func() { require.NoError(t, g.Conn.Close()) })
+	return g
+}
+
+func attribute(t *testing.T, logs plog.Logs, key string) any {
+	v, ok := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get(key)
+	require.True(t, ok, key)
+	return v.AsRaw()
+}
@@ -136,11 +136,106 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			fname: "config-logs-columns.yaml",
+			id:    component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				Config: sqlquery.Config{
+					ControllerConfig: scraperhelper.ControllerConfig{
+						CollectionInterval: 10 * time.Second,
+						InitialDelay:       time.Second,
+					},
+					Driver:     "mydriver",
+					DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+					Queries: []sqlquery.Query{
+						{
+							SQL:                "select * from audit_logs where id > ?",
+							TrackingColumn:     "id",
+							TrackingStartValue: "0",
+							Logs: []sqlquery.LogsCfg{
+								{
+									BodyColumn:       "details",
+									BodyFormat:       sqlquery.LogsBodyFormatJSON,
+									AttributeColumns: []string{"actor"},
+									TsColumn:         "created_at",
+									ObservedTsColumn: "inserted_at",
+									SeverityColumn:   "level",
+									SeverityMapping: map[string][]string{
+										"error": {"E", "ERR"},
+										"warn":  {"W"},
+									},
+									TraceIDColumn: "trace_id",
+									SpanIDColumn:  "span_id",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			fname:        "config-logs-invalid-severity.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'severity_mapping' has unsupported severity: 'critical'",
+		},
 		{
 			fname:        "config-logs-missing-body-column.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
 			errorMessage: "'body_column' must not be empty",
 		},
+		{
+			fname: "config-scheduling.yaml",
+			id:    component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				Config: sqlquery.Config{
+					ControllerConfig: scraperhelper.ControllerConfig{
+						CollectionInterval: 10 * time.Second,
+						InitialDelay:       time.Second,
+					},
+					Driver:     "mydriver",
+					DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+					Telemetry: sqlquery.TelemetryConfig{
+						Traces: sqlquery.TelemetryTracesConfig{Query: true},
+					},
+					Queries: []sqlquery.Query{
+						{
+							SQL:                "select count(*) as count from orders where created_at >= @start and created_at < @end",
+							CollectionInterval: time.Minute,
+							Timeout:            5 * time.Second,
+							Params: []sqlquery.ParamCfg{
+								{Name: "start", Source: sqlquery.ParamSourceWindowStart},
+								{Name: "end", Source: sqlquery.ParamSourceWindowEnd},
+							},
+							Metrics: []sqlquery.MetricCfg{
+								{
+									MetricName:  "orders.count",
+									ValueColumn: "count",
+								},
+							},
+						},
+						{
+							SQL:                "select * from test_logs where log_id > ? and tenant = ?",
+							TrackingColumn:     "log_id",
+							TrackingStartValue: "10",
+							Params: []sqlquery.ParamCfg{
+								{Source: sqlquery.ParamSourceTrackingValue},
+								{Value: "acme"},
+							},
+							Logs: []sqlquery.LogsCfg{
+								{
+									BodyColumn: "log_body",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			fname:        "config-invalid-param-source.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "param has unsupported source: 'last_id'",
+		},
 		{
 			fname:        "config-unnecessary-aggregation.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
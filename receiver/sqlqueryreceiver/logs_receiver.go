@@ -6,8 +6,13 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"context"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -17,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
@@ -32,9 +38,9 @@ type logsReceiver struct {
 	queryReceivers   []*logsQueryReceiver
 	nextConsumer     consumer.Logs
 
-	isStarted                bool
-	collectionIntervalTicker *time.Ticker
-	shutdownRequested        chan struct{}
+	isStarted         bool
+	collecting        sync.WaitGroup
+	shutdownRequested chan struct{}
 
 	id            component.ID
 	storageClient storage.Client
@@ -104,62 +110,62 @@ func (receiver *logsReceiver) Start(ctx context.Context, host component.Host) er
 
 func (receiver *logsReceiver) createQueryReceivers() error {
 	receiver.queryReceivers = nil
+	tracer := newQueryTracer(receiver.settings, receiver.config.Telemetry)
 	for i, query := range receiver.config.Queries {
 		if len(query.Logs) == 0 {
 			continue
 		}
 		id := fmt.Sprintf("query-%d: %s", i, query.SQL)
+		interval := query.CollectionInterval
+		if interval <= 0 {
+			interval = receiver.config.CollectionInterval
+		}
 		queryReceiver := newLogsQueryReceiver(
 			id,
 			query,
+			interval,
 			receiver.createConnection,
 			receiver.createClient,
 			receiver.settings.Logger,
 			receiver.config.Telemetry,
 			receiver.storageClient,
+			tracer,
 		)
 		receiver.queryReceivers = append(receiver.queryReceivers, queryReceiver)
 	}
 	return nil
 }
 
+// startCollecting collects the logs of each query on its own collection interval
 func (receiver *logsReceiver) startCollecting() {
-	receiver.collectionIntervalTicker = time.NewTicker(receiver.config.CollectionInterval)
-
-	go func() {
-		for {
-			select {
-			case <-receiver.collectionIntervalTicker.C:
-				receiver.collect()
-			case <-receiver.shutdownRequested:
-				return
-			}
-		}
-	}()
-}
-
-func (receiver *logsReceiver) collect() {
-	logsChannel := make(chan plog.Logs)
 	for _, queryReceiver := range receiver.queryReceivers {
-		go func(queryReceiver *logsQueryReceiver) {
-			logs, err := queryReceiver.collect(context.Background())
-			if err != nil {
-				receiver.settings.Logger.Error("error collecting logs", zap.Error(err), zap.String("query", queryReceiver.ID()))
+		receiver.collecting.Add(1)
+		go func() {
+			defer receiver.collecting.Done()
+			ticker := time.NewTicker(queryReceiver.interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					receiver.collect(queryReceiver)
+				case <-receiver.shutdownRequested:
+					return
+				}
 			}
-			logsChannel <- logs
-		}(queryReceiver)
+		}()
 	}
+}
 
-	allLogs := plog.NewLogs()
-	for range receiver.queryReceivers {
-		logs := <-logsChannel
-		logs.ResourceLogs().MoveAndAppendTo(allLogs.ResourceLogs())
+func (receiver *logsReceiver) collect(queryReceiver *logsQueryReceiver) {
+	logs, err := queryReceiver.collect(context.Background())
+	if err != nil {
+		receiver.settings.Logger.Error("error collecting logs", zap.Error(err), zap.String("query", queryReceiver.ID()))
 	}
 
-	logRecordCount := allLogs.LogRecordCount()
+	logRecordCount := logs.LogRecordCount()
 	if logRecordCount > 0 {
 		ctx := receiver.obsrecv.StartLogsOp(context.Background())
-		err := receiver.nextConsumer.ConsumeLogs(context.Background(), allLogs)
+		err := receiver.nextConsumer.ConsumeLogs(context.Background(), logs)
 		receiver.obsrecv.EndLogsOp(ctx, metadata.Type.String(), logRecordCount, err)
 		if err != nil {
 			receiver.settings.Logger.Error("failed to send logs: %w", zap.Error(err))
@@ -191,10 +197,8 @@ func (receiver *logsReceiver) Shutdown(ctx context.Context) error {
 }
 
 func (receiver *logsReceiver) stopCollecting() {
-	if receiver.collectionIntervalTicker != nil {
-		receiver.collectionIntervalTicker.Stop()
-	}
 	close(receiver.shutdownRequested)
+	receiver.collecting.Wait()
 }
 
 type logsQueryReceiver struct {
@@ -204,10 +208,13 @@ type logsQueryReceiver struct {
 	createClient sqlquery.ClientProviderFunc
 	logger       *zap.Logger
 	telemetry    sqlquery.TelemetryConfig
+	tracer       trace.Tracer
+	interval     time.Duration
 
 	db            *sql.DB
 	client        sqlquery.DbClient
 	trackingValue string
+	window        sqlquery.Window
 	// TODO: Extract persistence into its own component
 	storageClient           storage.Client
 	trackingValueStorageKey string
@@ -216,20 +223,24 @@ type logsQueryReceiver struct {
 func newLogsQueryReceiver(
 	id string,
 	query sqlquery.Query,
+	interval time.Duration,
 	dbProviderFunc sqlquery.DbProviderFunc,
 	clientProviderFunc sqlquery.ClientProviderFunc,
 	logger *zap.Logger,
 	telemetry sqlquery.TelemetryConfig,
 	storageClient storage.Client,
+	tracer trace.Tracer,
 ) *logsQueryReceiver {
 	queryReceiver := &logsQueryReceiver{
 		id:            id,
 		query:         query,
+		interval:      interval,
 		createDb:      dbProviderFunc,
 		createClient:  clientProviderFunc,
 		logger:        logger,
 		telemetry:     telemetry,
 		storageClient: storageClient,
+		tracer:        tracer,
 	}
 	queryReceiver.trackingValue = queryReceiver.query.TrackingStartValue
 	queryReceiver.trackingValueStorageKey = fmt.Sprintf("%s.%s", queryReceiver.id, "trackingValue")
@@ -272,17 +283,23 @@ func (queryReceiver *logsQueryReceiver) retrieveTrackingValue(ctx context.Contex
 func (queryReceiver *logsQueryReceiver) collect(ctx context.Context) (plog.Logs, error) {
 	logs := plog.NewLogs()
 
-	var rows []sqlquery.StringMap
-	var err error
-	observedAt := pcommon.NewTimestampFromTime(time.Now())
-	if queryReceiver.query.TrackingColumn != "" {
-		rows, err = queryReceiver.client.QueryRows(ctx, queryReceiver.trackingValue)
-	} else {
-		rows, err = queryReceiver.client.QueryRows(ctx)
+	now := time.Now()
+	observedAt := pcommon.NewTimestampFromTime(now)
+	windowStart, windowEnd := queryReceiver.window.Next(now, queryReceiver.interval)
+	args := queryReceiver.query.Args(sqlquery.ParamValues{
+		TrackingValue: queryReceiver.trackingValue,
+		WindowStart:   windowStart,
+		WindowEnd:     windowEnd,
+	})
+	// Without params, the tracking value is the only parameter of the query
+	if len(queryReceiver.query.Params) == 0 && queryReceiver.query.TrackingColumn != "" {
+		args = []any{queryReceiver.trackingValue}
 	}
+	rows, err := sqlquery.QueryRows(ctx, queryReceiver.client, queryReceiver.query, queryReceiver.tracer, args...)
 	if err != nil {
 		return logs, fmt.Errorf("error getting rows: %w", err)
 	}
+	queryReceiver.window.Advance(windowEnd)
 
 	var errs []error
 	scope := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
@@ -291,8 +308,8 @@ func (queryReceiver *logsQueryReceiver) collect(ctx context.Context) (plog.Logs,
 	for logsConfigIndex, logsConfig := range queryReceiver.query.Logs {
 		for _, row := range rows {
 			logRecord := scopeLogs.AppendEmpty()
-			errs = append(errs, rowToLog(row, logsConfig, logRecord))
 			logRecord.SetObservedTimestamp(observedAt)
+			errs = append(errs, rowToLog(row, logsConfig, logRecord))
 			if logsConfigIndex == 0 {
 				errs = append(errs, queryReceiver.storeTrackingValue(ctx, row))
 			}
@@ -320,6 +337,10 @@ func rowToLog(row sqlquery.StringMap, config sqlquery.LogsCfg, logRecord plog.Lo
 	value, found := row[config.BodyColumn]
 	if !found {
 		errs = append(errs, fmt.Errorf("rowToLog: body_column '%s' not found in result set", config.BodyColumn))
+	} else if config.BodyFormat == sqlquery.LogsBodyFormatJSON {
+		if err := setJSONBody(logRecord, value); err != nil {
+			errs = append(errs, fmt.Errorf("rowToLog: body_column '%s': %w", config.BodyColumn, err))
+		}
 	} else {
 		logRecord.Body().SetStr(value)
 	}
@@ -332,9 +353,113 @@ func rowToLog(row sqlquery.StringMap, config sqlquery.LogsCfg, logRecord plog.Lo
 			errs = append(errs, fmt.Errorf("rowToLog: attribute_column '%s' not found in result set", columnName))
 		}
 	}
+
+	if value, ok := columnValue(row, "ts_column", config.TsColumn, &errs); ok {
+		if ts, err := parseTimestamp(value); err != nil {
+			errs = append(errs, fmt.Errorf("rowToLog: ts_column '%s': %w", config.TsColumn, err))
+		} else {
+			logRecord.SetTimestamp(ts)
+		}
+	}
+	if value, ok := columnValue(row, "observed_ts_column", config.ObservedTsColumn, &errs); ok {
+		if ts, err := parseTimestamp(value); err != nil {
+			errs = append(errs, fmt.Errorf("rowToLog: observed_ts_column '%s': %w", config.ObservedTsColumn, err))
+		} else {
+			logRecord.SetObservedTimestamp(ts)
+		}
+	}
+	if value, ok := columnValue(row, "severity_column", config.SeverityColumn, &errs); ok {
+		logRecord.SetSeverityText(value)
+		logRecord.SetSeverityNumber(config.ParseSeverity(value))
+	}
+	if value, ok := columnValue(row, "trace_id_column", config.TraceIDColumn, &errs); ok {
+		var traceID pcommon.TraceID
+		if err := decodeID(value, traceID[:]); err != nil {
+			errs = append(errs, fmt.Errorf("rowToLog: trace_id_column '%s': %w", config.TraceIDColumn, err))
+		} else {
+			logRecord.SetTraceID(traceID)
+		}
+	}
+	if value, ok := columnValue(row, "span_id_column", config.SpanIDColumn, &errs); ok {
+		var spanID pcommon.SpanID
+		if err := decodeID(value, spanID[:]); err != nil {
+			errs = append(errs, fmt.Errorf("rowToLog: span_id_column '%s': %w", config.SpanIDColumn, err))
+		} else {
+			logRecord.SetSpanID(spanID)
+		}
+	}
 	return errors.Join(errs...)
 }
 
+// columnValue returns the value of an optional column of the logs config, and appends an
+// error if the column is configured but not found in the result set
+func columnValue(row sqlquery.StringMap, setting, columnName string, errs *[]error) (string, bool) {
+	if columnName == "" {
+		return "", false
+	}
+	value, found := row[columnName]
+	if !found {
+		*errs = append(*errs, fmt.Errorf("rowToLog: %s '%s' not found in result set", setting, columnName))
+	}
+	return value, found
+}
+
+// parseTimestamp parses a timestamp column, either a time formatted as RFC 3339, as the
+// time columns are, or a number of nanoseconds since the Unix epoch
+func parseTimestamp(value string) (pcommon.Timestamp, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return pcommon.NewTimestampFromTime(t), nil
+	}
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse timestamp %q", value)
+	}
+	return pcommon.Timestamp(nanos), nil
+}
+
+// decodeID decodes a hex encoded trace or span ID
+func decodeID(value string, id []byte) error {
+	decoded, err := hex.DecodeString(value)
+	if err != nil || len(decoded) != len(id) {
+		return fmt.Errorf("invalid ID %q", value)
+	}
+	copy(id, decoded)
+	return nil
+}
+
+// setJSONBody expands a JSON document into a structured body
+func setJSONBody(logRecord plog.LogRecord, value string) error {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var body any
+	if err := decoder.Decode(&body); err != nil {
+		logRecord.Body().SetStr(value)
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return logRecord.Body().FromRaw(convertJSONNumbers(body))
+}
+
+// convertJSONNumbers converts the JSON numbers into integers when possible, and into floats otherwise
+func convertJSONNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, item := range v {
+			v[key] = convertJSONNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = convertJSONNumbers(item)
+		}
+	}
+	return value
+}
+
 func (queryReceiver *logsQueryReceiver) shutdown(_ context.Context) error {
 	if queryReceiver.db == nil {
 		return nil
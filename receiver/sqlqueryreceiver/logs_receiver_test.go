@@ -5,13 +5,22 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
 )
 
 func TestLogsQueryReceiver_Collect(t *testing.T) {
@@ -74,3 +83,248 @@ func TestLogsQueryReceiver_MissingColumnInResultSet(t *testing.T) {
 	assert.ErrorContains(t, err, "rowToLog: attribute_column 'expected_column_2' not found in result set")
 	assert.ErrorContains(t, err, "rowToLog: body_column 'expected_body_column' not found in result set")
 }
+
+func TestLogsQueryReceiver_TrackingValue(t *testing.T) {
+	fakeClient := &sqlquery.FakeDBClient{
+		StringMaps: [][]sqlquery.StringMap{
+			{{"id": "11", "body": "a"}, {"id": "12", "body": "b"}},
+			{{"id": "13", "body": "c"}},
+		},
+	}
+	queryReceiver := logsQueryReceiver{
+		client: fakeClient,
+		query: sqlquery.Query{
+			TrackingColumn: "id",
+			Logs:           []sqlquery.LogsCfg{{BodyColumn: "body"}},
+		},
+		trackingValue: "10",
+	}
+	for range fakeClient.StringMaps {
+		_, err := queryReceiver.collect(context.Background())
+		require.NoError(t, err)
+	}
+	// Without params, the tracking value is the only argument of the query
+	assert.Equal(t, [][]any{{"10"}, {"12"}}, fakeClient.Args)
+}
+
+func TestLogsQueryReceiver_Params(t *testing.T) {
+	fakeClient := &sqlquery.FakeDBClient{
+		StringMaps: [][]sqlquery.StringMap{
+			{{"id": "11", "body": "a"}},
+			{{"id": "12", "body": "b"}},
+		},
+	}
+	queryReceiver := logsQueryReceiver{
+		client:   fakeClient,
+		interval: time.Minute,
+		query: sqlquery.Query{
+			TrackingColumn: "id",
+			Params: []sqlquery.ParamCfg{
+				{Value: "acme"},
+				{Name: "id", Source: sqlquery.ParamSourceTrackingValue},
+				{Source: sqlquery.ParamSourceWindowStart},
+				{Source: sqlquery.ParamSourceWindowEnd},
+			},
+			Logs: []sqlquery.LogsCfg{{BodyColumn: "body"}},
+		},
+		trackingValue: "10",
+	}
+	for range fakeClient.StringMaps {
+		_, err := queryReceiver.collect(context.Background())
+		require.NoError(t, err)
+	}
+
+	require.Len(t, fakeClient.Args, 2)
+	first, second := fakeClient.Args[0], fakeClient.Args[1]
+	assert.Equal(t, "acme", first[0])
+	assert.Equal(t, sql.Named("id", "10"), first[1])
+	assert.Equal(t, time.Minute, first[3].(time.Time).Sub(first[2].(time.Time)))
+	assert.Equal(t, sql.Named("id", "11"), second[1])
+	assert.Equal(t, first[3], second[2])
+}
+
+func TestLogsReceiver_CollectionIntervalPerQuery(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	createClient := func(_ sqlquery.Db, query string, _ *zap.Logger, _ sqlquery.TelemetryConfig) sqlquery.DbClient {
+		return repeatingDBClient{{"body": query}}
+	}
+	receiver, err := newLogsReceiver(
+		&Config{
+			Config: sqlquery.Config{
+				ControllerConfig: scraperhelper.ControllerConfig{
+					CollectionInterval: time.Hour,
+				},
+				Driver:     "mydriver",
+				DataSource: "my-datasource",
+				Queries: []sqlquery.Query{
+					{
+						SQL:  "select * from slow",
+						Logs: []sqlquery.LogsCfg{{BodyColumn: "body"}},
+					},
+					{
+						SQL:                "select * from fast",
+						CollectionInterval: 10 * time.Millisecond,
+						Logs:               []sqlquery.LogsCfg{{BodyColumn: "body"}},
+					},
+				},
+			},
+		},
+		receivertest.NewNopSettings(metadata.Type),
+		fakeDBConnect,
+		createClient,
+		sink,
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	for _, logs := range sink.AllLogs() {
+		assert.Equal(t, "select * from fast", logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	}
+}
+
+// repeatingDBClient returns the same rows for every request
+type repeatingDBClient []sqlquery.StringMap
+
+func (c repeatingDBClient) QueryRows(context.Context, ...any) ([]sqlquery.StringMap, error) {
+	return c, nil
+}
+
+func TestRowToLog(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	tests := []struct {
+		name     string
+		row      sqlquery.StringMap
+		config   sqlquery.LogsCfg
+		expected func(plog.LogRecord)
+		errMsg   string
+	}{
+		{
+			name: "all columns",
+			row: sqlquery.StringMap{
+				"body":     "user logged in",
+				"user":     "alice",
+				"ts":       ts.Format(time.RFC3339Nano),
+				"observed": "1715000000000000000",
+				"level":    "W",
+				"trace_id": "0102030405060708090a0b0c0d0e0f10",
+				"span_id":  "0102030405060708",
+			},
+			config: sqlquery.LogsCfg{
+				BodyColumn:       "body",
+				AttributeColumns: []string{"user"},
+				TsColumn:         "ts",
+				ObservedTsColumn: "observed",
+				SeverityColumn:   "level",
+				SeverityMapping:  map[string][]string{"warn": {"W"}},
+				TraceIDColumn:    "trace_id",
+				SpanIDColumn:     "span_id",
+			},
+			expected: func(lr plog.LogRecord) {
+				lr.Body().SetStr("user logged in")
+				lr.Attributes().PutStr("user", "alice")
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+				lr.SetObservedTimestamp(pcommon.Timestamp(1715000000000000000))
+				lr.SetSeverityText("W")
+				lr.SetSeverityNumber(plog.SeverityNumberWarn)
+				lr.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+				lr.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+			},
+		},
+		{
+			name: "json body",
+			row:  sqlquery.StringMap{"body": `{"action":"login","attempts":3,"ratio":0.5,"tags":["a",1],"ok":true,"extra":null}`},
+			config: sqlquery.LogsCfg{
+				BodyColumn: "body",
+				BodyFormat: sqlquery.LogsBodyFormatJSON,
+			},
+			expected: func(lr plog.LogRecord) {
+				require.NoError(t, lr.Body().FromRaw(map[string]any{
+					"action":   "login",
+					"attempts": int64(3),
+					"ratio":    0.5,
+					"tags":     []any{"a", int64(1)},
+					"ok":       true,
+					"extra":    nil,
+				}))
+			},
+		},
+		{
+			name: "invalid json body",
+			row:  sqlquery.StringMap{"body": `{"action":`},
+			config: sqlquery.LogsCfg{
+				BodyColumn: "body",
+				BodyFormat: sqlquery.LogsBodyFormatJSON,
+			},
+			expected: func(lr plog.LogRecord) {
+				lr.Body().SetStr(`{"action":`)
+			},
+			errMsg: "rowToLog: body_column 'body': failed to parse JSON: unexpected EOF",
+		},
+		{
+			name: "invalid columns",
+			row: sqlquery.StringMap{
+				"body":     "b",
+				"ts":       "yesterday",
+				"trace_id": "0102",
+				"span_id":  "not hex",
+			},
+			config: sqlquery.LogsCfg{
+				BodyColumn:     "body",
+				TsColumn:       "ts",
+				SeverityColumn: "level",
+				TraceIDColumn:  "trace_id",
+				SpanIDColumn:   "span_id",
+			},
+			expected: func(lr plog.LogRecord) {
+				lr.Body().SetStr("b")
+			},
+			errMsg: "rowToLog: ts_column 'ts': failed to parse timestamp \"yesterday\"\n" +
+				"rowToLog: severity_column 'level' not found in result set\n" +
+				"rowToLog: trace_id_column 'trace_id': invalid ID \"0102\"\n" +
+				"rowToLog: span_id_column 'span_id': invalid ID \"not hex\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logRecord := plog.NewLogRecord()
+			err := rowToLog(tt.row, tt.config, logRecord)
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.errMsg)
+			}
+			expected := plog.NewLogRecord()
+			tt.expected(expected)
+			assert.Equal(t, expected.Body().AsRaw(), logRecord.Body().AsRaw())
+			assert.Equal(t, expected.Attributes().AsRaw(), logRecord.Attributes().AsRaw())
+			assert.Equal(t, expected.Timestamp(), logRecord.Timestamp())
+			assert.Equal(t, expected.ObservedTimestamp(), logRecord.ObservedTimestamp())
+			assert.Equal(t, expected.SeverityText(), logRecord.SeverityText())
+			assert.Equal(t, expected.SeverityNumber(), logRecord.SeverityNumber())
+			assert.Equal(t, expected.TraceID(), logRecord.TraceID())
+			assert.Equal(t, expected.SpanID(), logRecord.SpanID())
+		})
+	}
+}
+
+func TestLogsQueryReceiver_ObservedTsColumn(t *testing.T) {
+	fakeClient := &sqlquery.FakeDBClient{
+		StringMaps: [][]sqlquery.StringMap{
+			{{"body": "a", "observed": "42"}},
+		},
+	}
+	queryReceiver := logsQueryReceiver{
+		client: fakeClient,
+		query: sqlquery.Query{
+			Logs: []sqlquery.LogsCfg{{BodyColumn: "body", ObservedTsColumn: "observed"}},
+		},
+	}
+	logs, err := queryReceiver.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, pcommon.Timestamp(42), logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).ObservedTimestamp())
+}
@@ -5,13 +5,16 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal"
@@ -38,28 +41,86 @@ func createMetricsReceiverFunc(sqlOpenerFunc sqlquery.SQLOpenerFunc, clientProvi
 		consumer consumer.Metrics,
 	) (receiver.Metrics, error) {
 		sqlCfg := cfg.(*Config)
-		var opts []scraperhelper.ControllerOption
 		pool := internal.NewPool(sqlOpenerFunc, sqlCfg.Driver, sqlCfg.DataSource, sqlCfg.MaxOpenConn)
+		tracer := newQueryTracer(settings, sqlCfg.Telemetry)
 
+		// The queries are scraped by a controller per collection interval
+		var intervals []time.Duration
+		optsByInterval := map[time.Duration][]scraperhelper.ControllerOption{}
 		for i, query := range sqlCfg.Queries {
 			if len(query.Metrics) == 0 {
 				continue
 			}
 			id := component.MustNewIDWithName("sqlqueryreceiver", fmt.Sprintf("query-%d: %s", i, query.SQL))
 
+			scrapeCfg := sqlCfg.ControllerConfig
+			if query.CollectionInterval > 0 {
+				scrapeCfg.CollectionInterval = query.CollectionInterval
+			}
+
 			scope := pcommon.NewInstrumentationScope()
 			scope.SetName(metadata.ScopeName)
-			mp := sqlquery.NewScraper(id, query, sqlCfg.ControllerConfig, settings.Logger, sqlCfg.Telemetry, pool.DB, clientProviderFunc, scope)
+			mp := sqlquery.NewScraper(id, query, scrapeCfg, settings.Logger, sqlCfg.Telemetry, pool.DB, clientProviderFunc, scope, tracer)
+
+			if _, ok := optsByInterval[scrapeCfg.CollectionInterval]; !ok {
+				intervals = append(intervals, scrapeCfg.CollectionInterval)
+			}
+			optsByInterval[scrapeCfg.CollectionInterval] = append(optsByInterval[scrapeCfg.CollectionInterval], scraperhelper.AddScraper(metadata.Type, mp))
+		}
 
-			opt := scraperhelper.AddScraper(metadata.Type, mp)
-			opts = append(opts, opt)
+		if len(intervals) <= 1 {
+			controllerCfg := sqlCfg.ControllerConfig
+			if len(intervals) == 1 {
+				// The only interval may be the one of the queries rather than the one of the receiver
+				controllerCfg.CollectionInterval = intervals[0]
+			}
+			return scraperhelper.NewMetricsController(
+				&controllerCfg,
+				settings,
+				consumer,
+				optsByInterval[controllerCfg.CollectionInterval]...,
+			)
 		}
 
-		return scraperhelper.NewMetricsController(
-			&sqlCfg.ControllerConfig,
-			settings,
-			consumer,
-			opts...,
-		)
+		var controllers metricsControllers
+		for _, interval := range intervals {
+			controllerCfg := sqlCfg.ControllerConfig
+			controllerCfg.CollectionInterval = interval
+			controller, err := scraperhelper.NewMetricsController(&controllerCfg, settings, consumer, optsByInterval[interval]...)
+			if err != nil {
+				return nil, err
+			}
+			controllers = append(controllers, controller)
+		}
+		return controllers, nil
+	}
+}
+
+// newQueryTracer returns the tracer of the spans of the executions of the queries, or nil
+// if they aren't traced
+func newQueryTracer(settings receiver.Settings, telemetry sqlquery.TelemetryConfig) trace.Tracer {
+	if !telemetry.Traces.Query {
+		return nil
+	}
+	return settings.TracerProvider.Tracer(metadata.ScopeName)
+}
+
+// metricsControllers are the scraper controllers of the queries with different collection intervals
+type metricsControllers []receiver.Metrics
+
+func (c metricsControllers) Start(ctx context.Context, host component.Host) error {
+	for _, controller := range c {
+		if err := controller.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c metricsControllers) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, controller := range c {
+		errs = append(errs, controller.Shutdown(ctx))
 	}
+	return errors.Join(errs...)
 }
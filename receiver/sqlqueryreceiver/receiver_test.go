@@ -80,6 +80,76 @@ func TestCreateMetrics(t *testing.T) {
 	require.NoError(t, receiver.Shutdown(ctx))
 }
 
+func TestCreateMetrics_CollectionIntervalPerQuery(t *testing.T) {
+	createReceiver := createMetricsReceiverFunc(fakeDBConnect, mkFakeClient)
+	ctx := context.Background()
+	metric := []sqlquery.MetricCfg{{
+		MetricName:  "my-metric",
+		ValueColumn: "my-column",
+	}}
+	receiver, err := createReceiver(
+		ctx,
+		receivertest.NewNopSettings(metadata.Type),
+		&Config{
+			Config: sqlquery.Config{
+				ControllerConfig: scraperhelper.ControllerConfig{
+					CollectionInterval: 10 * time.Second,
+					InitialDelay:       time.Second,
+				},
+				Driver:     "mydriver",
+				DataSource: "my-datasource",
+				Queries: []sqlquery.Query{
+					{SQL: "select * from foo", Metrics: metric},
+					{SQL: "select * from bar", Metrics: metric, CollectionInterval: time.Minute},
+					{SQL: "select * from baz", Metrics: metric, CollectionInterval: 10 * time.Second},
+				},
+			},
+		},
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	require.IsType(t, metricsControllers{}, receiver)
+	require.Len(t, receiver, 2)
+	err = receiver.Start(ctx, componenttest.NewNopHost())
+	require.NoError(t, err)
+	require.NoError(t, receiver.Shutdown(ctx))
+}
+
+func TestCreateMetrics_SingleQueryCollectionInterval(t *testing.T) {
+	createReceiver := createMetricsReceiverFunc(fakeDBConnect, mkFakeClient)
+	ctx := context.Background()
+	sink := &consumertest.MetricsSink{}
+	receiver, err := createReceiver(
+		ctx,
+		receivertest.NewNopSettings(metadata.Type),
+		&Config{
+			Config: sqlquery.Config{
+				ControllerConfig: scraperhelper.ControllerConfig{
+					CollectionInterval: 10 * time.Second,
+					InitialDelay:       time.Millisecond,
+				},
+				Driver:     "mydriver",
+				DataSource: "my-datasource",
+				Queries: []sqlquery.Query{{
+					SQL: "select * from foo",
+					Metrics: []sqlquery.MetricCfg{{
+						MetricName:  "my-metric",
+						ValueColumn: "foo",
+					}},
+					CollectionInterval: time.Minute,
+				}},
+			},
+		},
+		sink,
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(ctx, componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(ctx))
+}
+
 func fakeDBConnect(string, string) (*sql.DB, error) {
 	return nil, nil
 }
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
@@ -27,12 +28,18 @@ const (
 
 var defaultTimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "distribution", ObserverType: "gauge"}}
 
+// receivers are the StatsD receivers of the configurations, shared by the metrics and
+// logs pipelines as they listen on the same endpoint. A receiver is removed from the
+// map when it is shut down.
+var receivers = sharedcomponent.NewSharedComponents()
+
 // NewFactory creates a factory for the StatsD receiver.
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
 	)
 }
 
@@ -57,5 +64,35 @@ func createMetricsReceiver(
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
 	c := cfg.(*Config)
-	return newReceiver(params, *c, consumer)
+	r, err := getOrAddReceiver(params, c)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).registerMetricsConsumer(consumer)
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	c := cfg.(*Config)
+	r, err := getOrAddReceiver(params, c)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).registerLogsConsumer(consumer)
+	return r, nil
+}
+
+func getOrAddReceiver(params receiver.Settings, cfg *Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv component.Component
+		rcv, err = newReceiver(params, *cfg, nil)
+		return rcv
+	})
+	return r, err
 }
@@ -8,10 +8,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 )
 
@@ -31,3 +33,22 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateReceiverSharedByPipelines(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+
+	params := receivertest.NewNopSettings(metadata.Type)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, metricsSink)
+	require.NoError(t, err)
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, logsSink)
+	require.NoError(t, err)
+	assert.Same(t, mReceiver, lReceiver)
+
+	r := lReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*statsdReceiver)
+	assert.Equal(t, metricsSink, r.nextConsumer)
+	assert.Equal(t, logsSink, r.nextLogsConsumer)
+	assert.NoError(t, lReceiver.Shutdown(context.Background()))
+}
@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parser // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/parser"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.22.0"
+)
+
+// Prefixes of the DogStatsD events and service checks, as per DogStatsD protocol:
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=events
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+)
+
+// Names and attributes of the log records of the events and service checks
+const (
+	eventName        = "dogstatsd.event"
+	serviceCheckName = "dogstatsd.service_check"
+
+	attributeEventTitle          = "dogstatsd.event.title"
+	attributeEventPriority       = "dogstatsd.event.priority"
+	attributeEventAlertType      = "dogstatsd.event.alert_type"
+	attributeEventSourceType     = "dogstatsd.event.source_type"
+	attributeEventAggregationKey = "dogstatsd.event.aggregation_key"
+	attributeServiceCheckName    = "dogstatsd.service_check.name"
+	attributeServiceCheckStatus  = "dogstatsd.service_check.status"
+)
+
+var errEmptyServiceCheckName = errors.New("empty service check name")
+
+// eventSeverities maps the alert types of the events to severities
+var eventSeverities = map[string]plog.SeverityNumber{
+	"error":   plog.SeverityNumberError,
+	"warning": plog.SeverityNumberWarn,
+	"info":    plog.SeverityNumberInfo,
+	"success": plog.SeverityNumberInfo,
+}
+
+// serviceCheckStatuses are the names and severities of the statuses of the service checks
+var serviceCheckStatuses = []struct {
+	name     string
+	severity plog.SeverityNumber
+}{
+	{"ok", plog.SeverityNumberInfo},
+	{"warning", plog.SeverityNumberWarn},
+	{"critical", plog.SeverityNumberError},
+	{"unknown", plog.SeverityNumberUnspecified},
+}
+
+// IsLogLine returns whether the line is a DogStatsD event or service check, rather than a metric.
+func IsLogLine(line string) bool {
+	return strings.HasPrefix(line, eventPrefix) || strings.HasPrefix(line, serviceCheckPrefix)
+}
+
+// ParseLogs converts a DogStatsD event or service check into logs.
+func (p *StatsDParser) ParseLogs(line string) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(receiverName)
+	sl.Scope().SetVersion(p.BuildInfo.Version)
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+
+	var err error
+	if strings.HasPrefix(line, eventPrefix) {
+		err = parseEvent(line, lr, p.enableSimpleTags)
+	} else {
+		err = parseServiceCheck(line, lr, p.enableSimpleTags)
+	}
+	if err != nil {
+		return plog.NewLogs(), err
+	}
+	return logs, nil
+}
+
+// parseEvent parses an event with the format:
+// _e{<TITLE_LENGTH>,<TEXT_LENGTH>}:<TITLE>|<TEXT>|d:<TIMESTAMP>|h:<HOSTNAME>|p:<PRIORITY>|t:<ALERT_TYPE>|s:<SOURCE_TYPE>|k:<AGGREGATION_KEY>|#<TAGS>|c:<CONTAINER_ID>
+func parseEvent(line string, lr plog.LogRecord, enableSimpleTags bool) error {
+	lengths, rest, found := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !found {
+		return fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLengthStr, textLengthStr, found := strings.Cut(lengths, ",")
+	if !found {
+		return fmt.Errorf("invalid event lengths: %s", lengths)
+	}
+	titleLength, err := strconv.Atoi(titleLengthStr)
+	if err != nil || titleLength < 0 {
+		return fmt.Errorf("invalid event title length: %s", titleLengthStr)
+	}
+	textLength, err := strconv.Atoi(textLengthStr)
+	if err != nil || textLength < 0 {
+		return fmt.Errorf("invalid event text length: %s", textLengthStr)
+	}
+
+	// The lengths are in bytes, and the title and the text may contain '|'. They are compared
+	// to the length of the rest of the line one at a time, since their sum may overflow.
+	if titleLength >= len(rest) || textLength > len(rest)-titleLength-1 || rest[titleLength] != '|' {
+		return fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	title := rest[:titleLength]
+	text := rest[titleLength+1 : titleLength+1+textLength]
+	rest = rest[titleLength+1+textLength:]
+	if rest != "" && rest[0] != '|' {
+		return fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	if title == "" {
+		return errors.New("empty event title")
+	}
+
+	lr.SetEventName(eventName)
+	lr.Body().SetStr(unescapeNewlines(text))
+	lr.Attributes().PutStr(attributeEventTitle, unescapeNewlines(title))
+	alertType := "info"
+
+	var kvs []attribute.KeyValue
+	var part string
+	part, rest, _ = strings.Cut(strings.TrimPrefix(rest, "|"), "|")
+	for ; len(part) > 0; part, rest, _ = strings.Cut(rest, "|") {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			if err = setTimestamp(lr, strings.TrimPrefix(part, "d:")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(part, "h:"):
+			lr.Attributes().PutStr(string(semconv.HostNameKey), strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "p:"):
+			priority := strings.TrimPrefix(part, "p:")
+			if priority != "normal" && priority != "low" {
+				return fmt.Errorf("invalid event priority: %s", priority)
+			}
+			lr.Attributes().PutStr(attributeEventPriority, priority)
+		case strings.HasPrefix(part, "t:"):
+			alertType = strings.TrimPrefix(part, "t:")
+			if _, ok := eventSeverities[alertType]; !ok {
+				return fmt.Errorf("invalid event alert type: %s", alertType)
+			}
+		case strings.HasPrefix(part, "s:"):
+			lr.Attributes().PutStr(attributeEventSourceType, strings.TrimPrefix(part, "s:"))
+		case strings.HasPrefix(part, "k:"):
+			lr.Attributes().PutStr(attributeEventAggregationKey, strings.TrimPrefix(part, "k:"))
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return err
+			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, "c:"):
+			if containerID := strings.TrimPrefix(part, "c:"); containerID != "" {
+				lr.Attributes().PutStr(string(semconv.ContainerIDKey), containerID)
+			}
+		default:
+			return fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+
+	lr.Attributes().PutStr(attributeEventAlertType, alertType)
+	lr.SetSeverityNumber(eventSeverities[alertType])
+	lr.SetSeverityText(alertType)
+	putTags(lr, kvs)
+	return nil
+}
+
+// parseServiceCheck parses a service check with the format:
+// _sc|<NAME>|<STATUS>|d:<TIMESTAMP>|h:<HOSTNAME>|#<TAGS>|c:<CONTAINER_ID>|m:<MESSAGE>
+func parseServiceCheck(line string, lr plog.LogRecord, enableSimpleTags bool) error {
+	name, rest, _ := strings.Cut(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if name == "" {
+		return errEmptyServiceCheckName
+	}
+	statusStr, rest, _ := strings.Cut(rest, "|")
+	status, err := strconv.Atoi(statusStr)
+	if err != nil || status < 0 || status >= len(serviceCheckStatuses) {
+		return fmt.Errorf("invalid service check status: %s", statusStr)
+	}
+
+	lr.SetEventName(serviceCheckName)
+	lr.Attributes().PutStr(attributeServiceCheckName, name)
+	lr.Attributes().PutStr(attributeServiceCheckStatus, serviceCheckStatuses[status].name)
+	lr.SetSeverityNumber(serviceCheckStatuses[status].severity)
+	lr.SetSeverityText(strings.ToUpper(serviceCheckStatuses[status].name))
+
+	var kvs []attribute.KeyValue
+	for rest != "" {
+		// The message is the last part, and may contain '|'
+		if message, ok := strings.CutPrefix(rest, "m:"); ok {
+			lr.Body().SetStr(strings.ReplaceAll(unescapeNewlines(message), `m\:`, "m:"))
+			break
+		}
+
+		var part string
+		part, rest, _ = strings.Cut(rest, "|")
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			if err = setTimestamp(lr, strings.TrimPrefix(part, "d:")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(part, "h:"):
+			lr.Attributes().PutStr(string(semconv.HostNameKey), strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return err
+			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, "c:"):
+			if containerID := strings.TrimPrefix(part, "c:"); containerID != "" {
+				lr.Attributes().PutStr(string(semconv.ContainerIDKey), containerID)
+			}
+		default:
+			return fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	putTags(lr, kvs)
+	return nil
+}
+
+func setTimestamp(lr plog.LogRecord, timestampStr string) error {
+	timestampSeconds, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %s", timestampStr)
+	}
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(timestampSeconds, 0)))
+	return nil
+}
+
+func putTags(lr plog.LogRecord, kvs []attribute.KeyValue) {
+	for _, kv := range kvs {
+		lr.Attributes().PutStr(string(kv.Key), kv.Value.AsString())
+	}
+}
+
+// unescapeNewlines restores the newlines that DogStatsD clients escape as "\n"
+func unescapeNewlines(s string) string {
+	return strings.ReplaceAll(s, `\n`, "\n")
+}
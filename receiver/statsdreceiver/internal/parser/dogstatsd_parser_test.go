@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestIsLogLine(t *testing.T) {
+	assert.True(t, IsLogLine("_e{5,4}:title|text"))
+	assert.True(t, IsLogLine("_sc|my.check|0"))
+	assert.False(t, IsLogLine("test.metric:42|c"))
+	assert.False(t, IsLogLine("_e.metric:42|c"))
+}
+
+func TestStatsDParser_ParseLogs(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	defer func() {
+		timeNowFunc = time.Now
+	}()
+
+	tests := []struct {
+		name             string
+		input            string
+		enableSimpleTags bool
+		eventName        string
+		body             string
+		timestamp        pcommon.Timestamp
+		severityNumber   plog.SeverityNumber
+		severityText     string
+		attributes       map[string]any
+		err              error
+	}{
+		{
+			name:           "event with title and text",
+			input:          "_e{5,4}:title|text",
+			eventName:      "dogstatsd.event",
+			body:           "text",
+			severityNumber: plog.SeverityNumberInfo,
+			severityText:   "info",
+			attributes: map[string]any{
+				"dogstatsd.event.title":      "title",
+				"dogstatsd.event.alert_type": "info",
+			},
+		},
+		{
+			name:             "event with all fields",
+			input:            `_e{9,17}:dep|loyed|rollout\nfailed|c|d:1656581400|h:web-1|p:low|t:error|s:jenkins|k:deploy-42|#env:prod,team|c:abc123`,
+			enableSimpleTags: true,
+			eventName:        "dogstatsd.event",
+			body:             "rollout\nfailed|c",
+			timestamp:        pcommon.Timestamp(1656581400 * time.Second),
+			severityNumber:   plog.SeverityNumberError,
+			severityText:     "error",
+			attributes: map[string]any{
+				"dogstatsd.event.title":           "dep|loyed",
+				"dogstatsd.event.alert_type":      "error",
+				"dogstatsd.event.priority":        "low",
+				"dogstatsd.event.source_type":     "jenkins",
+				"dogstatsd.event.aggregation_key": "deploy-42",
+				"host.name":                       "web-1",
+				"container.id":                    "abc123",
+				"env":                             "prod",
+				"team":                            "",
+			},
+		},
+		{
+			name:           "service check",
+			input:          "_sc|my.check|1",
+			eventName:      "dogstatsd.service_check",
+			severityNumber: plog.SeverityNumberWarn,
+			severityText:   "WARNING",
+			attributes: map[string]any{
+				"dogstatsd.service_check.name":   "my.check",
+				"dogstatsd.service_check.status": "warning",
+			},
+		},
+		{
+			name:           "service check with all fields",
+			input:          `_sc|my.check|2|d:1656581400|h:web-1|#env:prod|c:abc123|m:db is down|see m\:runbook\nnow`,
+			eventName:      "dogstatsd.service_check",
+			body:           "db is down|see m:runbook\nnow",
+			timestamp:      pcommon.Timestamp(1656581400 * time.Second),
+			severityNumber: plog.SeverityNumberError,
+			severityText:   "CRITICAL",
+			attributes: map[string]any{
+				"dogstatsd.service_check.name":   "my.check",
+				"dogstatsd.service_check.status": "critical",
+				"host.name":                      "web-1",
+				"container.id":                   "abc123",
+				"env":                            "prod",
+			},
+		},
+		{
+			name:  "event unterminated lengths",
+			input: "_e{5,4title|text",
+			err:   errors.New("invalid event format: _e{5,4title|text"),
+		},
+		{
+			name:  "event invalid title length",
+			input: "_e{a,4}:title|text",
+			err:   errors.New("invalid event title length: a"),
+		},
+		{
+			name:  "event text longer than its length",
+			input: "_e{5,3}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,3}:title|text"),
+		},
+		{
+			name:  "event text shorter than its length",
+			input: "_e{5,5}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,5}:title|text"),
+		},
+		{
+			name:  "event lengths overflowing",
+			input: "_e{9223372036854775807,9223372036854775807}:a|b",
+			err:   errors.New("event title and text do not match their lengths: _e{9223372036854775807,9223372036854775807}:a|b"),
+		},
+		{
+			name:  "event title length too long",
+			input: "_e{9223372036854775807,1}:a|b",
+			err:   errors.New("event title and text do not match their lengths: _e{9223372036854775807,1}:a|b"),
+		},
+		{
+			name:  "event text length too long",
+			input: "_e{1,9223372036854775807}:a|b",
+			err:   errors.New("event title and text do not match their lengths: _e{1,9223372036854775807}:a|b"),
+		},
+		{
+			name:  "event empty title",
+			input: "_e{0,4}:|text",
+			err:   errors.New("empty event title"),
+		},
+		{
+			name:  "event invalid alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   errors.New("invalid event alert type: fatal"),
+		},
+		{
+			name:  "event invalid priority",
+			input: "_e{5,4}:title|text|p:high",
+			err:   errors.New("invalid event priority: high"),
+		},
+		{
+			name:  "event invalid timestamp",
+			input: "_e{5,4}:title|text|d:now",
+			err:   errors.New("invalid timestamp: now"),
+		},
+		{
+			name:  "event simple tag not enabled",
+			input: "_e{5,4}:title|text|#team",
+			err:   errors.New(`invalid tag format: "team"`),
+		},
+		{
+			name:  "event unrecognized part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   errors.New("unrecognized event part: x:y"),
+		},
+		{
+			name:  "service check empty name",
+			input: "_sc||0",
+			err:   errEmptyServiceCheckName,
+		},
+		{
+			name:  "service check invalid status",
+			input: "_sc|my.check|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "service check unrecognized part",
+			input: "_sc|my.check|0|x:y",
+			err:   errors.New("unrecognized service check part: x:y"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &StatsDParser{BuildInfo: component.BuildInfo{Version: "dev"}}
+			require.NoError(t, p.Initialize(false, tt.enableSimpleTags, false, false, nil))
+
+			logs, err := p.ParseLogs(tt.input)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				assert.Equal(t, 0, logs.LogRecordCount())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, logs.LogRecordCount())
+
+			sl := logs.ResourceLogs().At(0).ScopeLogs().At(0)
+			assert.Equal(t, receiverName, sl.Scope().Name())
+			assert.Equal(t, "dev", sl.Scope().Version())
+
+			lr := sl.LogRecords().At(0)
+			assert.Equal(t, tt.eventName, lr.EventName())
+			assert.Equal(t, tt.body, lr.Body().AsString())
+			assert.Equal(t, tt.timestamp, lr.Timestamp())
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), lr.ObservedTimestamp())
+			assert.Equal(t, tt.severityNumber, lr.SeverityNumber())
+			assert.Equal(t, tt.severityText, lr.SeverityText())
+			assert.Equal(t, tt.attributes, lr.Attributes().AsRaw())
+		})
+	}
+}
+
+func FuzzParseLogs(f *testing.F) {
+	f.Add("_e{5,4}:title|text|d:1234|h:web-1|p:low|t:error|#env:prod")
+	f.Add("_e{9223372036854775807,9223372036854775807}:a|b")
+	f.Add("_sc|my.check|2|d:1234|h:web-1|#env:prod|m:down")
+	f.Fuzz(func(t *testing.T, line string) {
+		p := &StatsDParser{}
+		require.NoError(t, p.Initialize(false, true, false, false, nil))
+		_, _ = p.ParseLogs(line)
+	})
+}
@@ -57,7 +57,11 @@ func buildGaugeMetric(parsedMetric statsDMetric, timeNow time.Time) pmetric.Scop
 	}
 	dp := nm.SetEmptyGauge().DataPoints().AppendEmpty()
 	dp.SetDoubleValue(parsedMetric.gaugeValue())
-	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	if parsedMetric.timestamp != 0 {
+		dp.SetTimestamp(pcommon.Timestamp(parsedMetric.timestamp))
+	} else {
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	}
 	for i := parsedMetric.description.attrs.Iter(); i.Next(); {
 		dp.Attributes().PutStr(string(i.Attribute().Key), i.Attribute().Value.AsString())
 	}
@@ -7,16 +7,19 @@ import (
 	"net"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
-// Parser is something that can map input StatsD strings to OTLP Metric representations.
+// Parser is something that can map input StatsD strings to OTLP Metric representations,
+// and DogStatsD events and service checks to OTLP Log representations.
 type Parser interface {
 	Initialize(enableMetricType bool, enableSimpleTags bool, isMonotonicCounter bool, enableIPOnlyAggregation bool, sendTimerHistogram []protocol.TimerHistogramMapping) error
 	GetMetrics() []BatchMetrics
 	Aggregate(line string, addr net.Addr) error
+	ParseLogs(line string) (plog.Logs, error)
 }
 
 type BatchMetrics struct {
@@ -60,6 +60,7 @@ type StatsDParser struct {
 	enableIPOnlyAggregation bool
 	timerEvents             ObserverCategory
 	histogramEvents         ObserverCategory
+	distributionEvents      ObserverCategory
 	lastIntervalTime        time.Time
 	BuildInfo               component.BuildInfo
 }
@@ -141,28 +142,41 @@ func (p *StatsDParser) Initialize(enableMetricType bool, enableSimpleTags bool,
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
+	p.distributionEvents = defaultObserverCategory
 	p.enableMetricType = enableMetricType
 	p.enableSimpleTags = enableSimpleTags
 	p.isMonotonicCounter = isMonotonicCounter
 	p.enableIPOnlyAggregation = enableIPOnlyAggregation
 
 	// Note: validation occurs in ("../".Config).validate()
+	distributionMapped := false
 	for _, eachMap := range sendTimerHistogram {
 		switch eachMap.StatsdType {
-		case protocol.HistogramTypeName, protocol.DistributionTypeName:
-			p.histogramEvents.method = eachMap.ObserverType
-			p.histogramEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
-			p.histogramEvents.summaryPercentiles = eachMap.Summary.Percentiles
+		case protocol.HistogramTypeName:
+			p.histogramEvents = newObserverCategory(eachMap)
+		case protocol.DistributionTypeName:
+			p.distributionEvents = newObserverCategory(eachMap)
+			distributionMapped = true
 		case protocol.TimingTypeName, protocol.TimingAltTypeName:
-			p.timerEvents.method = eachMap.ObserverType
-			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
-			p.timerEvents.summaryPercentiles = eachMap.Summary.Percentiles
+			p.timerEvents = newObserverCategory(eachMap)
 		case protocol.CounterTypeName, protocol.GaugeTypeName:
 		}
 	}
+	// Distributions are observed like histograms unless they have their own mapping
+	if !distributionMapped {
+		p.distributionEvents = p.histogramEvents
+	}
 	return nil
 }
 
+func newObserverCategory(mapping protocol.TimerHistogramMapping) ObserverCategory {
+	return ObserverCategory{
+		method:             mapping.ObserverType,
+		histogramConfig:    expoHistogramConfig(mapping.Histogram),
+		summaryPercentiles: mapping.Summary.Percentiles,
+	}
+}
+
 func expoHistogramConfig(opts protocol.HistogramConfig) structure.Config {
 	var r []structure.Option
 	if opts.MaxSize >= structure.MinSize {
@@ -247,8 +261,10 @@ var timeNowFunc = time.Now
 
 func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 	switch t {
-	case HistogramType, DistributionType:
+	case HistogramType:
 		return p.histogramEvents
+	case DistributionType:
+		return p.distributionEvents
 	case TimingType:
 		return p.timerEvents
 	case CounterType, GaugeType:
@@ -258,9 +274,16 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
-	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType, p.enableSimpleTags)
-	if err != nil {
-		return err
+	// All the values of a line are parsed before any of them is aggregated, so that
+	// an invalid value discards the whole line
+	values := unpackValues(line)
+	parsedMetrics := make([]statsDMetric, 0, len(values))
+	for _, value := range values {
+		parsedMetric, err := parseMessageToMetric(value, p.enableMetricType, p.enableSimpleTags)
+		if err != nil {
+			return err
+		}
+		parsedMetrics = append(parsedMetrics, parsedMetric)
 	}
 
 	addrKey := newNetAddr(addr)
@@ -274,6 +297,33 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 		p.instrumentsByAddress[addrKey] = instrument
 	}
 
+	for _, parsedMetric := range parsedMetrics {
+		p.aggregate(instrument, parsedMetric)
+	}
+	return nil
+}
+
+// unpackValues splits a line with several values, as sent by DogStatsD clients since
+// the v1.1 protocol, e.g. "name:1:2:3|d", into a line per value. The members of sets
+// aren't numbers and may contain ':', so they are never unpacked.
+func unpackValues(line string) []string {
+	nameValue, rest, foundName := strings.Cut(line, "|")
+	name, valueStr, foundValue := strings.Cut(nameValue, ":")
+	if !foundName || !foundValue || !strings.Contains(valueStr, ":") {
+		return []string{line}
+	}
+	if metricType, _, _ := strings.Cut(rest, "|"); metricType == "s" {
+		return []string{line}
+	}
+
+	var lines []string
+	for _, value := range strings.Split(valueStr, ":") {
+		lines = append(lines, name+":"+value+"|"+rest)
+	}
+	return lines
+}
+
+func (p *StatsDParser) aggregate(instrument *instruments, parsedMetric statsDMetric) {
 	switch parsedMetric.description.metricType {
 	case GaugeType:
 		_, ok := instrument.gauges[parsedMetric.description]
@@ -339,8 +389,6 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 			// No action.
 		}
 	}
-
-	return nil
 }
 
 func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags bool) (statsDMetric, error) {
@@ -392,30 +440,11 @@ func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags b
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			// handle an empty tag set
-			// where the tags part was still sent (some clients do this)
-			if len(tagsStr) == 0 {
-				continue
-			}
-
-			var tagSet string
-			tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
-			for ; len(tagSet) > 0; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
-				k, v, _ := strings.Cut(tagSet, ":")
-				if k == "" {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				// support both simple tags (w/o value) and dimension tags (w/ value).
-				// dogstatsd notably allows simple tags.
-				if v == "" && !enableSimpleTags {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
 		case strings.HasPrefix(part, "c:"):
 			// As per DogStatD protocol v1.2:
 			// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v12
@@ -462,6 +491,31 @@ func parseMessageToMetric(line string, enableMetricType bool, enableSimpleTags b
 	return result, nil
 }
 
+// parseTags parses the comma-separated tags of a line
+func parseTags(tagsStr string, enableSimpleTags bool) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+
+	// an empty tag set, where the tags part was still sent (some clients do this),
+	// has no tags
+	var tagSet string
+	tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
+	for ; len(tagSet) > 0; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
+		k, v, _ := strings.Cut(tagSet, ":")
+		if k == "" {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		// support both simple tags (w/o value) and dimension tags (w/ value).
+		// dogstatsd notably allows simple tags.
+		if v == "" && !enableSimpleTags {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return kvs, nil
+}
+
 type netAddr struct {
 	Network string
 	String  string
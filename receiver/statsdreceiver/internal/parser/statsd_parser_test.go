@@ -1963,3 +1963,78 @@ func TestStatsDParser_IPOnlyAggregation(t *testing.T) {
 
 	assert.Equal(t, int64(4), value)
 }
+
+func TestStatsDParser_AggregatePackedValues(t *testing.T) {
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, false, false, []protocol.TimerHistogramMapping{
+		{StatsdType: "distribution", ObserverType: "histogram"},
+	}))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	assert.NoError(t, p.Aggregate("test.dist:1:2:3|d|@0.5|#key:value", addr))
+	assert.NoError(t, p.Aggregate("test.counter:1:2|c", addr))
+
+	// An invalid value discards the whole line
+	assert.EqualError(t, p.Aggregate("test.counter:1:x|c", addr), "parse metric value string: x")
+	assert.EqualError(t, p.Aggregate("test.counter:1:|c", addr), "empty metric value")
+
+	instrument := p.instrumentsByAddress[newNetAddr(addr)]
+	histogram := instrument.histograms[testDescription("test.dist", "d", []string{"key"}, []string{"value"})]
+	require.NotNil(t, histogram.agg)
+	assert.Equal(t, uint64(6), histogram.agg.Count())
+	assert.Equal(t, 12.0, histogram.agg.Sum())
+
+	counter := instrument.counters[statsDMetricDescription{name: "test.counter", metricType: CounterType}]
+	assert.Equal(t, int64(3), counter.Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+}
+
+func TestUnpackValues(t *testing.T) {
+	assert.Equal(t, []string{"test.dist:1|d|#key:value", "test.dist:2|d|#key:value"}, unpackValues("test.dist:1:2|d|#key:value"))
+	assert.Equal(t, []string{"test.counter:1|c"}, unpackValues("test.counter:1|c"))
+	// The members of sets may contain ':'
+	assert.Equal(t, []string{"test.set:host:8080|s|#key:value"}, unpackValues("test.set:host:8080|s|#key:value"))
+}
+
+func TestStatsDParser_DistributionMapping(t *testing.T) {
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	metricTypes := func(t *testing.T, mapping []protocol.TimerHistogramMapping) map[string]pmetric.MetricType {
+		p := &StatsDParser{}
+		require.NoError(t, p.Initialize(false, false, false, false, mapping))
+		require.NoError(t, p.Aggregate("H:10|h", addr))
+		require.NoError(t, p.Aggregate("D:10|d", addr))
+
+		types := map[string]pmetric.MetricType{}
+		ilm := p.GetMetrics()[0].Metrics.ResourceMetrics().At(0).ScopeMetrics()
+		for i := 0; i < ilm.Len(); i++ {
+			m := ilm.At(i).Metrics().At(0)
+			types[m.Name()] = m.Type()
+		}
+		return types
+	}
+
+	// Distributions have their own mapping
+	assert.Equal(t, map[string]pmetric.MetricType{
+		"H": pmetric.MetricTypeSummary,
+		"D": pmetric.MetricTypeExponentialHistogram,
+	}, metricTypes(t, []protocol.TimerHistogramMapping{
+		{StatsdType: "distribution", ObserverType: "histogram"},
+		{StatsdType: "histogram", ObserverType: "summary"},
+	}))
+
+	// Distributions are observed like histograms without their own mapping
+	assert.Equal(t, map[string]pmetric.MetricType{
+		"H": pmetric.MetricTypeSummary,
+		"D": pmetric.MetricTypeSummary,
+	}, metricTypes(t, []protocol.TimerHistogramMapping{
+		{StatsdType: "histogram", ObserverType: "summary"},
+	}))
+}
+
+func TestStatsDParser_GaugeTimestamp(t *testing.T) {
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	assert.NoError(t, p.Aggregate("test.gauge:42|g|T1656581400", addr))
+
+	dp := p.GetMetrics()[0].Metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, time.Unix(1656581400, 0).UTC(), dp.Timestamp().AsTime())
+}
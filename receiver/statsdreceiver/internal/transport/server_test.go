@@ -13,7 +13,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport/client"
@@ -52,8 +51,6 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, srv)
 
-			mc := new(consumertest.MetricsSink)
-			require.NoError(t, err)
 			mr := NewMockReporter(1)
 			transferChan := make(chan Metric, 10)
 
@@ -61,7 +58,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			wgListenAndServe.Add(1)
 			go func() {
 				defer wgListenAndServe.Done()
-				assert.Error(t, srv.ListenAndServe(mc, mr, transferChan))
+				assert.Error(t, srv.ListenAndServe(mr, transferChan))
 			}()
 
 			runtime.Gosched()
@@ -11,8 +11,6 @@ import (
 	"net"
 	"strings"
 	"sync"
-
-	"go.opentelemetry.io/collector/consumer"
 )
 
 var errTCPServerDone = errors.New("server stopped")
@@ -48,8 +46,8 @@ func NewTCPServer(transport Transport, address string) (Server, error) {
 }
 
 // ListenAndServe starts the server ready to receive metrics.
-func (t *tcpServer) ListenAndServe(nextConsumer consumer.Metrics, reporter Reporter, transferChan chan<- Metric) error {
-	if nextConsumer == nil || reporter == nil {
+func (t *tcpServer) ListenAndServe(reporter Reporter, transferChan chan<- Metric) error {
+	if reporter == nil {
 		return errNilListenAndServeParameters
 	}
 
@@ -25,19 +25,24 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
 )
 
-var _ receiver.Metrics = (*statsdReceiver)(nil)
+var (
+	_ receiver.Metrics = (*statsdReceiver)(nil)
+	_ receiver.Logs    = (*statsdReceiver)(nil)
+)
 
-// statsdReceiver implements the receiver.Metrics for StatsD protocol.
+// statsdReceiver implements the receiver.Metrics for StatsD protocol, and the
+// receiver.Logs for the events and service checks of the DogStatsD protocol.
 type statsdReceiver struct {
 	settings receiver.Settings
 	config   *Config
 
-	server       transport.Server
-	reporter     *reporter
-	obsrecv      *receiverhelper.ObsReport
-	parser       parser.Parser
-	nextConsumer consumer.Metrics
-	cancel       context.CancelFunc
+	server           transport.Server
+	reporter         *reporter
+	obsrecv          *receiverhelper.ObsReport
+	parser           parser.Parser
+	nextConsumer     consumer.Metrics
+	nextLogsConsumer consumer.Logs
+	cancel           context.CancelFunc
 }
 
 // newReceiver creates the StatsD receiver with the given parameters.
@@ -84,6 +89,16 @@ func newReceiver(
 	return r, nil
 }
 
+// registerMetricsConsumer sets the consumer of the metrics of the receiver shared with a logs pipeline.
+func (r *statsdReceiver) registerMetricsConsumer(nextConsumer consumer.Metrics) {
+	r.nextConsumer = nextConsumer
+}
+
+// registerLogsConsumer sets the consumer of the DogStatsD events and service checks.
+func (r *statsdReceiver) registerLogsConsumer(nextLogsConsumer consumer.Logs) {
+	r.nextLogsConsumer = nextLogsConsumer
+}
+
 func buildTransportServer(config Config) (transport.Server, error) {
 	trans := transport.NewTransport(strings.ToLower(string(config.NetAddr.Transport)))
 	switch trans {
@@ -119,7 +134,7 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 	go func() {
-		if err := r.server.ListenAndServe(r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
 			}
@@ -142,7 +157,12 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 					r.obsrecv.EndMetricsOp(flushCtx, metadata.Type.String(), numPoints, err)
 				}
 			case metric := <-transferChan:
-				err := r.parser.Aggregate(metric.Raw, metric.Addr)
+				var err error
+				if parser.IsLogLine(metric.Raw) {
+					err = r.consumeLogs(ctx, metric)
+				} else if r.nextConsumer != nil {
+					err = r.parser.Aggregate(metric.Raw, metric.Addr)
+				}
 				if err != nil {
 					failCnt++
 					if failCnt%100 == 0 {
@@ -178,6 +198,26 @@ func (r *statsdReceiver) Shutdown(context.Context) error {
 	return err
 }
 
+// consumeLogs sends a DogStatsD event or service check to the logs pipeline, if any, and
+// returns an error only if the line is invalid.
+func (r *statsdReceiver) consumeLogs(ctx context.Context, metric transport.Metric) error {
+	if r.nextLogsConsumer == nil {
+		return nil
+	}
+	logs, err := r.parser.ParseLogs(metric.Raw)
+	if err != nil {
+		return err
+	}
+
+	logsCtx := r.obsrecv.StartLogsOp(client.NewContext(ctx, client.Info{Addr: metric.Addr}))
+	err = r.nextLogsConsumer.ConsumeLogs(logsCtx, logs)
+	if err != nil {
+		r.reporter.OnDebugf("Error consuming logs", zap.Error(err))
+	}
+	r.obsrecv.EndLogsOp(logsCtx, metadata.Type.String(), logs.LogRecordCount(), err)
+	return nil
+}
+
 func (r *statsdReceiver) Flush(ctx context.Context, metrics pmetric.Metrics, nextConsumer consumer.Metrics) error {
 	return nextConsumer.ConsumeMetrics(ctx, metrics)
 }
@@ -6,6 +6,7 @@ package statsdreceiver
 import (
 	"context"
 	"errors"
+	"net"
 	"runtime"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport/client"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
 func Test_statsdreceiver_Start(t *testing.T) {
@@ -184,3 +186,47 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsdReceiver_EventsAndServiceChecks(t *testing.T) {
+	addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = addr
+	cfg.AggregationInterval = time.Second
+	cfg.TimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "distribution", ObserverType: "histogram"}}
+
+	params := receivertest.NewNopSettings(metadata.Type)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, metricsSink)
+	require.NoError(t, err)
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, logsSink)
+	require.NoError(t, err)
+
+	require.NoError(t, mReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, lReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, mReceiver.Shutdown(context.Background()))
+		assert.NoError(t, lReceiver.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("udp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("_e{5,4}:title|text|t:error\n_sc|my.check|2|m:down\ntest.metric:1:2:3|d\n_sc||0\n"))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 2 && len(metricsSink.AllMetrics()) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	var eventNames []string
+	for _, logs := range logsSink.AllLogs() {
+		eventNames = append(eventNames, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).EventName())
+	}
+	assert.ElementsMatch(t, []string{"dogstatsd.event", "dogstatsd.service_check"}, eventNames)
+
+	metric := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "test.metric", metric.Name())
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+	assert.Equal(t, uint64(3), metric.ExponentialHistogram().DataPoints().At(0).Count())
+}
@@ -5,6 +5,7 @@ package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
@@ -16,12 +17,33 @@ import (
 // Predefined error responses for configuration validation failures
 var errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <hostname>:<port>`)
 
+// Protocols of the connections upgraded to TLS with STARTTLS
+const (
+	startTLSSMTP     = "smtp"
+	startTLSIMAP     = "imap"
+	startTLSPostgres = "postgres"
+	startTLSLDAP     = "ldap"
+)
+
 // CertificateTarget represents a target for certificate checking, which can be either
 // a network endpoint or a local file
 type CertificateTarget struct {
 	confignet.TCPAddrConfig `mapstructure:",squash"`
 	FilePath                string `mapstructure:"file_path"`
 
+	// ServerName is the name sent with SNI and that the leaf certificate is verified against.
+	// Default: the host of the endpoint. File certificates are only verified against it if set.
+	ServerName string `mapstructure:"server_name"`
+
+	// StartTLS is the protocol used to upgrade the connection to the endpoint to TLS.
+	// Valid options: "smtp", "imap", "postgres", "ldap"
+	// Default: the TLS handshake starts as soon as connected
+	StartTLS string `mapstructure:"starttls"`
+
+	// CAFile is the path of the PEM bundle of the CAs the certificate chain is verified against.
+	// Default: the CAs of the system
+	CAFile string `mapstructure:"ca_file"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -43,6 +65,15 @@ func validateTarget(ct *CertificateTarget) error {
 	if ct.Endpoint == "" && ct.FilePath == "" {
 		return errors.New("must specify either endpoint or file_path")
 	}
+	switch ct.StartTLS {
+	case "":
+	case startTLSSMTP, startTLSIMAP, startTLSPostgres, startTLSLDAP:
+		if ct.FilePath != "" {
+			return errors.New("cannot specify starttls with file_path")
+		}
+	default:
+		return fmt.Errorf("invalid starttls %q: must be either smtp, imap, postgres or ldap", ct.StartTLS)
+	}
 	return nil
 }
 
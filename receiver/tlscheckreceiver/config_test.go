@@ -105,6 +105,51 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: errors.New("cannot specify both endpoint and file_path"),
 		},
+		{
+			desc: "valid starttls config",
+			cfg: &Config{
+				Targets: []*CertificateTarget{
+					{
+						TCPAddrConfig: confignet.TCPAddrConfig{
+							Endpoint: "mail.example.com:587",
+						},
+						StartTLS:   "smtp",
+						ServerName: "smtp.example.com",
+						CAFile:     tmpFile.Name(),
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "invalid starttls",
+			cfg: &Config{
+				Targets: []*CertificateTarget{
+					{
+						TCPAddrConfig: confignet.TCPAddrConfig{
+							Endpoint: "mail.example.com:110",
+						},
+						StartTLS: "pop3",
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: errors.New(`invalid starttls "pop3": must be either smtp, imap, postgres or ldap`),
+		},
+		{
+			desc: "starttls with file path",
+			cfg: &Config{
+				Targets: []*CertificateTarget{
+					{
+						FilePath: tmpFile.Name(),
+						StartTLS: "ldap",
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: errors.New("cannot specify starttls with file_path"),
+		},
 	}
 
 	for _, tc := range testCases {
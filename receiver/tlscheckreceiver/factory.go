@@ -47,7 +47,7 @@ func newReceiver(
 	}
 
 	mp := newScraper(tlsCheckConfig, settings, getConnectionState)
-	s, err := collectorscraper.NewMetrics(mp.scrape)
+	s, err := collectorscraper.NewMetrics(mp.scrape, collectorscraper.WithStart(mp.start))
 	if err != nil {
 		return nil, err
 	}
@@ -28,11 +28,27 @@ func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
 
 // MetricsConfig provides config for tlscheck metrics.
 type MetricsConfig struct {
-	TlscheckTimeLeft MetricConfig `mapstructure:"tlscheck.time_left"`
+	TlscheckChainTimeLeft MetricConfig `mapstructure:"tlscheck.chain.time_left"`
+	TlscheckChainValid    MetricConfig `mapstructure:"tlscheck.chain.valid"`
+	TlscheckHostnameValid MetricConfig `mapstructure:"tlscheck.hostname.valid"`
+	TlscheckRevoked       MetricConfig `mapstructure:"tlscheck.revoked"`
+	TlscheckTimeLeft      MetricConfig `mapstructure:"tlscheck.time_left"`
 }
 
 func DefaultMetricsConfig() MetricsConfig {
 	return MetricsConfig{
+		TlscheckChainTimeLeft: MetricConfig{
+			Enabled: false,
+		},
+		TlscheckChainValid: MetricConfig{
+			Enabled: false,
+		},
+		TlscheckHostnameValid: MetricConfig{
+			Enabled: false,
+		},
+		TlscheckRevoked: MetricConfig{
+			Enabled: false,
+		},
 		TlscheckTimeLeft: MetricConfig{
 			Enabled: true,
 		},
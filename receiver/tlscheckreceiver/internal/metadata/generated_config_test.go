@@ -27,7 +27,11 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "all_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					TlscheckTimeLeft: MetricConfig{Enabled: true},
+					TlscheckChainTimeLeft: MetricConfig{Enabled: true},
+					TlscheckChainValid:    MetricConfig{Enabled: true},
+					TlscheckHostnameValid: MetricConfig{Enabled: true},
+					TlscheckRevoked:       MetricConfig{Enabled: true},
+					TlscheckTimeLeft:      MetricConfig{Enabled: true},
 				},
 				ResourceAttributes: ResourceAttributesConfig{
 					TlscheckTarget: ResourceAttributeConfig{Enabled: true},
@@ -38,7 +42,11 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "none_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					TlscheckTimeLeft: MetricConfig{Enabled: false},
+					TlscheckChainTimeLeft: MetricConfig{Enabled: false},
+					TlscheckChainValid:    MetricConfig{Enabled: false},
+					TlscheckHostnameValid: MetricConfig{Enabled: false},
+					TlscheckRevoked:       MetricConfig{Enabled: false},
+					TlscheckTimeLeft:      MetricConfig{Enabled: false},
 				},
 				ResourceAttributes: ResourceAttributesConfig{
 					TlscheckTarget: ResourceAttributeConfig{Enabled: false},
@@ -12,20 +12,269 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 )
 
+// AttributeTlscheckRevocationSource specifies the value tlscheck.revocation.source attribute.
+type AttributeTlscheckRevocationSource int
+
+const (
+	_ AttributeTlscheckRevocationSource = iota
+	AttributeTlscheckRevocationSourceOcsp
+	AttributeTlscheckRevocationSourceCrl
+)
+
+// String returns the string representation of the AttributeTlscheckRevocationSource.
+func (av AttributeTlscheckRevocationSource) String() string {
+	switch av {
+	case AttributeTlscheckRevocationSourceOcsp:
+		return "ocsp"
+	case AttributeTlscheckRevocationSourceCrl:
+		return "crl"
+	}
+	return ""
+}
+
+// MapAttributeTlscheckRevocationSource is a helper map of string to AttributeTlscheckRevocationSource attribute value.
+var MapAttributeTlscheckRevocationSource = map[string]AttributeTlscheckRevocationSource{
+	"ocsp": AttributeTlscheckRevocationSourceOcsp,
+	"crl":  AttributeTlscheckRevocationSourceCrl,
+}
+
 var MetricsInfo = metricsInfo{
+	TlscheckChainTimeLeft: metricInfo{
+		Name: "tlscheck.chain.time_left",
+	},
+	TlscheckChainValid: metricInfo{
+		Name: "tlscheck.chain.valid",
+	},
+	TlscheckHostnameValid: metricInfo{
+		Name: "tlscheck.hostname.valid",
+	},
+	TlscheckRevoked: metricInfo{
+		Name: "tlscheck.revoked",
+	},
 	TlscheckTimeLeft: metricInfo{
 		Name: "tlscheck.time_left",
 	},
 }
 
 type metricsInfo struct {
-	TlscheckTimeLeft metricInfo
+	TlscheckChainTimeLeft metricInfo
+	TlscheckChainValid    metricInfo
+	TlscheckHostnameValid metricInfo
+	TlscheckRevoked       metricInfo
+	TlscheckTimeLeft      metricInfo
 }
 
 type metricInfo struct {
 	Name string
 }
 
+type metricTlscheckChainTimeLeft struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.chain.time_left metric with initial data.
+func (m *metricTlscheckChainTimeLeft) init() {
+	m.data.SetName("tlscheck.chain.time_left")
+	m.data.SetDescription("Time in seconds until the expiry of each certificate of the chain, as specified by `NotAfter` field in the x.509 certificate. Negative values represent time in seconds since expiration.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricTlscheckChainTimeLeft) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, tlscheckX509IssuerAttributeValue string, tlscheckX509CnAttributeValue string, tlscheckX509ChainIndexAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("tlscheck.x509.issuer", tlscheckX509IssuerAttributeValue)
+	dp.Attributes().PutStr("tlscheck.x509.cn", tlscheckX509CnAttributeValue)
+	dp.Attributes().PutInt("tlscheck.x509.chain_index", tlscheckX509ChainIndexAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckChainTimeLeft) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckChainTimeLeft) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckChainTimeLeft(cfg MetricConfig) metricTlscheckChainTimeLeft {
+	m := metricTlscheckChainTimeLeft{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckChainValid struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.chain.valid metric with initial data.
+func (m *metricTlscheckChainValid) init() {
+	m.data.SetName("tlscheck.chain.valid")
+	m.data.SetDescription("1 if the certificate chain is verified against the trusted CAs, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricTlscheckChainValid) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckChainValid) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckChainValid) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckChainValid(cfg MetricConfig) metricTlscheckChainValid {
+	m := metricTlscheckChainValid{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckHostnameValid struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.hostname.valid metric with initial data.
+func (m *metricTlscheckHostnameValid) init() {
+	m.data.SetName("tlscheck.hostname.valid")
+	m.data.SetDescription("1 if the leaf certificate is valid for the server name, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricTlscheckHostnameValid) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, tlscheckServerNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("tlscheck.server_name", tlscheckServerNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckHostnameValid) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckHostnameValid) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckHostnameValid(cfg MetricConfig) metricTlscheckHostnameValid {
+	m := metricTlscheckHostnameValid{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckRevoked struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.revoked metric with initial data.
+func (m *metricTlscheckRevoked) init() {
+	m.data.SetName("tlscheck.revoked")
+	m.data.SetDescription("1 if the certificate is revoked according to the source, otherwise 0. Not recorded when the source doesn't provide the status of the certificate.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricTlscheckRevoked) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, tlscheckX509IssuerAttributeValue string, tlscheckX509CnAttributeValue string, tlscheckX509ChainIndexAttributeValue int64, tlscheckRevocationSourceAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("tlscheck.x509.issuer", tlscheckX509IssuerAttributeValue)
+	dp.Attributes().PutStr("tlscheck.x509.cn", tlscheckX509CnAttributeValue)
+	dp.Attributes().PutInt("tlscheck.x509.chain_index", tlscheckX509ChainIndexAttributeValue)
+	dp.Attributes().PutStr("tlscheck.revocation.source", tlscheckRevocationSourceAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckRevoked) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckRevoked) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckRevoked(cfg MetricConfig) metricTlscheckRevoked {
+	m := metricTlscheckRevoked{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricTlscheckTimeLeft struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -89,6 +338,10 @@ type MetricsBuilder struct {
 	buildInfo                      component.BuildInfo  // contains version information.
 	resourceAttributeIncludeFilter map[string]filter.Filter
 	resourceAttributeExcludeFilter map[string]filter.Filter
+	metricTlscheckChainTimeLeft    metricTlscheckChainTimeLeft
+	metricTlscheckChainValid       metricTlscheckChainValid
+	metricTlscheckHostnameValid    metricTlscheckHostnameValid
+	metricTlscheckRevoked          metricTlscheckRevoked
 	metricTlscheckTimeLeft         metricTlscheckTimeLeft
 }
 
@@ -115,6 +368,10 @@ func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, opt
 		startTime:                      pcommon.NewTimestampFromTime(time.Now()),
 		metricsBuffer:                  pmetric.NewMetrics(),
 		buildInfo:                      settings.BuildInfo,
+		metricTlscheckChainTimeLeft:    newMetricTlscheckChainTimeLeft(mbc.Metrics.TlscheckChainTimeLeft),
+		metricTlscheckChainValid:       newMetricTlscheckChainValid(mbc.Metrics.TlscheckChainValid),
+		metricTlscheckHostnameValid:    newMetricTlscheckHostnameValid(mbc.Metrics.TlscheckHostnameValid),
+		metricTlscheckRevoked:          newMetricTlscheckRevoked(mbc.Metrics.TlscheckRevoked),
 		metricTlscheckTimeLeft:         newMetricTlscheckTimeLeft(mbc.Metrics.TlscheckTimeLeft),
 		resourceAttributeIncludeFilter: make(map[string]filter.Filter),
 		resourceAttributeExcludeFilter: make(map[string]filter.Filter),
@@ -194,6 +451,10 @@ func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
 	ils.Scope().SetName(ScopeName)
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricTlscheckChainTimeLeft.emit(ils.Metrics())
+	mb.metricTlscheckChainValid.emit(ils.Metrics())
+	mb.metricTlscheckHostnameValid.emit(ils.Metrics())
+	mb.metricTlscheckRevoked.emit(ils.Metrics())
 	mb.metricTlscheckTimeLeft.emit(ils.Metrics())
 
 	for _, op := range options {
@@ -226,6 +487,26 @@ func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics
 	return metrics
 }
 
+// RecordTlscheckChainTimeLeftDataPoint adds a data point to tlscheck.chain.time_left metric.
+func (mb *MetricsBuilder) RecordTlscheckChainTimeLeftDataPoint(ts pcommon.Timestamp, val int64, tlscheckX509IssuerAttributeValue string, tlscheckX509CnAttributeValue string, tlscheckX509ChainIndexAttributeValue int64) {
+	mb.metricTlscheckChainTimeLeft.recordDataPoint(mb.startTime, ts, val, tlscheckX509IssuerAttributeValue, tlscheckX509CnAttributeValue, tlscheckX509ChainIndexAttributeValue)
+}
+
+// RecordTlscheckChainValidDataPoint adds a data point to tlscheck.chain.valid metric.
+func (mb *MetricsBuilder) RecordTlscheckChainValidDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricTlscheckChainValid.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordTlscheckHostnameValidDataPoint adds a data point to tlscheck.hostname.valid metric.
+func (mb *MetricsBuilder) RecordTlscheckHostnameValidDataPoint(ts pcommon.Timestamp, val int64, tlscheckServerNameAttributeValue string) {
+	mb.metricTlscheckHostnameValid.recordDataPoint(mb.startTime, ts, val, tlscheckServerNameAttributeValue)
+}
+
+// RecordTlscheckRevokedDataPoint adds a data point to tlscheck.revoked metric.
+func (mb *MetricsBuilder) RecordTlscheckRevokedDataPoint(ts pcommon.Timestamp, val int64, tlscheckX509IssuerAttributeValue string, tlscheckX509CnAttributeValue string, tlscheckX509ChainIndexAttributeValue int64, tlscheckRevocationSourceAttributeValue AttributeTlscheckRevocationSource) {
+	mb.metricTlscheckRevoked.recordDataPoint(mb.startTime, ts, val, tlscheckX509IssuerAttributeValue, tlscheckX509CnAttributeValue, tlscheckX509ChainIndexAttributeValue, tlscheckRevocationSourceAttributeValue.String())
+}
+
 // RecordTlscheckTimeLeftDataPoint adds a data point to tlscheck.time_left metric.
 func (mb *MetricsBuilder) RecordTlscheckTimeLeftDataPoint(ts pcommon.Timestamp, val int64, tlscheckX509IssuerAttributeValue string, tlscheckX509CnAttributeValue string, tlscheckX509SanAttributeValue []any) {
 	mb.metricTlscheckTimeLeft.recordDataPoint(mb.startTime, ts, val, tlscheckX509IssuerAttributeValue, tlscheckX509CnAttributeValue, tlscheckX509SanAttributeValue)
@@ -68,6 +68,18 @@ func TestMetricsBuilder(t *testing.T) {
 			defaultMetricsCount := 0
 			allMetricsCount := 0
 
+			allMetricsCount++
+			mb.RecordTlscheckChainTimeLeftDataPoint(ts, 1, "tlscheck.x509.issuer-val", "tlscheck.x509.cn-val", 25)
+
+			allMetricsCount++
+			mb.RecordTlscheckChainValidDataPoint(ts, 1)
+
+			allMetricsCount++
+			mb.RecordTlscheckHostnameValidDataPoint(ts, 1, "tlscheck.server_name-val")
+
+			allMetricsCount++
+			mb.RecordTlscheckRevokedDataPoint(ts, 1, "tlscheck.x509.issuer-val", "tlscheck.x509.cn-val", 25, AttributeTlscheckRevocationSourceOcsp)
+
 			defaultMetricsCount++
 			allMetricsCount++
 			mb.RecordTlscheckTimeLeftDataPoint(ts, 1, "tlscheck.x509.issuer-val", "tlscheck.x509.cn-val", []any{"tlscheck.x509.san-item1", "tlscheck.x509.san-item2"})
@@ -96,6 +108,78 @@ func TestMetricsBuilder(t *testing.T) {
 			validatedMetrics := make(map[string]bool)
 			for i := 0; i < ms.Len(); i++ {
 				switch ms.At(i).Name() {
+				case "tlscheck.chain.time_left":
+					assert.False(t, validatedMetrics["tlscheck.chain.time_left"], "Found a duplicate in the metrics slice: tlscheck.chain.time_left")
+					validatedMetrics["tlscheck.chain.time_left"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Time in seconds until the expiry of each certificate of the chain, as specified by `NotAfter` field in the x.509 certificate. Negative values represent time in seconds since expiration.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("tlscheck.x509.issuer")
+					assert.True(t, ok)
+					assert.Equal(t, "tlscheck.x509.issuer-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tlscheck.x509.cn")
+					assert.True(t, ok)
+					assert.Equal(t, "tlscheck.x509.cn-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tlscheck.x509.chain_index")
+					assert.True(t, ok)
+					assert.EqualValues(t, 25, attrVal.Int())
+				case "tlscheck.chain.valid":
+					assert.False(t, validatedMetrics["tlscheck.chain.valid"], "Found a duplicate in the metrics slice: tlscheck.chain.valid")
+					validatedMetrics["tlscheck.chain.valid"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "1 if the certificate chain is verified against the trusted CAs, otherwise 0.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "tlscheck.hostname.valid":
+					assert.False(t, validatedMetrics["tlscheck.hostname.valid"], "Found a duplicate in the metrics slice: tlscheck.hostname.valid")
+					validatedMetrics["tlscheck.hostname.valid"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "1 if the leaf certificate is valid for the server name, otherwise 0.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("tlscheck.server_name")
+					assert.True(t, ok)
+					assert.Equal(t, "tlscheck.server_name-val", attrVal.Str())
+				case "tlscheck.revoked":
+					assert.False(t, validatedMetrics["tlscheck.revoked"], "Found a duplicate in the metrics slice: tlscheck.revoked")
+					validatedMetrics["tlscheck.revoked"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "1 if the certificate is revoked according to the source, otherwise 0. Not recorded when the source doesn't provide the status of the certificate.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("tlscheck.x509.issuer")
+					assert.True(t, ok)
+					assert.Equal(t, "tlscheck.x509.issuer-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tlscheck.x509.cn")
+					assert.True(t, ok)
+					assert.Equal(t, "tlscheck.x509.cn-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tlscheck.x509.chain_index")
+					assert.True(t, ok)
+					assert.EqualValues(t, 25, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("tlscheck.revocation.source")
+					assert.True(t, ok)
+					assert.Equal(t, "ocsp", attrVal.Str())
 				case "tlscheck.time_left":
 					assert.False(t, validatedMetrics["tlscheck.time_left"], "Found a duplicate in the metrics slice: tlscheck.time_left")
 					validatedMetrics["tlscheck.time_left"] = true
@@ -19,6 +19,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
@@ -33,7 +34,10 @@ var errMissingTargets = errors.New(`No targets specified`)
 type scraper struct {
 	cfg                *Config
 	settings           receiver.Settings
-	getConnectionState func(endpoint string) (tls.ConnectionState, error)
+	getConnectionState func(ctx context.Context, target *CertificateTarget) (tls.ConnectionState, error)
+	crls               *crlCache
+	// roots are the pools of the CA files of the targets, loaded on start
+	roots map[string]*x509.CertPool
 }
 
 // listens to the error channel and combines errors sent from different go routines,
@@ -100,24 +104,56 @@ func validateFilepath(filePath string) error {
 	return nil
 }
 
-func getConnectionState(endpoint string) (tls.ConnectionState, error) {
-	conn, err := tls.Dial("tcp", endpoint, &tls.Config{InsecureSkipVerify: true})
+// serverName returns the name sent with SNI and the leaf certificate is verified against
+func serverName(target *CertificateTarget) string {
+	if target.ServerName != "" {
+		return target.ServerName
+	}
+	host, _, err := net.SplitHostPort(target.Endpoint)
+	if err != nil {
+		return target.Endpoint
+	}
+	return host
+}
+
+func getConnectionState(ctx context.Context, target *CertificateTarget) (tls.ConnectionState, error) {
+	dialer := &net.Dialer{Timeout: target.DialerConfig.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", target.Endpoint)
 	if err != nil {
 		return tls.ConnectionState{}, err
 	}
 	defer conn.Close()
-	return conn.ConnectionState(), nil
+
+	// The dialer timeout also applies to the STARTTLS exchange and the TLS handshake
+	if target.DialerConfig.Timeout > 0 {
+		if err = conn.SetDeadline(time.Now().Add(target.DialerConfig.Timeout)); err != nil {
+			return tls.ConnectionState{}, err
+		}
+	}
+	if target.StartTLS != "" {
+		if err = startTLS(conn, target.StartTLS); err != nil {
+			return tls.ConnectionState{}, err
+		}
+	}
+
+	// The certificates are verified by the scraper, to report the result rather than fail
+	tlsConn := tls.Client(conn, &tls.Config{InsecureSkipVerify: true, ServerName: serverName(target)})
+	if err = tlsConn.HandshakeContext(ctx); err != nil {
+		return tls.ConnectionState{}, err
+	}
+	return tlsConn.ConnectionState(), nil
 }
 
-func (s *scraper) scrapeEndpoint(endpoint string, metrics *pmetric.Metrics, wg *sync.WaitGroup, mux *sync.Mutex, errs chan error) {
+func (s *scraper) scrapeEndpoint(ctx context.Context, target *CertificateTarget, metrics *pmetric.Metrics, wg *sync.WaitGroup, mux *sync.Mutex, errs chan error) {
 	defer wg.Done()
+	endpoint := target.Endpoint
 	if err := validateEndpoint(endpoint); err != nil {
 		s.settings.Logger.Error("Failed to validate endpoint", zap.String("endpoint", endpoint), zap.Error(err))
 		errs <- err
 		return
 	}
 
-	state, err := s.getConnectionState(endpoint)
+	state, err := s.getConnectionState(ctx, target)
 	if err != nil {
 		s.settings.Logger.Error("TCP connection error encountered", zap.String("endpoint", endpoint), zap.Error(err))
 		errs <- err
@@ -132,49 +168,12 @@ func (s *scraper) scrapeEndpoint(endpoint string, metrics *pmetric.Metrics, wg *
 		return
 	}
 
-	cert := state.PeerCertificates[0]
-	issuer := cert.Issuer.String()
-	commonName := cert.Subject.CommonName
-	sans := make([]any, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.URIs)+len(cert.EmailAddresses))
-	i := 0
-	for _, ip := range cert.IPAddresses {
-		sans[i] = ip.String()
-		i++
-	}
-
-	for _, uri := range cert.URIs {
-		sans[i] = uri.String()
-		i++
-	}
-
-	for _, dnsName := range cert.DNSNames {
-		sans[i] = dnsName
-		i++
-	}
-
-	for _, emailAddress := range cert.EmailAddresses {
-		sans[i] = emailAddress
-		i++
-	}
-
-	currentTime := time.Now()
-	timeLeft := cert.NotAfter.Sub(currentTime).Seconds()
-	timeLeftInt := int64(timeLeft)
-	now := pcommon.NewTimestampFromTime(time.Now())
-
-	mux.Lock()
-	defer mux.Unlock()
-
-	mb := metadata.NewMetricsBuilder(s.cfg.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.NewTimestampFromTime(time.Now())))
-	rb := mb.NewResourceBuilder()
-	rb.SetTlscheckTarget(endpoint)
-	mb.RecordTlscheckTimeLeftDataPoint(now, timeLeftInt, issuer, commonName, sans)
-	resourceMetrics := mb.Emit(metadata.WithResource(rb.Emit()))
-	resourceMetrics.ResourceMetrics().At(0).MoveTo(metrics.ResourceMetrics().AppendEmpty())
+	s.recordCertificates(ctx, target, endpoint, state.PeerCertificates, state.OCSPResponse, serverName(target), metrics, mux)
 }
 
-func (s *scraper) scrapeFile(filePath string, metrics *pmetric.Metrics, wg *sync.WaitGroup, mux *sync.Mutex, errs chan error) {
+func (s *scraper) scrapeFile(ctx context.Context, target *CertificateTarget, metrics *pmetric.Metrics, wg *sync.WaitGroup, mux *sync.Mutex, errs chan error) {
 	defer wg.Done()
+	filePath := target.FilePath
 	if err := validateFilepath(filePath); err != nil {
 		s.settings.Logger.Error("Failed to validate certificate file", zap.String("file_path", filePath), zap.Error(err))
 		errs <- err
@@ -226,47 +225,126 @@ func (s *scraper) scrapeFile(filePath string, metrics *pmetric.Metrics, wg *sync
 
 	s.settings.Logger.Debug("Found certificates in chain", zap.String("file_path", filePath), zap.Int("count", len(certs)))
 
-	cert := certs[0] // Use the leaf certificate
-	issuer := cert.Issuer.String()
-	commonName := cert.Subject.CommonName
-	sans := make([]any, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.URIs)+len(cert.EmailAddresses))
-	i := 0
-	for _, ip := range cert.IPAddresses {
-		sans[i] = ip.String()
-		i++
+	s.recordCertificates(ctx, target, filePath, certs, nil, target.ServerName, metrics, mux)
+}
+
+// start loads the CA files of the targets, which are not read again on every scrape
+func (s *scraper) start(_ context.Context, _ component.Host) error {
+	s.roots = make(map[string]*x509.CertPool)
+	for _, target := range s.cfg.Targets {
+		if _, ok := s.roots[target.CAFile]; ok || target.CAFile == "" {
+			continue
+		}
+		roots, err := loadRoots(target.CAFile)
+		if err != nil {
+			return err
+		}
+		s.roots[target.CAFile] = roots
 	}
+	return nil
+}
 
-	for _, uri := range cert.URIs {
-		sans[i] = uri.String()
-		i++
+// recordCertificates verifies the certificates, the leaf first, and records the metrics of
+// the leaf certificate and of every certificate of the chain. The chain is the verified chain up to the trusted root if
+// the certificates are verified, otherwise the certificates as they are.
+func (s *scraper) recordCertificates(ctx context.Context, target *CertificateTarget, targetName string, certs []*x509.Certificate, ocspResponse []byte, serverName string, metrics *pmetric.Metrics, mux *sync.Mutex) {
+	currentTime := time.Now()
+	now := pcommon.NewTimestampFromTime(currentTime)
+	mb := metadata.NewMetricsBuilder(s.cfg.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.NewTimestampFromTime(time.Now())))
+
+	// The certificates of the files are not necessarily server certificates
+	keyUsage := x509.ExtKeyUsageServerAuth
+	if target.FilePath != "" {
+		keyUsage = x509.ExtKeyUsageAny
+	}
+	chain := certs
+	verified, err := verifyChain(certs, s.roots[target.CAFile], keyUsage, currentTime)
+	if err == nil {
+		chain = verified
+	} else {
+		s.settings.Logger.Debug("Failed to verify certificate chain", zap.String("target", targetName), zap.Error(err))
 	}
+	mb.RecordTlscheckChainValidDataPoint(now, boolToInt(err == nil))
 
-	for _, dnsName := range cert.DNSNames {
-		sans[i] = dnsName
-		i++
+	if serverName != "" {
+		hostnameErr := certs[0].VerifyHostname(serverName)
+		if hostnameErr != nil {
+			s.settings.Logger.Debug("Certificate is not valid for the server name", zap.String("target", targetName), zap.Error(hostnameErr))
+		}
+		mb.RecordTlscheckHostnameValidDataPoint(now, boolToInt(hostnameErr == nil), serverName)
 	}
 
-	for _, emailAddress := range cert.EmailAddresses {
-		sans[i] = emailAddress
-		i++
+	leaf := certs[0]
+	mb.RecordTlscheckTimeLeftDataPoint(now, int64(leaf.NotAfter.Sub(currentTime).Seconds()), leaf.Issuer.String(), leaf.Subject.CommonName, subjectAltNames(leaf))
+	for i, cert := range chain {
+		mb.RecordTlscheckChainTimeLeftDataPoint(now, int64(cert.NotAfter.Sub(currentTime).Seconds()), cert.Issuer.String(), cert.Subject.CommonName, int64(i))
 	}
 
-	currentTime := time.Now()
-	timeLeft := cert.NotAfter.Sub(currentTime).Seconds()
-	timeLeftInt := int64(timeLeft)
-	now := pcommon.NewTimestampFromTime(time.Now())
+	if s.cfg.Metrics.TlscheckRevoked.Enabled {
+		s.recordRevocation(ctx, mb, now, targetName, chain, ocspResponse, currentTime)
+	}
 
 	mux.Lock()
 	defer mux.Unlock()
 
-	mb := metadata.NewMetricsBuilder(s.cfg.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.NewTimestampFromTime(time.Now())))
 	rb := mb.NewResourceBuilder()
-	rb.SetTlscheckTarget(filePath)
-	mb.RecordTlscheckTimeLeftDataPoint(now, timeLeftInt, issuer, commonName, sans)
+	rb.SetTlscheckTarget(targetName)
 	resourceMetrics := mb.Emit(metadata.WithResource(rb.Emit()))
 	resourceMetrics.ResourceMetrics().At(0).MoveTo(metrics.ResourceMetrics().AppendEmpty())
 }
 
+// recordRevocation records the revocation status of the leaf certificate in the stapled
+// OCSP response, and of the certificates of the chain in the CRLs of their issuers
+func (s *scraper) recordRevocation(ctx context.Context, mb *metadata.MetricsBuilder, now pcommon.Timestamp, targetName string, chain []*x509.Certificate, ocspResponse []byte, currentTime time.Time) {
+	// The status of a certificate is signed by its issuer, so the root has none
+	for i := 0; i+1 < len(chain); i++ {
+		cert, issuer := chain[i], chain[i+1]
+
+		if i == 0 && len(ocspResponse) > 0 {
+			revoked, err := ocspRevoked(ocspResponse, cert, issuer, currentTime)
+			if err != nil {
+				s.settings.Logger.Debug("Failed to check stapled OCSP response", zap.String("target", targetName), zap.Error(err))
+			} else {
+				mb.RecordTlscheckRevokedDataPoint(now, boolToInt(revoked), cert.Issuer.String(), cert.Subject.CommonName, int64(i), metadata.AttributeTlscheckRevocationSourceOcsp)
+			}
+		}
+
+		if len(cert.CRLDistributionPoints) > 0 {
+			revoked, err := s.crls.revoked(ctx, cert, issuer, currentTime)
+			if err != nil {
+				s.settings.Logger.Debug("Failed to check CRL", zap.String("target", targetName), zap.Error(err))
+			} else {
+				mb.RecordTlscheckRevokedDataPoint(now, boolToInt(revoked), cert.Issuer.String(), cert.Subject.CommonName, int64(i), metadata.AttributeTlscheckRevocationSourceCrl)
+			}
+		}
+	}
+}
+
+// subjectAltNames returns the IP addresses, URIs, DNS names and email addresses of the certificate
+func subjectAltNames(cert *x509.Certificate) []any {
+	sans := make([]any, 0, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.URIs)+len(cert.EmailAddresses))
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	for _, dnsName := range cert.DNSNames {
+		sans = append(sans, dnsName)
+	}
+	for _, emailAddress := range cert.EmailAddresses {
+		sans = append(sans, emailAddress)
+	}
+	return sans
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 	var errs *scrapererror.ScrapeErrors
 
@@ -288,9 +366,9 @@ func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 
 	for _, target := range s.cfg.Targets {
 		if target.FilePath != "" {
-			go s.scrapeFile(target.FilePath, &metrics, &wg, &mux, errChan)
+			go s.scrapeFile(ctx, target, &metrics, &wg, &mux, errChan)
 		} else {
-			go s.scrapeEndpoint(target.Endpoint, &metrics, &wg, &mux, errChan)
+			go s.scrapeEndpoint(ctx, target, &metrics, &wg, &mux, errChan)
 		}
 	}
 
@@ -300,10 +378,11 @@ func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 	return metrics, errs.Combine()
 }
 
-func newScraper(cfg *Config, settings receiver.Settings, getConnectionState func(endpoint string) (tls.ConnectionState, error)) *scraper {
+func newScraper(cfg *Config, settings receiver.Settings, getConnectionState func(ctx context.Context, target *CertificateTarget) (tls.ConnectionState, error)) *scraper {
 	return &scraper{
 		cfg:                cfg,
 		settings:           settings,
 		getConnectionState: getConnectionState,
+		crls:               newCRLCache(),
 	}
 }
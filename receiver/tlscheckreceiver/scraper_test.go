@@ -12,20 +12,25 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"golang.org/x/crypto/ocsp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver/internal/metadata"
 )
 
 //nolint:revive
-func mockGetConnectionStateValid(endpoint string) (tls.ConnectionState, error) {
+func mockGetConnectionStateValid(_ context.Context, _ *CertificateTarget) (tls.ConnectionState, error) {
 	cert := &x509.Certificate{
 		NotBefore: time.Now().Add(-1 * time.Hour),
 		NotAfter:  time.Now().Add(24 * time.Hour),
@@ -39,7 +44,7 @@ func mockGetConnectionStateValid(endpoint string) (tls.ConnectionState, error) {
 }
 
 //nolint:revive
-func mockGetConnectionStateExpired(endpoint string) (tls.ConnectionState, error) {
+func mockGetConnectionStateExpired(_ context.Context, _ *CertificateTarget) (tls.ConnectionState, error) {
 	cert := &x509.Certificate{
 		NotBefore: time.Now().Add(-48 * time.Hour),
 		NotAfter:  time.Now().Add(-24 * time.Hour),
@@ -52,7 +57,7 @@ func mockGetConnectionStateExpired(endpoint string) (tls.ConnectionState, error)
 }
 
 //nolint:revive
-func mockGetConnectionStateNotYetValid(endpoint string) (tls.ConnectionState, error) {
+func mockGetConnectionStateNotYetValid(_ context.Context, _ *CertificateTarget) (tls.ConnectionState, error) {
 	cert := &x509.Certificate{
 		NotBefore: time.Now().Add(24 * time.Hour),
 		NotAfter:  time.Now().Add(48 * time.Hour),
@@ -426,3 +431,110 @@ func TestValidateFilepath(t *testing.T) {
 		})
 	}
 }
+
+func TestScrape_EndpointVerification(t *testing.T) {
+	var crl []byte
+	crlServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write(crl)
+		assert.NoError(t, err)
+	}))
+	defer crlServer.Close()
+
+	p := newTestPKI(t, crlServer.URL+"/intermediate.crl")
+	crl = p.crl(t, p.leaf.SerialNumber)
+	cert := p.tlsCertificate()
+	cert.OCSPStaple = p.ocspResponse(t, ocsp.Good)
+	endpoint := newTLSServer(t, cert, "")
+
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.TlscheckChainValid.Enabled = true
+	mbc.Metrics.TlscheckHostnameValid.Enabled = true
+	mbc.Metrics.TlscheckRevoked.Enabled = true
+	mbc.Metrics.TlscheckChainTimeLeft.Enabled = true
+	cfg := &Config{
+		Targets: []*CertificateTarget{
+			{
+				TCPAddrConfig: confignet.TCPAddrConfig{
+					Endpoint: endpoint,
+				},
+				ServerName: "localhost",
+				CAFile:     p.writeRootFile(t),
+			},
+			{
+				TCPAddrConfig: confignet.TCPAddrConfig{
+					Endpoint: endpoint,
+				},
+				ServerName: "www.example.com",
+			},
+		},
+		MetricsBuilderConfig: mbc,
+	}
+	settings := receivertest.NewNopSettings(receivertest.NopType)
+	s := newScraper(cfg, settings, getConnectionState)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		points := map[string][]map[string]any{}
+		ms := metrics.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			dps := ms.At(j).Gauge().DataPoints()
+			for k := 0; k < dps.Len(); k++ {
+				point := dps.At(k).Attributes().AsRaw()
+				point["value"] = dps.At(k).IntValue()
+				points[ms.At(j).Name()] = append(points[ms.At(j).Name()], point)
+			}
+		}
+
+		// Only the leaf certificate has the time left
+		require.Len(t, points["tlscheck.time_left"], 1)
+		assert.Equal(t, "localhost", points["tlscheck.time_left"][0]["tlscheck.x509.cn"])
+		var cns, indexes []any
+		for _, point := range points["tlscheck.chain.time_left"] {
+			cns = append(cns, point["tlscheck.x509.cn"])
+			indexes = append(indexes, point["tlscheck.x509.chain_index"])
+		}
+		revoked := []map[string]any{
+			{"tlscheck.x509.issuer": "CN=Test Intermediate CA", "tlscheck.x509.cn": "localhost", "tlscheck.x509.chain_index": int64(0), "tlscheck.revocation.source": "ocsp", "value": int64(0)},
+			{"tlscheck.x509.issuer": "CN=Test Intermediate CA", "tlscheck.x509.cn": "localhost", "tlscheck.x509.chain_index": int64(0), "tlscheck.revocation.source": "crl", "value": int64(1)},
+		}
+
+		// The targets are scraped concurrently, in any order
+		if points["tlscheck.hostname.valid"][0]["tlscheck.server_name"] == "localhost" {
+			// Verified against the CA file, with the root in the chain
+			assert.Equal(t, []map[string]any{{"value": int64(1)}}, points["tlscheck.chain.valid"])
+			assert.Equal(t, []map[string]any{{"tlscheck.server_name": "localhost", "value": int64(1)}}, points["tlscheck.hostname.valid"])
+			assert.Equal(t, []any{"localhost", "Test Intermediate CA", "Test Root CA"}, cns)
+			assert.Equal(t, []any{int64(0), int64(1), int64(2)}, indexes)
+			assert.Equal(t, revoked, points["tlscheck.revoked"])
+		} else {
+			// Not verified against the CAs of the system, with the chain as presented
+			assert.Equal(t, []map[string]any{{"value": int64(0)}}, points["tlscheck.chain.valid"])
+			assert.Equal(t, []map[string]any{{"tlscheck.server_name": "www.example.com", "value": int64(0)}}, points["tlscheck.hostname.valid"])
+			assert.Equal(t, []any{"localhost", "Test Intermediate CA"}, cns)
+			assert.Equal(t, []any{int64(0), int64(1)}, indexes)
+			assert.Equal(t, revoked, points["tlscheck.revoked"])
+		}
+	}
+}
+
+func TestStartInvalidCAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	cfg := &Config{
+		Targets: []*CertificateTarget{
+			{
+				TCPAddrConfig: confignet.TCPAddrConfig{
+					Endpoint: "localhost:443",
+				},
+				CAFile: caFile,
+			},
+		},
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+	}
+	s := newScraper(cfg, receivertest.NewNopSettings(receivertest.NopType), mockGetConnectionStateValid)
+	require.ErrorContains(t, s.start(context.Background(), componenttest.NewNopHost()), "no certificates found in CA file")
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// postgresSSLRequestCode is the code of the SSLRequest message of the PostgreSQL protocol
+const postgresSSLRequestCode = 80877103
+
+// ldapStartTLSRequest is the LDAP extended request with the StartTLS OID 1.3.6.1.4.1.1466.20037,
+// BER-encoded with message ID 1
+var ldapStartTLSRequest = []byte{
+	0x30, 0x1d, // LDAPMessage SEQUENCE
+	0x02, 0x01, 0x01, // messageID INTEGER 1
+	0x77, 0x18, // ExtendedRequest [APPLICATION 23]
+	0x80, 0x16, // requestName [0]
+	'1', '.', '3', '.', '6', '.', '1', '.', '4', '.', '1', '.', '1', '4', '6', '6', '.', '2', '0', '0', '3', '7',
+}
+
+// startTLS negotiates the upgrade of the connection to TLS with the protocol. The
+// TLS handshake can start on the connection once it returns without error.
+func startTLS(conn net.Conn, protocol string) error {
+	switch protocol {
+	case startTLSSMTP:
+		return startTLSWithSMTP(conn)
+	case startTLSIMAP:
+		return startTLSWithIMAP(conn)
+	case startTLSPostgres:
+		return startTLSWithPostgres(conn)
+	case startTLSLDAP:
+		return startTLSWithLDAP(conn)
+	}
+	return fmt.Errorf("unsupported starttls protocol %q", protocol)
+}
+
+// startTLSWithSMTP sends the STARTTLS command of RFC 3207
+func startTLSWithSMTP(conn net.Conn) error {
+	// The server doesn't send anything after its last reply until the TLS handshake
+	// starts, so the reader can't buffer bytes of the handshake
+	r := bufio.NewReader(conn)
+
+	if err := readSMTPReply(r, "220"); err != nil {
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	if _, err := io.WriteString(conn, "EHLO tlscheck\r\n"); err != nil {
+		return err
+	}
+	if err := readSMTPReply(r, "250"); err != nil {
+		return fmt.Errorf("smtp EHLO: %w", err)
+	}
+	if _, err := io.WriteString(conn, "STARTTLS\r\n"); err != nil {
+		return err
+	}
+	if err := readSMTPReply(r, "220"); err != nil {
+		return fmt.Errorf("smtp STARTTLS: %w", err)
+	}
+	return nil
+}
+
+// readSMTPReply reads a single or multi-line reply, which must have the code
+func readSMTPReply(r *bufio.Reader, code string) error {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if len(line) < 4 || line[:3] != code {
+			return fmt.Errorf("unexpected reply %q", strings.TrimSpace(line))
+		}
+		// The last line of a reply has a space after the code
+		if line[3] != '-' {
+			return nil
+		}
+	}
+}
+
+// startTLSWithIMAP sends the STARTTLS command of RFC 3501
+func startTLSWithIMAP(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+
+	greeting, err := r.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("imap greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting, "* OK") {
+		return fmt.Errorf("imap greeting: unexpected response %q", strings.TrimSpace(greeting))
+	}
+	if _, err = io.WriteString(conn, "a1 STARTTLS\r\n"); err != nil {
+		return err
+	}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("imap STARTTLS: %w", err)
+		}
+		// Skip the untagged responses
+		if strings.HasPrefix(line, "* ") {
+			continue
+		}
+		if !strings.HasPrefix(line, "a1 OK") {
+			return fmt.Errorf("imap STARTTLS: unexpected response %q", strings.TrimSpace(line))
+		}
+		return nil
+	}
+}
+
+// startTLSWithPostgres sends the SSLRequest message of the PostgreSQL protocol
+func startTLSWithPostgres(conn net.Conn) error {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], postgresSSLRequestCode)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(conn, response); err != nil {
+		return fmt.Errorf("postgres SSLRequest: %w", err)
+	}
+	if response[0] != 'S' {
+		return errors.New("postgres SSLRequest: the server does not support SSL")
+	}
+	return nil
+}
+
+// startTLSWithLDAP sends the StartTLS extended request of RFC 4511
+func startTLSWithLDAP(conn net.Conn) error {
+	if _, err := conn.Write(ldapStartTLSRequest); err != nil {
+		return err
+	}
+
+	// LDAPMessage SEQUENCE
+	message, err := readBERElement(conn, 0x30)
+	if err != nil {
+		return fmt.Errorf("ldap StartTLS: %w", err)
+	}
+	// Skip the messageID INTEGER
+	if len(message) < 2 || message[0] != 0x02 || len(message) < 2+int(message[1]) {
+		return errors.New("ldap StartTLS: invalid message ID")
+	}
+	message = message[2+int(message[1]):]
+	// ExtendedResponse [APPLICATION 24] starting with the resultCode ENUMERATED
+	response, err := readBERElement(bytes.NewReader(message), 0x78)
+	if err != nil {
+		return fmt.Errorf("ldap StartTLS: %w", err)
+	}
+	if len(response) < 3 || response[0] != 0x0a || response[1] != 0x01 {
+		return errors.New("ldap StartTLS: invalid result code")
+	}
+	if response[2] != 0 {
+		return fmt.Errorf("ldap StartTLS: result code %d", response[2])
+	}
+	return nil
+}
+
+// readBERElement reads an element with the tag and returns its contents
+func readBERElement(r io.Reader, tag byte) ([]byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if header[0] != tag {
+		return nil, fmt.Errorf("unexpected tag 0x%02x", header[0])
+	}
+
+	length := int(header[1])
+	if length&0x80 != 0 {
+		// Long form, the low bits are the number of bytes of the length, which is at
+		// most 2 for the small responses of StartTLS
+		n := length & 0x7f
+		if n == 0 || n > 2 {
+			return nil, errors.New("invalid length")
+		}
+		lengthBytes := make([]byte, n)
+		if _, err := io.ReadFull(r, lengthBytes); err != nil {
+			return nil, err
+		}
+		length = 0
+		for _, b := range lengthBytes {
+			length = length<<8 | int(b)
+		}
+	}
+
+	contents := make([]byte, length)
+	if _, err := io.ReadFull(r, contents); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
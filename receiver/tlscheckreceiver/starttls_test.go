@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// ldapStartTLSResponse is a successful StartTLS extended response to the message ID 1
+var ldapStartTLSResponse = []byte{
+	0x30, 0x0c, // LDAPMessage SEQUENCE
+	0x02, 0x01, 0x01, // messageID INTEGER 1
+	0x78, 0x07, // ExtendedResponse [APPLICATION 24]
+	0x0a, 0x01, 0x00, // resultCode ENUMERATED success
+	0x04, 0x00, // matchedDN
+	0x04, 0x00, // diagnosticMessage
+}
+
+// startTLSServers negotiate STARTTLS on the server side of the connection
+var startTLSServers = map[string]func(conn net.Conn) error{
+	startTLSSMTP: func(conn net.Conn) error {
+		r := bufio.NewReader(conn)
+		if _, err := io.WriteString(conn, "220 mail.example.com ESMTP\r\n"); err != nil {
+			return err
+		}
+		if line, _ := r.ReadString('\n'); !strings.HasPrefix(line, "EHLO ") {
+			return fmt.Errorf("unexpected command %q", line)
+		}
+		if _, err := io.WriteString(conn, "250-mail.example.com\r\n250-SIZE 1000000\r\n250 STARTTLS\r\n"); err != nil {
+			return err
+		}
+		if line, _ := r.ReadString('\n'); line != "STARTTLS\r\n" {
+			return fmt.Errorf("unexpected command %q", line)
+		}
+		_, err := io.WriteString(conn, "220 Ready to start TLS\r\n")
+		return err
+	},
+	startTLSIMAP: func(conn net.Conn) error {
+		r := bufio.NewReader(conn)
+		if _, err := io.WriteString(conn, "* OK [CAPABILITY IMAP4rev1 STARTTLS] ready\r\n"); err != nil {
+			return err
+		}
+		if line, _ := r.ReadString('\n'); line != "a1 STARTTLS\r\n" {
+			return fmt.Errorf("unexpected command %q", line)
+		}
+		_, err := io.WriteString(conn, "a1 OK Begin TLS negotiation now\r\n")
+		return err
+	},
+	startTLSPostgres: func(conn net.Conn) error {
+		request := make([]byte, 8)
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return err
+		}
+		if binary.BigEndian.Uint32(request[4:]) != postgresSSLRequestCode {
+			return errors.New("not an SSLRequest")
+		}
+		_, err := conn.Write([]byte{'S'})
+		return err
+	},
+	startTLSLDAP: func(conn net.Conn) error {
+		request := make([]byte, len(ldapStartTLSRequest))
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return err
+		}
+		if string(request) != string(ldapStartTLSRequest) {
+			return errors.New("not a StartTLS request")
+		}
+		_, err := conn.Write(ldapStartTLSResponse)
+		return err
+	},
+}
+
+// newTLSServer serves TLS with the certificate on a local listener, after negotiating
+// STARTTLS with the protocol if set, and returns its address
+func newTLSServer(t *testing.T, cert tls.Certificate, protocol string) string {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if protocol != "" {
+					if err := startTLSServers[protocol](conn); err != nil {
+						return
+					}
+				}
+				tlsConn := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{cert}})
+				_ = tlsConn.Handshake()
+				// Wait for the client to close the connection
+				_, _ = io.Copy(io.Discard, tlsConn)
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestGetConnectionStateStartTLS(t *testing.T) {
+	p := newTestPKI(t, "")
+
+	for _, protocol := range []string{"", startTLSSMTP, startTLSIMAP, startTLSPostgres, startTLSLDAP} {
+		t.Run("starttls="+protocol, func(t *testing.T) {
+			endpoint := newTLSServer(t, p.tlsCertificate(), protocol)
+			target := &CertificateTarget{
+				TCPAddrConfig: confignet.TCPAddrConfig{
+					Endpoint:     endpoint,
+					DialerConfig: confignet.DialerConfig{Timeout: 5 * time.Second},
+				},
+				StartTLS: protocol,
+			}
+
+			state, err := getConnectionState(context.Background(), target)
+			require.NoError(t, err)
+			require.Len(t, state.PeerCertificates, 2)
+			assert.Equal(t, p.leaf.Raw, state.PeerCertificates[0].Raw)
+		})
+	}
+}
+
+func TestGetConnectionStateStartTLSRejected(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		_, _ = io.WriteString(conn, "220 mail.example.com ESMTP\r\n")
+		_, _ = r.ReadString('\n')
+		_, _ = io.WriteString(conn, "250 mail.example.com\r\n")
+		_, _ = r.ReadString('\n')
+		_, _ = io.WriteString(conn, "454 TLS not available\r\n")
+	}()
+
+	target := &CertificateTarget{
+		TCPAddrConfig: confignet.TCPAddrConfig{
+			Endpoint:     listener.Addr().String(),
+			DialerConfig: confignet.DialerConfig{Timeout: 5 * time.Second},
+		},
+		StartTLS: startTLSSMTP,
+	}
+	_, err = getConnectionState(context.Background(), target)
+	assert.EqualError(t, err, `smtp STARTTLS: unexpected reply "454 TLS not available"`)
+}
+
+func TestGetConnectionStateServerName(t *testing.T) {
+	p := newTestPKI(t, "")
+	serverNames := make(chan string, 1)
+	listener, err := tls.Listen("tcp", "localhost:0", &tls.Config{
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			serverNames <- hello.ServerName
+			cert := p.tlsCertificate()
+			return &cert, nil
+		},
+	})
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.(*tls.Conn).Handshake()
+	}()
+
+	target := &CertificateTarget{
+		TCPAddrConfig: confignet.TCPAddrConfig{Endpoint: listener.Addr().String()},
+		ServerName:    "www.example.com",
+	}
+	_, err = getConnectionState(context.Background(), target)
+	require.NoError(t, err)
+	assert.Equal(t, "www.example.com", <-serverNames)
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// Limits of the requests and of the cache of the CRLs
+const (
+	crlRequestTimeout = 10 * time.Second
+	maxCRLSize        = 10 << 20
+	maxCachedCRLs     = 100
+)
+
+// loadRoots returns the pool of the CAs of the file, or nil to verify against the CAs of the system
+func loadRoots(caFile string) (*x509.CertPool, error) {
+	if caFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+	}
+	return roots, nil
+}
+
+// verifyChain verifies the certificates, the leaf first followed by the intermediates,
+// against the roots for the key usage and returns the verified chain from the leaf to the root
+func verifyChain(certs []*x509.Certificate, roots *x509.CertPool, keyUsage x509.ExtKeyUsage, now time.Time) ([]*x509.Certificate, error) {
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	chains, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{keyUsage},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chains[0], nil
+}
+
+// ocspRevoked returns whether the certificate is revoked according to the OCSP response
+// signed by its issuer or by a responder the issuer delegated to
+func ocspRevoked(response []byte, cert, issuer *x509.Certificate, now time.Time) (bool, error) {
+	resp, err := ocsp.ParseResponseForCert(response, cert, issuer)
+	if err != nil {
+		return false, fmt.Errorf("invalid OCSP response: %w", err)
+	}
+	if !resp.NextUpdate.IsZero() && now.After(resp.NextUpdate) {
+		return false, fmt.Errorf("OCSP response expired at %s", resp.NextUpdate)
+	}
+	switch resp.Status {
+	case ocsp.Good:
+		return false, nil
+	case ocsp.Revoked:
+		return true, nil
+	default:
+		return false, errors.New("OCSP response has an unknown status")
+	}
+}
+
+// crlCache fetches the CRLs of the distribution points of the certificates, and keeps
+// up to maxCachedCRLs of them until their next update
+type crlCache struct {
+	client *http.Client
+
+	mu    sync.Mutex
+	lists map[string]*x509.RevocationList
+}
+
+func newCRLCache() *crlCache {
+	return &crlCache{
+		client: &http.Client{Timeout: crlRequestTimeout},
+		lists:  map[string]*x509.RevocationList{},
+	}
+}
+
+// revoked returns whether the certificate is revoked according to the CRL of the
+// first of its distribution points that is available
+func (c *crlCache) revoked(ctx context.Context, cert, issuer *x509.Certificate, now time.Time) (bool, error) {
+	var errs error
+	for _, url := range cert.CRLDistributionPoints {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			continue
+		}
+		list, err := c.get(ctx, url, issuer, now)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		for _, entry := range list.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	if errs == nil {
+		errs = errors.New("no HTTP CRL distribution point")
+	}
+	return false, errs
+}
+
+// get returns the CRL at the URL, fetched again once it reaches its next update
+func (c *crlCache) get(ctx context.Context, url string, issuer *x509.Certificate, now time.Time) (*x509.RevocationList, error) {
+	c.mu.Lock()
+	list, ok := c.lists[url]
+	c.mu.Unlock()
+	if ok && now.Before(list.NextUpdate) && list.CheckSignatureFrom(issuer) == nil {
+		return list, nil
+	}
+
+	list, err := c.fetch(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch CRL %s: %w", url, err)
+	}
+	if err = list.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("invalid signature of CRL %s: %w", url, err)
+	}
+	if !list.NextUpdate.IsZero() && now.After(list.NextUpdate) {
+		return nil, fmt.Errorf("CRL %s expired at %s", url, list.NextUpdate)
+	}
+
+	c.mu.Lock()
+	c.store(url, list, now)
+	c.mu.Unlock()
+	return list, nil
+}
+
+// store caches the CRL, after removing the CRLs past their next update and, if the cache
+// is still full, another CRL
+func (c *crlCache) store(url string, list *x509.RevocationList, now time.Time) {
+	for cachedURL, cached := range c.lists {
+		if !now.Before(cached.NextUpdate) {
+			delete(c.lists, cachedURL)
+		}
+	}
+	if _, ok := c.lists[url]; !ok && len(c.lists) >= maxCachedCRLs {
+		for cachedURL := range c.lists {
+			delete(c.lists, cachedURL)
+			break
+		}
+	}
+	c.lists[url] = list
+}
+
+func (c *crlCache) fetch(ctx context.Context, url string) (*x509.RevocationList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCRLSize))
+	if err != nil {
+		return nil, err
+	}
+
+	// CRLs are usually DER-encoded, but some distribution points serve them PEM-encoded
+	if bytes.HasPrefix(data, []byte("-----BEGIN")) {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("invalid PEM")
+		}
+		data = block.Bytes
+	}
+	return x509.ParseRevocationList(data)
+}
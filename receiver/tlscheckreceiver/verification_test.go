@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+// testPKI is a root CA, an intermediate CA, and a leaf certificate for localhost
+type testPKI struct {
+	root, intermediate, leaf          *x509.Certificate
+	rootKey, intermediateKey, leafKey crypto.Signer
+}
+
+func newTestKey(t *testing.T) crypto.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newTestCert(t *testing.T, template, parent *x509.Certificate, key, parentKey crypto.Signer) *x509.Certificate {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// newTestPKI creates the certificates, with the CRL distribution point of the
+// intermediate CA in the leaf certificate if crlURL is set
+func newTestPKI(t *testing.T, crlURL string) *testPKI {
+	now := time.Now()
+	p := &testPKI{rootKey: newTestKey(t), intermediateKey: newTestKey(t), leafKey: newTestKey(t)}
+
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	p.root = newTestCert(t, rootTemplate, rootTemplate, p.rootKey, p.rootKey)
+
+	intermediateTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(180 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	p.intermediate = newTestCert(t, intermediateTemplate, p.root, p.intermediateKey, p.rootKey)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(30 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if crlURL != "" {
+		leafTemplate.CRLDistributionPoints = []string{crlURL}
+	}
+	p.leaf = newTestCert(t, leafTemplate, p.intermediate, p.leafKey, p.intermediateKey)
+
+	return p
+}
+
+// writeRootFile writes the root CA to a PEM file and returns its path
+func (p *testPKI) writeRootFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.root.Raw}), 0o600))
+	return path
+}
+
+// tlsCertificate returns the certificate of a server presenting the leaf and the intermediate CA
+func (p *testPKI) tlsCertificate() tls.Certificate {
+	return tls.Certificate{
+		Certificate: [][]byte{p.leaf.Raw, p.intermediate.Raw},
+		PrivateKey:  p.leafKey,
+		Leaf:        p.leaf,
+	}
+}
+
+// ocspResponse returns an OCSP response for the leaf signed by the intermediate CA
+func (p *testPKI) ocspResponse(t *testing.T, status int) []byte {
+	now := time.Now()
+	template := ocsp.Response{
+		Status:       status,
+		SerialNumber: p.leaf.SerialNumber,
+		ThisUpdate:   now.Add(-time.Hour),
+		NextUpdate:   now.Add(time.Hour),
+	}
+	if status == ocsp.Revoked {
+		template.RevokedAt = now.Add(-time.Minute)
+	}
+	resp, err := ocsp.CreateResponse(p.intermediate, p.intermediate, template, p.intermediateKey)
+	require.NoError(t, err)
+	return resp
+}
+
+// crl returns the CRL of the intermediate CA revoking the serial numbers
+func (p *testPKI) crl(t *testing.T, revoked ...*big.Int) []byte {
+	now := time.Now()
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: now.Add(-time.Hour),
+		NextUpdate: now.Add(time.Hour),
+	}
+	for _, serial := range revoked {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: now.Add(-time.Minute),
+		})
+	}
+	crl, err := x509.CreateRevocationList(rand.Reader, template, p.intermediate, p.intermediateKey)
+	require.NoError(t, err)
+	return crl
+}
+
+func TestVerifyChain(t *testing.T) {
+	p := newTestPKI(t, "")
+	now := time.Now()
+
+	roots, err := loadRoots(p.writeRootFile(t))
+	require.NoError(t, err)
+
+	chain, err := verifyChain([]*x509.Certificate{p.leaf, p.intermediate}, roots, x509.ExtKeyUsageServerAuth, now)
+	require.NoError(t, err)
+	assert.Equal(t, []*x509.Certificate{p.leaf, p.intermediate, p.root}, chain)
+
+	// Missing intermediate
+	_, err = verifyChain([]*x509.Certificate{p.leaf}, roots, x509.ExtKeyUsageServerAuth, now)
+	assert.Error(t, err)
+
+	// Untrusted root
+	other := newTestPKI(t, "")
+	otherRoots, err := loadRoots(other.writeRootFile(t))
+	require.NoError(t, err)
+	_, err = verifyChain([]*x509.Certificate{p.leaf, p.intermediate}, otherRoots, x509.ExtKeyUsageServerAuth, now)
+	assert.Error(t, err)
+
+	// Expired
+	_, err = verifyChain([]*x509.Certificate{p.leaf, p.intermediate}, roots, x509.ExtKeyUsageServerAuth, now.Add(60*24*time.Hour))
+	assert.Error(t, err)
+
+	// Not a server certificate
+	clientTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(4),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(30 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	client := newTestCert(t, clientTemplate, p.intermediate, newTestKey(t), p.intermediateKey)
+	_, err = verifyChain([]*x509.Certificate{client, p.intermediate}, roots, x509.ExtKeyUsageServerAuth, now)
+	assert.Error(t, err)
+	_, err = verifyChain([]*x509.Certificate{client, p.intermediate}, roots, x509.ExtKeyUsageAny, now)
+	assert.NoError(t, err)
+
+	_, err = loadRoots(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.ErrorContains(t, err, "failed to read CA file")
+}
+
+func TestOCSPRevoked(t *testing.T) {
+	p := newTestPKI(t, "")
+	now := time.Now()
+
+	revoked, err := ocspRevoked(p.ocspResponse(t, ocsp.Good), p.leaf, p.intermediate, now)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = ocspRevoked(p.ocspResponse(t, ocsp.Revoked), p.leaf, p.intermediate, now)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	_, err = ocspRevoked(p.ocspResponse(t, ocsp.Unknown), p.leaf, p.intermediate, now)
+	assert.ErrorContains(t, err, "unknown status")
+
+	_, err = ocspRevoked(p.ocspResponse(t, ocsp.Good), p.leaf, p.intermediate, now.Add(2*time.Hour))
+	assert.ErrorContains(t, err, "OCSP response expired")
+
+	// Signed by another CA
+	_, err = ocspRevoked(p.ocspResponse(t, ocsp.Good), p.leaf, newTestPKI(t, "").intermediate, now)
+	assert.ErrorContains(t, err, "invalid OCSP response")
+}
+
+func TestCRLCache(t *testing.T) {
+	var requests int
+	var crl []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, err := w.Write(crl)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	p := newTestPKI(t, server.URL+"/intermediate.crl")
+	cache := newCRLCache()
+	now := time.Now()
+
+	crl = p.crl(t, big.NewInt(42))
+	revoked, err := cache.revoked(context.Background(), p.leaf, p.intermediate, now)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// The CRL is cached until its next update
+	crl = p.crl(t, p.leaf.SerialNumber)
+	revoked, err = cache.revoked(context.Background(), p.leaf, p.intermediate, now)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	assert.Equal(t, 1, requests)
+
+	// The CRL is fetched again after its next update, and the new CRL is expired too at that time
+	_, err = cache.revoked(context.Background(), p.leaf, p.intermediate, now.Add(90*time.Minute))
+	assert.ErrorContains(t, err, "expired")
+	assert.Equal(t, 2, requests)
+
+	// PEM-encoded CRL
+	crl = pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: p.crl(t, p.leaf.SerialNumber)})
+	cache = newCRLCache()
+	revoked, err = cache.revoked(context.Background(), p.leaf, p.intermediate, now)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// Signed by another CA
+	_, err = newCRLCache().revoked(context.Background(), p.leaf, newTestPKI(t, "").intermediate, now)
+	assert.ErrorContains(t, err, "invalid signature of CRL")
+
+	// No distribution point
+	_, err = cache.revoked(context.Background(), p.intermediate, p.root, now)
+	assert.ErrorContains(t, err, "no HTTP CRL distribution point")
+}
+
+func TestCRLCacheStore(t *testing.T) {
+	now := time.Now()
+	cache := newCRLCache()
+	current := &x509.RevocationList{NextUpdate: now.Add(time.Hour)}
+
+	// The CRLs past their next update are removed
+	cache.store("expired", &x509.RevocationList{NextUpdate: now.Add(-time.Minute)}, now)
+	cache.store("current", current, now)
+	assert.Equal(t, map[string]*x509.RevocationList{"current": current}, cache.lists)
+
+	// The cache is bounded
+	for i := range 2 * maxCachedCRLs {
+		cache.store(strconv.Itoa(i), current, now)
+	}
+	assert.Len(t, cache.lists, maxCachedCRLs)
+}
@@ -5,19 +5,43 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.uber.org/multierr"
 )
 
+// Supported types of request signatures
+const (
+	signatureTypeGitHub = "github"
+	signatureTypeStripe = "stripe"
+	signatureTypeSlack  = "slack"
+	signatureTypeHMAC   = "hmac"
+)
+
+// Supported formats of request bodies
+const (
+	bodyFormatRaw    = "raw"
+	bodyFormatJSON   = "json"
+	bodyFormatNDJSON = "ndjson"
+)
+
 var (
 	errMissingEndpointFromConfig   = errors.New("missing receiver server endpoint from config")
 	errReadTimeoutExceedsMaxValue  = errors.New("the duration specified for read_timeout exceeds the maximum allowed value of 10s")
 	errWriteTimeoutExceedsMaxValue = errors.New("the duration specified for write_timeout exceeds the maximum allowed value of 10s")
 	errRequiredHeader              = errors.New("both key and value are required to assign a required_header")
 	errHeaderAttributeRegexCompile = errors.New("regex for header_attribute_regex failed to compile")
+	errMissingSignatureSecret      = errors.New("a secret is required to validate signatures")
+	errMissingSignatureHeader      = errors.New("a header is required to validate signatures of type hmac")
+	errHMACOnlySetting             = errors.New("header, algorithm, encoding and prefix are only supported by signatures of type hmac")
+	errNegativeTolerance           = errors.New("the signature tolerance cannot be negative")
+	errResponseStatusCode          = errors.New("the response status code must be a 2xx status code")
+	errInvalidPath                 = errors.New("paths must start with '/'")
 )
 
 // Config defines configuration for the Generic Webhook receiver.
@@ -31,6 +55,10 @@ type Config struct {
 	SplitLogsAtNewLine         bool                     `mapstructure:"split_logs_at_newline"`         // optional setting to split logs into multiple log records
 	ConvertHeadersToAttributes bool                     `mapstructure:"convert_headers_to_attributes"` // optional to convert all headers to attributes
 	HeaderAttributeRegex       string                   `mapstructure:"header_attribute_regex"`        // optional to convert headers matching a regex to log attributes
+	Signature                  SignatureConfig          `mapstructure:"signature"`                     // optional setting to validate the HMAC signature of requests
+	BodyFormat                 string                   `mapstructure:"body_format"`                   // format of the request bodies: raw, json or ndjson. Default is raw.
+	Response                   ResponseConfig           `mapstructure:"response"`                      // response to successful requests
+	Paths                      []PathConfig             `mapstructure:"paths"`                         // optional paths to serve instead of path, each with its own settings
 }
 
 type RequiredHeader struct {
@@ -38,6 +66,91 @@ type RequiredHeader struct {
 	Value string `mapstructure:"value"`
 }
 
+// SignatureConfig defines how the HMAC signature of the requests is validated.
+type SignatureConfig struct {
+	// Type of the signature: github, stripe, slack or hmac. Signatures are not validated if empty.
+	Type string `mapstructure:"type"`
+	// Secret shared with the sender of the requests.
+	Secret configopaque.String `mapstructure:"secret"`
+	// Tolerance is the maximum age of the timestamp of signatures including one, to prevent
+	// replay attacks. Default is 5m.
+	Tolerance time.Duration `mapstructure:"tolerance"`
+
+	// The following settings only apply to signatures of type hmac.
+
+	// Header containing the signature.
+	Header string `mapstructure:"header"`
+	// Algorithm of the hash function: sha1, sha256 or sha512. Default is sha256.
+	Algorithm string `mapstructure:"algorithm"`
+	// Encoding of the signature: hex or base64. Default is hex.
+	Encoding string `mapstructure:"encoding"`
+	// Prefix of the signature in the header, such as "sha256=".
+	Prefix string `mapstructure:"prefix"`
+}
+
+// ResponseConfig defines the response to successful requests.
+type ResponseConfig struct {
+	StatusCode  int    `mapstructure:"status_code"`  // Default is 200.
+	Body        string `mapstructure:"body"`         // Default is empty.
+	ContentType string `mapstructure:"content_type"` // Content-Type header of the body.
+}
+
+// PathConfig defines a path accepting events. The settings left unset default to the
+// ones of the receiver.
+type PathConfig struct {
+	Path       string            `mapstructure:"path"`
+	Attributes map[string]string `mapstructure:"attributes"` // resource attributes of the logs of the path
+	Signature  *SignatureConfig  `mapstructure:"signature"`
+	BodyFormat string            `mapstructure:"body_format"`
+	Response   *ResponseConfig   `mapstructure:"response"`
+}
+
+func (cfg *SignatureConfig) validate() error {
+	var errs error
+	switch cfg.Type {
+	case "":
+		return nil
+	case signatureTypeGitHub, signatureTypeStripe, signatureTypeSlack:
+		if cfg.Header != "" || cfg.Algorithm != "" || cfg.Encoding != "" || cfg.Prefix != "" {
+			errs = multierr.Append(errs, errHMACOnlySetting)
+		}
+	case signatureTypeHMAC:
+		if cfg.Header == "" {
+			errs = multierr.Append(errs, errMissingSignatureHeader)
+		}
+		if _, ok := hashFunctions[strings.ToLower(cfg.Algorithm)]; !ok && cfg.Algorithm != "" {
+			errs = multierr.Append(errs, fmt.Errorf("unsupported signature algorithm %q", cfg.Algorithm))
+		}
+		if cfg.Encoding != "" && cfg.Encoding != "hex" && cfg.Encoding != "base64" {
+			errs = multierr.Append(errs, fmt.Errorf("unsupported signature encoding %q", cfg.Encoding))
+		}
+	default:
+		return fmt.Errorf("unsupported signature type %q", cfg.Type)
+	}
+	if cfg.Secret == "" {
+		errs = multierr.Append(errs, errMissingSignatureSecret)
+	}
+	if cfg.Tolerance < 0 {
+		errs = multierr.Append(errs, errNegativeTolerance)
+	}
+	return errs
+}
+
+func (cfg *ResponseConfig) validate() error {
+	if cfg.StatusCode != 0 && (cfg.StatusCode < 200 || cfg.StatusCode > 299) {
+		return errResponseStatusCode
+	}
+	return nil
+}
+
+func validateBodyFormat(format string) error {
+	switch format {
+	case "", bodyFormatRaw, bodyFormatJSON, bodyFormatNDJSON:
+		return nil
+	}
+	return fmt.Errorf("unsupported body_format %q", format)
+}
+
 func (cfg *Config) Validate() error {
 	var errs error
 
@@ -83,5 +196,33 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	errs = multierr.Append(errs, cfg.Signature.validate())
+	errs = multierr.Append(errs, validateBodyFormat(cfg.BodyFormat))
+	errs = multierr.Append(errs, cfg.Response.validate())
+
+	paths := map[string]bool{}
+	for _, p := range cfg.Paths {
+		if !strings.HasPrefix(p.Path, "/") {
+			errs = multierr.Append(errs, fmt.Errorf("path %q: %w", p.Path, errInvalidPath))
+		}
+		if paths[p.Path] {
+			errs = multierr.Append(errs, fmt.Errorf("duplicate path %q", p.Path))
+		}
+		paths[p.Path] = true
+		if p.Signature != nil {
+			if err := p.Signature.validate(); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("path %q: %w", p.Path, err))
+			}
+		}
+		if err := validateBodyFormat(p.BodyFormat); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("path %q: %w", p.Path, err))
+		}
+		if p.Response != nil {
+			if err := p.Response.validate(); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("path %q: %w", p.Path, err))
+			}
+		}
+	}
+
 	return errs
 }
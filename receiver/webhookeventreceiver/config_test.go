@@ -4,8 +4,11 @@
 package webhookeventreceiver
 
 import (
+	"errors"
+	"net/http"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -87,6 +90,106 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:   "Signature without secret",
+			expect: errMissingSignatureSecret,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{Type: signatureTypeGitHub},
+			},
+		},
+		{
+			desc:   "Signature of type hmac without header",
+			expect: errMissingSignatureHeader,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{Type: signatureTypeHMAC, Secret: "secret"},
+			},
+		},
+		{
+			desc:   "Signature setting only supported by type hmac",
+			expect: errHMACOnlySetting,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{Type: signatureTypeSlack, Secret: "secret", Header: "X-Signature"},
+			},
+		},
+		{
+			desc:   "Unsupported signature algorithm",
+			expect: errors.New(`unsupported signature algorithm "md5"`),
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{Type: signatureTypeHMAC, Secret: "secret", Header: "X-Signature", Algorithm: "md5"},
+			},
+		},
+		{
+			desc:   "Negative signature tolerance",
+			expect: errNegativeTolerance,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Signature: SignatureConfig{Type: signatureTypeStripe, Secret: "secret", Tolerance: -time.Minute},
+			},
+		},
+		{
+			desc:   "Unsupported body format",
+			expect: errors.New(`unsupported body_format "xml"`),
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				BodyFormat: "xml",
+			},
+		},
+		{
+			desc:   "Response status code is not 2xx",
+			expect: errResponseStatusCode,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Response: ResponseConfig{StatusCode: http.StatusFound},
+			},
+		},
+		{
+			desc:   "Path does not start with a slash",
+			expect: errInvalidPath,
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Paths: []PathConfig{{Path: "github"}},
+			},
+		},
+		{
+			desc:   "Duplicate paths",
+			expect: errors.New(`duplicate path "/github"`),
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Paths: []PathConfig{{Path: "/github"}, {Path: "/github"}},
+			},
+		},
+		{
+			desc:   "Invalid settings of a path",
+			expect: errors.New(`path "/stripe": a secret is required to validate signatures`),
+			conf: Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:0",
+				},
+				Paths: []PathConfig{{Path: "/stripe", Signature: &SignatureConfig{Type: signatureTypeStripe}}},
+			},
+		},
 		{
 			desc:   "Multiple invalid configs",
 			expect: errs,
@@ -5,6 +5,7 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"net/http"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -44,6 +45,10 @@ func createDefaultConfig() component.Config {
 		WriteTimeout:               defaultWriteTimeout,
 		ConvertHeadersToAttributes: false, // optional, off by default
 		SplitLogsAtNewLine:         false,
+		BodyFormat:                 bodyFormatRaw,
+		Response: ResponseConfig{
+			StatusCode: http.StatusOK,
+		},
 	}
 }
 
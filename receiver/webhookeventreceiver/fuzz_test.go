@@ -39,6 +39,6 @@ func FuzzHandleReq(f *testing.F) {
 		r := receiver.(*eventReceiver)
 
 		w := httptest.NewRecorder()
-		r.handleReq(w, req, httprouter.ParamsFromContext(context.Background()))
+		r.handleReq(r.routes[0])(w, req, httprouter.ParamsFromContext(context.Background()))
 	})
 }
@@ -4,7 +4,6 @@
 package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
 
 import (
-	"bufio"
 	"compress/gzip"
 	"context"
 	"errors"
@@ -45,6 +44,47 @@ type eventReceiver struct {
 	obsrecv             *receiverhelper.ObsReport
 	gzipPool            *sync.Pool
 	includeHeadersRegex *regexp.Regexp
+	routes              []*route
+}
+
+// route is a path accepting events, with the settings of its requests
+type route struct {
+	path       string
+	attributes map[string]string
+	verifier   *signatureVerifier
+	bodyFormat string
+	response   ResponseConfig
+}
+
+// newRoutes returns the route of the path of the config, or the routes of its paths if set
+func newRoutes(cfg *Config) []*route {
+	defaultRoute := route{
+		path:       cfg.Path,
+		verifier:   newSignatureVerifier(cfg.Signature),
+		bodyFormat: cfg.BodyFormat,
+		response:   cfg.Response,
+	}
+	if len(cfg.Paths) == 0 {
+		return []*route{&defaultRoute}
+	}
+
+	routes := make([]*route, 0, len(cfg.Paths))
+	for _, p := range cfg.Paths {
+		rt := defaultRoute
+		rt.path = p.Path
+		rt.attributes = p.Attributes
+		if p.Signature != nil {
+			rt.verifier = newSignatureVerifier(*p.Signature)
+		}
+		if p.BodyFormat != "" {
+			rt.bodyFormat = p.BodyFormat
+		}
+		if p.Response != nil {
+			rt.response = *p.Response
+		}
+		routes = append(routes, &rt)
+	}
+	return routes
 }
 
 func newLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
@@ -83,6 +123,7 @@ func newLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Log
 		obsrecv:             obsrecv,
 		gzipPool:            &sync.Pool{New: func() any { return new(gzip.Reader) }},
 		includeHeadersRegex: includeHeaderRegex,
+		routes:              newRoutes(&cfg),
 	}
 
 	return er, nil
@@ -104,7 +145,9 @@ func (er *eventReceiver) Start(ctx context.Context, host component.Host) error {
 	// set up router.
 	router := httprouter.New()
 
-	router.POST(er.cfg.Path, er.handleReq)
+	for _, rt := range er.routes {
+		router.POST(rt.path, er.handleReq(rt))
+	}
 	router.GET(er.cfg.HealthPath, er.handleHealthCheck)
 
 	// webhook server standup and configuration
@@ -151,64 +194,100 @@ func (er *eventReceiver) Shutdown(_ context.Context) error {
 	return err
 }
 
-// handleReq handles incoming request from webhook. On success returns a 200 response code to the webhook
-func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	ctx := r.Context()
-	ctx = er.obsrecv.StartLogsOp(ctx)
+// handleReq returns the handler of the requests from webhooks to the route. On success it
+// writes the response of the route, a 200 response code by default
+func (er *eventReceiver) handleReq(rt *route) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		ctx = er.obsrecv.StartLogsOp(ctx)
 
-	if r.Method != http.MethodPost {
-		er.failBadReq(ctx, w, http.StatusBadRequest, errInvalidRequestMethod)
-		return
-	}
+		if r.Method != http.MethodPost {
+			er.failBadReq(ctx, w, http.StatusBadRequest, errInvalidRequestMethod)
+			return
+		}
+
+		if er.cfg.RequiredHeader.Key != "" {
+			requiredHeaderValue := r.Header.Get(er.cfg.RequiredHeader.Key)
+			if requiredHeaderValue != er.cfg.RequiredHeader.Value {
+				er.failBadReq(ctx, w, http.StatusUnauthorized, errMissingRequiredHeader)
+				return
+			}
+		}
 
-	if er.cfg.RequiredHeader.Key != "" {
-		requiredHeaderValue := r.Header.Get(er.cfg.RequiredHeader.Key)
-		if requiredHeaderValue != er.cfg.RequiredHeader.Value {
-			er.failBadReq(ctx, w, http.StatusUnauthorized, errMissingRequiredHeader)
+		encoding := r.Header.Get("Content-Encoding")
+		// only support gzip if encoding header is set.
+		if encoding != "" && encoding != "gzip" {
+			er.failBadReq(ctx, w, http.StatusUnsupportedMediaType, errInvalidEncodingType)
 			return
 		}
-	}
 
-	encoding := r.Header.Get("Content-Encoding")
-	// only support gzip if encoding header is set.
-	if encoding != "" && encoding != "gzip" {
-		er.failBadReq(ctx, w, http.StatusUnsupportedMediaType, errInvalidEncodingType)
-		return
-	}
+		if r.ContentLength == 0 {
+			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, nil)
+			er.failBadReq(ctx, w, http.StatusBadRequest, errEmptyResponseBody)
+			return
+		}
 
-	if r.ContentLength == 0 {
-		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, nil)
-		er.failBadReq(ctx, w, http.StatusBadRequest, errEmptyResponseBody)
-	}
+		bodyReader := r.Body
+		// gzip encoded case
+		if encoding == "gzip" || encoding == "x-gzip" {
+			reader := er.gzipPool.Get().(*gzip.Reader)
+			err := reader.Reset(bodyReader)
+			if err != nil {
+				er.failBadReq(ctx, w, http.StatusBadRequest, err)
+				_, _ = io.ReadAll(r.Body)
+				_ = r.Body.Close()
+				return
+			}
+			bodyReader = reader
+			defer er.gzipPool.Put(reader)
+		}
 
-	bodyReader := r.Body
-	// gzip encoded case
-	if encoding == "gzip" || encoding == "x-gzip" {
-		reader := er.gzipPool.Get().(*gzip.Reader)
-		err := reader.Reset(bodyReader)
+		// the whole body is needed to validate its signature before converting it into logs
+		body, err := io.ReadAll(bodyReader)
+		_ = bodyReader.Close()
 		if err != nil {
 			er.failBadReq(ctx, w, http.StatusBadRequest, err)
-			_, _ = io.ReadAll(r.Body)
-			_ = r.Body.Close()
 			return
 		}
-		bodyReader = reader
-		defer er.gzipPool.Put(reader)
-	}
 
-	// send body into a scanner and then convert the request body into a log
-	sc := bufio.NewScanner(bodyReader)
-	ld, numLogs := er.reqToLog(sc, r.Header, r.URL.Query())
-	consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
+		if rt.verifier != nil {
+			if err = rt.verifier.verify(r.Header, body, time.Now()); err != nil {
+				er.failBadReq(ctx, w, http.StatusUnauthorized, err)
+				return
+			}
+		}
+
+		ld, numLogs, err := er.bodyToLog(rt, body, r.Header, r.URL.Query())
+		if err != nil {
+			er.failBadReq(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
 
-	_ = bodyReader.Close()
+		if consumerErr != nil {
+			er.failBadReq(ctx, w, http.StatusInternalServerError, consumerErr)
+		} else {
+			er.writeResponse(w, rt.response)
+		}
+		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), numLogs, consumerErr)
+	}
+}
 
-	if consumerErr != nil {
-		er.failBadReq(ctx, w, http.StatusInternalServerError, consumerErr)
-	} else {
-		w.WriteHeader(http.StatusOK)
+// write the configured response on a successful request.
+func (er *eventReceiver) writeResponse(w http.ResponseWriter, response ResponseConfig) {
+	if response.ContentType != "" {
+		w.Header().Set("Content-Type", response.ContentType)
+	}
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	if response.Body != "" {
+		if _, err := io.WriteString(w, response.Body); err != nil {
+			er.settings.Logger.Warn("failed to write response", zap.Error(err))
+		}
 	}
-	er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), numLogs, consumerErr)
 }
 
 // Simple healthcheck endpoint.
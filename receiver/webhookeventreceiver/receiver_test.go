@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -206,7 +207,7 @@ func TestHandleReq(t *testing.T) {
 			}()
 
 			w := httptest.NewRecorder()
-			r.handleReq(w, test.req, httprouter.ParamsFromContext(context.Background()))
+			r.handleReq(r.routes[0])(w, test.req, httprouter.ParamsFromContext(context.Background()))
 
 			response := w.Result()
 			_, err = io.ReadAll(response.Body)
@@ -288,7 +289,7 @@ func TestFailedReq(t *testing.T) {
 			}()
 
 			w := httptest.NewRecorder()
-			r.handleReq(w, test.req, httprouter.ParamsFromContext(context.Background()))
+			r.handleReq(r.routes[0])(w, test.req, httprouter.ParamsFromContext(context.Background()))
 
 			response := w.Result()
 			require.Equal(t, test.status, response.StatusCode)
@@ -315,3 +316,85 @@ func TestHealthCheck(t *testing.T) {
 	response := w.Result()
 	require.Equal(t, http.StatusOK, response.StatusCode)
 }
+
+func TestHandleReqRoutes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.BodyFormat = bodyFormatNDJSON
+	cfg.Paths = []PathConfig{
+		{
+			Path:       "/github",
+			Attributes: map[string]string{"webhook.source": "github"},
+			Signature:  &SignatureConfig{Type: signatureTypeGitHub, Secret: testSecret},
+			BodyFormat: bodyFormatJSON,
+			Response: &ResponseConfig{
+				StatusCode:  http.StatusAccepted,
+				Body:        `{"ok":true}`,
+				ContentType: "application/json",
+			},
+		},
+		{
+			Path:       "/batch",
+			Attributes: map[string]string{"webhook.source": "batch"},
+		},
+	}
+
+	sink := new(consumertest.LogsSink)
+	receiver, err := newLogsReceiver(receivertest.NewNopSettings(metadata.Type), *cfg, sink)
+	require.NoError(t, err, "Failed to create receiver")
+
+	r := receiver.(*eventReceiver)
+	require.Len(t, r.routes, 2)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()), "Failed to start receiver")
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()), "Failed to shutdown receiver")
+	}()
+
+	send := func(rt *route, body string, header http.Header) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "http://localhost"+rt.path, strings.NewReader(body))
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		r.handleReq(rt)(w, req, nil)
+		return w.Result()
+	}
+
+	// signed JSON event
+	body := `{"action":"opened","number":42}`
+	response := send(r.routes[0], body, http.Header{gitHubSignatureHeader: {"sha256=" + hmacHex(sha256.New, body)}})
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+	require.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	responseBody, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"ok":true}`, string(responseBody))
+
+	require.Len(t, sink.AllLogs(), 1)
+	logs := sink.AllLogs()[0]
+	source, ok := logs.ResourceLogs().At(0).Resource().Attributes().Get("webhook.source")
+	require.True(t, ok)
+	require.Equal(t, "github", source.Str())
+	require.Equal(t, 1, logs.LogRecordCount())
+	require.Equal(t, map[string]any{"action": "opened", "number": int64(42)},
+		logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw())
+
+	// invalid signature
+	response = send(r.routes[0], body, http.Header{gitHubSignatureHeader: {"sha256=" + hmacHex(sha256.New, "another body")}})
+	require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	require.Len(t, sink.AllLogs(), 1)
+
+	// NDJSON events with the default response
+	response = send(r.routes[1], "{\"id\":1}\n{\"id\":2}\n", nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Len(t, sink.AllLogs(), 2)
+	logs = sink.AllLogs()[1]
+	source, ok = logs.ResourceLogs().At(0).Resource().Attributes().Get("webhook.source")
+	require.True(t, ok)
+	require.Equal(t, "batch", source.Str())
+	require.Equal(t, 2, logs.LogRecordCount())
+
+	// invalid JSON
+	response = send(r.routes[1], "{\"id\":1}\nnot json\n", nil)
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	require.Len(t, sink.AllLogs(), 2)
+}
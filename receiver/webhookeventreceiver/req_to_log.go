@@ -5,6 +5,11 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -21,6 +26,32 @@ const (
 	headerNamespace = "header"
 )
 
+// bodyToLog converts the request body into logs according to the body format of the route
+func (er *eventReceiver) bodyToLog(rt *route,
+	body []byte,
+	headers http.Header,
+	query url.Values,
+) (plog.Logs, int, error) {
+	var log plog.Logs
+	var numLogs int
+	switch rt.bodyFormat {
+	case bodyFormatJSON, bodyFormatNDJSON:
+		var err error
+		log, numLogs, err = er.jsonToLog(body, rt.bodyFormat == bodyFormatNDJSON, headers, query)
+		if err != nil {
+			return log, 0, err
+		}
+	default:
+		log, numLogs = er.reqToLog(bufio.NewScanner(bytes.NewReader(body)), headers, query)
+	}
+
+	attributes := log.ResourceLogs().At(0).Resource().Attributes()
+	for k, v := range rt.attributes {
+		attributes.PutStr(k, v)
+	}
+	return log, numLogs, nil
+}
+
 func (er *eventReceiver) reqToLog(sc *bufio.Scanner,
 	headers http.Header,
 	query url.Values,
@@ -41,6 +72,96 @@ func (er *eventReceiver) reqToLog(sc *bufio.Scanner,
 		sc.Split(split)
 	}
 
+	log, scopeLog := er.newLogs(query)
+	for sc.Scan() {
+		logRecord := er.appendLogRecord(scopeLog, headers)
+		line := sc.Text()
+		logRecord.Body().SetStr(line)
+	}
+
+	return log, scopeLog.LogRecords().Len()
+}
+
+// jsonToLog converts a JSON or NDJSON body into logs with structured bodies. A JSON
+// array creates a log record per element, as webhooks batch events that way.
+func (er *eventReceiver) jsonToLog(body []byte,
+	ndjson bool,
+	headers http.Header,
+	query url.Values,
+) (plog.Logs, int, error) {
+	var values []any
+	if ndjson {
+		for i, line := range bytes.Split(body, []byte("\n")) {
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+			value, err := decodeJSON(line)
+			if err != nil {
+				return plog.NewLogs(), 0, fmt.Errorf("invalid JSON on line %d: %w", i+1, err)
+			}
+			values = append(values, value)
+		}
+	} else {
+		value, err := decodeJSON(body)
+		if err != nil {
+			return plog.NewLogs(), 0, fmt.Errorf("invalid JSON body: %w", err)
+		}
+		if array, ok := value.([]any); ok {
+			values = array
+		} else {
+			values = []any{value}
+		}
+	}
+
+	log, scopeLog := er.newLogs(query)
+	for _, value := range values {
+		logRecord := er.appendLogRecord(scopeLog, headers)
+		if err := logRecord.Body().FromRaw(value); err != nil {
+			return plog.NewLogs(), 0, err
+		}
+	}
+
+	return log, scopeLog.LogRecords().Len(), nil
+}
+
+// decodeJSON decodes a single JSON value, keeping integers as int64
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return convertJSONNumbers(value), nil
+}
+
+// convertJSONNumbers replaces the numbers with int64 or float64 values supported by pdata
+func convertJSONNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, e := range v {
+			v[k] = convertJSONNumbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = convertJSONNumbers(e)
+		}
+	}
+	return value
+}
+
+// newLogs returns logs with the query parameters as resource attributes and the scope of the receiver
+func (er *eventReceiver) newLogs(query url.Values) (plog.Logs, plog.ScopeLogs) {
 	log := plog.NewLogs()
 	resourceLog := log.ResourceLogs().AppendEmpty()
 	appendMetadata(resourceLog, query)
@@ -51,17 +172,17 @@ func (er *eventReceiver) reqToLog(sc *bufio.Scanner,
 	scopeLog.Scope().Attributes().PutStr("source", er.settings.ID.String())
 	scopeLog.Scope().Attributes().PutStr("receiver", metadata.Type.String())
 
-	for sc.Scan() {
-		logRecord := scopeLog.LogRecords().AppendEmpty()
-		logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
-		line := sc.Text()
-		logRecord.Body().SetStr(line)
-		if er.includeHeadersRegex != nil {
-			appendHeaders(headers, logRecord, er.includeHeadersRegex)
-		}
-	}
+	return log, scopeLog
+}
 
-	return log, scopeLog.LogRecords().Len()
+// appendLogRecord appends a log record with the headers matching the regex as attributes
+func (er *eventReceiver) appendLogRecord(scopeLog plog.ScopeLogs, headers http.Header) plog.LogRecord {
+	logRecord := scopeLog.LogRecords().AppendEmpty()
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	if er.includeHeadersRegex != nil {
+		appendHeaders(headers, logRecord, er.includeHeadersRegex)
+	}
+	return logRecord
 }
 
 // append query parameters and webhook source as resource attributes
@@ -298,3 +298,75 @@ func processLogRecords(logs plog.Logs, fn func(lr plog.LogRecord)) {
 		}
 	}
 }
+
+func TestJSONToLog(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:8080"
+	receiver, err := newLogsReceiver(receivertest.NewNopSettings(metadata.Type), *cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	eventReceiver := receiver.(*eventReceiver)
+
+	tests := []struct {
+		desc   string
+		body   string
+		ndjson bool
+		expect []any
+		err    string
+	}{
+		{
+			desc:   "JSON object",
+			body:   `{"name":"francis","age":42,"height":1.8,"tags":["a",1],"address":{"city":"newyork"},"active":true,"parent":null}`,
+			expect: []any{map[string]any{"name": "francis", "age": int64(42), "height": 1.8, "tags": []any{"a", int64(1)}, "address": map[string]any{"city": "newyork"}, "active": true, "parent": nil}},
+		},
+		{
+			desc:   "JSON array",
+			body:   `[{"name":"francis"},{"name":"john"}]`,
+			expect: []any{map[string]any{"name": "francis"}, map[string]any{"name": "john"}},
+		},
+		{
+			desc:   "JSON string",
+			body:   `"event"`,
+			expect: []any{"event"},
+		},
+		{
+			desc: "invalid JSON",
+			body: `{"name":`,
+			err:  "invalid JSON body: unexpected EOF",
+		},
+		{
+			desc: "multiple JSON values",
+			body: `{"name":"francis"} {"name":"john"}`,
+			err:  "invalid JSON body: unexpected data after the JSON value",
+		},
+		{
+			desc:   "NDJSON",
+			body:   "{\"name\":\"francis\"}\n\n[1,2]\r\n\"event\"",
+			ndjson: true,
+			expect: []any{map[string]any{"name": "francis"}, []any{int64(1), int64(2)}, "event"},
+		},
+		{
+			desc:   "invalid NDJSON",
+			body:   "{\"name\":\"francis\"}\n{\"name\":\"john\"} a third line",
+			ndjson: true,
+			err:    "invalid JSON on line 2: unexpected data after the JSON value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			reqLog, reqLen, err := eventReceiver.jsonToLog([]byte(test.body), test.ndjson, nil, nil)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(test.expect), reqLen)
+
+			var bodies []any
+			processLogRecords(reqLog, func(lr plog.LogRecord) {
+				bodies = append(bodies, lr.Body().AsRaw())
+			})
+			require.Equal(t, test.expect, bodies)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // some webhooks still sign their requests with HMAC-SHA1
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errMissingSignature          = errors.New("request was missing the signature header")
+	errInvalidSignature          = errors.New("request signature is invalid")
+	errMissingTimestamp          = errors.New("request was missing the signature timestamp")
+	errInvalidTimestamp          = errors.New("request signature timestamp is invalid")
+	errTimestampOutsideTolerance = errors.New("request signature timestamp is outside of the tolerance")
+)
+
+const defaultSignatureTolerance = 5 * time.Minute
+
+// Headers of the signatures of the webhooks of GitHub, Stripe and Slack
+const (
+	gitHubSignatureHeader = "X-Hub-Signature-256"
+	stripeSignatureHeader = "Stripe-Signature"
+	slackSignatureHeader  = "X-Slack-Signature"
+	slackTimestampHeader  = "X-Slack-Request-Timestamp"
+)
+
+var hashFunctions = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// signatureVerifier validates the HMAC signature of the requests
+type signatureVerifier struct {
+	signatureType string
+	secret        []byte
+	tolerance     time.Duration
+	header        string
+	hash          func() hash.Hash
+	encoding      string
+	prefix        string
+}
+
+// newSignatureVerifier returns the verifier of the signatures of the config, or nil
+// if it doesn't validate signatures
+func newSignatureVerifier(cfg SignatureConfig) *signatureVerifier {
+	if cfg.Type == "" {
+		return nil
+	}
+	v := &signatureVerifier{
+		signatureType: cfg.Type,
+		secret:        []byte(cfg.Secret),
+		tolerance:     cfg.Tolerance,
+		header:        cfg.Header,
+		hash:          sha256.New,
+		encoding:      cfg.Encoding,
+		prefix:        cfg.Prefix,
+	}
+	if v.tolerance == 0 {
+		v.tolerance = defaultSignatureTolerance
+	}
+	if cfg.Algorithm != "" {
+		v.hash = hashFunctions[strings.ToLower(cfg.Algorithm)]
+	}
+	return v
+}
+
+// verify returns an error if the signature in the headers isn't the signature of the body
+func (v *signatureVerifier) verify(header http.Header, body []byte, now time.Time) error {
+	switch v.signatureType {
+	case signatureTypeGitHub:
+		return v.verifyGitHub(header, body)
+	case signatureTypeStripe:
+		return v.verifyStripe(header, body, now)
+	case signatureTypeSlack:
+		return v.verifySlack(header, body, now)
+	default:
+		return v.verifyHMAC(header, body)
+	}
+}
+
+// verifyGitHub validates a "sha256=<hex>" signature of the body
+func (v *signatureVerifier) verifyGitHub(header http.Header, body []byte) error {
+	value := header.Get(gitHubSignatureHeader)
+	if value == "" {
+		return errMissingSignature
+	}
+	signature, ok := strings.CutPrefix(value, "sha256=")
+	if !ok || !equalHex(signature, v.sign(body)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// verifyStripe validates a "t=<timestamp>,v1=<hex>" signature of "<timestamp>.<body>". The
+// header may contain several v1 signatures while the secret is rolled.
+func (v *signatureVerifier) verifyStripe(header http.Header, body []byte, now time.Time) error {
+	value := header.Get(stripeSignatureHeader)
+	if value == "" {
+		return errMissingSignature
+	}
+	var timestamp string
+	var signatures []string
+	for _, item := range strings.Split(value, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch key {
+		case "t":
+			timestamp = val
+		case "v1":
+			signatures = append(signatures, val)
+		}
+	}
+	if timestamp == "" {
+		return errMissingTimestamp
+	}
+
+	expected := v.sign([]byte(timestamp+"."), body)
+	for _, signature := range signatures {
+		if equalHex(signature, expected) {
+			return v.checkTimestamp(timestamp, now)
+		}
+	}
+	return errInvalidSignature
+}
+
+// verifySlack validates a "v0=<hex>" signature of "v0:<timestamp>:<body>"
+func (v *signatureVerifier) verifySlack(header http.Header, body []byte, now time.Time) error {
+	value := header.Get(slackSignatureHeader)
+	if value == "" {
+		return errMissingSignature
+	}
+	timestamp := header.Get(slackTimestampHeader)
+	if timestamp == "" {
+		return errMissingTimestamp
+	}
+	signature, ok := strings.CutPrefix(value, "v0=")
+	if !ok || !equalHex(signature, v.sign([]byte("v0:"+timestamp+":"), body)) {
+		return errInvalidSignature
+	}
+	return v.checkTimestamp(timestamp, now)
+}
+
+// verifyHMAC validates a signature of the body with the algorithm, encoding and prefix of the config
+func (v *signatureVerifier) verifyHMAC(header http.Header, body []byte) error {
+	value := header.Get(v.header)
+	if value == "" {
+		return errMissingSignature
+	}
+	value, ok := strings.CutPrefix(value, v.prefix)
+	if !ok {
+		return errInvalidSignature
+	}
+
+	var signature []byte
+	var err error
+	if v.encoding == "base64" {
+		signature, err = base64.StdEncoding.DecodeString(value)
+	} else {
+		signature, err = hex.DecodeString(value)
+	}
+	if err != nil || !hmac.Equal(signature, v.sign(body)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// sign returns the HMAC of the concatenation of the parts
+func (v *signatureVerifier) sign(parts ...[]byte) []byte {
+	mac := hmac.New(v.hash, v.secret)
+	for _, part := range parts {
+		mac.Write(part)
+	}
+	return mac.Sum(nil)
+}
+
+// checkTimestamp rejects the Unix timestamps outside of the tolerance, to prevent replay attacks
+func (v *signatureVerifier) checkTimestamp(timestamp string, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errInvalidTimestamp
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age < 0 {
+		age = -age
+	}
+	if age > v.tolerance {
+		return errTimestampOutsideTolerance
+	}
+	return nil
+}
+
+func equalHex(signature string, expected []byte) bool {
+	decoded, err := hex.DecodeString(signature)
+	return err == nil && hmac.Equal(decoded, expected)
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // some webhooks still sign their requests with HMAC-SHA1
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "It's a Secret to Everybody"
+
+func hmacHex(h func() hash.Hash, payload string) string {
+	mac := hmac.New(h, []byte(testSecret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	stale := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+	body := "Hello, World!"
+
+	base64Mac := hmac.New(sha1.New, []byte(testSecret))
+	base64Mac.Write([]byte(body))
+	base64Signature := base64.StdEncoding.EncodeToString(base64Mac.Sum(nil))
+
+	tests := []struct {
+		desc   string
+		cfg    SignatureConfig
+		header http.Header
+		expect error
+	}{
+		{
+			// example of the GitHub documentation
+			desc:   "github",
+			cfg:    SignatureConfig{Type: signatureTypeGitHub, Secret: testSecret},
+			header: http.Header{gitHubSignatureHeader: {"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}},
+		},
+		{
+			desc:   "github missing signature",
+			cfg:    SignatureConfig{Type: signatureTypeGitHub, Secret: testSecret},
+			header: http.Header{},
+			expect: errMissingSignature,
+		},
+		{
+			desc:   "github missing prefix",
+			cfg:    SignatureConfig{Type: signatureTypeGitHub, Secret: testSecret},
+			header: http.Header{gitHubSignatureHeader: {"757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}},
+			expect: errInvalidSignature,
+		},
+		{
+			desc:   "github wrong secret",
+			cfg:    SignatureConfig{Type: signatureTypeGitHub, Secret: "another secret"},
+			header: http.Header{gitHubSignatureHeader: {"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}},
+			expect: errInvalidSignature,
+		},
+		{
+			desc: "stripe with rolled secret",
+			cfg:  SignatureConfig{Type: signatureTypeStripe, Secret: testSecret},
+			header: http.Header{stripeSignatureHeader: {
+				"t=" + timestamp + ",v1=" + hmacHex(sha256.New, "old") + ",v1=" + hmacHex(sha256.New, timestamp+"."+body) + ",v0=abc",
+			}},
+		},
+		{
+			desc:   "stripe missing timestamp",
+			cfg:    SignatureConfig{Type: signatureTypeStripe, Secret: testSecret},
+			header: http.Header{stripeSignatureHeader: {"v1=" + hmacHex(sha256.New, timestamp+"."+body)}},
+			expect: errMissingTimestamp,
+		},
+		{
+			desc:   "stripe signature of another timestamp",
+			cfg:    SignatureConfig{Type: signatureTypeStripe, Secret: testSecret},
+			header: http.Header{stripeSignatureHeader: {"t=" + stale + ",v1=" + hmacHex(sha256.New, timestamp+"."+body)}},
+			expect: errInvalidSignature,
+		},
+		{
+			desc:   "stripe replayed",
+			cfg:    SignatureConfig{Type: signatureTypeStripe, Secret: testSecret},
+			header: http.Header{stripeSignatureHeader: {"t=" + stale + ",v1=" + hmacHex(sha256.New, stale+"."+body)}},
+			expect: errTimestampOutsideTolerance,
+		},
+		{
+			desc:   "stripe within custom tolerance",
+			cfg:    SignatureConfig{Type: signatureTypeStripe, Secret: testSecret, Tolerance: 15 * time.Minute},
+			header: http.Header{stripeSignatureHeader: {"t=" + stale + ",v1=" + hmacHex(sha256.New, stale+"."+body)}},
+		},
+		{
+			desc: "slack",
+			cfg:  SignatureConfig{Type: signatureTypeSlack, Secret: testSecret},
+			header: http.Header{
+				slackSignatureHeader: {"v0=" + hmacHex(sha256.New, "v0:"+timestamp+":"+body)},
+				slackTimestampHeader: {timestamp},
+			},
+		},
+		{
+			desc:   "slack missing timestamp",
+			cfg:    SignatureConfig{Type: signatureTypeSlack, Secret: testSecret},
+			header: http.Header{slackSignatureHeader: {"v0=" + hmacHex(sha256.New, "v0:"+timestamp+":"+body)}},
+			expect: errMissingTimestamp,
+		},
+		{
+			desc: "slack replayed",
+			cfg:  SignatureConfig{Type: signatureTypeSlack, Secret: testSecret},
+			header: http.Header{
+				slackSignatureHeader: {"v0=" + hmacHex(sha256.New, "v0:"+stale+":"+body)},
+				slackTimestampHeader: {stale},
+			},
+			expect: errTimestampOutsideTolerance,
+		},
+		{
+			desc: "slack invalid timestamp",
+			cfg:  SignatureConfig{Type: signatureTypeSlack, Secret: testSecret},
+			header: http.Header{
+				slackSignatureHeader: {"v0=" + hmacHex(sha256.New, "v0:now:"+body)},
+				slackTimestampHeader: {"now"},
+			},
+			expect: errInvalidTimestamp,
+		},
+		{
+			desc:   "hmac hex with prefix",
+			cfg:    SignatureConfig{Type: signatureTypeHMAC, Secret: testSecret, Header: "X-Signature", Prefix: "sha256="},
+			header: http.Header{"X-Signature": {"sha256=" + hmacHex(sha256.New, body)}},
+		},
+		{
+			desc:   "hmac base64 sha1",
+			cfg:    SignatureConfig{Type: signatureTypeHMAC, Secret: testSecret, Header: "X-Signature", Algorithm: "SHA1", Encoding: "base64"},
+			header: http.Header{"X-Signature": {base64Signature}},
+		},
+		{
+			desc:   "hmac wrong algorithm",
+			cfg:    SignatureConfig{Type: signatureTypeHMAC, Secret: testSecret, Header: "X-Signature"},
+			header: http.Header{"X-Signature": {hmacHex(sha1.New, body)}},
+			expect: errInvalidSignature,
+		},
+		{
+			desc:   "hmac invalid encoding",
+			cfg:    SignatureConfig{Type: signatureTypeHMAC, Secret: testSecret, Header: "X-Signature"},
+			header: http.Header{"X-Signature": {base64Signature}},
+			expect: errInvalidSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := newSignatureVerifier(test.cfg)
+			require.NotNil(t, v)
+			err := v.verify(test.header, []byte(body), now)
+			if test.expect == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.expect)
+			}
+		})
+	}
+
+	require.Nil(t, newSignatureVerifier(SignatureConfig{}))
+}